@@ -0,0 +1,320 @@
+// Package bench 프로세스 수와 송신 속도를 정해 부하를 걸고 처리량, 지연 백분위, 할당, 시계 연산 비용을 재는 벤치마크
+//
+//	res, err := bench.Run(bench.Config{N: 8, Messages: 10000, Rate: 5000})
+//	res.WriteText(os.Stdout)
+//
+// 같은 설정으로 시계 유지 단위(Mode), 메일박스(채널 / 링 버퍼), 매니저 옵션만 바꿔 돌리면
+// 구현 사이의 성능 차이와 회귀를 비교할 수 있다 (CLI: vectorclock bench -mode per-channel -json).
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// ErrInvalidConfig 실행할 수 없는 벤치마크 설정
+var ErrInvalidConfig = errors.New("bench: invalid config")
+
+// Pattern 송신자가 받는 쪽을 고르는 방식
+type Pattern string
+
+const (
+	// PatternRing 프로세스 i 가 i+1 에게만 보냄
+	PatternRing Pattern = "ring"
+	// PatternAll 프로세스마다 다른 모든 프로세스에게 차례로 보냄
+	PatternAll Pattern = "all"
+	// PatternRandom 메시지마다 자신이 아닌 프로세스를 무작위로 고름 (Seed)
+	PatternRandom Pattern = "random"
+)
+
+// 기본 설정값
+const (
+	DefaultN            = 4
+	DefaultMessages     = 10000
+	DefaultMailbox      = 128
+	DefaultOpIterations = 10000
+)
+
+// Config 벤치마크 설정 (0 인 값은 기본값)
+type Config struct {
+	N            int                // 프로세스 수 (기본값 DefaultN)
+	Messages     int                // 프로세스마다 보낼 메시지 수 (기본값 DefaultMessages)
+	Rate         int                // 프로세스마다 초당 보낼 메시지 수 (0 이면 제한 없음)
+	Pattern      Pattern            // 받는 쪽 고르는 방식 (기본값 PatternRing)
+	Mailbox      int                // 메일박스 크기 (기본값 DefaultMailbox)
+	Ring         bool               // 채널 대신 링 버퍼 메일박스 (OverflowBlock)
+	Mode         vc.ClockMode       // 시계 유지 단위
+	OpIterations int                // 시계 연산마다 반복 횟수 (기본값 DefaultOpIterations, 음수면 재지 않음)
+	LockProfile  bool               // 잠금 경합 계측 (WithLockProfiling, Result.Locks)
+	Seed         int64              // PatternRandom 의 시드 (0 이면 1)
+	Options      []vc.ManagerOption // 추가 매니저 옵션
+}
+
+// withDefaults 0 인 값을 기본값으로 채운 설정
+func (c Config) withDefaults() Config {
+	if c.N == 0 {
+		c.N = DefaultN
+	}
+	if c.Messages == 0 {
+		c.Messages = DefaultMessages
+	}
+	if c.Pattern == "" {
+		c.Pattern = PatternRing
+	}
+	if c.Mailbox == 0 {
+		c.Mailbox = DefaultMailbox
+	}
+	if c.OpIterations == 0 {
+		c.OpIterations = DefaultOpIterations
+	}
+	if c.Seed == 0 {
+		c.Seed = 1
+	}
+	return c
+}
+
+// validate 설정 검증
+func (c Config) validate() error {
+	switch {
+	case c.N < 2:
+		return fmt.Errorf("%w: need at least 2 processes (got %d)", ErrInvalidConfig, c.N)
+	case c.Messages < 0 || c.Rate < 0 || c.Mailbox < 0:
+		return fmt.Errorf("%w: negative messages, rate or mailbox", ErrInvalidConfig)
+	}
+	switch c.Pattern {
+	case PatternRing, PatternAll, PatternRandom:
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown pattern %q", ErrInvalidConfig, c.Pattern)
+	}
+}
+
+// Latency 송신부터 받는 쪽이 시계를 병합할 때까지 걸린 시간의 분포
+type Latency struct {
+	Mean time.Duration `json:"mean_ns"`
+	P50  time.Duration `json:"p50_ns"`
+	P90  time.Duration `json:"p90_ns"`
+	P99  time.Duration `json:"p99_ns"`
+	P999 time.Duration `json:"p999_ns"`
+	Max  time.Duration `json:"max_ns"`
+}
+
+// OpCost 시계 연산 한 번의 비용
+type OpCost struct {
+	Name        string  `json:"name"`
+	Iterations  int     `json:"iterations"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	AllocsPerOp float64 `json:"allocs_per_op"`
+	BytesPerOp  float64 `json:"bytes_per_op"`
+}
+
+// Result 벤치마크 결과
+type Result struct {
+	N            int             `json:"n"`
+	Mode         string          `json:"mode"`
+	Mailbox      string          `json:"mailbox"` // 예: chan(128), ring(128)
+	Pattern      Pattern         `json:"pattern"`
+	Rate         int             `json:"rate"` // 프로세스마다 초당 메시지 (0 이면 제한 없음)
+	Sent         int64           `json:"sent"`
+	Received     int64           `json:"received"`
+	Elapsed      time.Duration   `json:"elapsed_ns"`
+	Throughput   float64         `json:"throughput"` // 초당 수신 메시지
+	Latency      Latency         `json:"latency"`
+	AllocsPerMsg float64         `json:"allocs_per_msg"`
+	BytesPerMsg  float64         `json:"bytes_per_msg"`
+	Ops          []OpCost        `json:"ops,omitempty"`
+	Locks        *vc.LockMetrics `json:"locks,omitempty"`
+}
+
+// Run 설정대로 부하를 건 뒤 시계 연산 비용을 재서 결과 반환
+//
+// 프로세스마다 수신 루프(Start)를 돌리고 송신 고루틴 하나가 Messages 건을 Rate 속도로 보낸다.
+// 모든 메시지가 병합될 때까지(AwaitTermination) 걸린 시간으로 처리량을, 부하 구간의 할당을 메시지 수로 나눠
+// 메시지당 할당을 계산한다. 이벤트 기록도 비용에 포함된다.
+func Run(cfg Config) (*Result, error) {
+	cfg = cfg.withDefaults()
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	res, err := load(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.OpIterations > 0 {
+		res.Ops = ops(cfg)
+	}
+	return res, nil
+}
+
+// newManager 설정의 시계 유지 단위와 옵션으로 로그 없는 매니저 생성
+func newManager(cfg Config) *vc.VectorClockManager {
+	opts := []vc.ManagerOption{vc.WithLogger(vc.NopLogger), vc.WithClockMode(cfg.Mode)}
+	if cfg.LockProfile {
+		opts = append(opts, vc.WithLockProfiling())
+	}
+	return vc.NewVectorClockManager(cfg.N, append(opts, cfg.Options...)...)
+}
+
+// load 부하 구간 실행
+func load(cfg Config) (*Result, error) {
+	vcm := newManager(cfg)
+	mailbox := vc.WithMailboxSize(cfg.Mailbox)
+	kind := "chan"
+	if cfg.Ring {
+		mailbox = vc.WithRingMailbox(cfg.Mailbox, vc.OverflowBlock)
+		kind = "ring"
+	}
+
+	// 이벤트 이름은 메시지 번호 (수신 측이 송신 시각을 찾는 데 씀, 부하 전에 만들어 할당에서 제외)
+	names := make([]string, cfg.Messages)
+	for k := range names {
+		names[k] = strconv.Itoa(k)
+	}
+	sentAt := make([][]int64, cfg.N)    // 송신자 -> 메시지 번호 -> 송신 시각 (유닉스 나노초)
+	latencies := make([][]int64, cfg.N) // 수신자 -> 지연 (수신 루프 하나만 씀)
+	procs := make([]*vc.Process, cfg.N)
+	for id := range procs {
+		sentAt[id] = make([]int64, cfg.Messages)
+		latencies[id] = make([]int64, 0, cfg.Messages)
+		procs[id] = vc.NewProcess(id, vcm, mailbox)
+	}
+	for _, p := range procs {
+		id := p.ID
+		if err := p.Start(func(msg vc.Message) []vc.Outgoing {
+			if k, err := strconv.Atoi(msg.Event); err == nil {
+				latencies[id] = append(latencies[id], time.Now().UnixNano()-sentAt[msg.From][k])
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		for _, p := range procs {
+			p.Stop()
+		}
+	}()
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	errs := make([]error, cfg.N)
+	sent := make([]int64, cfg.N)
+	for _, p := range procs {
+		wg.Add(1)
+		go func(p *vc.Process) {
+			defer wg.Done()
+			sent[p.ID], errs[p.ID] = sendAll(p, cfg, names, sentAt[p.ID], start)
+		}(p)
+	}
+	wg.Wait()
+	if err := vcm.AwaitTermination(0); err != nil {
+		return nil, err
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	for _, p := range procs {
+		p.Stop() // 지연 기록을 읽기 전에 수신 루프 종료
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	res := &Result{
+		N:       cfg.N,
+		Mode:    cfg.Mode.String(),
+		Mailbox: fmt.Sprintf("%s(%d)", kind, cfg.Mailbox),
+		Pattern: cfg.Pattern,
+		Rate:    cfg.Rate,
+		Elapsed: elapsed,
+	}
+	var all []int64
+	for id := range procs {
+		res.Sent += sent[id]
+		all = append(all, latencies[id]...)
+	}
+	res.Received = int64(len(all))
+	res.Latency = percentiles(all)
+	if elapsed > 0 {
+		res.Throughput = float64(res.Received) / elapsed.Seconds()
+	}
+	if res.Sent > 0 {
+		res.AllocsPerMsg = float64(after.Mallocs-before.Mallocs) / float64(res.Sent)
+		res.BytesPerMsg = float64(after.TotalAlloc-before.TotalAlloc) / float64(res.Sent)
+	}
+	if cfg.LockProfile {
+		locks := vcm.LockMetrics()
+		res.Locks = &locks
+	}
+	return res, nil
+}
+
+// sendAll 프로세스 p 가 Messages 건을 보냄 (Rate 가 있으면 start 기준 일정 간격, 늦어지면 따라잡음)
+func sendAll(p *vc.Process, cfg Config, names []string, sentAt []int64, start time.Time) (int64, error) {
+	rng := rand.New(rand.NewSource(cfg.Seed + int64(p.ID)))
+	var interval time.Duration
+	if cfg.Rate > 0 {
+		interval = time.Second / time.Duration(cfg.Rate)
+	}
+	var sent int64
+	for k := 0; k < cfg.Messages; k++ {
+		if interval > 0 {
+			if d := time.Until(start.Add(time.Duration(k) * interval)); d > 0 {
+				time.Sleep(d)
+			}
+		}
+		to := target(cfg, p.ID, k, rng)
+		sentAt[k] = time.Now().UnixNano()
+		if err := p.Send(to, names[k]); err != nil {
+			return sent, fmt.Errorf("process %d: %w", p.ID, err)
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// target 프로세스 from 의 k 번째 메시지를 받을 프로세스
+func target(cfg Config, from, k int, rng *rand.Rand) int {
+	switch cfg.Pattern {
+	case PatternAll:
+		return (from + 1 + k%(cfg.N-1)) % cfg.N
+	case PatternRandom:
+		return (from + 1 + rng.Intn(cfg.N-1)) % cfg.N
+	default:
+		return (from + 1) % cfg.N
+	}
+}
+
+// percentiles 지연 분포 (나노초 값, 정렬함)
+func percentiles(ns []int64) Latency {
+	if len(ns) == 0 {
+		return Latency{}
+	}
+	sort.Slice(ns, func(i, j int) bool { return ns[i] < ns[j] })
+	at := func(q float64) time.Duration {
+		return time.Duration(ns[int(q*float64(len(ns)-1))])
+	}
+	var sum int64
+	for _, v := range ns {
+		sum += v
+	}
+	return Latency{
+		Mean: time.Duration(sum / int64(len(ns))),
+		P50:  at(0.50),
+		P90:  at(0.90),
+		P99:  at(0.99),
+		P999: at(0.999),
+		Max:  time.Duration(ns[len(ns)-1]),
+	}
+}
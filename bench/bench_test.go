@@ -0,0 +1,75 @@
+package bench
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRunDeliversEveryMessage(t *testing.T) {
+	for _, pattern := range []Pattern{PatternRing, PatternAll, PatternRandom} {
+		for _, ring := range []bool{false, true} {
+			res, err := Run(Config{N: 3, Messages: 200, Pattern: pattern, Mailbox: 8, Ring: ring, OpIterations: -1})
+			if err != nil {
+				t.Fatalf("%s (ring %v): %v", pattern, ring, err)
+			}
+			if res.Sent != 600 || res.Received != 600 {
+				t.Fatalf("%s (ring %v): sent %d, received %d, want 600", pattern, ring, res.Sent, res.Received)
+			}
+			l := res.Latency
+			if l.P50 > l.P99 || l.P99 > l.Max || l.Mean > l.Max || l.Max <= 0 {
+				t.Fatalf("%s (ring %v): latency %+v is not ordered", pattern, ring, l)
+			}
+			if res.Throughput <= 0 || res.Ops != nil || res.Locks != nil {
+				t.Fatalf("%s (ring %v): result %+v", pattern, ring, res)
+			}
+		}
+	}
+}
+
+func TestRunMeasuresOpsAndLocks(t *testing.T) {
+	res, err := Run(Config{N: 2, Messages: 20, OpIterations: 10, LockProfile: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, op := range res.Ops {
+		if op.Iterations != 10 {
+			t.Fatalf("op %s ran %d times, want 10", op.Name, op.Iterations)
+		}
+		names = append(names, op.Name)
+	}
+	want := "local-event send-receive get-clock get-clock-copy update-clock compare wire-roundtrip"
+	if got := strings.Join(names, " "); got != want {
+		t.Fatalf("ops = %s, want %s", got, want)
+	}
+	if res.Locks == nil || res.Locks.Clock.Acquired == 0 {
+		t.Fatalf("locks = %+v, want clock locks counted", res.Locks)
+	}
+	if res.Mailbox != "chan(128)" || res.Pattern != PatternRing || res.N != 2 {
+		t.Fatalf("defaults not applied: %+v", res)
+	}
+
+	var out bytes.Buffer
+	if err := res.WriteText(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"bench: 2 processes", "40 sent, 40 received", "wire-roundtrip", "manager-read"} {
+		if !strings.Contains(out.String(), s) {
+			t.Fatalf("text output misses %q:\n%s", s, out.String())
+		}
+	}
+}
+
+func TestRunRejectsInvalidConfig(t *testing.T) {
+	for _, cfg := range []Config{
+		{N: 1},
+		{N: 2, Rate: -1},
+		{N: 2, Pattern: "star"},
+	} {
+		if _, err := Run(cfg); !errors.Is(err, ErrInvalidConfig) {
+			t.Fatalf("Run(%+v) = %v, want ErrInvalidConfig", cfg, err)
+		}
+	}
+}
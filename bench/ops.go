@@ -0,0 +1,109 @@
+package bench
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// ops 시계 연산 비용 측정 (연산마다 새 매니저, 이벤트를 기록하는 연산은 기록 비용 포함)
+func ops(cfg Config) []OpCost {
+	n := cfg.OpIterations
+	var costs []OpCost
+
+	vcm := newManager(cfg)
+	p := vc.NewProcess(0, vcm)
+	costs = append(costs, measure("local-event", n, func() { p.LocalEvent("op") }))
+
+	vcm = newManager(cfg)
+	sender := vc.NewProcess(0, vcm)
+	receiver := vc.NewProcess(1, vcm)
+	costs = append(costs, measure("send-receive", n, func() {
+		_ = sender.SendMessage(1, "op", receiver.MessageCh, false)
+		_ = receiver.ReceiveMessages(receiver.MessageCh)
+	}))
+
+	vcm = newManager(cfg)
+	costs = append(costs, measure("get-clock", n, func() { vcm.GetClock(0) }))
+	costs = append(costs, measure("get-clock-copy", n, func() { vcm.GetClockCopy(0) }))
+
+	other := make([]int, cfg.N)
+	for i := range other {
+		other[i] = i
+	}
+	costs = append(costs, measure("update-clock", n, func() {
+		other[1]++
+		vcm.UpdateClock(0, other)
+	}))
+
+	a, b := vcm.GetClockCopy(0), other
+	costs = append(costs, measure("compare", n, func() { vc.Compare(a, b) }))
+
+	msg := vc.Message{From: 0, To: 1, Vector: other, Event: "op", MessageID: "0-1"}
+	costs = append(costs, measure("wire-roundtrip", n, func() {
+		data, err := vc.MarshalMessage(msg)
+		if err == nil {
+			_, _ = vc.UnmarshalMessage(data)
+		}
+	}))
+	return costs
+}
+
+// measure op 를 iterations 번 실행한 평균 시간과 할당
+func measure(name string, iterations int, op func()) OpCost {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		op()
+	}
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	return OpCost{
+		Name:        name,
+		Iterations:  iterations,
+		NsPerOp:     float64(elapsed.Nanoseconds()) / float64(iterations),
+		AllocsPerOp: float64(after.Mallocs-before.Mallocs) / float64(iterations),
+		BytesPerOp:  float64(after.TotalAlloc-before.TotalAlloc) / float64(iterations),
+	}
+}
+
+// WriteText 결과를 사람이 읽는 표로 출력
+func (r *Result) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "bench: %d processes, mode %s, mailbox %s, pattern %s", r.N, r.Mode, r.Mailbox, r.Pattern)
+	if r.Rate > 0 {
+		fmt.Fprintf(bw, ", %d msg/s per process", r.Rate)
+	}
+	fmt.Fprintf(bw, "\n")
+	fmt.Fprintf(bw, "messages:   %d sent, %d received in %v (%.0f msg/s)\n", r.Sent, r.Received, r.Elapsed.Round(time.Microsecond), r.Throughput)
+	l := r.Latency
+	fmt.Fprintf(bw, "latency:    mean %v  p50 %v  p90 %v  p99 %v  p99.9 %v  max %v\n", l.Mean, l.P50, l.P90, l.P99, l.P999, l.Max)
+	fmt.Fprintf(bw, "allocation: %.1f allocs/msg, %.0f B/msg\n", r.AllocsPerMsg, r.BytesPerMsg)
+	if len(r.Ops) > 0 {
+		fmt.Fprintf(bw, "\n%-16s %12s %12s %12s\n", "op", "ns/op", "allocs/op", "B/op")
+		for _, op := range r.Ops {
+			fmt.Fprintf(bw, "%-16s %12.1f %12.2f %12.1f\n", op.Name, op.NsPerOp, op.AllocsPerOp, op.BytesPerOp)
+		}
+	}
+	if r.Locks != nil {
+		fmt.Fprintf(bw, "\n%-16s %12s %12s %14s %14s\n", "lock", "acquired", "contended", "wait", "max wait")
+		for _, row := range []struct {
+			name string
+			s    vc.LockStats
+		}{
+			{"manager", r.Locks.Manager},
+			{"manager-read", r.Locks.ManagerRead},
+			{"clock", r.Locks.Clock},
+			{"process", r.Locks.Process},
+		} {
+			fmt.Fprintf(bw, "%-16s %12d %12d %14v %14v\n", row.name, row.s.Acquired, row.s.Contended, row.s.Wait, row.s.MaxWait)
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,58 @@
+// Package clockstore 여러 서비스 인스턴스가 함께 쓰는 프로세스별 Vector Clock 저장소
+//
+//	s := redis.New(goredis.NewClient(&goredis.Options{Addr: "localhost:6379"}), 3) // clockstore/redis
+//	defer s.Close()
+//	clock, err := s.Update(ctx, 1, msg.Vector) // 수신 시 병합 후 자신의 항목 증가
+//
+// 인메모리 매니저(process.VectorClockManager)를 한 프로세스에 두지 않고도 여러 인스턴스가 같은 시계를
+// 이어서 갱신할 수 있다. 갱신은 저장소 안에서 원자적으로 이루어진다. 외부 저장소 구현은 클라이언트
+// 라이브러리를 이 모듈에 두지 않도록 하위 디렉터리의 별도 모듈에 있다 (Redis 는 clockstore/redis).
+package clockstore
+
+import (
+	"context"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// ClockStore 프로세스별 Vector Clock 저장소
+type ClockStore interface {
+	// Get 프로세스 id 의 Vector Clock (저장된 적이 없으면 0 시계)
+	Get(ctx context.Context, id int) ([]int, error)
+	// Update received 를 병합하고 자신의 항목을 1 증가시킨 뒤 결과 반환 (received 가 nil 이면 로컬 이벤트)
+	Update(ctx context.Context, id int, received []int) ([]int, error)
+	// Close 저장소 닫기
+	Close() error
+}
+
+// managerStore 인메모리 매니저를 ClockStore 로 쓰는 어댑터
+type managerStore struct {
+	vcm *vc.VectorClockManager
+}
+
+// FromManager 인메모리 매니저 vcm 을 ClockStore 로 (단일 인스턴스나 테스트에서 같은 코드를 쓸 때)
+//
+// Update 의 결과는 갱신 직후 다시 읽은 시계이므로, 그 사이 다른 갱신이 있었다면 함께 반영된다.
+func FromManager(vcm *vc.VectorClockManager) ClockStore {
+	return managerStore{vcm: vcm}
+}
+
+// Get 매니저의 시계 복사본
+func (s managerStore) Get(ctx context.Context, id int) ([]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.vcm.GetClockCopy(id), nil
+}
+
+// Update 매니저의 UpdateClock 후 시계 복사본
+func (s managerStore) Update(ctx context.Context, id int, received []int) ([]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.vcm.UpdateClock(id, received)
+	return s.vcm.GetClockCopy(id), nil
+}
+
+// Close 아무것도 하지 않음 (매니저는 호출한 쪽이 소유)
+func (s managerStore) Close() error { return nil }
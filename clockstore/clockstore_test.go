@@ -0,0 +1,45 @@
+package clockstore
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestFromManagerUpdatesManagerClocks(t *testing.T) {
+	ctx := context.Background()
+	vcm := vc.NewVectorClockManager(3, vc.WithLogger(nil))
+	s := FromManager(vcm)
+	defer s.Close()
+
+	if got, err := s.Get(ctx, 1); err != nil || !reflect.DeepEqual(got, []int{0, 0, 0}) {
+		t.Fatalf("Get before any update = %v, %v", got, err)
+	}
+	if _, err := s.Update(ctx, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Update(ctx, 1, []int{1, 0, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 1, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Update = %v, want %v", got, want)
+	}
+	if got := vcm.GetClock(1); !reflect.DeepEqual(got, []int{1, 1, 4}) {
+		t.Fatalf("manager clock = %v", got)
+	}
+}
+
+func TestFromManagerHonoursContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := FromManager(vc.NewVectorClockManager(1, vc.WithLogger(nil)))
+	if _, err := s.Update(ctx, 0, nil); err != context.Canceled {
+		t.Fatalf("Update with a cancelled context = %v, want context.Canceled", err)
+	}
+	if _, err := s.Get(ctx, 0); err != context.Canceled {
+		t.Fatalf("Get with a cancelled context = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,19 @@
+module github.com/seoyhaein/vectorclock/clockstore/redis
+
+go 1.22
+
+require (
+	github.com/redis/go-redis/v9 v9.18.0
+	github.com/seoyhaein/vectorclock v0.0.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
+	github.com/stretchr/testify v1.11.1 // indirect
+	go.uber.org/atomic v1.11.0 // indirect
+)
+
+replace github.com/seoyhaein/vectorclock => ../..
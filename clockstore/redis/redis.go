@@ -0,0 +1,134 @@
+// Package redis Redis 해시에 프로세스별 시계를 두는 clockstore.ClockStore
+//
+//	s := redis.New(goredis.NewClient(&goredis.Options{Addr: "localhost:6379"}), 3)
+//	defer s.Close()
+//	clock, err := s.Update(ctx, 1, msg.Vector) // 수신 시 병합 후 자신의 항목 증가
+//
+// Redis 클라이언트 라이브러리를 루트 모듈에 두지 않도록 따로 둔 모듈이다.
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/seoyhaein/vectorclock/clockstore"
+)
+
+// DefaultKeyPrefix Redis 키 접두사 기본값
+const DefaultKeyPrefix = "vectorclock"
+
+// updateScript 받은 시계를 병합하고 자신의 항목을 증가시킨 뒤 해시 전체를 반환 (한 번에 원자적으로 실행)
+//
+// KEYS[1] 프로세스의 해시 (필드 = 프로세스 ID, 값 = 항목), ARGV[1] 자신의 ID, ARGV[2:] 받은 시계
+var updateScript = goredis.NewScript(`
+local key = KEYS[1]
+for i = 2, #ARGV do
+	local v = tonumber(ARGV[i])
+	if v > 0 then
+		local field = tostring(i - 2)
+		local cur = tonumber(redis.call('HGET', key, field) or '0')
+		if v > cur then
+			redis.call('HSET', key, field, v)
+		end
+	end
+end
+redis.call('HINCRBY', key, ARGV[1], 1)
+return redis.call('HGETALL', key)
+`)
+
+// Store Redis 해시에 프로세스별 시계를 두는 ClockStore
+//
+// 프로세스마다 해시 하나(<prefix>:clock:<id>)를 쓰고, 필드는 프로세스 ID, 값은 그 항목이다.
+// 0 인 항목은 저장하지 않으므로, 프로세스가 늘어나도 기존 해시를 고칠 필요가 없다.
+type Store struct {
+	client goredis.UniversalClient
+	size   int
+	prefix string
+}
+
+var _ clockstore.ClockStore = (*Store)(nil)
+
+// Option Redis 저장소 옵션
+type Option func(*Store)
+
+// WithKeyPrefix 키 접두사 (기본값 DefaultKeyPrefix, 여러 클러스터가 같은 Redis 를 쓸 때 구분)
+func WithKeyPrefix(prefix string) Option {
+	return func(s *Store) {
+		s.prefix = prefix
+	}
+}
+
+// New client 를 쓰는 프로세스 size 개짜리 시계 저장소 (Close 하면 client 도 닫힘)
+//
+// 돌려주는 시계는 항목이 size 개이며, 더 큰 프로세스 ID 의 항목이 저장돼 있으면 그만큼 길다.
+func New(client goredis.UniversalClient, size int, opts ...Option) *Store {
+	s := &Store{client: client, size: size, prefix: DefaultKeyPrefix}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// key 프로세스 id 의 해시 키
+func (s *Store) key(id int) string {
+	return s.prefix + ":clock:" + strconv.Itoa(id)
+}
+
+// Get 프로세스 id 의 Vector Clock
+func (s *Store) Get(ctx context.Context, id int) ([]int, error) {
+	fields, err := s.client.HGetAll(ctx, s.key(id)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("clockstore: get clock of process %d: %w", id, err)
+	}
+	clock := make([]int, s.size)
+	for field, value := range fields {
+		if clock, err = s.set(clock, field, value); err != nil {
+			return nil, fmt.Errorf("clockstore: clock of process %d: %w", id, err)
+		}
+	}
+	return clock, nil
+}
+
+// Update received 를 병합하고 자신의 항목을 증가시킨 결과 (Lua 스크립트 하나로 원자적으로)
+func (s *Store) Update(ctx context.Context, id int, received []int) ([]int, error) {
+	args := make([]any, 0, 1+len(received))
+	args = append(args, id)
+	for _, v := range received {
+		args = append(args, v)
+	}
+	res, err := updateScript.Run(ctx, s.client, []string{s.key(id)}, args...).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("clockstore: update clock of process %d: %w", id, err)
+	}
+	clock := make([]int, s.size)
+	for i := 0; i+1 < len(res); i += 2 {
+		if clock, err = s.set(clock, res[i], res[i+1]); err != nil {
+			return nil, fmt.Errorf("clockstore: clock of process %d: %w", id, err)
+		}
+	}
+	return clock, nil
+}
+
+// set 해시 필드 하나를 시계 항목으로 (필요하면 시계를 늘림)
+func (s *Store) set(clock []int, field, value string) ([]int, error) {
+	i, err := strconv.Atoi(field)
+	if err != nil || i < 0 {
+		return clock, fmt.Errorf("bad field %q", field)
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return clock, fmt.Errorf("bad value %q for process %d", value, i)
+	}
+	for len(clock) <= i {
+		clock = append(clock, 0)
+	}
+	clock[i] = v
+	return clock, nil
+}
+
+// Close Redis 연결 닫기
+func (s *Store) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,79 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// open VECTORCLOCK_REDIS_ADDR 의 Redis 로 프로세스 size 개짜리 저장소 (없으면 건너뜀)
+func open(t *testing.T, size int) *Store {
+	t.Helper()
+	addr := os.Getenv("VECTORCLOCK_REDIS_ADDR")
+	if addr == "" {
+		t.Skip("VECTORCLOCK_REDIS_ADDR not set")
+	}
+	// 같은 Redis 를 여러 번 실행해도 겹치지 않도록 키 접두사를 새로 만듦
+	prefix := fmt.Sprintf("vectorclock-test-%d", time.Now().UnixNano())
+	s := New(goredis.NewClient(&goredis.Options{Addr: addr}), size, WithKeyPrefix(prefix))
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestUpdateMergesAndIncrements(t *testing.T) {
+	ctx := context.Background()
+	s := open(t, 3)
+	if got, err := s.Get(ctx, 1); err != nil || !reflect.DeepEqual(got, []int{0, 0, 0}) {
+		t.Fatalf("Get before any update = %v, %v", got, err)
+	}
+	if _, err := s.Update(ctx, 1, nil); err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Update(ctx, 1, []int{2, 0, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{2, 2, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Update = %v, want %v", got, want)
+	}
+	// 더 작은 값은 병합해도 그대로
+	if got, err = s.Update(ctx, 1, []int{1, 0, 1, 0, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{2, 3, 5, 0, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Update with a longer clock = %v, want %v", got, want)
+	}
+}
+
+func TestUpdateIsAtomic(t *testing.T) {
+	ctx := context.Background()
+	s := open(t, 2)
+	const workers, each = 8, 25
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				if _, err := s.Update(ctx, 0, nil); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	got, err := s.Get(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0] != workers*each {
+		t.Fatalf("own entry = %d after %d concurrent updates", got[0], workers*each)
+	}
+}
@@ -0,0 +1,57 @@
+// Package cluster 네트워크에 흩어진 노드의 프로세스 ID 배정, 생존 알림, 멤버 발견
+//
+//	m, err := cluster.JoinEtcd(ctx, etcdClient, "node-a", "10.0.0.2:7000")
+//	defer m.Leave(ctx)
+//	mgr := process.NewVectorClockManager(size, process.WithTransport(tcp))
+//	p := process.NewProcess(m.Self().ID, mgr)
+//	go m.Watch(ctx, cluster.Sync(mgr, tcp, m.Self().ID))
+//
+// etcd(JoinEtcd) 는 ID 배정과 시계 크기까지 맡고, Consul(RegisterConsul) 은 이미 ID 가 정해진 프로세스를
+// 서비스 카탈로그에 헬스 체크와 함께 올려 운영 도구에서 보이게 한다. Kubernetes(InCluster) 는 StatefulSet 파드의
+// 순번을 ID 로 쓰고 레이블 선택자로 다른 파드를 찾아 전송 계층을 연결한다.
+//
+// 멤버 정보로 전송 계층(transport.TCP)의 주소를 채우므로, 노드마다 다른 노드의 주소를 설정하지 않아도 된다.
+// 시작한 뒤 들어온 멤버의 ID 가 매니저의 시계 크기 이상이면 Sync 가 매니저를 먼저 늘린다(Grow).
+package cluster
+
+// Member 클러스터의 프로세스 하나
+type Member struct {
+	ID   int    `json:"id"`   // 프로세스 ID (Vector Clock 의 항목 위치)
+	Name string `json:"name"` // 노드 이름 (같은 이름은 다시 참여해도 같은 ID)
+	Addr string `json:"addr"` // 전송 계층 주소 (host:port)
+}
+
+// PeerAdder 다른 프로세스의 주소를 받는 전송 계층 (transport.TCP)
+type PeerAdder interface {
+	AddPeer(id int, addr string)
+}
+
+// Connect self 를 뺀 멤버의 주소를 전송 계층 t 에 등록
+func Connect(t PeerAdder, self int, members []Member) {
+	for _, m := range members {
+		if m.ID != self && m.Addr != "" {
+			t.AddPeer(m.ID, m.Addr)
+		}
+	}
+}
+
+// Grower 실행 중 Vector Clock 항목 수를 늘릴 수 있는 매니저 (process.VectorClockManager)
+type Grower interface {
+	Grow(n int)
+}
+
+// Sync 멤버 변화마다 매니저 mgr 를 시계 크기 size 와 가장 큰 멤버 ID + 1 중 큰 쪽까지 늘리고
+// self 를 뺀 멤버의 주소를 전송 계층 t 에 등록하는 Watch 콜백
+//
+// 나중에 들어온 멤버가 보내거나 받기 전에 이 노드의 모든 시계가 그 멤버의 항목을 갖게 된다.
+func Sync(mgr Grower, t PeerAdder, self int) func(members []Member, size int) {
+	return func(members []Member, size int) {
+		for _, m := range members {
+			if m.ID+1 > size {
+				size = m.ID + 1
+			}
+		}
+		mgr.Grow(size)
+		Connect(t, self, members)
+	}
+}
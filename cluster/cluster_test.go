@@ -0,0 +1,67 @@
+package cluster
+
+import (
+	"reflect"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestConnectAddsOtherMembers(t *testing.T) {
+	book := peerBook{}
+	Connect(book, 1, []Member{
+		{ID: 0, Name: "a", Addr: "a:7000"},
+		{ID: 1, Name: "b", Addr: "b:7000"},
+		{ID: 2, Name: "c"}, // 주소를 아직 모름
+		{ID: 3, Name: "d", Addr: "d:7000"},
+	})
+	if want := (peerBook{0: "a:7000", 3: "d:7000"}); !reflect.DeepEqual(book, want) {
+		t.Fatalf("peers = %v, want %v", book, want)
+	}
+}
+
+// peerBook 등록된 주소를 기억하는 PeerAdder
+type peerBook map[int]string
+
+func (b peerBook) AddPeer(id int, addr string) { b[id] = addr }
+
+func TestSyncGrowsManagerForLateMember(t *testing.T) {
+	mgr := vc.NewVectorClockManager(2, vc.WithLogger(nil))
+	self := vc.NewProcess(0, mgr, vc.WithMailboxSize(4))
+	book := peerBook{}
+	onChange := Sync(mgr, book, 0)
+
+	onChange([]Member{{ID: 0, Addr: "a:1"}, {ID: 1, Addr: "b:1"}}, 2)
+	if n := mgr.Size(); n != 2 {
+		t.Fatalf("Size() = %d, want 2", n)
+	}
+
+	// 시작한 뒤 들어온 멤버 (Watch 콜백)
+	onChange([]Member{{ID: 0, Addr: "a:1"}, {ID: 1, Addr: "b:1"}, {ID: 2, Addr: "c:1"}}, 3)
+	if n := mgr.Size(); n != 3 {
+		t.Fatalf("Size() = %d after join, want 3", n)
+	}
+	if want := (peerBook{1: "b:1", 2: "c:1"}); !reflect.DeepEqual(book, want) {
+		t.Fatalf("peers = %v, want %v", book, want)
+	}
+
+	late := vc.NewProcess(2, mgr, vc.WithMailboxSize(4))
+	if err := late.Send(0, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := self.ReceiveMessages(self.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := mgr.GetClockCopy(0), []int{1, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("process 0 clock = %v, want %v", got, want)
+	}
+}
+
+func TestSyncCoversMemberIDsAboveSize(t *testing.T) {
+	mgr := vc.NewVectorClockManager(1, vc.WithLogger(nil))
+	// 크기를 모르는 멤버 발견(Consul, Kubernetes)은 0 을 넘김
+	Sync(mgr, peerBook{}, 0)([]Member{{ID: 0}, {ID: 4, Addr: "e:1"}}, 0)
+	if n := mgr.Size(); n != 5 {
+		t.Fatalf("Size() = %d, want 5", n)
+	}
+}
@@ -0,0 +1,223 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// DefaultConsulService Consul 서비스 이름 기본값
+const DefaultConsulService = "vectorclock"
+
+// Consul 서비스 메타데이터 키
+const (
+	consulMetaProcess = "process_id"
+	consulMetaNode    = "node"
+)
+
+// Consul Consul 서비스 카탈로그에 등록된 프로세스
+//
+// 등록하면 TTL 헬스 체크를 함께 만들고, TTL 의 절반마다 프로세스 상태(process.Process.Health)를
+// 체크 결과로 보낸다. 노드가 멈춰 체크가 오래 critical 이면 Consul 이 서비스를 지운다.
+type Consul struct {
+	client  *api.Client
+	service string
+	tags    []string
+	ttl     time.Duration
+	proc    *vc.Process // 상태를 보고할 프로세스 (nil 이면 등록돼 있는 동안 항상 통과)
+	self    Member
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// ConsulOption Consul 등록 옵션
+type ConsulOption func(*Consul)
+
+// WithConsulService 서비스 이름 (기본값 DefaultConsulService)
+func WithConsulService(name string) ConsulOption {
+	return func(c *Consul) {
+		c.service = name
+	}
+}
+
+// WithConsulTags 서비스 태그
+func WithConsulTags(tags ...string) ConsulOption {
+	return func(c *Consul) {
+		c.tags = append(c.tags, tags...)
+	}
+}
+
+// WithCheckTTL 헬스 체크 TTL (기본값 DefaultTTL, 최소 1초)
+func WithCheckTTL(ttl time.Duration) ConsulOption {
+	return func(c *Consul) {
+		if ttl >= time.Second {
+			c.ttl = ttl
+		}
+	}
+}
+
+// WithHealthOf 헬스 체크에 프로세스 p 의 상태를 보고 (Healthy 가 아니면 warning)
+func WithHealthOf(p *vc.Process) ConsulOption {
+	return func(c *Consul) {
+		c.proc = p
+	}
+}
+
+// RegisterConsul 멤버 m 을 Consul 서비스로 등록하고 헬스 체크 보고 시작
+//
+// 서비스 ID 는 "<서비스>-<프로세스 ID>", 주소와 포트는 m.Addr, 메타데이터에 프로세스 ID 와 노드 이름을 둔다.
+func RegisterConsul(client *api.Client, m Member, opts ...ConsulOption) (*Consul, error) {
+	c := &Consul{client: client, service: DefaultConsulService, ttl: DefaultTTL, self: m}
+	for _, opt := range opts {
+		opt(c)
+	}
+	host, portStr, err := net.SplitHostPort(m.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("cluster: consul address of process %d: %w", m.ID, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, fmt.Errorf("cluster: consul port of process %d: %w", m.ID, err)
+	}
+
+	reg := &api.AgentServiceRegistration{
+		ID:      c.serviceID(),
+		Name:    c.service,
+		Tags:    c.tags,
+		Address: host,
+		Port:    port,
+		Meta: map[string]string{
+			consulMetaProcess: strconv.Itoa(m.ID),
+			consulMetaNode:    m.Name,
+		},
+		Check: &api.AgentServiceCheck{
+			CheckID:                        c.checkID(),
+			Name:                           fmt.Sprintf("process %d health", m.ID),
+			TTL:                            c.ttl.String(),
+			DeregisterCriticalServiceAfter: (6 * c.ttl).String(),
+		},
+	}
+	if err := client.Agent().ServiceRegister(reg); err != nil {
+		return nil, fmt.Errorf("cluster: register process %d with consul: %w", m.ID, err)
+	}
+
+	c.stop, c.done = make(chan struct{}), make(chan struct{})
+	c.report()
+	go c.run()
+	return c, nil
+}
+
+// serviceID, checkID 등록 ID
+func (c *Consul) serviceID() string { return c.service + "-" + strconv.Itoa(c.self.ID) }
+func (c *Consul) checkID() string   { return "service:" + c.serviceID() }
+
+// run TTL 의 절반마다 상태 보고 (Deregister 까지)
+func (c *Consul) run() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.ttl / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.report()
+		}
+	}
+}
+
+// report 헬스 체크 결과 보내기 (실패는 다음 주기에 다시 보냄)
+func (c *Consul) report() {
+	status, output := api.HealthPassing, "registered"
+	if c.proc != nil {
+		h := c.proc.Health()
+		output = fmt.Sprintf("running=%v mailbox=%d/%d max_lag=%d idle=%v",
+			h.Running, h.MailboxDepth, h.MailboxCap, h.MaxLag, h.Idle.Truncate(time.Millisecond))
+		if !h.Healthy {
+			status = api.HealthWarning
+		}
+	}
+	c.client.Agent().UpdateTTL(c.checkID(), output, status)
+}
+
+// Self 등록한 멤버 정보
+func (c *Consul) Self() Member {
+	return c.self
+}
+
+// Members 헬스 체크를 통과한 같은 서비스의 멤버 (ID 순서)
+func (c *Consul) Members(ctx context.Context) ([]Member, error) {
+	members, _, err := c.members(ctx, 0)
+	return members, err
+}
+
+// members index 이후 바뀔 때까지 기다리는 조회 (index 가 0 이면 바로 반환)
+func (c *Consul) members(ctx context.Context, index uint64) ([]Member, uint64, error) {
+	q := (&api.QueryOptions{WaitIndex: index}).WithContext(ctx)
+	entries, meta, err := c.client.Health().Service(c.service, "", true, q)
+	if err != nil {
+		return nil, 0, fmt.Errorf("cluster: list consul service %s: %w", c.service, err)
+	}
+	members := make([]Member, 0, len(entries))
+	for _, e := range entries {
+		id, err := strconv.Atoi(e.Service.Meta[consulMetaProcess])
+		if err != nil {
+			continue // 이 라이브러리가 등록하지 않은 인스턴스
+		}
+		host := e.Service.Address
+		if host == "" {
+			host = e.Node.Address
+		}
+		members = append(members, Member{
+			ID:   id,
+			Name: e.Service.Meta[consulMetaNode],
+			Addr: net.JoinHostPort(host, strconv.Itoa(e.Service.Port)),
+		})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+	return members, meta.LastIndex, nil
+}
+
+// Watch 통과한 멤버 목록이 바뀔 때마다 fn 호출 (처음 한 번은 바로 호출, ctx 가 끝나면 ctx.Err() 반환)
+//
+// Consul 의 blocking query 로 기다리므로 주기적으로 묻지 않는다.
+func (c *Consul) Watch(ctx context.Context, fn func(members []Member)) error {
+	var index uint64
+	for {
+		members, next, err := c.members(ctx, index)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if next != index {
+			fn(members)
+		}
+		if next < index {
+			next = 0 // 인덱스가 되돌아가면 처음부터 (Consul 권장)
+		}
+		index = next
+	}
+}
+
+// Deregister 상태 보고를 멈추고 서비스 등록 해제
+func (c *Consul) Deregister() error {
+	c.once.Do(func() {
+		close(c.stop)
+		<-c.done
+	})
+	if err := c.client.Agent().ServiceDeregister(c.serviceID()); err != nil {
+		return fmt.Errorf("cluster: deregister process %d from consul: %w", c.self.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// fakeConsul 서비스 등록, TTL 보고, 헬스 조회만 흉내 내는 Consul 에이전트
+type fakeConsul struct {
+	mu         sync.Mutex
+	registered map[string]api.AgentServiceRegistration
+	ttl        map[string]string // 체크 ID -> 마지막 상태
+}
+
+func newFakeConsul(t *testing.T) (*fakeConsul, *api.Client) {
+	t.Helper()
+	f := &fakeConsul{registered: make(map[string]api.AgentServiceRegistration), ttl: make(map[string]string)}
+	srv := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(srv.Close)
+	client, err := api.NewClient(&api.Config{Address: strings.TrimPrefix(srv.URL, "http://")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, client
+}
+
+func (f *fakeConsul) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch path := r.URL.Path; {
+	case path == "/v1/agent/service/register":
+		var reg api.AgentServiceRegistration
+		if err := json.NewDecoder(r.Body).Decode(&reg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.registered[reg.ID] = reg
+	case strings.HasPrefix(path, "/v1/agent/service/deregister/"):
+		delete(f.registered, strings.TrimPrefix(path, "/v1/agent/service/deregister/"))
+	case strings.HasPrefix(path, "/v1/agent/check/update/"):
+		var update struct{ Status string }
+		json.NewDecoder(r.Body).Decode(&update)
+		f.ttl[strings.TrimPrefix(path, "/v1/agent/check/update/")] = update.Status
+	case strings.HasPrefix(path, "/v1/health/service/"):
+		name := strings.TrimPrefix(path, "/v1/health/service/")
+		entries := []*api.ServiceEntry{}
+		for _, reg := range f.registered {
+			if reg.Name == name {
+				entries = append(entries, &api.ServiceEntry{
+					Node:    &api.Node{Address: "10.0.0.9"},
+					Service: &api.AgentService{ID: reg.ID, Service: reg.Name, Address: reg.Address, Port: reg.Port, Meta: reg.Meta},
+				})
+			}
+		}
+		w.Header().Set("X-Consul-Index", "1")
+		json.NewEncoder(w).Encode(entries)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// status 체크 checkID 의 마지막 보고 상태
+func (f *fakeConsul) status(checkID string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.ttl[checkID]
+}
+
+func TestRegisterConsulPublishesMember(t *testing.T) {
+	f, client := newFakeConsul(t)
+	c, err := RegisterConsul(client, Member{ID: 2, Name: "node-c", Addr: "10.0.0.3:7000"},
+		WithConsulTags("blue"), WithCheckTTL(2*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.mu.Lock()
+	reg, ok := f.registered["vectorclock-2"]
+	f.mu.Unlock()
+	if !ok {
+		t.Fatal("service vectorclock-2 was not registered")
+	}
+	if reg.Address != "10.0.0.3" || reg.Port != 7000 || !reflect.DeepEqual(reg.Tags, []string{"blue"}) {
+		t.Fatalf("registration = %+v", reg)
+	}
+	if reg.Meta["process_id"] != "2" || reg.Meta["node"] != "node-c" || reg.Check.TTL != "2s" {
+		t.Fatalf("registration meta %v, check %+v", reg.Meta, reg.Check)
+	}
+	if got := f.status("service:vectorclock-2"); got != api.HealthPassing {
+		t.Fatalf("initial TTL status = %q, want passing", got)
+	}
+
+	members, err := c.Members(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Member{{ID: 2, Name: "node-c", Addr: "10.0.0.3:7000"}}; !reflect.DeepEqual(members, want) {
+		t.Fatalf("Members() = %v, want %v", members, want)
+	}
+
+	if err := c.Deregister(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Deregister(); err != nil {
+		t.Fatalf("second Deregister: %v", err)
+	}
+	if members, err = c.Members(context.Background()); err != nil || len(members) != 0 {
+		t.Fatalf("Members() after deregister = %v, %v", members, err)
+	}
+}
+
+func TestRegisterConsulRejectsBadAddress(t *testing.T) {
+	_, client := newFakeConsul(t)
+	for _, addr := range []string{"no-port", "host:http"} {
+		if _, err := RegisterConsul(client, Member{ID: 0, Addr: addr}); err == nil {
+			t.Fatalf("registered address %q", addr)
+		}
+	}
+}
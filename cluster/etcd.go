@@ -0,0 +1,210 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// DefaultEtcdPrefix etcd 키 접두사 기본값
+const DefaultEtcdPrefix = "/vectorclock"
+
+// DefaultTTL 생존 알림 임대 기간 (이 시간 동안 갱신이 없으면 멤버에서 빠짐)
+const DefaultTTL = 10 * time.Second
+
+// Etcd etcd 로 ID 를 배정받고 생존을 알리는 멤버
+//
+// 키 구성 (<prefix> 아래):
+//
+//	names/<name>  배정된 ID (영구, 같은 이름은 다시 참여해도 같은 ID)
+//	next          다음에 배정할 ID = 클러스터의 시계 크기
+//	members/<id>  Member JSON (임대에 묶여 노드가 멈추면 TTL 뒤 사라짐)
+type Etcd struct {
+	client *clientv3.Client
+	prefix string
+	ttl    time.Duration
+	self   Member
+	lease  clientv3.LeaseID
+	cancel context.CancelFunc // 임대 갱신 중단
+}
+
+// EtcdOption etcd 멤버 옵션
+type EtcdOption func(*Etcd)
+
+// WithEtcdPrefix 키 접두사 (기본값 DefaultEtcdPrefix, 여러 클러스터가 같은 etcd 를 쓸 때 구분)
+func WithEtcdPrefix(prefix string) EtcdOption {
+	return func(e *Etcd) {
+		e.prefix = prefix
+	}
+}
+
+// WithTTL 생존 알림 임대 기간 (기본값 DefaultTTL, 최소 1초)
+func WithTTL(ttl time.Duration) EtcdOption {
+	return func(e *Etcd) {
+		if ttl >= time.Second {
+			e.ttl = ttl
+		}
+	}
+}
+
+// JoinEtcd 이름 name, 전송 주소 addr 로 클러스터에 참여 (ID 배정 후 생존 알림 시작)
+//
+// 처음 참여하는 이름은 다음 ID 를 받고 클러스터의 시계 크기가 1 커진다. 크기 변화는 Watch 로 알 수 있다 (Sync 로 매니저를 늘림).
+// client 는 호출한 쪽이 소유하며 Leave 뒤에 닫는다.
+func JoinEtcd(ctx context.Context, client *clientv3.Client, name, addr string, opts ...EtcdOption) (*Etcd, error) {
+	e := &Etcd{client: client, prefix: DefaultEtcdPrefix, ttl: DefaultTTL}
+	for _, opt := range opts {
+		opt(e)
+	}
+	id, err := e.assign(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	e.self = Member{ID: id, Name: name, Addr: addr}
+
+	lease, err := client.Grant(ctx, int64(e.ttl/time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("cluster: grant lease for %s: %w", name, err)
+	}
+	e.lease = lease.ID
+	kaCtx, cancel := context.WithCancel(context.Background())
+	alive, err := client.KeepAlive(kaCtx, lease.ID)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("cluster: keep lease of %s alive: %w", name, err)
+	}
+	e.cancel = cancel
+	go func() {
+		for range alive {
+		}
+	}()
+
+	data, err := json.Marshal(e.self)
+	if err != nil {
+		e.cancel()
+		return nil, err
+	}
+	if _, err := client.Put(ctx, e.memberKey(id), string(data), clientv3.WithLease(lease.ID)); err != nil {
+		e.cancel()
+		return nil, fmt.Errorf("cluster: publish member %s: %w", name, err)
+	}
+	return e, nil
+}
+
+// nameKey, nextKey, memberKey 키 이름
+func (e *Etcd) nameKey(name string) string { return e.prefix + "/names/" + name }
+func (e *Etcd) nextKey() string            { return e.prefix + "/next" }
+func (e *Etcd) memberKey(id int) string    { return e.prefix + "/members/" + strconv.Itoa(id) }
+
+// assign 이름 name 의 ID (없으면 next 를 하나 올리는 트랜잭션으로 배정, 경쟁에서 지면 다시 시도)
+func (e *Etcd) assign(ctx context.Context, name string) (int, error) {
+	for {
+		resp, err := e.client.Txn(ctx).Then(
+			clientv3.OpGet(e.nameKey(name)),
+			clientv3.OpGet(e.nextKey()),
+		).Commit()
+		if err != nil {
+			return 0, fmt.Errorf("cluster: read id of %s: %w", name, err)
+		}
+		if kvs := resp.Responses[0].GetResponseRange().Kvs; len(kvs) > 0 {
+			return strconv.Atoi(string(kvs[0].Value))
+		}
+		next, rev := 0, int64(0)
+		if kvs := resp.Responses[1].GetResponseRange().Kvs; len(kvs) > 0 {
+			if next, err = strconv.Atoi(string(kvs[0].Value)); err != nil {
+				return 0, fmt.Errorf("cluster: bad %s: %w", e.nextKey(), err)
+			}
+			rev = kvs[0].ModRevision
+		}
+
+		id := strconv.Itoa(next)
+		txn, err := e.client.Txn(ctx).If(
+			clientv3.Compare(clientv3.CreateRevision(e.nameKey(name)), "=", 0),
+			clientv3.Compare(clientv3.ModRevision(e.nextKey()), "=", rev),
+		).Then(
+			clientv3.OpPut(e.nameKey(name), id),
+			clientv3.OpPut(e.nextKey(), strconv.Itoa(next+1)),
+		).Commit()
+		if err != nil {
+			return 0, fmt.Errorf("cluster: assign id to %s: %w", name, err)
+		}
+		if txn.Succeeded {
+			return next, nil
+		}
+	}
+}
+
+// Self 이 노드의 멤버 정보
+func (e *Etcd) Self() Member {
+	return e.self
+}
+
+// Size 클러스터의 시계 크기 (지금까지 배정한 ID 수)
+func (e *Etcd) Size(ctx context.Context) (int, error) {
+	resp, err := e.client.Get(ctx, e.nextKey())
+	if err != nil {
+		return 0, fmt.Errorf("cluster: read size: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, nil
+	}
+	return strconv.Atoi(string(resp.Kvs[0].Value))
+}
+
+// Members 살아 있는 멤버 (ID 순서)
+func (e *Etcd) Members(ctx context.Context) ([]Member, error) {
+	resp, err := e.client.Get(ctx, e.prefix+"/members/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("cluster: list members: %w", err)
+	}
+	members := make([]Member, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var m Member
+		if err := json.Unmarshal(kv.Value, &m); err != nil {
+			return nil, fmt.Errorf("cluster: bad member %s: %w", kv.Key, err)
+		}
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+	return members, nil
+}
+
+// Watch 멤버나 시계 크기가 바뀔 때마다 fn 호출 (처음 한 번은 바로 호출, ctx 가 끝나면 ctx.Err() 반환)
+//
+// fn 을 Sync 로 만들면 시작한 뒤 들어온 멤버만큼 매니저의 시계가 늘어나고 전송 계층에 주소가 등록된다.
+func (e *Etcd) Watch(ctx context.Context, fn func(members []Member, size int)) error {
+	changes := e.client.Watch(ctx, e.prefix+"/", clientv3.WithPrefix())
+	for {
+		members, err := e.Members(ctx)
+		if err != nil {
+			return err
+		}
+		size, err := e.Size(ctx)
+		if err != nil {
+			return err
+		}
+		fn(members, size)
+
+		resp, ok := <-changes
+		if !ok {
+			return ctx.Err()
+		}
+		if err := resp.Err(); err != nil {
+			return fmt.Errorf("cluster: watch: %w", err)
+		}
+	}
+}
+
+// Leave 생존 알림을 멈추고 멤버에서 빠짐 (배정된 ID 는 이름에 남아 다시 참여하면 그대로 씀)
+func (e *Etcd) Leave(ctx context.Context) error {
+	e.cancel()
+	if _, err := e.client.Revoke(ctx, e.lease); err != nil {
+		return fmt.Errorf("cluster: revoke lease of %s: %w", e.self.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// etcdClient VECTORCLOCK_ETCD_ENDPOINTS(쉼표로 구분)의 etcd 클라이언트와 실행마다 새 키 접두사 (없으면 건너뜀)
+func etcdClient(t *testing.T) (*clientv3.Client, EtcdOption) {
+	t.Helper()
+	endpoints := os.Getenv("VECTORCLOCK_ETCD_ENDPOINTS")
+	if endpoints == "" {
+		t.Skip("VECTORCLOCK_ETCD_ENDPOINTS not set")
+	}
+	client, err := clientv3.New(clientv3.Config{Endpoints: strings.Split(endpoints, ","), DialTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client, WithEtcdPrefix(fmt.Sprintf("/vectorclock-test-%d", time.Now().UnixNano()))
+}
+
+func TestJoinEtcdAssignsStableIDs(t *testing.T) {
+	ctx := context.Background()
+	client, prefix := etcdClient(t)
+
+	a, err := JoinEtcd(ctx, client, "node-a", "a:7000", prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := JoinEtcd(ctx, client, "node-b", "b:7000", prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Self().ID != 0 || b.Self().ID != 1 {
+		t.Fatalf("IDs = %d, %d, want 0, 1", a.Self().ID, b.Self().ID)
+	}
+	members, err := a.Members(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Member{a.Self(), b.Self()}; !reflect.DeepEqual(members, want) {
+		t.Fatalf("Members() = %v, want %v", members, want)
+	}
+
+	// 빠졌다가 다시 참여해도 같은 ID, 크기는 그대로
+	if err := b.Leave(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if members, err = a.Members(ctx); err != nil || len(members) != 1 {
+		t.Fatalf("Members() after leave = %v, %v", members, err)
+	}
+	again, err := JoinEtcd(ctx, client, "node-b", "b:7001", prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer again.Leave(ctx)
+	defer a.Leave(ctx)
+	if again.Self().ID != 1 {
+		t.Fatalf("rejoined with ID %d, want 1", again.Self().ID)
+	}
+	if size, err := a.Size(ctx); err != nil || size != 2 {
+		t.Fatalf("Size() = %d, %v, want 2", size, err)
+	}
+}
+
+func TestEtcdWatchReportsJoins(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, prefix := etcdClient(t)
+	a, err := JoinEtcd(ctx, client, "node-a", "a:7000", prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Leave(context.Background())
+
+	sizes := make(chan int, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- a.Watch(ctx, func(members []Member, size int) { sizes <- size })
+	}()
+	if n := <-sizes; n != 1 {
+		t.Fatalf("first Watch call size = %d, want 1", n)
+	}
+	b, err := JoinEtcd(ctx, client, "node-b", "b:7000", prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Leave(context.Background())
+	deadline := time.After(5 * time.Second)
+	for n := 0; n != 2; {
+		select {
+		case n = <-sizes:
+		case <-deadline:
+			t.Fatal("Watch did not report the second member")
+		}
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Watch = %v, want context.Canceled", err)
+	}
+}
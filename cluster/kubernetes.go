@@ -0,0 +1,205 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// DefaultWatchBackoff Watch 가 실패하거나 변화 없이 끊긴 뒤 목록을 다시 읽기 전 처음 대기 시간
+var DefaultWatchBackoff = 100 * time.Millisecond
+
+// DefaultMaxWatchBackoff 실패가 이어질 때 두 배씩 늘어나는 대기 시간의 상한
+var DefaultMaxWatchBackoff = 30 * time.Second
+
+// namespaceFile 파드 안에서 자신의 네임스페이스를 알려 주는 서비스 계정 파일
+const namespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// OrdinalOf StatefulSet 파드 이름의 순번 (예: "vc-3" -> 3)
+func OrdinalOf(podName string) (int, error) {
+	i := strings.LastIndexByte(podName, '-')
+	if i < 0 {
+		return 0, fmt.Errorf("cluster: pod %q has no ordinal suffix", podName)
+	}
+	n, err := strconv.Atoi(podName[i+1:])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("cluster: pod %q has no ordinal suffix", podName)
+	}
+	return n, nil
+}
+
+// Kubernetes StatefulSet 파드를 Kubernetes API 로 찾아 멤버로 쓰는 클러스터 모드
+//
+// 파드 순번이 프로세스 ID 가 되므로, 파드가 다시 만들어져도 같은 ID 를 쓴다. 레이블 선택자에 맞는 파드 중
+// 준비(Ready)되고 IP 가 있는 파드가 멤버이며, 시계 크기는 선택된 파드의 가장 큰 순번 + 1 이다.
+// 서비스 계정에는 네임스페이스의 파드 list / watch 권한이 필요하다.
+type Kubernetes struct {
+	client    kubernetes.Interface
+	namespace string
+	selector  string
+	port      int
+	self      Member
+}
+
+// NewKubernetes client 로 namespace 에서 selector 에 맞는 파드를 찾는 클러스터 모드 (이 노드는 파드 podName)
+//
+// 멤버 주소는 파드 IP 와 전송 계층 포트 port 이다.
+func NewKubernetes(client kubernetes.Interface, namespace, selector, podName string, port int) (*Kubernetes, error) {
+	id, err := OrdinalOf(podName)
+	if err != nil {
+		return nil, err
+	}
+	return &Kubernetes{
+		client:    client,
+		namespace: namespace,
+		selector:  selector,
+		port:      port,
+		self:      Member{ID: id, Name: podName},
+	}, nil
+}
+
+// InCluster 파드 안에서 서비스 계정으로 API 에 연결하는 클러스터 모드 (네임스페이스와 파드 이름은 자동으로)
+//
+// 파드 이름은 POD_NAME 환경 변수, 없으면 호스트 이름이다.
+func InCluster(selector string, port int) (*Kubernetes, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("cluster: kubernetes config: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("cluster: kubernetes client: %w", err)
+	}
+	ns, err := os.ReadFile(namespaceFile)
+	if err != nil {
+		return nil, fmt.Errorf("cluster: kubernetes namespace: %w", err)
+	}
+	pod := os.Getenv("POD_NAME")
+	if pod == "" {
+		if pod, err = os.Hostname(); err != nil {
+			return nil, fmt.Errorf("cluster: pod name: %w", err)
+		}
+	}
+	return NewKubernetes(client, strings.TrimSpace(string(ns)), selector, pod, port)
+}
+
+// Self 이 노드의 멤버 정보 (ID 와 파드 이름, 주소는 Members 에서 확인)
+func (k *Kubernetes) Self() Member {
+	return k.self
+}
+
+// Members 준비된 멤버 (ID 순서) 와 시계 크기
+func (k *Kubernetes) Members(ctx context.Context) ([]Member, int, error) {
+	members, size, _, err := k.list(ctx)
+	return members, size, err
+}
+
+// list 파드 목록을 멤버로 (이어서 watch 할 resourceVersion 포함)
+func (k *Kubernetes) list(ctx context.Context) ([]Member, int, string, error) {
+	pods, err := k.client.CoreV1().Pods(k.namespace).List(ctx, metav1.ListOptions{LabelSelector: k.selector})
+	if err != nil {
+		return nil, 0, "", fmt.Errorf("cluster: list pods %q in %s: %w", k.selector, k.namespace, err)
+	}
+	var members []Member
+	size := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		id, err := OrdinalOf(pod.Name)
+		if err != nil {
+			continue // StatefulSet 파드가 아님
+		}
+		if id+1 > size {
+			size = id + 1
+		}
+		if pod.DeletionTimestamp != nil || pod.Status.PodIP == "" || !podReady(pod) {
+			continue
+		}
+		members = append(members, Member{ID: id, Name: pod.Name, Addr: net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(k.port))})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+	return members, size, pods.ResourceVersion, nil
+}
+
+// podReady 파드의 Ready 조건
+func podReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// Watch 파드가 바뀔 때마다 멤버와 시계 크기로 fn 호출 (처음 한 번은 바로 호출, ctx 가 끝나면 ctx.Err() 반환)
+//
+// API 서버가 watch 를 끊으면 목록을 다시 읽고 이어서 기다린다. 목록이나 watch 가 실패하거나 변화 없이 끊기면
+// DefaultWatchBackoff 부터 두 배씩 DefaultMaxWatchBackoff 까지 기다렸다가 다시 읽고, 변화를 받으면 처음 간격으로 돌아간다.
+func (k *Kubernetes) Watch(ctx context.Context, fn func(members []Member, size int)) error {
+	var backoff time.Duration
+	for {
+		if backoff > 0 {
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		changed := k.watchOnce(ctx, fn)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		switch {
+		case changed:
+			backoff = 0
+		case backoff == 0:
+			backoff = DefaultWatchBackoff
+		case backoff < DefaultMaxWatchBackoff:
+			backoff *= 2
+			if backoff > DefaultMaxWatchBackoff {
+				backoff = DefaultMaxWatchBackoff
+			}
+		}
+	}
+}
+
+// watchOnce 목록을 읽어 fn 을 호출하고 파드 변화 하나를 기다림 (변화를 받았으면 true, 실패하거나 변화 없이 끊기면 false)
+func (k *Kubernetes) watchOnce(ctx context.Context, fn func(members []Member, size int)) bool {
+	members, size, version, err := k.list(ctx)
+	if err != nil {
+		return false
+	}
+	fn(members, size)
+
+	w, err := k.client.CoreV1().Pods(k.namespace).Watch(ctx, metav1.ListOptions{
+		LabelSelector:   k.selector,
+		ResourceVersion: version,
+	})
+	if err != nil {
+		return false
+	}
+	defer w.Stop()
+	select { // 변화 하나를 받으면 목록을 다시 읽음
+	case ev, ok := <-w.ResultChan():
+		return ok && ev.Type != watch.Error
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Run 멤버가 바뀔 때마다 매니저 mgr 를 시계 크기까지 늘리고 다른 파드의 주소를 전송 계층 t 에 등록 (ctx 가 끝날 때까지)
+//
+// StatefulSet 을 늘려 기존 크기 이상의 순번을 가진 파드가 생기면 그 파드가 보내기 전에 모든 시계가 늘어난다 (Sync).
+func (k *Kubernetes) Run(ctx context.Context, mgr Grower, t PeerAdder) error {
+	return k.Watch(ctx, Sync(mgr, t, k.self.ID))
+}
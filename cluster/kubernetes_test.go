@@ -0,0 +1,217 @@
+package cluster
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestOrdinalOf(t *testing.T) {
+	for name, want := range map[string]int{"vc-0": 0, "vc-12": 12, "my-app-vc-3": 3} {
+		if got, err := OrdinalOf(name); err != nil || got != want {
+			t.Errorf("OrdinalOf(%q) = %d, %v, want %d", name, got, err, want)
+		}
+	}
+	for _, name := range []string{"vc", "vc-", "vc-x", "vc-1a"} {
+		if _, err := OrdinalOf(name); err == nil {
+			t.Errorf("OrdinalOf(%q) succeeded", name)
+		}
+	}
+	if _, err := NewKubernetes(fake.NewSimpleClientset(), "default", "app=vc", "vc", 7000); err == nil {
+		t.Fatal("NewKubernetes accepted a pod name without an ordinal")
+	}
+}
+
+func TestKubernetesMembersAreReadyPods(t *testing.T) {
+	starting := readyPod("vc-3", "")
+	notReady := readyPod("vc-1", "10.0.0.2")
+	notReady.Status.Conditions[0].Status = corev1.ConditionFalse
+	other := readyPod("vc-2", "10.0.0.3")
+	other.Labels = map[string]string{"app": "other"}
+	client := fake.NewSimpleClientset(readyPod("vc-0", "10.0.0.1"), notReady, other, starting, readyPod("vc-web", "10.0.0.9"))
+
+	k, err := NewKubernetes(client, "default", "app=vc", "vc-0", 7000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if self := k.Self(); self.ID != 0 || self.Name != "vc-0" {
+		t.Fatalf("Self() = %+v", self)
+	}
+	members, size, err := k.Members(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Member{{ID: 0, Name: "vc-0", Addr: "10.0.0.1:7000"}}; !reflect.DeepEqual(members, want) {
+		t.Fatalf("Members() = %v, want %v", members, want)
+	}
+	// 준비되지 않은 파드도 순번만큼 시계 크기를 차지함
+	if size != 4 {
+		t.Fatalf("size = %d, want 4", size)
+	}
+}
+
+func TestKubernetesWatchReportsNewPods(t *testing.T) {
+	client := fake.NewSimpleClientset(readyPod("vc-0", "10.0.0.1"))
+	watching := make(chan struct{}, 16)
+	client.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		w, err := client.Tracker().Watch(corev1.SchemeGroupVersion.WithResource("pods"), action.GetNamespace())
+		watching <- struct{}{}
+		return true, w, err
+	})
+	k, err := NewKubernetes(client, "default", "app=vc", "vc-0", 7000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sizes := make(chan int, 16)
+	done := make(chan error, 1)
+	go func() { done <- k.Watch(ctx, func(members []Member, size int) { sizes <- size }) }()
+	if n := <-sizes; n != 1 {
+		t.Fatalf("first size = %d, want 1", n)
+	}
+	<-watching
+	if _, err := client.CoreV1().Pods("default").Create(ctx, readyPod("vc-1", "10.0.0.2"), metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case n := <-sizes:
+		if n != 2 {
+			t.Fatalf("size after scale-up = %d, want 2", n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch did not report the new pod")
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatalf("Watch = %v, want context.Canceled", err)
+	}
+}
+
+// readyPod 준비된 StatefulSet 파드
+func readyPod(name, ip string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "vc"}},
+		Status: corev1.PodStatus{
+			PodIP:      ip,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+// waitSize mgr 의 시계 크기가 n 이 될 때까지 대기
+func waitSize(t *testing.T, mgr *vc.VectorClockManager, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for mgr.Size() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Size() = %d, want %d", mgr.Size(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// lockedBook Run 고루틴이 등록한 주소를 테스트에서 읽을 수 있는 PeerAdder
+type lockedBook struct {
+	peers atomic.Value // map[int]string
+}
+
+func (b *lockedBook) AddPeer(id int, addr string) {
+	old, _ := b.peers.Load().(map[int]string)
+	next := map[int]string{id: addr}
+	for k, v := range old {
+		if k != id {
+			next[k] = v
+		}
+	}
+	b.peers.Store(next)
+}
+
+func TestKubernetesRunGrowsOnScaleUp(t *testing.T) {
+	client := fake.NewSimpleClientset(readyPod("vc-0", "10.0.0.1"), readyPod("vc-1", "10.0.0.2"))
+	watching := make(chan struct{}, 16)
+	client.PrependWatchReactor("pods", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		w, err := client.Tracker().Watch(corev1.SchemeGroupVersion.WithResource("pods"), action.GetNamespace())
+		watching <- struct{}{}
+		return true, w, err
+	})
+	k, err := NewKubernetes(client, "default", "app=vc", "vc-0", 7000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mgr := vc.NewVectorClockManager(2, vc.WithLogger(nil))
+	self := vc.NewProcess(0, mgr, vc.WithMailboxSize(4))
+	book := &lockedBook{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- k.Run(ctx, mgr, book) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Errorf("Run = %v, want context.Canceled", err)
+		}
+	}()
+
+	<-watching
+	if _, err := client.CoreV1().Pods("default").Create(ctx, readyPod("vc-2", "10.0.0.3"), metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitSize(t, mgr, 3)
+	<-watching // 새 목록으로 주소를 등록한 뒤 다시 기다림
+	want := map[int]string{1: "10.0.0.2:7000", 2: "10.0.0.3:7000"}
+	if got, _ := book.peers.Load().(map[int]string); !reflect.DeepEqual(got, want) {
+		t.Fatalf("peers = %v, want %v", got, want)
+	}
+
+	late := vc.NewProcess(2, mgr, vc.WithMailboxSize(4))
+	if err := late.Send(0, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := self.ReceiveMessages(self.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKubernetesWatchBacksOff(t *testing.T) {
+	defer func(min, max time.Duration) { DefaultWatchBackoff, DefaultMaxWatchBackoff = min, max }(DefaultWatchBackoff, DefaultMaxWatchBackoff)
+	DefaultWatchBackoff, DefaultMaxWatchBackoff = 20*time.Millisecond, 80*time.Millisecond
+
+	client := fake.NewSimpleClientset(readyPod("vc-0", "10.0.0.1"))
+	var lists atomic.Int32
+	client.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		lists.Add(1)
+		return false, nil, nil
+	})
+	// API 서버가 watch 를 바로 끊음
+	client.PrependWatchReactor("pods", func(k8stesting.Action) (bool, watch.Interface, error) {
+		w := watch.NewFake()
+		w.Stop()
+		return true, w, nil
+	})
+	k, err := NewKubernetes(client, "default", "app=vc", "vc-0", 7000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := k.Watch(ctx, func([]Member, int) {}); err != context.DeadlineExceeded {
+		t.Fatalf("Watch = %v, want context.DeadlineExceeded", err)
+	}
+	// 20 + 40 + 80 + 80 ... ms 간격이면 300ms 동안 6 번 안팎
+	if n := lists.Load(); n < 2 || n > 8 {
+		t.Fatalf("listed pods %d times in 300ms, want a backed-off handful", n)
+	}
+}
@@ -0,0 +1,247 @@
+// Command vcnode 프로세스 하나를 네트워크 전송 계층으로 실행하는 노드 (여러 OS 프로세스/호스트로 이루어진 테스트베드)
+//
+//	vcnode -id 0 -listen :7000 -peers 1=10.0.0.2:7000,2=10.0.0.3:7000 -send-every 500ms
+//
+// 모든 플래그는 VCNODE_<플래그 이름> 환경 변수로도 줄 수 있다 (예: VCNODE_ID=0, VCNODE_SEND_EVERY=1s).
+// 플래그가 환경 변수보다 우선한다. SIGINT / SIGTERM 을 받으면 수신 루프를 멈추고 마지막 시계를 출력한다.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+	"github.com/seoyhaein/vectorclock/transport"
+)
+
+// config 노드 설정
+type config struct {
+	id        int
+	size      int
+	listen    string
+	peers     map[int]string
+	sendEvery time.Duration
+	duration  time.Duration
+	httpAddr  string
+	token     string
+	tlsCert   string
+	tlsKey    string
+	tlsCA     string
+	quiet     bool
+}
+
+// parseConfig 플래그와 환경 변수로 설정 읽기
+func parseConfig(args []string, getenv func(string) string) (*config, error) {
+	fs := flag.NewFlagSet("vcnode", flag.ContinueOnError)
+	id := fs.Int("id", -1, "이 노드의 프로세스 ID (필수)")
+	size := fs.Int("n", 0, "Vector Clock 크기 (0 이면 가장 큰 프로세스 ID + 1, 노드마다 달라도 연결할 때 큰 쪽으로 맞춤)")
+	listen := fs.String("listen", ":7000", "메시지를 받을 주소")
+	peers := fs.String("peers", "", "다른 프로세스 주소 목록 (id=host:port,...)")
+	sendEvery := fs.Duration("send-every", 0, "이 간격마다 임의의 상대에게 메시지 전송 (0 이면 받기만)")
+	duration := fs.Duration("duration", 0, "실행 시간 (0 이면 신호를 받을 때까지)")
+	httpAddr := fs.String("http", "", "디버그/헬스 HTTP 주소 (비어 있으면 사용하지 않음)")
+	token := fs.String("token", "", "모든 노드가 공유하는 인증 토큰 (비어 있으면 인증하지 않음)")
+	tlsCert := fs.String("tls-cert", "", "TLS 인증서 파일")
+	tlsKey := fs.String("tls-key", "", "TLS 키 파일")
+	tlsCA := fs.String("tls-ca", "", "상대 인증서를 확인할 CA 파일 (있으면 상호 TLS)")
+	quiet := fs.Bool("quiet", false, "메시지 로그를 출력하지 않음")
+
+	// 환경 변수를 기본값으로 (플래그가 우선)
+	var envErr error
+	fs.VisitAll(func(f *flag.Flag) {
+		name := "VCNODE_" + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if v := getenv(name); v != "" && envErr == nil {
+			if err := f.Value.Set(v); err != nil {
+				envErr = fmt.Errorf("%s=%q: %w", name, v, err)
+			}
+		}
+	})
+	if envErr != nil {
+		return nil, envErr
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	cfg := &config{
+		id: *id, size: *size, listen: *listen, sendEvery: *sendEvery, duration: *duration,
+		httpAddr: *httpAddr, token: *token, tlsCert: *tlsCert, tlsKey: *tlsKey, tlsCA: *tlsCA, quiet: *quiet,
+	}
+	if cfg.id < 0 {
+		return nil, fmt.Errorf("-id (or VCNODE_ID) is required")
+	}
+	var err error
+	if cfg.peers, err = parsePeers(*peers); err != nil {
+		return nil, err
+	}
+	if _, ok := cfg.peers[cfg.id]; ok {
+		return nil, fmt.Errorf("-peers lists this node's own id %d", cfg.id)
+	}
+	need := cfg.id + 1
+	for p := range cfg.peers {
+		if p+1 > need {
+			need = p + 1
+		}
+	}
+	if cfg.size == 0 {
+		cfg.size = need
+	} else if cfg.size < need {
+		return nil, fmt.Errorf("-n %d is too small for process ids up to %d", cfg.size, need-1)
+	}
+	if (cfg.tlsCert == "") != (cfg.tlsKey == "") {
+		return nil, fmt.Errorf("-tls-cert and -tls-key must be given together")
+	}
+	return cfg, nil
+}
+
+// parsePeers "id=host:port,..." 목록 읽기
+func parsePeers(s string) (map[int]string, error) {
+	peers := make(map[int]string)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		idStr, addr, ok := strings.Cut(entry, "=")
+		id, err := strconv.Atoi(strings.TrimSpace(idStr))
+		if !ok || err != nil || id < 0 || addr == "" {
+			return nil, fmt.Errorf("bad peer %q (want id=host:port)", entry)
+		}
+		if _, dup := peers[id]; dup {
+			return nil, fmt.Errorf("peer %d listed twice", id)
+		}
+		peers[id] = strings.TrimSpace(addr)
+	}
+	return peers, nil
+}
+
+// transportOptions 인증과 TLS 옵션
+func (cfg *config) transportOptions() ([]transport.Option, error) {
+	var opts []transport.Option
+	if cfg.token != "" {
+		tokens := make(map[int]string, cfg.size)
+		for i := 0; i < cfg.size; i++ {
+			tokens[i] = cfg.token
+		}
+		opts = append(opts, transport.WithAuth(transport.TokenAuth(tokens)))
+	}
+	if cfg.tlsCert != "" {
+		tlsCfg, err := transport.LoadTLS(cfg.tlsCert, cfg.tlsKey, cfg.tlsCA, cfg.tlsCA != "")
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, transport.WithTLS(tlsCfg))
+	}
+	return opts, nil
+}
+
+func main() {
+	cfg, err := parseConfig(os.Args[1:], os.Getenv)
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		log.Fatalf("vcnode: %v", err)
+	}
+	if err := run(cfg); err != nil {
+		log.Fatalf("vcnode: %v", err)
+	}
+}
+
+// run 노드 실행 (신호나 실행 시간이 끝날 때까지)
+func run(cfg *config) error {
+	topts, err := cfg.transportOptions()
+	if err != nil {
+		return err
+	}
+	tcp := transport.NewTCP(cfg.peers, topts...)
+	mopts := []vc.ManagerOption{vc.WithTransport(tcp)}
+	if cfg.quiet {
+		mopts = append(mopts, vc.WithLogger(vc.NopLogger))
+	}
+	mgr := vc.NewVectorClockManager(cfg.size, mopts...)
+	p := vc.NewProcess(cfg.id, mgr, vc.WithMailboxSize(transport.DefaultQueueSize))
+
+	if err := tcp.Start(mgr, cfg.listen); err != nil {
+		return err
+	}
+	defer tcp.Close()
+	if err := p.Start(func(vc.Message) []vc.Outgoing { return nil }); err != nil {
+		return err
+	}
+	defer p.Stop()
+	log.Printf("vcnode: process %d of %d listening on %v, peers %v", cfg.id, cfg.size, tcp.Addr(), sortedPeers(cfg.peers))
+
+	if cfg.httpAddr != "" {
+		mux := http.NewServeMux()
+		mgr.RegisterDebugHandler(mux)
+		mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+			if !p.Health().Healthy {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			fmt.Fprintln(w, vc.FormatClock(mgr.GetClock(cfg.id)))
+		})
+		srv := &http.Server{Addr: cfg.httpAddr, Handler: mux}
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Printf("vcnode: http: %v", err)
+			}
+		}()
+		defer srv.Close()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if cfg.duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.duration)
+		defer cancel()
+	}
+
+	if cfg.sendEvery > 0 && len(cfg.peers) > 0 {
+		go workload(ctx, p, sortedPeers(cfg.peers), cfg.sendEvery)
+	}
+	<-ctx.Done()
+
+	fmt.Printf("process %d final clock %s\n", cfg.id, vc.FormatClock(mgr.GetClock(cfg.id)))
+	return nil
+}
+
+// workload interval 마다 임의의 상대에게 메시지 전송
+func workload(ctx context.Context, p *vc.Process, peers []int, interval time.Duration) {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano() + int64(p.ID)))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for n := 1; ; n++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		to := peers[rng.Intn(len(peers))]
+		if err := p.Send(to, fmt.Sprintf("tick %d from %d", n, p.ID)); err != nil {
+			log.Printf("vcnode: send to %d: %v", to, err)
+		}
+	}
+}
+
+// sortedPeers 상대 프로세스 ID (오름차순)
+func sortedPeers(peers map[int]string) []int {
+	ids := make([]int, 0, len(peers))
+	for id := range peers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// env 환경 변수 대신 쓰는 맵
+func env(vars map[string]string) func(string) string {
+	return func(name string) string { return vars[name] }
+}
+
+func TestParseConfigFlags(t *testing.T) {
+	cfg, err := parseConfig([]string{"-id", "1", "-peers", "0=a:7000, 3=d:7000", "-send-every", "250ms"}, env(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.id != 1 || cfg.size != 4 || cfg.sendEvery != 250*time.Millisecond || cfg.listen != ":7000" {
+		t.Fatalf("config = %+v", cfg)
+	}
+	if want := map[int]string{0: "a:7000", 3: "d:7000"}; !reflect.DeepEqual(cfg.peers, want) {
+		t.Fatalf("peers = %v, want %v", cfg.peers, want)
+	}
+}
+
+func TestParseConfigEnvironment(t *testing.T) {
+	vars := map[string]string{"VCNODE_ID": "2", "VCNODE_SEND_EVERY": "1s", "VCNODE_QUIET": "true", "VCNODE_N": "5"}
+	cfg, err := parseConfig(nil, env(vars))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.id != 2 || cfg.sendEvery != time.Second || !cfg.quiet || cfg.size != 5 {
+		t.Fatalf("config = %+v", cfg)
+	}
+	// 플래그가 환경 변수보다 우선
+	if cfg, err = parseConfig([]string{"-id", "0"}, env(vars)); err != nil || cfg.id != 0 {
+		t.Fatalf("flag over environment = %+v, %v", cfg, err)
+	}
+	if _, err := parseConfig(nil, env(map[string]string{"VCNODE_ID": "x"})); err == nil {
+		t.Fatal("bad VCNODE_ID accepted")
+	}
+}
+
+func TestParseConfigRejectsBadSettings(t *testing.T) {
+	for name, args := range map[string][]string{
+		"missing id":   {},
+		"own id":       {"-id", "0", "-peers", "0=a:1"},
+		"small n":      {"-id", "0", "-n", "2", "-peers", "4=e:1"},
+		"half tls":     {"-id", "0", "-tls-cert", "cert.pem"},
+		"bad peer":     {"-id", "0", "-peers", "1"},
+		"peer twice":   {"-id", "0", "-peers", "1=a:1,1=b:1"},
+		"negative id":  {"-id", "0", "-peers", "-1=a:1"},
+		"unknown flag": {"-id", "0", "-bogus"},
+	} {
+		if _, err := parseConfig(args, env(nil)); err == nil {
+			t.Errorf("%s: parseConfig(%q) succeeded", name, args)
+		}
+	}
+}
+
+func TestSortedPeers(t *testing.T) {
+	if got := sortedPeers(map[int]string{3: "d", 0: "a", 2: "c"}); !reflect.DeepEqual(got, []int{0, 2, 3}) {
+		t.Fatalf("sortedPeers = %v", got)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+	"github.com/seoyhaein/vectorclock/sim"
+)
+
+// runAnalyze analyze 명령: 트레이스의 요약, 인과 일관성 검사, Timestamp 역전 출력 (위반이 있으면 종료 코드 1)
+func runAnalyze(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	strict := fs.Bool("strict", false, "Timestamp 역전도 위반으로 취급")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: vectorclock analyze [-strict] trace.jsonl|trace.parquet")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("analyze: expected one trace file")
+	}
+
+	events, err := loadTrace(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	summarize(stdout, events)
+
+	report := sim.CheckCausal(events)
+	fmt.Fprintln(stdout, report)
+	inversions := sim.TimestampInversions(events)
+	fmt.Fprintf(stdout, "timestamp inversions: %d\n", len(inversions))
+	for _, inv := range inversions {
+		fmt.Fprintf(stdout, "  %v\n", inv)
+	}
+
+	if !report.OK() || (*strict && len(inversions) > 0) {
+		return errViolations
+	}
+	return nil
+}
+
+// summarize 프로세스별 이벤트 수와 마지막 시계 출력
+func summarize(w io.Writer, events []vc.Event) {
+	type stats struct {
+		counts map[vc.EventKind]int
+		last   []int
+	}
+	byProcess := make(map[int]*stats)
+	var ids []int
+	for _, e := range events {
+		if e.Domain != "" {
+			continue
+		}
+		s, ok := byProcess[e.Process]
+		if !ok {
+			s = &stats{counts: make(map[vc.EventKind]int)}
+			byProcess[e.Process] = s
+			ids = append(ids, e.Process)
+		}
+		s.counts[e.Kind]++
+		s.last = e.Clock
+	}
+	sort.Ints(ids)
+
+	fmt.Fprintf(w, "%d events, %d processes\n", len(events), len(ids))
+	for _, id := range ids {
+		s := byProcess[id]
+		fmt.Fprintf(w, "  P%d local=%d send=%d recv=%d drop=%d last=%s\n", id,
+			s.counts[vc.EventLocal], s.counts[vc.EventSend], s.counts[vc.EventReceive], s.counts[vc.EventDrop],
+			vc.FormatClock(s.last))
+	}
+}
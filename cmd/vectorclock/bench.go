@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/seoyhaein/vectorclock/bench"
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// runBench bench 명령: N 개 프로세스에 부하를 걸고 처리량, 지연 백분위, 할당, 시계 연산 비용 출력
+func runBench(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	n := fs.Int("n", bench.DefaultN, "프로세스 수")
+	messages := fs.Int("messages", bench.DefaultMessages, "프로세스마다 보낼 메시지 수")
+	rate := fs.Int("rate", 0, "프로세스마다 초당 보낼 메시지 수 (0 이면 제한 없음)")
+	pattern := fs.String("pattern", string(bench.PatternRing), "받는 쪽 고르는 방식 (ring, all, random)")
+	mailbox := fs.Int("mailbox", bench.DefaultMailbox, "메일박스 크기")
+	ring := fs.Bool("ring", false, "채널 대신 링 버퍼 메일박스 사용")
+	mode := fs.String("mode", vc.ClockPerProcess.String(), "시계 유지 단위 (per-process, per-channel)")
+	ops := fs.Int("ops", bench.DefaultOpIterations, "시계 연산마다 반복 횟수 (-1 이면 재지 않음)")
+	locks := fs.Bool("locks", false, "잠금 경합 계측")
+	seed := fs.Int64("seed", 1, "random 패턴의 시드")
+	asJSON := fs.Bool("json", false, "결과를 JSON 으로 출력")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("bench: unexpected arguments %v", fs.Args())
+	}
+	clockMode, ok := vc.ParseClockMode(*mode)
+	if !ok {
+		return fmt.Errorf("bench: unknown clock mode %q", *mode)
+	}
+
+	res, err := bench.Run(bench.Config{
+		N:            *n,
+		Messages:     *messages,
+		Rate:         *rate,
+		Pattern:      bench.Pattern(*pattern),
+		Mailbox:      *mailbox,
+		Ring:         *ring,
+		Mode:         clockMode,
+		OpIterations: *ops,
+		LockProfile:  *locks,
+		Seed:         *seed,
+	})
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	}
+	return res.WriteText(stdout)
+}
@@ -0,0 +1,84 @@
+// Command vectorclock Vector Clock 시뮬레이션과 트레이스 분석 도구
+//
+//	vectorclock simulate  [-file scenario.yaml | -scenario name] [-seed N] [-out trace.jsonl]  시나리오 실행
+//	vectorclock analyze   trace.jsonl                                                         인과 일관성 보고
+//	vectorclock visualize [-format dot|mermaid|svg] [-o out] trace.jsonl                       시공간 그림 출력
+//	vectorclock bench     [-n 8] [-messages 10000] [-rate 5000] [-mode per-channel] [-json]    처리량, 지연, 할당, 연산 비용 측정
+//
+// 트레이스 파일은 확장자로 형식을 정한다 (.jsonl, .parquet, simulate -out 은 .csv 도 가능).
+// 하위 명령 없이 실행하면 (플래그만 주어도) simulate 로 실행하며, 기본 시나리오는 표준 시나리오 request-reply 이다.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+// errViolations 분석한 트레이스에서 위반이 발견됨 (종료 코드 1, 보고는 이미 출력됨)
+var errViolations = errors.New("causality violations found")
+
+// command 하위 명령
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout io.Writer) error
+}
+
+// commands 하위 명령 목록 (도움말에 나오는 순서)
+var commands = []command{
+	{"simulate", "시나리오 파일 또는 표준 시나리오를 실행하고 트레이스 저장", runSimulate},
+	{"analyze", "트레이스 파일의 인과 일관성과 Timestamp 역전 보고", runAnalyze},
+	{"visualize", "트레이스 파일을 DOT / Mermaid / SVG 시공간 그림으로 출력", runVisualize},
+	{"bench", "프로세스 수와 송신 속도를 정해 처리량, 지연 백분위, 할당, 시계 연산 비용 측정", runBench},
+}
+
+func main() {
+	log.SetFlags(0)
+	if err := dispatch(os.Args[1:], os.Stdout); err != nil {
+		if err == flag.ErrHelp {
+			return
+		}
+		if err != errViolations {
+			log.Printf("vectorclock: %v", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// dispatch 첫 인자로 하위 명령을 골라 실행 (인자가 없거나 플래그로 시작하면 simulate)
+func dispatch(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return runSimulate(nil, stdout)
+	}
+	name := args[0]
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		usage(stdout)
+		return nil
+	}
+	if strings.HasPrefix(name, "-") {
+		return runSimulate(args, stdout)
+	}
+	for _, c := range commands {
+		if c.name == name {
+			return c.run(args[1:], stdout)
+		}
+	}
+	usage(os.Stderr)
+	return fmt.Errorf("unknown command %q", name)
+}
+
+// usage 하위 명령 목록 출력
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: vectorclock <command> [flags]")
+	fmt.Fprintln(w)
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "vectorclock <command> -h 로 명령별 플래그를 볼 수 있다.")
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seoyhaein/vectorclock/bench"
+)
+
+// runCommand dispatch 를 실행하고 표준 출력을 반환
+func runCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	var out bytes.Buffer
+	if err := dispatch(args, &out); err != nil {
+		t.Fatalf("vectorclock %s: %v\n%s", strings.Join(args, " "), err, out.String())
+	}
+	return out.String()
+}
+
+func TestSimulateAnalyzeVisualize(t *testing.T) {
+	for _, ext := range []string{".jsonl", ".parquet"} {
+		t.Run(ext, func(t *testing.T) {
+			trace := filepath.Join(t.TempDir(), "trace"+ext)
+			if out := runCommand(t, "simulate", "-quiet", "-out", trace); !strings.Contains(out, "written to "+trace) {
+				t.Fatalf("simulate output:\n%s", out)
+			}
+			events, err := loadTrace(trace)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) == 0 {
+				t.Fatal("trace has no events")
+			}
+
+			out := runCommand(t, "analyze", trace)
+			if !strings.Contains(out, "timestamp inversions: 0") {
+				t.Fatalf("analyze output:\n%s", out)
+			}
+			if out := runCommand(t, "visualize", "-format", "mermaid", trace); !strings.HasPrefix(out, "sequenceDiagram\n") {
+				t.Fatalf("visualize output:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestDispatchRejectsUnknownCommand(t *testing.T) {
+	var out bytes.Buffer
+	if err := dispatch([]string{"teleport"}, &out); err == nil {
+		t.Fatal("unknown command succeeded")
+	}
+	if err := dispatch([]string{"analyze"}, &out); err == nil {
+		t.Fatal("analyze without a trace file succeeded")
+	}
+	if err := saveTrace(filepath.Join(t.TempDir(), "trace.txt"), nil); err == nil {
+		t.Fatal("saveTrace accepted an unknown extension")
+	}
+	if help := runCommand(t, "help"); !strings.Contains(help, "analyze") {
+		t.Fatalf("help output:\n%s", help)
+	}
+}
+
+func TestSimulateStandardScenarios(t *testing.T) {
+	list := runCommand(t, "simulate", "-list")
+	for _, name := range []string{"request-reply", "broadcast-storm", "partition-and-heal", "concurrent-writers"} {
+		if !strings.Contains(list, name) {
+			t.Fatalf("-list output misses %s:\n%s", name, list)
+		}
+	}
+	out := runCommand(t, "simulate", "-quiet", "-scenario", "broadcast-storm", "-n", "3", "-rounds", "1", "-seed", "7")
+	if !strings.HasPrefix(out, "broadcast-storm: seed 7, 3 processes, 12 steps") {
+		t.Fatalf("simulate output:\n%s", out)
+	}
+	var buf bytes.Buffer
+	if err := dispatch([]string{"simulate", "-scenario", "gossip"}, &buf); err == nil {
+		t.Fatal("simulate accepted an unknown scenario")
+	}
+	if err := dispatch([]string{"simulate", "-file", "s.yaml", "-scenario", "request-reply"}, &buf); err == nil {
+		t.Fatal("simulate accepted both -file and -scenario")
+	}
+}
+
+func TestBenchCommand(t *testing.T) {
+	var res bench.Result
+	out := runCommand(t, "bench", "-n", "2", "-messages", "50", "-ops", "-1", "-ring", "-json")
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("bench -json output:\n%s", out)
+	}
+	if res.Received != 100 || res.Mailbox != "ring(128)" || res.Ops != nil {
+		t.Fatalf("bench result = %+v", res)
+	}
+	if out := runCommand(t, "bench", "-n", "2", "-messages", "10", "-ops", "2"); !strings.Contains(out, "get-clock") {
+		t.Fatalf("bench output:\n%s", out)
+	}
+	var buf bytes.Buffer
+	if err := dispatch([]string{"bench", "-mode", "per-domain"}, &buf); err == nil {
+		t.Fatal("bench accepted an unknown clock mode")
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+	"github.com/seoyhaein/vectorclock/scenarios"
+	"github.com/seoyhaein/vectorclock/sim"
+	_ "github.com/seoyhaein/vectorclock/sim/simyaml" // .yaml / .yml 시나리오 파일
+)
+
+// defaultScenario 파일도 시나리오 이름도 없을 때 실행하는 표준 시나리오
+const defaultScenario = "request-reply"
+
+// runSimulate simulate 명령: 시나리오 파일 또는 표준 시나리오를 실행하고 최종 시계 출력, -out 이 있으면 트레이스 저장
+func runSimulate(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	file := fs.String("file", "", "실행할 시나리오 파일 (.yaml / .json)")
+	name := fs.String("scenario", "", "실행할 표준 시나리오 이름 (-list 로 목록, 파일과 이름이 모두 없으면 "+defaultScenario+")")
+	n := fs.Int("n", 0, "표준 시나리오의 프로세스 수 (0 이면 시나리오 기본값)")
+	rounds := fs.Int("rounds", 0, "표준 시나리오의 반복 횟수 (0 이면 시나리오 기본값)")
+	list := fs.Bool("list", false, "표준 시나리오 목록 출력")
+	seed := fs.Int64("seed", 0, "시나리오의 루트 시드 (0 이면 파일 설정 또는 현재 시각)")
+	out := fs.String("out", "", "트레이스를 저장할 파일 (.jsonl, .parquet, .csv)")
+	quiet := fs.Bool("quiet", false, "프로세스 로그를 출력하지 않음")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("simulate: unexpected arguments %v", fs.Args())
+	}
+
+	if *list {
+		for _, name := range scenarios.Names() {
+			e, _ := scenarios.Lookup(name)
+			fmt.Fprintf(stdout, "  %-20s %s (n=%d, rounds=%d)\n", name, e.Summary, e.N, e.Rounds)
+		}
+		return nil
+	}
+	if *file != "" && *name != "" {
+		return fmt.Errorf("simulate: -file and -scenario are mutually exclusive")
+	}
+
+	var opts []vc.ManagerOption
+	if *quiet {
+		opts = append(opts, vc.WithLogger(vc.NopLogger))
+	}
+
+	var s *sim.Scenario
+	var err error
+	source := *file
+	if *file != "" {
+		s, err = sim.LoadFile(*file, opts...)
+	} else {
+		if source = *name; source == "" {
+			source = defaultScenario
+		}
+		s, err = scenarios.Build(source, *n, *rounds, opts...)
+	}
+	if err != nil {
+		return err
+	}
+	if *seed != 0 {
+		s.Seed(*seed)
+	}
+	res, err := s.Run()
+	if err != nil {
+		return fmt.Errorf("simulate %s: %w", source, err)
+	}
+	fmt.Fprintf(stdout, "%s: seed %d, %d processes, %d steps, %d events, %d dropped, %d faults\n",
+		source, res.Seed, s.N, len(res.Order), len(res.Events), len(res.Dropped), len(res.Faults))
+	for id, clock := range res.Clocks {
+		fmt.Fprintf(stdout, "P%d %s\n", id, vc.FormatClock(clock))
+	}
+
+	if *out != "" {
+		if err := saveTrace(*out, res.Events); err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "trace: %d events written to %s\n", len(res.Events), *out)
+	}
+	return nil
+}
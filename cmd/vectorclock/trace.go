@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seoyhaein/vectorclock/export"
+	"github.com/seoyhaein/vectorclock/export/parquet"
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// loadTrace 트레이스 파일 읽기 (.parquet 이면 Parquet, 그 외는 JSON Lines)
+func loadTrace(path string) ([]vc.Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if !strings.EqualFold(filepath.Ext(path), ".parquet") {
+		events, err := export.ReadJSONL(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return events, nil
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := parquet.Read(f, info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	events := make([]vc.Event, len(rows))
+	for i, row := range rows {
+		if events[i], err = row.Event(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return events, nil
+}
+
+// saveTrace 트레이스 파일 쓰기 (확장자 .jsonl / .json, .parquet, .csv 로 형식 선택)
+func saveTrace(path string, events []vc.Event) error {
+	var write func(f *os.File) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".json", ".ndjson":
+		write = func(f *os.File) error { return export.WriteJSONL(f, events) }
+	case ".parquet":
+		write = func(f *os.File) error { return parquet.Write(f, events) }
+	case ".csv":
+		write = func(f *os.File) error { return export.WriteCSV(f, events) }
+	default:
+		return fmt.Errorf("%s: unknown trace format (use .jsonl, .parquet or .csv)", path)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return f.Close()
+}
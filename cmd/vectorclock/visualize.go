@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seoyhaein/vectorclock/export"
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// diagramWriters visualize 형식별 출력 함수
+var diagramWriters = map[string]func(io.Writer, []vc.Event) error{
+	"dot":     export.WriteDOT,
+	"mermaid": export.WriteMermaid,
+	"svg":     export.WriteSVG,
+}
+
+// runVisualize visualize 명령: 트레이스를 시공간 그림으로 출력 (-o 가 없으면 표준 출력)
+func runVisualize(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("visualize", flag.ContinueOnError)
+	format := fs.String("format", "", "출력 형식 dot | mermaid | svg (비어 있으면 -o 확장자, 그것도 없으면 dot)")
+	out := fs.String("o", "", "출력 파일 (비어 있으면 표준 출력)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: vectorclock visualize [-format dot|mermaid|svg] [-o file] trace.jsonl|trace.parquet")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("visualize: expected one trace file")
+	}
+
+	name := strings.ToLower(*format)
+	if name == "" {
+		switch strings.ToLower(filepath.Ext(*out)) {
+		case ".svg":
+			name = "svg"
+		case ".mmd", ".mermaid", ".md":
+			name = "mermaid"
+		default:
+			name = "dot"
+		}
+	}
+	write, ok := diagramWriters[name]
+	if !ok {
+		return fmt.Errorf("visualize: unknown format %q (use dot, mermaid or svg)", *format)
+	}
+
+	events, err := loadTrace(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		return write(stdout, events)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := write(f, events); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,46 @@
+package codec
+
+import (
+	"github.com/fxamacker/cbor/v2"
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// cborEnc 결정적 인코딩 (RFC 8949 Core Deterministic, 같은 메시지는 항상 같은 바이트)
+var cborEnc = func() cbor.EncMode {
+	em, err := cbor.CoreDetEncOptions().EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return em
+}()
+
+// MarshalCBOR 메시지를 CBOR 맵으로 직렬화 (키는 JSON 과 같은 이름, 빈 선택 필드는 생략)
+//
+// 맵 키를 정렬하는 결정적 인코딩이라 서명이나 해시 대상으로 그대로 쓸 수 있다.
+// MessagePack 처럼 destClocks 의 키는 정수 프로세스 ID 이다.
+func MarshalCBOR(msg vc.Message) ([]byte, error) {
+	return cborEnc.Marshal(msg.Wire())
+}
+
+// UnmarshalCBOR CBOR 메시지를 읽어 현재 버전으로 옮김 (모르는 키는 무시)
+func UnmarshalCBOR(data []byte) (vc.Message, error) {
+	var w vc.WireMessage
+	if err := cbor.Unmarshal(data, &w); err != nil {
+		return vc.Message{}, err
+	}
+	return w.Decode()
+}
+
+// MarshalClockCBOR Vector Clock 을 CBOR 정수 배열로 직렬화 (CoAP 페이로드 등에 실을 때)
+func MarshalClockCBOR(clock []int) ([]byte, error) {
+	return cborEnc.Marshal(clock)
+}
+
+// UnmarshalClockCBOR CBOR 정수 배열을 Vector Clock 으로 읽음
+func UnmarshalClockCBOR(data []byte) ([]int, error) {
+	var clock []int
+	if err := cbor.Unmarshal(data, &clock); err != nil {
+		return nil, err
+	}
+	return clock, nil
+}
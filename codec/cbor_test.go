@@ -0,0 +1,77 @@
+package codec
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestCBORRoundTrip(t *testing.T) {
+	want := sampleMessage()
+	data, err := MarshalCBOR(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalCBOR(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Wire(), want.Wire()) {
+		t.Fatalf("round trip:\n got %+v\nwant %+v", got.Wire(), want.Wire())
+	}
+}
+
+func TestCBORIsDeterministic(t *testing.T) {
+	a := sampleMessage()
+	b := sampleMessage()
+	// 같은 내용의 맵을 다른 순서로 채워도 같은 바이트
+	b.DestClocks = map[int][]int{}
+	for _, id := range []int{2, 0} {
+		b.DestClocks[id] = a.DestClocks[id]
+	}
+	first, err := MarshalCBOR(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		again, err := MarshalCBOR(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(first, again) {
+			t.Fatalf("encoding %d differs:\n%x\n%x", i, first, again)
+		}
+	}
+}
+
+func TestCBORClock(t *testing.T) {
+	data, err := MarshalClockCBOR([]int{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 원소 3 개 배열, 작은 정수는 한 바이트
+	if want := []byte{0x83, 0x01, 0x02, 0x03}; !bytes.Equal(data, want) {
+		t.Fatalf("clock encoding = %x, want %x", data, want)
+	}
+	clock, err := UnmarshalClockCBOR(data)
+	if err != nil || !reflect.DeepEqual(clock, []int{1, 2, 3}) {
+		t.Fatalf("UnmarshalClockCBOR = %v, %v", clock, err)
+	}
+}
+
+func TestUnmarshalCBORRejectsGarbage(t *testing.T) {
+	data, err := MarshalCBOR(vc.Message{From: 1, Vector: []int{1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n := 0; n < len(data); n++ {
+		if _, err := UnmarshalCBOR(data[:n]); err == nil {
+			t.Fatalf("truncated to %d of %d bytes: no error", n, len(data))
+		}
+	}
+	if _, err := UnmarshalClockCBOR([]byte{0x83, 0x01}); err == nil {
+		t.Fatal("truncated clock: no error")
+	}
+}
@@ -0,0 +1,9 @@
+// Package codec process.Message 의 이진 직렬화 형식
+//
+// FlatBuffers(MarshalFlat / ReadFlat) 는 버퍼를 복사하거나 풀지 않고 필드를 바로 읽을 수 있어,
+// 처리량이 많은 전송 계층에서 디코딩 비용을 줄인다. 스키마는 message.fbs 에 있다.
+//
+// MessagePack(MarshalMsgpack / UnmarshalMsgpack) 은 스키마 없이 JSON 과 같은 키 이름을 쓰는 작은 형식으로,
+// 동적 언어로 작성된 노드나 도구와 메시지를 주고받을 때 쓴다. CBOR(MarshalCBOR / UnmarshalCBOR) 는 같은 키 이름의
+// 결정적 인코딩으로, CoAP 같은 IoT 페이로드에 메시지나 Vector Clock 을 실을 때 쓴다.
+package codec
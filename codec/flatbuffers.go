@@ -0,0 +1,426 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// flatIdentifier FlatBuffers 메시지 파일 식별자 (message.fbs 의 file_identifier)
+const flatIdentifier = "VCMS"
+
+// ErrNotFlatMessage FlatBuffers 메시지가 아니거나 잘린 버퍼
+var ErrNotFlatMessage = errors.New("codec: not a flatbuffers message")
+
+// Message 테이블 필드의 vtable 위치 (message.fbs 의 필드 순서)
+const (
+	flatVersion flatbuffers.VOffsetT = 4 + 2*iota
+	flatFrom
+	flatTo
+	flatVector
+	flatEvent
+	flatMessageID
+	flatTimestamp
+	flatEpoch
+	flatDomain
+	flatTopic
+	flatKind
+	flatReplyTo
+	flatCausalParent
+	flatTraceID
+	flatSignature
+	flatCausal
+	flatDestClocks
+	flatFields = iota
+)
+
+// DestClock 테이블 필드의 vtable 위치
+const (
+	flatDestProcess flatbuffers.VOffsetT = 4 + 2*iota
+	flatDestClock
+	flatDestFields = iota
+)
+
+// MarshalFlat 메시지를 FlatBuffers 로 직렬화
+func MarshalFlat(msg vc.Message) []byte {
+	return AppendFlat(flatbuffers.NewBuilder(256), msg)
+}
+
+// AppendFlat 빌더 b 를 비우고 메시지를 직렬화 (빌더를 재사용해 할당을 줄일 때, 결과는 다음 호출 전까지 유효)
+func AppendFlat(b *flatbuffers.Builder, msg vc.Message) []byte {
+	b.Reset()
+
+	// 하위 객체를 테이블보다 먼저 만든다
+	vector := flatInts(b, msg.Vector)
+	event := b.CreateString(msg.Event)
+	id := b.CreateString(msg.MessageID)
+	domain := optString(b, msg.Domain)
+	topic := optString(b, msg.Topic)
+	replyTo := optString(b, msg.ReplyTo)
+	parent := optString(b, msg.CausalParent)
+	trace := optString(b, msg.TraceID)
+	var signature, causal, dests flatbuffers.UOffsetT
+	if len(msg.Signature) > 0 {
+		signature = b.CreateByteVector(msg.Signature)
+	}
+	if len(msg.Causal) > 0 {
+		causal = flatInts(b, msg.Causal)
+	}
+	if len(msg.DestClocks) > 0 {
+		dests = flatDests(b, msg.DestClocks)
+	}
+
+	b.StartObject(flatFields)
+	b.PrependInt32Slot(slot(flatVersion), vc.WireVersion, 0)
+	b.PrependInt32Slot(slot(flatFrom), int32(msg.From), 0)
+	b.PrependInt32Slot(slot(flatTo), int32(msg.To), 0)
+	b.PrependUOffsetTSlot(slot(flatVector), vector, 0)
+	b.PrependUOffsetTSlot(slot(flatEvent), event, 0)
+	b.PrependUOffsetTSlot(slot(flatMessageID), id, 0)
+	b.PrependInt64Slot(slot(flatTimestamp), msg.Timestamp, 0)
+	b.PrependInt32Slot(slot(flatEpoch), int32(msg.Epoch), 0)
+	b.PrependUOffsetTSlot(slot(flatDomain), domain, 0)
+	b.PrependUOffsetTSlot(slot(flatTopic), topic, 0)
+	b.PrependInt32Slot(slot(flatKind), int32(msg.Kind), 0)
+	b.PrependUOffsetTSlot(slot(flatReplyTo), replyTo, 0)
+	b.PrependUOffsetTSlot(slot(flatCausalParent), parent, 0)
+	b.PrependUOffsetTSlot(slot(flatTraceID), trace, 0)
+	b.PrependUOffsetTSlot(slot(flatSignature), signature, 0)
+	b.PrependUOffsetTSlot(slot(flatCausal), causal, 0)
+	b.PrependUOffsetTSlot(slot(flatDestClocks), dests, 0)
+	b.FinishWithFileIdentifier(b.EndObject(), []byte(flatIdentifier))
+	return b.FinishedBytes()
+}
+
+// slot vtable 위치를 필드 번호로
+func slot(o flatbuffers.VOffsetT) int {
+	return int(o-4) / 2
+}
+
+// optString 비어 있지 않은 문자열만 생성 (비어 있으면 필드를 생략)
+func optString(b *flatbuffers.Builder, s string) flatbuffers.UOffsetT {
+	if s == "" {
+		return 0
+	}
+	return b.CreateString(s)
+}
+
+// flatInts [long] 벡터 생성
+func flatInts(b *flatbuffers.Builder, v []int) flatbuffers.UOffsetT {
+	b.StartVector(8, len(v), 8)
+	for i := len(v) - 1; i >= 0; i-- {
+		b.PrependInt64(int64(v[i]))
+	}
+	return b.EndVector(len(v))
+}
+
+// flatDests [DestClock] 벡터 생성 (프로세스 ID 순서)
+func flatDests(b *flatbuffers.Builder, dests map[int][]int) flatbuffers.UOffsetT {
+	ids := sortedKeys(dests)
+	tables := make([]flatbuffers.UOffsetT, len(ids))
+	for i, id := range ids {
+		clock := flatInts(b, dests[id])
+		b.StartObject(flatDestFields)
+		b.PrependInt32Slot(slot(flatDestProcess), int32(id), 0)
+		b.PrependUOffsetTSlot(slot(flatDestClock), clock, 0)
+		tables[i] = b.EndObject()
+	}
+	return b.CreateVectorOfTables(tables)
+}
+
+// FlatMessage 직렬화된 버퍼를 그대로 읽는 메시지 (필드에 접근할 때만 해당 바이트를 읽음)
+//
+// 문자열 접근자는 버퍼를 가리키는 []byte 를 반환하므로, 버퍼를 재사용하기 전에 필요한 값은 복사해야 한다.
+type FlatMessage struct {
+	tab flatbuffers.Table
+}
+
+// ReadFlat buf 를 복사하지 않고 FlatMessage 로 읽음
+//
+// 식별자와 루트 위치, 그리고 모든 테이블, vtable, 필드, 벡터와 문자열의 위치와 길이가 버퍼 안에 있는지
+// 먼저 검사하므로, 손상되거나 조작된 버퍼는 접근자에서 패닉 대신 여기서 ErrNotFlatMessage 로 거부된다.
+func ReadFlat(buf []byte) (*FlatMessage, error) {
+	if len(buf) < flatbuffers.SizeUOffsetT+len(flatIdentifier) ||
+		!flatbuffers.BufferHasIdentifier(buf, flatIdentifier) {
+		return nil, ErrNotFlatMessage
+	}
+	root := flatbuffers.GetUOffsetT(buf)
+	if err := verifyFlat(buf, root); err != nil {
+		return nil, err
+	}
+	return &FlatMessage{tab: flatbuffers.Table{Bytes: buf, Pos: root}}, nil
+}
+
+// flatTable 범위를 검사한 테이블 (vtable 과 테이블 본문이 모두 버퍼 안)
+type flatTable struct {
+	buf    []byte
+	pos    int // 테이블 시작 위치
+	vtable int // vtable 시작 위치
+	vsize  int // vtable 크기 (바이트)
+	size   int // 테이블 본문 크기 (바이트)
+}
+
+// within buf 의 pos 부터 size 바이트가 버퍼 안에 있는지
+func within(buf []byte, pos, size uint64) bool {
+	return pos <= uint64(len(buf)) && size <= uint64(len(buf))-pos
+}
+
+// checkTable 위치 pos 의 테이블과 그 vtable 범위 검사
+func checkTable(buf []byte, pos uint64) (flatTable, error) {
+	if !within(buf, pos, flatbuffers.SizeSOffsetT) {
+		return flatTable{}, fmt.Errorf("%w: table at %d beyond %d bytes", ErrNotFlatMessage, pos, len(buf))
+	}
+	vtable := int64(pos) - int64(flatbuffers.GetSOffsetT(buf[pos:]))
+	if vtable < 0 || !within(buf, uint64(vtable), 2*flatbuffers.SizeVOffsetT) {
+		return flatTable{}, fmt.Errorf("%w: vtable of table at %d out of range", ErrNotFlatMessage, pos)
+	}
+	vsize := int(flatbuffers.GetVOffsetT(buf[vtable:]))
+	size := int(flatbuffers.GetVOffsetT(buf[vtable+flatbuffers.SizeVOffsetT:]))
+	switch {
+	case vsize < 2*flatbuffers.SizeVOffsetT || vsize%flatbuffers.SizeVOffsetT != 0 ||
+		!within(buf, uint64(vtable), uint64(vsize)):
+		return flatTable{}, fmt.Errorf("%w: vtable of table at %d has bad size %d", ErrNotFlatMessage, pos, vsize)
+	case size < flatbuffers.SizeSOffsetT || !within(buf, pos, uint64(size)):
+		return flatTable{}, fmt.Errorf("%w: table at %d has bad size %d", ErrNotFlatMessage, pos, size)
+	}
+	return flatTable{buf: buf, pos: int(pos), vtable: int(vtable), vsize: vsize, size: size}, nil
+}
+
+// field 필드의 테이블 안 위치를 width 바이트 값이 본문 안에 들어가는지 검사해 반환 (없으면 0)
+func (t flatTable) field(field flatbuffers.VOffsetT, width int) (int, error) {
+	if int(field)+flatbuffers.SizeVOffsetT > t.vsize {
+		return 0, nil
+	}
+	o := int(flatbuffers.GetVOffsetT(t.buf[t.vtable+int(field):]))
+	if o != 0 && (o < flatbuffers.SizeSOffsetT || o+width > t.size) {
+		return 0, fmt.Errorf("%w: field %d of table at %d overruns the table", ErrNotFlatMessage, slot(field), t.pos)
+	}
+	return o, nil
+}
+
+// scalars 고정 크기 필드 검사
+func (t flatTable) scalars(width int, fields ...flatbuffers.VOffsetT) error {
+	for _, f := range fields {
+		if _, err := t.field(f, width); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vector 벡터(문자열 포함) 필드가 가리키는 위치와 길이 검사 후 원소 시작 위치와 원소 수 반환 (없으면 0, 0)
+//
+// 원소 수는 남은 바이트로 담을 수 있는 수를 넘을 수 없다.
+func (t flatTable) vector(field flatbuffers.VOffsetT, elem int) (start uint64, n int, err error) {
+	o, err := t.field(field, flatbuffers.SizeUOffsetT)
+	if err != nil || o == 0 {
+		return 0, 0, err
+	}
+	at := uint64(t.pos + o)
+	vec := at + uint64(flatbuffers.GetUOffsetT(t.buf[at:]))
+	if !within(t.buf, vec, flatbuffers.SizeUOffsetT) {
+		return 0, 0, fmt.Errorf("%w: field %d of table at %d points beyond %d bytes", ErrNotFlatMessage, slot(field), t.pos, len(t.buf))
+	}
+	count := uint64(flatbuffers.GetUOffsetT(t.buf[vec:]))
+	start = vec + flatbuffers.SizeUOffsetT
+	if count > (uint64(len(t.buf))-start)/uint64(elem) {
+		return 0, 0, fmt.Errorf("%w: field %d of table at %d has %d elements, only %d bytes remain",
+			ErrNotFlatMessage, slot(field), t.pos, count, uint64(len(t.buf))-start)
+	}
+	return start, int(count), nil
+}
+
+// vectors 벡터 필드 검사
+func (t flatTable) vectors(elem int, fields ...flatbuffers.VOffsetT) error {
+	for _, f := range fields {
+		if _, _, err := t.vector(f, elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyFlat 위치 root 의 Message 테이블과 모든 하위 객체의 범위 검사
+func verifyFlat(buf []byte, root flatbuffers.UOffsetT) error {
+	t, err := checkTable(buf, uint64(root))
+	if err != nil {
+		return err
+	}
+	if err := t.scalars(4, flatVersion, flatFrom, flatTo, flatEpoch, flatKind); err != nil {
+		return err
+	}
+	if err := t.scalars(8, flatTimestamp); err != nil {
+		return err
+	}
+	if err := t.vectors(1, flatEvent, flatMessageID, flatDomain, flatTopic, flatReplyTo,
+		flatCausalParent, flatTraceID, flatSignature); err != nil {
+		return err
+	}
+	if err := t.vectors(8, flatVector, flatCausal); err != nil {
+		return err
+	}
+
+	start, n, err := t.vector(flatDestClocks, flatbuffers.SizeUOffsetT)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		at := start + uint64(i*flatbuffers.SizeUOffsetT)
+		d, err := checkTable(buf, at+uint64(flatbuffers.GetUOffsetT(buf[at:])))
+		if err != nil {
+			return err
+		}
+		if err := d.scalars(4, flatDestProcess); err != nil {
+			return err
+		}
+		if err := d.vectors(8, flatDestClock); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// offset 필드의 위치 (없으면 0)
+func (m *FlatMessage) offset(field flatbuffers.VOffsetT) flatbuffers.UOffsetT {
+	return flatbuffers.UOffsetT(m.tab.Offset(field))
+}
+
+// int32 정수 필드
+func (m *FlatMessage) int32(field flatbuffers.VOffsetT) int {
+	if o := m.offset(field); o != 0 {
+		return int(m.tab.GetInt32(o + m.tab.Pos))
+	}
+	return 0
+}
+
+// bytes 문자열/바이트 필드 (버퍼를 가리킴)
+func (m *FlatMessage) bytes(field flatbuffers.VOffsetT) []byte {
+	if o := m.offset(field); o != 0 {
+		return m.tab.ByteVector(o + m.tab.Pos)
+	}
+	return nil
+}
+
+// ints [long] 벡터 필드를 새 슬라이스로
+func (m *FlatMessage) ints(field flatbuffers.VOffsetT) []int {
+	o := m.offset(field)
+	if o == 0 {
+		return nil
+	}
+	start, n := m.tab.Vector(o), m.tab.VectorLen(o)
+	out := make([]int, n)
+	for i := range out {
+		out[i] = int(m.tab.GetInt64(start + flatbuffers.UOffsetT(i*8)))
+	}
+	return out
+}
+
+// Version 메시지 형식 버전
+func (m *FlatMessage) Version() int { return m.int32(flatVersion) }
+
+// From 보낸 프로세스 ID
+func (m *FlatMessage) From() int { return m.int32(flatFrom) }
+
+// To 받는 프로세스 ID
+func (m *FlatMessage) To() int { return m.int32(flatTo) }
+
+// VectorLen Vector Clock 항목 수
+func (m *FlatMessage) VectorLen() int {
+	if o := m.offset(flatVector); o != 0 {
+		return m.tab.VectorLen(o)
+	}
+	return 0
+}
+
+// VectorAt Vector Clock 의 i 번째 항목 (전체를 풀지 않고 한 항목만 읽음)
+func (m *FlatMessage) VectorAt(i int) int {
+	o := m.offset(flatVector)
+	if o == 0 || i < 0 || i >= m.tab.VectorLen(o) {
+		return 0
+	}
+	return int(m.tab.GetInt64(m.tab.Vector(o) + flatbuffers.UOffsetT(i*8)))
+}
+
+// Vector Vector Clock 복사본
+func (m *FlatMessage) Vector() []int { return m.ints(flatVector) }
+
+// Event 메시지 내용 (버퍼를 가리킴)
+func (m *FlatMessage) Event() []byte { return m.bytes(flatEvent) }
+
+// MessageID 메시지 ID (버퍼를 가리킴)
+func (m *FlatMessage) MessageID() []byte { return m.bytes(flatMessageID) }
+
+// Timestamp 전송 시점
+func (m *FlatMessage) Timestamp() int64 {
+	if o := m.offset(flatTimestamp); o != 0 {
+		return m.tab.GetInt64(o + m.tab.Pos)
+	}
+	return 0
+}
+
+// Epoch 보낸 시점의 에포크
+func (m *FlatMessage) Epoch() int { return m.int32(flatEpoch) }
+
+// Domain 시계 도메인 (버퍼를 가리킴)
+func (m *FlatMessage) Domain() []byte { return m.bytes(flatDomain) }
+
+// Kind 메시지 종류
+func (m *FlatMessage) Kind() vc.MessageKind { return vc.MessageKind(m.int32(flatKind)) }
+
+// TraceID 애플리케이션 trace ID (버퍼를 가리킴)
+func (m *FlatMessage) TraceID() []byte { return m.bytes(flatTraceID) }
+
+// Message 모든 필드를 복사해 process.Message 로 변환 (이전 형식 버전이면 현재 버전으로 옮김)
+func (m *FlatMessage) Message() (vc.Message, error) {
+	w := vc.WireMessage{
+		Version:      m.Version(),
+		From:         m.From(),
+		To:           m.To(),
+		Vector:       m.Vector(),
+		Event:        string(m.Event()),
+		MessageID:    string(m.MessageID()),
+		Timestamp:    m.Timestamp(),
+		Epoch:        m.Epoch(),
+		Domain:       string(m.Domain()),
+		Topic:        string(m.bytes(flatTopic)),
+		Kind:         m.Kind(),
+		ReplyTo:      string(m.bytes(flatReplyTo)),
+		CausalParent: string(m.bytes(flatCausalParent)),
+		TraceID:      string(m.TraceID()),
+		Causal:       m.ints(flatCausal),
+	}
+	if sig := m.bytes(flatSignature); sig != nil {
+		w.Signature = append([]byte(nil), sig...)
+	}
+	if o := m.offset(flatDestClocks); o != 0 {
+		start, n := m.tab.Vector(o), m.tab.VectorLen(o)
+		w.DestClocks = make(map[int][]int, n)
+		for i := 0; i < n; i++ {
+			d := &FlatMessage{tab: flatbuffers.Table{Bytes: m.tab.Bytes, Pos: m.tab.Indirect(start + flatbuffers.UOffsetT(i*4))}}
+			w.DestClocks[d.int32(flatDestProcess)] = d.ints(flatDestClock)
+		}
+	}
+	return w.Decode()
+}
+
+// UnmarshalFlat FlatBuffers 버퍼를 process.Message 로 변환
+func UnmarshalFlat(buf []byte) (vc.Message, error) {
+	m, err := ReadFlat(buf)
+	if err != nil {
+		return vc.Message{}, err
+	}
+	return m.Message()
+}
+
+// sortedKeys 맵의 키 (오름차순, 인코딩 결과를 고정)
+func sortedKeys(m map[int][]int) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,154 @@
+package codec
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestReadFlatReadsFieldsInPlace(t *testing.T) {
+	msg := sampleMessage()
+	buf := MarshalFlat(msg)
+	m, err := ReadFlat(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Version() != vc.WireVersion || m.From() != 1 || m.To() != 2 || m.Kind() != vc.KindRequest {
+		t.Fatalf("header fields = v%d %d->%d %v", m.Version(), m.From(), m.To(), m.Kind())
+	}
+	if m.VectorLen() != 3 || m.VectorAt(2) != 4 || m.Timestamp() != msg.Timestamp {
+		t.Fatalf("vector len %d, entry 2 = %d, timestamp %d", m.VectorLen(), m.VectorAt(2), m.Timestamp())
+	}
+	if string(m.Event()) != "event" || string(m.TraceID()) != "trace" || string(m.Domain()) != "orders" {
+		t.Fatalf("strings = %q %q %q", m.Event(), m.TraceID(), m.Domain())
+	}
+	// 문자열 필드는 복사하지 않고 버퍼를 가리킴
+	if id := m.MessageID(); len(id) == 0 || &id[0] != &buf[indexOf(buf, id)] {
+		t.Fatal("MessageID copied the buffer")
+	}
+}
+
+// indexOf buf 에서 sub 가 처음 나오는 위치
+func indexOf(buf, sub []byte) int {
+	for i := 0; i+len(sub) <= len(buf); i++ {
+		if string(buf[i:i+len(sub)]) == string(sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestAppendFlatReusesBuilder(t *testing.T) {
+	b := flatbuffers.NewBuilder(0)
+	for _, msg := range []vc.Message{sampleMessage(), {From: 0, To: 1, Vector: []int{1, 0}, MessageID: "0-1"}} {
+		got, err := UnmarshalFlat(AppendFlat(b, msg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got.Wire(), msg.Wire()) {
+			t.Fatalf("reused builder:\n got %+v\nwant %+v", got.Wire(), msg.Wire())
+		}
+	}
+}
+
+func TestReadFlatRejectsForeignBuffer(t *testing.T) {
+	foreign := []byte("{\"from\":1,\"to\":2,\"vector\":[1]}")
+	if _, err := ReadFlat(foreign); !errors.Is(err, ErrNotFlatMessage) {
+		t.Fatalf("ReadFlat of JSON = %v, want ErrNotFlatMessage", err)
+	}
+}
+
+// sampleMessage 모든 필드를 채운 메시지
+func sampleMessage() vc.Message {
+	return vc.Message{
+		From:         1,
+		To:           2,
+		Vector:       []int{3, 1, 4},
+		Event:        "event",
+		MessageID:    "1-7",
+		Timestamp:    1234567890,
+		Epoch:        2,
+		Domain:       "orders",
+		Topic:        "topic",
+		Kind:         vc.KindRequest,
+		ReplyTo:      "0-1",
+		CausalParent: "0-2",
+		TraceID:      "trace",
+		Signature:    []byte{1, 2, 3},
+		Causal:       []int{1, 1, 1},
+		DestClocks:   map[int][]int{0: {1, 0, 0}, 2: {0, 0, 5}},
+	}
+}
+
+func TestFlatRoundTrip(t *testing.T) {
+	want := sampleMessage()
+	got, err := UnmarshalFlat(MarshalFlat(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Wire(), want.Wire()) {
+		t.Fatalf("round trip:\n got %+v\nwant %+v", got.Wire(), want.Wire())
+	}
+}
+
+func TestReadFlatRejectsTruncatedBuffers(t *testing.T) {
+	buf := MarshalFlat(sampleMessage())
+	for n := 0; n < len(buf); n++ {
+		// 잘린 버퍼는 범위 밖을 가리키므로 패닉 없이 거부되어야 함
+		if _, err := UnmarshalFlat(buf[:n]); !errors.Is(err, ErrNotFlatMessage) {
+			t.Fatalf("truncated to %d of %d bytes: got %v, want ErrNotFlatMessage", n, len(buf), err)
+		}
+	}
+}
+
+func TestReadFlatRejectsOversizedVector(t *testing.T) {
+	msg := vc.Message{From: 0, To: 1, Vector: []int{7, 7}, MessageID: "0-1"}
+	buf := MarshalFlat(msg)
+	// 벡터 길이 2 를 찾아 남은 바이트보다 훨씬 크게 바꿈
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], 2)
+	at := -1
+	for i := 0; i+12 <= len(buf); i++ {
+		if string(buf[i:i+4]) == string(length[:]) &&
+			binary.LittleEndian.Uint64(buf[i+4:]) == 7 {
+			at = i
+			break
+		}
+	}
+	if at < 0 {
+		t.Fatal("vector length not found in buffer")
+	}
+	binary.LittleEndian.PutUint32(buf[at:], 0xFFFFFFF0)
+	if _, err := ReadFlat(buf); !errors.Is(err, ErrNotFlatMessage) {
+		t.Fatalf("got %v, want ErrNotFlatMessage", err)
+	}
+}
+
+func TestReadFlatSurvivesCorruption(t *testing.T) {
+	buf := MarshalFlat(sampleMessage())
+	for i := range buf {
+		for _, b := range []byte{0x00, 0x7F, 0x80, 0xFF} {
+			corrupt := append([]byte(nil), buf...)
+			corrupt[i] = b
+			// 값이 바뀌는 것은 괜찮지만 패닉은 안 됨
+			if m, err := ReadFlat(corrupt); err == nil {
+				m.Message()
+				m.VectorAt(1)
+			}
+		}
+	}
+}
+
+func FuzzReadFlat(f *testing.F) {
+	f.Add(MarshalFlat(sampleMessage()))
+	f.Add(MarshalFlat(vc.Message{}))
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		if m, err := ReadFlat(buf); err == nil {
+			m.Message()
+		}
+	})
+}
@@ -0,0 +1,20 @@
+module github.com/seoyhaein/vectorclock/codec
+
+go 1.22
+
+require (
+	github.com/fxamacker/cbor/v2 v2.9.4
+	github.com/google/flatbuffers v25.2.10+incompatible
+	github.com/seoyhaein/vectorclock v0.0.0
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
+	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
+	github.com/stretchr/testify v1.11.1 // indirect
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
+)
+
+replace github.com/seoyhaein/vectorclock => ..
@@ -0,0 +1,57 @@
+package codec
+
+import (
+	"bytes"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// MarshalMsgpack 메시지를 MessagePack 맵으로 직렬화 (키는 JSON 과 같은 이름, 빈 선택 필드는 생략)
+//
+// JSON 과 달리 destClocks 의 키는 문자열이 아닌 정수 프로세스 ID 로 쓴다.
+func MarshalMsgpack(msg vc.Message) ([]byte, error) {
+	return marshalMsgpack(msg.Wire())
+}
+
+// UnmarshalMsgpack MessagePack 메시지를 읽어 현재 버전으로 옮김 (모르는 키는 무시)
+func UnmarshalMsgpack(data []byte) (vc.Message, error) {
+	var w vc.WireMessage
+	if err := unmarshalMsgpack(data, &w); err != nil {
+		return vc.Message{}, err
+	}
+	return w.Decode()
+}
+
+// MarshalClockMsgpack Vector Clock 을 MessagePack 정수 배열로 직렬화
+func MarshalClockMsgpack(clock []int) ([]byte, error) {
+	return marshalMsgpack(clock)
+}
+
+// UnmarshalClockMsgpack MessagePack 정수 배열을 Vector Clock 으로 읽음
+func UnmarshalClockMsgpack(data []byte) ([]int, error) {
+	var clock []int
+	if err := unmarshalMsgpack(data, &clock); err != nil {
+		return nil, err
+	}
+	return clock, nil
+}
+
+// marshalMsgpack 정수를 가장 작은 형식으로 쓰는 인코더로 직렬화
+func marshalMsgpack(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalMsgpack JSON 태그 이름으로 필드를 맞추는 디코더로 읽음
+func unmarshalMsgpack(data []byte, v any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(v)
+}
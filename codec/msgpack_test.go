@@ -0,0 +1,87 @@
+package codec
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	want := sampleMessage()
+	data, err := MarshalMsgpack(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := UnmarshalMsgpack(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Wire(), want.Wire()) {
+		t.Fatalf("round trip:\n got %+v\nwant %+v", got.Wire(), want.Wire())
+	}
+}
+
+func TestMsgpackUsesJSONKeysAndIntegerDestClocks(t *testing.T) {
+	data, err := MarshalMsgpack(sampleMessage())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw map[string]msgpack.RawMessage
+	if err := msgpack.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"from", "to", "vector", "event", "messageId", "destClocks"} {
+		if _, ok := raw[key]; !ok {
+			t.Fatalf("key %q missing", key)
+		}
+	}
+	dec := msgpack.NewDecoder(bytes.NewReader(raw["destClocks"]))
+	n, err := dec.DecodeMapLen()
+	if err != nil || n != 2 {
+		t.Fatalf("destClocks map length = %d, %v", n, err)
+	}
+	for i := 0; i < n; i++ {
+		key, err := dec.DecodeInterface()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := key.(string); ok {
+			t.Fatalf("destClocks key %v is a string", key)
+		}
+		if err := dec.Skip(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMsgpackClockIsCompact(t *testing.T) {
+	data, err := MarshalClockMsgpack([]int{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fixarray 와 양의 fixint
+	if want := []byte{0x93, 0x01, 0x02, 0x03}; !bytes.Equal(data, want) {
+		t.Fatalf("clock encoding = %x, want %x", data, want)
+	}
+	clock, err := UnmarshalClockMsgpack(data)
+	if err != nil || !reflect.DeepEqual(clock, []int{1, 2, 3}) {
+		t.Fatalf("UnmarshalClockMsgpack = %v, %v", clock, err)
+	}
+}
+
+func TestUnmarshalMsgpackIgnoresUnknownKeys(t *testing.T) {
+	data, err := msgpack.Marshal(map[string]any{"from": 1, "to": 0, "vector": []int{0, 1}, "shiny": "new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := UnmarshalMsgpack(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (vc.Message{From: 1, Vector: []int{0, 1}}); msg.From != want.From || !reflect.DeepEqual(msg.Vector, want.Vector) {
+		t.Fatalf("message = %+v", msg)
+	}
+}
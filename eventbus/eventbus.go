@@ -0,0 +1,292 @@
+package eventbus
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Event 버스로 발행된 이벤트
+type Event struct {
+	ID      string         // 이벤트 고유 ID (발행 버스 이름 + 발행 번호)
+	Source  string         // 발행한 버스 이름
+	Topic   string         // 토픽
+	Payload interface{}    // 애플리케이션 데이터
+	Clock   map[string]int // 발행 시점의 Vector Clock (버스 이름 -> 발행 수)
+}
+
+// Handler 이벤트 처리 함수
+type Handler func(Event)
+
+// Bus 인과 순서를 보장하는 프로세스 내 이벤트 버스
+//
+// 모든 이벤트는 발행 시점의 Vector Clock 으로 표시되어 연결된 다른 버스 인스턴스로 전달되며,
+// 각 버스는 이벤트를 인과 순서대로 (BSS 방식으로) 핸들러에 전달한다. 버스 인스턴스는
+// 서로 다른 서비스/노드를 나타낼 수 있고, Connect 대신 Receive 를 직접 호출하여
+// 임의의 전송 계층으로 연결할 수도 있다. 이벤트는 중계되지 않으므로 버스들은
+// 서로 모두 연결(완전 연결)되어 있어야 한다.
+type Bus struct {
+	name string
+
+	mu        sync.Mutex
+	delivered map[string]int       // 버스별 전달 완료된 이벤트 수 (자신은 발행 수)
+	handlers  map[string][]*handle // 토픽별 핸들러 ("" 는 모든 토픽)
+	pending   []Event              // 선행 이벤트가 오지 않아 대기 중인 이벤트
+	ready     []Event              // 핸들러 호출을 기다리는 이벤트
+	draining  bool                 // 핸들러 호출 중 여부
+	links     []*link              // 연결된 버스로의 전송 링크
+	closed    bool
+}
+
+// handle 구독 핸들
+type handle struct {
+	fn Handler
+}
+
+// link 다른 버스로의 FIFO 전송 링크
+type link struct {
+	peer *Bus
+	ch   chan Event
+	done chan struct{}
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// send 링크로 이벤트 전송 (닫힌 링크는 무시)
+func (l *link) send(e Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.closed {
+		l.ch <- e
+	}
+}
+
+// close 링크를 닫고 남은 전송이 끝날 때까지 대기
+func (l *link) close() {
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.ch)
+	}
+	l.mu.Unlock()
+	<-l.done
+}
+
+// New 이름을 가진 버스 생성 (이름은 연결된 버스들 사이에서 유일해야 함)
+func New(name string) *Bus {
+	return &Bus{
+		name:      name,
+		delivered: make(map[string]int),
+		handlers:  make(map[string][]*handle),
+	}
+}
+
+// Name 버스 이름
+func (b *Bus) Name() string {
+	return b.name
+}
+
+// Connect 다른 버스들과 양방향 연결 (이후 발행되는 이벤트부터 서로 전달)
+func (b *Bus) Connect(peers ...*Bus) {
+	for _, peer := range peers {
+		if peer == b {
+			continue
+		}
+		b.addLink(peer)
+		peer.addLink(b)
+	}
+}
+
+// addLink 전송 링크 추가 (이미 있으면 무시)
+func (b *Bus) addLink(peer *Bus) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	for _, l := range b.links {
+		if l.peer == peer {
+			return
+		}
+	}
+	l := &link{peer: peer, ch: make(chan Event, 64), done: make(chan struct{})}
+	b.links = append(b.links, l)
+	go func() {
+		defer close(l.done)
+		for e := range l.ch {
+			peer.Receive(e)
+		}
+	}()
+}
+
+// Subscribe 토픽 핸들러 등록 (topic 이 "" 이면 모든 토픽), 반환된 함수로 해제
+func (b *Bus) Subscribe(topic string, fn Handler) (unsubscribe func()) {
+	h := &handle{fn: fn}
+	b.mu.Lock()
+	b.handlers[topic] = append(b.handlers[topic], h)
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		hs := b.handlers[topic]
+		for i, x := range hs {
+			if x == h {
+				b.handlers[topic] = append(hs[:i:i], hs[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Publish 이벤트를 발행하여 로컬 핸들러와 연결된 모든 버스에 전달
+//
+// 핸들러 안에서 Publish 를 호출해도 되며, 그 이벤트는 현재 이벤트 처리 후 전달된다.
+func (b *Bus) Publish(topic string, payload interface{}) Event {
+	b.mu.Lock()
+	b.delivered[b.name]++
+	e := Event{
+		ID:      fmt.Sprintf("%s-%d", b.name, b.delivered[b.name]),
+		Source:  b.name,
+		Topic:   topic,
+		Payload: payload,
+		Clock:   copyClock(b.delivered),
+	}
+	b.ready = append(b.ready, e)
+	links := append([]*link(nil), b.links...)
+	b.mu.Unlock()
+
+	// 잠금 밖에서 전송 (순서가 뒤바뀌어도 받는 쪽에서 인과 순서로 정렬됨)
+	for _, l := range links {
+		l.send(e)
+	}
+	b.drain()
+	return e
+}
+
+// Receive 다른 버스에서 온 이벤트 수신 (인과 순서가 맞을 때까지 보관 후 전달)
+func (b *Bus) Receive(e Event) {
+	b.mu.Lock()
+	if e.Source == b.name || e.Clock[e.Source] <= b.delivered[e.Source] {
+		b.mu.Unlock() // 자신의 이벤트이거나 이미 전달된 이벤트
+		return
+	}
+	b.pending = append(b.pending, e)
+	for {
+		idx := -1
+		for i, p := range b.pending {
+			if b.deliverable(p) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			break
+		}
+		p := b.pending[idx]
+		b.pending = append(b.pending[:idx], b.pending[idx+1:]...)
+		b.delivered[p.Source]++
+		b.ready = append(b.ready, p)
+	}
+	b.mu.Unlock()
+
+	b.drain()
+}
+
+// Clock 현재 Vector Clock 복사본
+func (b *Bus) Clock() map[string]int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return copyClock(b.delivered)
+}
+
+// Pending 인과 순서 대기 중인 이벤트 수
+func (b *Bus) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// Close 전송 링크를 닫고 남은 전송이 끝날 때까지 대기
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	links := b.links
+	b.links = nil
+	b.mu.Unlock()
+
+	for _, l := range links {
+		l.close()
+	}
+}
+
+// deliverable BSS 전달 조건 (b.mu 보유 상태에서 호출)
+func (b *Bus) deliverable(e Event) bool {
+	for source, v := range e.Clock {
+		if source == e.Source {
+			if v != b.delivered[source]+1 {
+				return false
+			}
+		} else if v > b.delivered[source] {
+			return false
+		}
+	}
+	return true
+}
+
+// drain 전달 준비된 이벤트를 순서대로 핸들러에 전달 (한 번에 한 고루틴만 수행)
+func (b *Bus) drain() {
+	b.mu.Lock()
+	if b.draining {
+		b.mu.Unlock()
+		return
+	}
+	b.draining = true
+	for len(b.ready) > 0 {
+		e := b.ready[0]
+		b.ready = b.ready[1:]
+		hs := append(append([]*handle(nil), b.handlers[e.Topic]...), b.handlers[""]...)
+		b.mu.Unlock()
+
+		for _, h := range hs {
+			h.fn(e)
+		}
+
+		b.mu.Lock()
+	}
+	b.draining = false
+	b.mu.Unlock()
+}
+
+// copyClock Vector Clock 복사
+func copyClock(clock map[string]int) map[string]int {
+	out := make(map[string]int, len(clock))
+	for k, v := range clock {
+		out[k] = v
+	}
+	return out
+}
+
+// String 이벤트 요약 (Clock 은 버스 이름 순서)
+func (e Event) String() string {
+	names := make([]string, 0, len(e.Clock))
+	for name := range e.Clock {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	s := fmt.Sprintf("%s %s@%s {", e.ID, e.Topic, e.Source)
+	for i, name := range names {
+		if i > 0 {
+			s += ","
+		}
+		s += fmt.Sprintf("%s:%d", name, e.Clock[name])
+	}
+	return s + "}"
+}
@@ -0,0 +1,94 @@
+package eventbus
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder 핸들러에 전달된 이벤트 ID 기록
+type recorder struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (r *recorder) handle(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ids = append(r.ids, e.ID)
+}
+
+func (r *recorder) seen() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.ids...)
+}
+
+func TestReceiveHoldsEventUntilPredecessorArrives(t *testing.T) {
+	a, b, c := New("a"), New("b"), New("c")
+	e1 := a.Publish("t", 1)
+	b.Receive(e1)
+	e2 := b.Publish("t", 2) // e1 이후에 일어난 이벤트
+
+	var r recorder
+	c.Subscribe("t", r.handle)
+	c.Receive(e2)
+	if got := r.seen(); len(got) != 0 || c.Pending() != 1 {
+		t.Fatalf("delivered %v with %d pending, want e2 held", got, c.Pending())
+	}
+	c.Receive(e1)
+	c.Receive(e1) // 중복은 무시
+	if got, want := r.seen(), []string{"a-1", "b-1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("delivered %v, want %v", got, want)
+	}
+	if got, want := c.Clock(), map[string]int{"a": 1, "b": 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Clock() = %v, want %v", got, want)
+	}
+}
+
+func TestConnectedBusesDeliverAndUnsubscribe(t *testing.T) {
+	a, b := New("a"), New("b")
+	a.Connect(b)
+
+	var all, topic recorder
+	b.Subscribe("", all.handle)
+	unsubscribe := b.Subscribe("t", topic.handle)
+	a.Publish("t", 1)
+	a.Publish("other", 2)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(all.seen()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("delivered %v, want 2 events", all.seen())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	unsubscribe()
+	a.Publish("t", 3)
+	a.Close()
+	b.Close()
+
+	if got, want := topic.seen(), []string{"a-1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("topic handler saw %v, want %v", got, want)
+	}
+}
+
+func TestPublishInsideHandlerRunsAfterCurrentEvent(t *testing.T) {
+	bus := New("a")
+	var r recorder
+	bus.Subscribe("", func(e Event) {
+		r.handle(e)
+		if e.Topic == "first" {
+			bus.Publish("second", nil)
+			r.handle(Event{ID: "after-publish"})
+		}
+	})
+	bus.Publish("first", nil)
+	if got, want := r.seen(), []string{"a-1", "after-publish", "a-2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("handled %v, want %v", got, want)
+	}
+	if got := (Event{ID: "a-1", Source: "a", Topic: "t", Clock: map[string]int{"b": 2, "a": 1}}).String(); got != "a-1 t@a {a:1,b:2}" {
+		t.Fatalf("String() = %q", got)
+	}
+}
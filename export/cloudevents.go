@@ -0,0 +1,148 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// CloudEventsSpecVersion 내보내는 CloudEvents 사양 버전
+const CloudEventsSpecVersion = "1.0"
+
+// DefaultCloudEventsType CloudEvents type 의 기본 접두사 (뒤에 이벤트 종류가 붙음: ...send)
+const DefaultCloudEventsType = "io.github.seoyhaein.vectorclock."
+
+// CloudEvent CloudEvents 1.0 구조화(JSON) 형식의 이벤트 한 건
+//
+// Vector Clock 은 확장 속성 vclock("1,0,2" 처럼 쉼표로 구분한 문자열)에, 이벤트가 일어난 프로세스와
+// 기록 순서는 vcprocess, vcseq 에 담긴다 (확장 속성 값은 문자열, 정수만 쓸 수 있음).
+type CloudEvent struct {
+	SpecVersion     string         `json:"specversion"`
+	ID              string         `json:"id"`
+	Source          string         `json:"source"`
+	Type            string         `json:"type"`
+	Subject         string         `json:"subject,omitempty"`
+	Time            time.Time      `json:"time"`
+	DataContentType string         `json:"datacontenttype"`
+	Data            CloudEventData `json:"data"`
+
+	VClock    string `json:"vclock"`    // 이벤트 직후의 Vector Clock
+	VCProcess int    `json:"vcprocess"` // 이벤트가 일어난 프로세스 ID
+	VCSeq     int64  `json:"vcseq"`     // 매니저 전체에서의 기록 순서
+}
+
+// CloudEventData CloudEvent 의 data (이벤트 내용)
+type CloudEventData struct {
+	Name      string `json:"name,omitempty"`       // 로컬 이벤트 이름 또는 메시지 내용
+	MessageID string `json:"message_id,omitempty"` // 송신/수신 메시지 ID
+	From      int    `json:"from"`                 // 메시지를 보낸 프로세스 ID
+	To        int    `json:"to"`                   // 메시지를 받는 프로세스 ID
+	Domain    string `json:"domain,omitempty"`     // 시계 도메인
+	Clock     []int  `json:"clock"`                // 이벤트 직후의 Vector Clock
+	Timestamp int64  `json:"timestamp,omitempty"`  // 메시지 Timestamp (송신자의 벽시계)
+}
+
+// CloudEventsOption CloudEvents 변환 설정 옵션
+type CloudEventsOption func(*cloudEventsConfig)
+
+// cloudEventsConfig CloudEvents 변환 설정
+type cloudEventsConfig struct {
+	source   func(processID int) string
+	typeName string
+}
+
+// WithSource 프로세스별 CloudEvents source (기본값 "/vectorclock/process/<ID>")
+func WithSource(source func(processID int) string) CloudEventsOption {
+	return func(c *cloudEventsConfig) {
+		c.source = source
+	}
+}
+
+// WithTypePrefix CloudEvents type 접두사 (기본값 DefaultCloudEventsType)
+func WithTypePrefix(prefix string) CloudEventsOption {
+	return func(c *cloudEventsConfig) {
+		c.typeName = prefix
+	}
+}
+
+// newCloudEventsConfig 기본 설정에 옵션 적용
+func newCloudEventsConfig(opts []CloudEventsOption) *cloudEventsConfig {
+	c := &cloudEventsConfig{
+		source:   func(id int) string { return "/vectorclock/process/" + strconv.Itoa(id) },
+		typeName: DefaultCloudEventsType,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ToCloudEvent 이벤트 한 건을 CloudEvent 로 변환
+//
+// id 는 한 매니저 안에서 유일한 기록 순서(Seq)와 종류로 만들고, 메시지 이벤트의 subject 는 메시지 ID 이다.
+func ToCloudEvent(e vc.Event, opts ...CloudEventsOption) CloudEvent {
+	return newCloudEventsConfig(opts).convert(e)
+}
+
+// convert 설정으로 이벤트 변환
+func (c *cloudEventsConfig) convert(e vc.Event) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              fmt.Sprintf("%d-%s", e.Seq, e.Kind),
+		Source:          c.source(e.Process),
+		Type:            c.typeName + e.Kind.String(),
+		Subject:         e.MessageID,
+		Time:            e.Time,
+		DataContentType: "application/json",
+		Data: CloudEventData{
+			Name:      e.Name,
+			MessageID: e.MessageID,
+			From:      e.From,
+			To:        e.To,
+			Domain:    e.Domain,
+			Clock:     e.Clock,
+			Timestamp: e.Timestamp,
+		},
+		VClock:    joinClock(e.Clock, ","),
+		VCProcess: e.Process,
+		VCSeq:     e.Seq,
+	}
+}
+
+// WriteCloudEvents 이벤트를 한 줄에 하나씩 CloudEvents JSON 으로 출력 (JSON Lines)
+//
+// 각 줄은 application/cloudevents+json 문서 하나로, Kafka, NATS, HTTP 수집기로 그대로 보낼 수 있다.
+func WriteCloudEvents(w io.Writer, events []vc.Event, opts ...CloudEventsOption) error {
+	c := newCloudEventsConfig(opts)
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		if err := enc.Encode(c.convert(e)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteCloudEventsBatch 이벤트를 CloudEvents 배치 JSON 배열 하나로 출력 (application/cloudevents-batch+json)
+func WriteCloudEventsBatch(w io.Writer, events []vc.Event, opts ...CloudEventsOption) error {
+	c := newCloudEventsConfig(opts)
+	batch := make([]CloudEvent, len(events))
+	for i, e := range events {
+		batch[i] = c.convert(e)
+	}
+	return json.NewEncoder(w).Encode(batch)
+}
+
+// joinClock 시계 항목을 sep 로 이어 붙인 문자열
+func joinClock(clock []int, sep string) string {
+	parts := make([]string, len(clock))
+	for i, v := range clock {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, sep)
+}
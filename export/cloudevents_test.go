@@ -0,0 +1,82 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// pingEvents 0 이 1 에게 보내고 1 이 받은 이벤트 기록
+func pingEvents(t *testing.T) []vc.Event {
+	t.Helper()
+	mgr := vc.NewVectorClockManager(2, vc.WithLogger(nil))
+	a := vc.NewProcess(0, mgr)
+	b := vc.NewProcess(1, mgr)
+	if err := a.Send(1, "ping"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	return mgr.Events()
+}
+
+func TestToCloudEventCarriesClockExtensions(t *testing.T) {
+	events := pingEvents(t)
+	recv := events[len(events)-1]
+	ce := ToCloudEvent(recv)
+	if ce.SpecVersion != "1.0" || ce.Type != DefaultCloudEventsType+"receive" || ce.Source != "/vectorclock/process/1" {
+		t.Fatalf("event = %+v", ce)
+	}
+	if ce.VClock != "1,1" || ce.VCProcess != 1 || ce.VCSeq != recv.Seq || ce.Subject != recv.MessageID {
+		t.Fatalf("extensions = %q %d %d %q", ce.VClock, ce.VCProcess, ce.VCSeq, ce.Subject)
+	}
+	if ce.Data.Name != "ping" || ce.Data.From != 0 || ce.Data.To != 1 {
+		t.Fatalf("data = %+v", ce.Data)
+	}
+}
+
+func TestWriteCloudEventsOptions(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteCloudEvents(&buf, pingEvents(t),
+		WithSource(func(id int) string { return "urn:node:" + string(rune('a'+id)) }),
+		WithTypePrefix("test."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []map[string]interface{}
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var doc map[string]interface{}
+		if err := json.Unmarshal(sc.Bytes(), &doc); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, doc)
+	}
+	if len(got) != 2 {
+		t.Fatalf("wrote %d lines, want 2", len(got))
+	}
+	if got[0]["type"] != "test.send" || got[0]["source"] != "urn:node:a" || got[0]["vclock"] != "1,0" {
+		t.Fatalf("first event = %v", got[0])
+	}
+	if got[1]["type"] != "test.receive" || got[1]["source"] != "urn:node:b" {
+		t.Fatalf("second event = %v", got[1])
+	}
+}
+
+func TestWriteCloudEventsBatch(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCloudEventsBatch(&buf, pingEvents(t)); err != nil {
+		t.Fatal(err)
+	}
+	var batch []CloudEvent
+	if err := json.Unmarshal(buf.Bytes(), &batch); err != nil {
+		t.Fatal(err)
+	}
+	if len(batch) != 2 || batch[0].ID == batch[1].ID {
+		t.Fatalf("batch = %+v, want two events with distinct IDs", batch)
+	}
+}
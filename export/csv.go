@@ -0,0 +1,61 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// WriteCSV 이벤트를 CSV 로 출력 (머리글 한 줄 + 이벤트마다 한 줄)
+//
+// 열은 seq, kind, process, name, message_id, from, to, domain, timestamp, time 다음에
+// 시계 항목마다 하나씩 vc0, vc1, ... 이다. 시계 길이가 다른 이벤트(도메인 시계)는 남는 열을 비운다.
+// 작은 실행 결과를 스프레드시트나 pandas(read_csv) 로 바로 볼 때 쓴다.
+func WriteCSV(w io.Writer, events []vc.Event) error {
+	width := 0
+	for _, e := range events {
+		if len(e.Clock) > width {
+			width = len(e.Clock)
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	header := []string{"seq", "kind", "process", "name", "message_id", "from", "to", "domain", "timestamp", "time"}
+	for i := 0; i < width; i++ {
+		header = append(header, "vc"+strconv.Itoa(i))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for _, e := range events {
+		record = append(record[:0],
+			strconv.FormatInt(e.Seq, 10),
+			e.Kind.String(),
+			strconv.Itoa(e.Process),
+			e.Name,
+			e.MessageID,
+			strconv.Itoa(e.From),
+			strconv.Itoa(e.To),
+			e.Domain,
+			strconv.FormatInt(e.Timestamp, 10),
+			e.Time.Format(time.RFC3339Nano),
+		)
+		for i := 0; i < width; i++ {
+			if i < len(e.Clock) {
+				record = append(record, strconv.Itoa(e.Clock[i]))
+			} else {
+				record = append(record, "")
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,49 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestWriteCSVOneColumnPerClockEntry(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	events := []vc.Event{
+		{Seq: 1, Kind: vc.EventSend, Process: 0, Name: "ping", MessageID: "0-1", From: 0, To: 1, Clock: []int{1, 0, 0}, Timestamp: 9, Time: at},
+		{Seq: 2, Kind: vc.EventLocal, Process: 1, Name: "tick", Domain: "app", Clock: []int{4}, Time: at},
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, events); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"seq", "kind", "process", "name", "message_id", "from", "to", "domain", "timestamp", "time", "vc0", "vc1", "vc2"},
+		{"1", "send", "0", "ping", "0-1", "0", "1", "", "9", "2024-01-02T03:04:05Z", "1", "0", "0"},
+		{"2", "local", "1", "tick", "", "0", "0", "app", "0", "2024-01-02T03:04:05Z", "4", "", ""},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Fatalf("CSV =\n%v\nwant\n%v", records, want)
+	}
+}
+
+func TestWriteCSVOfRecordedRun(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, pingEvents(t)); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 || records[2][1] != "receive" || records[2][10] != "1" || records[2][11] != "1" {
+		t.Fatalf("CSV = %v", records)
+	}
+}
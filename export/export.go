@@ -0,0 +1,4 @@
+// Package export 매니저 이벤트 기록(process.Event)을 다른 도구가 읽는 형식으로 내보냄
+//
+//	export.WriteCloudEvents(w, mgr.Events())
+package export
@@ -0,0 +1,182 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// diagram 그림으로 그릴 이벤트 (기본 시계만, 기록 순서)
+type diagram struct {
+	events    []vc.Event
+	processes []int       // 등장한 프로세스 ID (오름차순)
+	receiveOf map[int]int // 송신 이벤트 위치 -> 그 메시지를 받은 수신 이벤트 위치
+}
+
+// newDiagram 이벤트 정리 (도메인 시계 이벤트는 제외, 메시지 ID 로 송수신 짝 맞춤)
+func newDiagram(events []vc.Event) *diagram {
+	d := &diagram{receiveOf: make(map[int]int)}
+	seen := make(map[int]bool)
+	sends := make(map[string]int)
+	for _, e := range events {
+		if e.Domain != "" {
+			continue
+		}
+		i := len(d.events)
+		d.events = append(d.events, e)
+		if !seen[e.Process] {
+			seen[e.Process] = true
+			d.processes = append(d.processes, e.Process)
+		}
+		switch e.Kind {
+		case vc.EventSend:
+			if e.MessageID != "" {
+				sends[e.MessageID] = i
+			}
+		case vc.EventReceive:
+			if s, ok := sends[e.MessageID]; ok && e.MessageID != "" {
+				d.receiveOf[s] = i
+				delete(sends, e.MessageID)
+			}
+		}
+	}
+	sort.Ints(d.processes)
+	return d
+}
+
+// label 이벤트 이름표 (종류, 이름, 시계)
+func label(e vc.Event) string {
+	name := e.Kind.String()
+	if e.Name != "" && e.Kind != vc.EventReceive {
+		name += " " + e.Name
+	}
+	return name + " " + vc.FormatClock(e.Clock)
+}
+
+// WriteDOT 이벤트를 Graphviz DOT 시공간 그림으로 출력 (프로세스마다 한 줄, 메시지는 송신에서 수신으로 향하는 화살표)
+//
+//	dot -Tsvg trace.dot -o trace.svg
+func WriteDOT(w io.Writer, events []vc.Event) error {
+	d := newDiagram(events)
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph vectorclock {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, `  node [shape=box, style=rounded, fontsize=10];`)
+	for _, p := range d.processes {
+		fmt.Fprintf(bw, "  subgraph cluster_p%d {\n    label=\"P%d\";\n", p, p)
+		prev := -1
+		for i, e := range d.events {
+			if e.Process != p {
+				continue
+			}
+			fmt.Fprintf(bw, "    e%d [label=%q];\n", i, label(e))
+			if prev >= 0 {
+				fmt.Fprintf(bw, "    e%d -> e%d [weight=10];\n", prev, i)
+			}
+			prev = i
+		}
+		fmt.Fprintln(bw, "  }")
+	}
+	for i, e := range d.events {
+		if r, ok := d.receiveOf[i]; ok {
+			fmt.Fprintf(bw, "  e%d -> e%d [color=blue, label=%q, fontsize=9];\n", i, r, e.Name)
+		} else if e.Kind == vc.EventDrop {
+			fmt.Fprintf(bw, "  lost%d [shape=point, color=red];\n  e%d -> lost%d [color=red, style=dashed];\n", i, i, i)
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// WriteMermaid 이벤트를 Mermaid 시퀀스 다이어그램으로 출력 (Markdown 의 mermaid 코드 블록에 넣으면 그려짐)
+//
+// 메시지 화살표는 송신 시점에 그리고, 수신과 로컬 이벤트는 그 프로세스 위의 메모로 시계와 함께 표시한다.
+func WriteMermaid(w io.Writer, events []vc.Event) error {
+	d := newDiagram(events)
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "sequenceDiagram")
+	for _, p := range d.processes {
+		fmt.Fprintf(bw, "  participant P%d\n", p)
+	}
+	text := func(s string) string { // Mermaid 에서 의미가 있는 문자 제거
+		return strings.NewReplacer(";", ",", "#", "", "\n", " ").Replace(s)
+	}
+	for i, e := range d.events {
+		switch e.Kind {
+		case vc.EventSend:
+			arrow := "-)" // 받았는지 알 수 없는 비동기 메시지
+			if _, ok := d.receiveOf[i]; ok {
+				arrow = "->>"
+			}
+			fmt.Fprintf(bw, "  P%d%sP%d: %s %s\n", e.Process, arrow, e.To, text(e.Name), vc.FormatClock(e.Clock))
+		case vc.EventDrop:
+			fmt.Fprintf(bw, "  P%d-xP%d: %s %s (lost)\n", e.Process, e.To, text(e.Name), vc.FormatClock(e.Clock))
+		case vc.EventReceive:
+			fmt.Fprintf(bw, "  Note over P%d: recv from P%d %s\n", e.Process, e.From, vc.FormatClock(e.Clock))
+		default:
+			fmt.Fprintf(bw, "  Note over P%d: %s\n", e.Process, text(label(e)))
+		}
+	}
+	return bw.Flush()
+}
+
+// SVG 그림 크기 (픽셀)
+const (
+	svgLane   = 70  // 프로세스 줄 사이 간격
+	svgStep   = 90  // 이벤트 사이 가로 간격
+	svgMargin = 60  // 왼쪽(프로세스 이름)과 위아래 여백
+	svgRadius = 5   // 이벤트 점 반지름
+	svgLabelY = -10 // 점 위 이름표 위치
+)
+
+// WriteSVG 이벤트를 SVG 시공간 그림으로 출력 (Graphviz 없이 브라우저에서 바로 봄)
+//
+// 프로세스마다 가로줄 하나, 이벤트는 기록 순서대로 왼쪽에서 오른쪽으로 놓은 점이며 점 위에 시계를 쓴다.
+// 메시지는 송신 점에서 수신 점으로 향하는 화살표, 유실된 메시지는 빨간 점선이다.
+func WriteSVG(w io.Writer, events []vc.Event) error {
+	d := newDiagram(events)
+	lane := make(map[int]int, len(d.processes))
+	for i, p := range d.processes {
+		lane[p] = i
+	}
+	x := func(i int) int { return svgMargin*2 + i*svgStep }
+	y := func(p int) int { return svgMargin + lane[p]*svgLane }
+	width := x(len(d.events)) + svgMargin
+	height := svgMargin*2 + (len(d.processes)-1)*svgLane
+	if len(d.processes) == 0 {
+		height = svgMargin * 2
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="10">`+"\n", width, height)
+	fmt.Fprintln(bw, `  <defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto-start-reverse"><path d="M 0 0 L 10 5 L 0 10 z" fill="#1f6feb"/></marker></defs>`)
+	for _, p := range d.processes {
+		fmt.Fprintf(bw, `  <text x="10" y="%d" font-size="12" font-weight="bold">P%d</text>`+"\n", y(p)+4, p)
+		fmt.Fprintf(bw, `  <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#999"/>`+"\n", svgMargin, y(p), width-svgMargin/2, y(p))
+	}
+	for i, e := range d.events {
+		if r, ok := d.receiveOf[i]; ok {
+			fmt.Fprintf(bw, `  <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#1f6feb" marker-end="url(#arrow)"/>`+"\n",
+				x(i), y(e.Process), x(r), y(d.events[r].Process))
+		} else if e.Kind == vc.EventDrop {
+			if _, known := lane[e.To]; known {
+				fmt.Fprintf(bw, `  <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="red" stroke-dasharray="4 3"/>`+"\n",
+					x(i), y(e.Process), x(i)+svgStep/2, (y(e.Process)+y(e.To))/2)
+			}
+		}
+	}
+	for i, e := range d.events {
+		fill := map[vc.EventKind]string{vc.EventLocal: "#666", vc.EventSend: "#1f6feb", vc.EventReceive: "#2da44e", vc.EventDrop: "red"}[e.Kind]
+		fmt.Fprintf(bw, `  <circle cx="%d" cy="%d" r="%d" fill="%s"><title>%s</title></circle>`+"\n",
+			x(i), y(e.Process), svgRadius, fill, html.EscapeString(label(e)))
+		fmt.Fprintf(bw, `  <text x="%d" y="%d" text-anchor="middle">%s</text>`+"\n",
+			x(i), y(e.Process)+svgLabelY, html.EscapeString(vc.FormatClock(e.Clock)))
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
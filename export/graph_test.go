@@ -0,0 +1,85 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestWriteDOTDrawsMessageEdges(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, pingEvents(t)); err != nil {
+		t.Fatal(err)
+	}
+	dot := buf.String()
+	for _, want := range []string{
+		"digraph vectorclock {",
+		`subgraph cluster_p0 {`,
+		`e0 [label="send ping {P0:1,P1:0}"];`,
+		`e1 [label="receive {P0:1,P1:1}"];`,
+		`e0 -> e1 [color=blue, label="ping", fontsize=9];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Fatalf("DOT output lacks %q:\n%s", want, dot)
+		}
+	}
+}
+
+func TestWriteMermaidSequence(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteMermaid(&buf, pingEvents(t)); err != nil {
+		t.Fatal(err)
+	}
+	want := `sequenceDiagram
+  participant P0
+  participant P1
+  P0->>P1: ping {P0:1,P1:0}
+  Note over P1: recv from P0 {P0:1,P1:1}
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("Mermaid output =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteMermaidMarksLostMessages(t *testing.T) {
+	events := []vc.Event{
+		{Seq: 1, Kind: vc.EventSend, Process: 0, To: 1, Name: "a;b", MessageID: "0-1", Clock: []int{1, 0}},
+		{Seq: 2, Kind: vc.EventDrop, Process: 0, To: 1, Name: "c", MessageID: "0-2", Clock: []int{2, 0}},
+	}
+	var buf bytes.Buffer
+	if err := WriteMermaid(&buf, events); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "P0-)P1: a,b {P0:1,P1:0}") || !strings.Contains(out, "P0-xP1: c {P0:2,P1:0} (lost)") {
+		t.Fatalf("Mermaid output =\n%s", out)
+	}
+}
+
+func TestWriteSVGIsWellFormed(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSVG(&buf, pingEvents(t)); err != nil {
+		t.Fatal(err)
+	}
+	dec := xml.NewDecoder(&buf)
+	circles := 0
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("SVG is not well-formed XML: %v", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "circle" {
+			circles++
+		}
+	}
+	if circles != 2 {
+		t.Fatalf("SVG has %d event points, want 2", circles)
+	}
+}
@@ -0,0 +1,43 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// WriteJSONL 이벤트를 JSON Lines 트레이스로 출력 (이벤트마다 EventRow 한 줄)
+//
+// CLI 의 analyze / visualize 가 읽는 트레이스 파일 형식이다.
+func WriteJSONL(w io.Writer, events []vc.Event) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, e := range events {
+		if err := enc.Encode(NewEventRow(e)); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadJSONL WriteJSONL 로 쓴 트레이스 읽기
+func ReadJSONL(r io.Reader) ([]vc.Event, error) {
+	dec := json.NewDecoder(r)
+	var events []vc.Event
+	for line := 1; ; line++ {
+		var row EventRow
+		if err := dec.Decode(&row); err == io.EOF {
+			return events, nil
+		} else if err != nil {
+			return events, fmt.Errorf("export: trace record %d: %w", line, err)
+		}
+		e, err := row.Event()
+		if err != nil {
+			return events, fmt.Errorf("export: trace record %d: %w", line, err)
+		}
+		events = append(events, e)
+	}
+}
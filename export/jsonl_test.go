@@ -0,0 +1,51 @@
+package export
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJSONLRoundTrip(t *testing.T) {
+	events := pingEvents(t)
+	var buf bytes.Buffer
+	if err := WriteJSONL(&buf, events); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), "\n"); n != len(events) {
+		t.Fatalf("wrote %d lines for %d events", n, len(events))
+	}
+	got, err := ReadJSONL(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(events) {
+		t.Fatalf("read %d events, want %d", len(got), len(events))
+	}
+	for i, e := range events {
+		if !got[i].Time.Equal(e.Time) {
+			t.Fatalf("event %d time = %v, want %v", i, got[i].Time, e.Time)
+		}
+		got[i].Time = e.Time
+		if !reflect.DeepEqual(NewEventRow(got[i]), NewEventRow(e)) {
+			t.Fatalf("event %d = %+v, want %+v", i, got[i], e)
+		}
+	}
+}
+
+func TestReadJSONLReportsBadRecord(t *testing.T) {
+	trace := `{"seq":1,"kind":"local","process":0,"clock":[1]}
+{"seq":2,"kind":"teleport","process":0,"clock":[2]}
+`
+	events, err := ReadJSONL(strings.NewReader(trace))
+	if err == nil || !strings.Contains(err.Error(), "record 2") {
+		t.Fatalf("ReadJSONL = %v, want an error for record 2", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("read %d events before the bad record, want 1", len(events))
+	}
+	if _, err := ReadJSONL(strings.NewReader("{not json")); err == nil {
+		t.Fatal("malformed JSON accepted")
+	}
+}
@@ -0,0 +1,22 @@
+module github.com/seoyhaein/vectorclock/export/parquet
+
+go 1.22
+
+require (
+	github.com/parquet-go/parquet-go v0.25.0
+	github.com/seoyhaein/vectorclock v0.0.0
+)
+
+require (
+	github.com/andybalholm/brotli v1.1.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/klauspost/compress v1.17.9 // indirect
+	github.com/mattn/go-runewidth v0.0.15 // indirect
+	github.com/olekukonko/tablewriter v0.0.5 // indirect
+	github.com/pierrec/lz4/v4 v4.1.21 // indirect
+	github.com/rivo/uniseg v0.4.7 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	google.golang.org/protobuf v1.36.7 // indirect
+)
+
+replace github.com/seoyhaein/vectorclock => ../..
@@ -0,0 +1,54 @@
+// Package parquet 매니저 이벤트 기록을 Parquet 파일로 내보내고 읽음
+//
+//	parquet.Write(f, mgr.Events())
+//
+// Parquet 라이브러리를 루트 모듈에 두지 않도록 따로 둔 모듈이다. 행 형식은 JSON Lines 트레이스와 같은 export.EventRow 이다.
+package parquet
+
+import (
+	"io"
+
+	parquetgo "github.com/parquet-go/parquet-go"
+	"github.com/seoyhaein/vectorclock/export"
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// batchSize Parquet 로 한 번에 쓰는 행 수
+const batchSize = 1024
+
+// Write 이벤트를 Parquet 파일 하나로 출력 (열: export.EventRow, 압축: Zstd)
+//
+// 큰 시뮬레이션 결과를 텍스트로 파싱하지 않고 DuckDB, Spark, pandas 에서 바로 읽을 수 있다.
+//
+//	SELECT process, count(*) FROM 'events.parquet' WHERE kind = 'send' GROUP BY process
+func Write(w io.Writer, events []vc.Event) error {
+	pw := parquetgo.NewGenericWriter[export.EventRow](w, parquetgo.Compression(&parquetgo.Zstd))
+	rows := make([]export.EventRow, 0, batchSize)
+	for i, e := range events {
+		rows = append(rows, export.NewEventRow(e))
+		if len(rows) == batchSize || i == len(events)-1 {
+			if _, err := pw.Write(rows); err != nil {
+				return err
+			}
+			rows = rows[:0]
+		}
+	}
+	return pw.Close()
+}
+
+// Read Write 로 쓴 파일의 행 읽기
+func Read(r io.ReaderAt, size int64) ([]export.EventRow, error) {
+	f, err := parquetgo.OpenFile(r, size)
+	if err != nil {
+		return nil, err
+	}
+	pr := parquetgo.NewGenericReader[export.EventRow](f)
+	defer pr.Close()
+
+	rows := make([]export.EventRow, pr.NumRows())
+	n, err := pr.Read(rows)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return rows[:n], nil
+}
@@ -0,0 +1,69 @@
+package parquet
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/seoyhaein/vectorclock/export"
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	mgr := vc.NewVectorClockManager(2, vc.WithLogger(nil))
+	a := vc.NewProcess(0, mgr)
+	b := vc.NewProcess(1, mgr)
+	a.LocalEvent("start")
+	if err := a.Send(1, "ping"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	events := mgr.Events()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, events); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != len(events) {
+		t.Fatalf("read %d rows, want %d", len(rows), len(events))
+	}
+	for i, e := range events {
+		want := export.NewEventRow(e)
+		got := rows[i]
+		// Parquet 시각은 마이크로초 단위로 저장됨
+		if !got.Time.Equal(want.Time.Truncate(1000)) {
+			t.Fatalf("row %d time = %v, want %v", i, got.Time, want.Time)
+		}
+		got.Time = want.Time
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("row %d = %+v, want %+v", i, got, want)
+		}
+	}
+	if last := rows[len(rows)-1]; last.Kind != "receive" || !reflect.DeepEqual(last.Clock, []int64{2, 1}) {
+		t.Fatalf("last row = %+v", last)
+	}
+}
+
+func TestWriteEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil || len(rows) != 0 {
+		t.Fatalf("Read = %v, %v, want no rows", rows, err)
+	}
+}
+
+func TestReadRejectsNonParquet(t *testing.T) {
+	data := []byte("not a parquet file")
+	if _, err := Read(bytes.NewReader(data), int64(len(data))); err == nil {
+		t.Fatal("Read accepted a non-Parquet file")
+	}
+}
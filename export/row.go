@@ -0,0 +1,71 @@
+package export
+
+import (
+	"fmt"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// EventRow 트레이스로 내보내는 이벤트 한 행 (JSON Lines 트레이스의 한 줄, export/parquet 모듈의 Parquet 행)
+//
+// clock 은 정수 목록(LIST) 열이므로 DuckDB 에서 clock[1] 처럼 항목을 꺼내거나 list 함수로 다룰 수 있다.
+type EventRow struct {
+	Seq       int64     `parquet:"seq" json:"seq"`
+	Kind      string    `parquet:"kind,dict" json:"kind"`
+	Process   int32     `parquet:"process" json:"process"`
+	Name      string    `parquet:"name,dict" json:"name"`
+	MessageID string    `parquet:"message_id" json:"message_id"`
+	From      int32     `parquet:"from" json:"from"`
+	To        int32     `parquet:"to" json:"to"`
+	Domain    string    `parquet:"domain,dict" json:"domain"`
+	Clock     []int64   `parquet:"clock,list" json:"clock"`
+	Timestamp int64     `parquet:"timestamp" json:"timestamp"`
+	Time      time.Time `parquet:"time,timestamp(microsecond)" json:"time"`
+}
+
+// Event 행을 이벤트로 되돌림 (종류 이름을 모르면 에러)
+func (r EventRow) Event() (vc.Event, error) {
+	kind, ok := vc.ParseEventKind(r.Kind)
+	if !ok {
+		return vc.Event{}, fmt.Errorf("export: unknown event kind %q in event %d", r.Kind, r.Seq)
+	}
+	clock := make([]int, len(r.Clock))
+	for i, v := range r.Clock {
+		clock[i] = int(v)
+	}
+	return vc.Event{
+		Seq:       r.Seq,
+		Kind:      kind,
+		Process:   int(r.Process),
+		Name:      r.Name,
+		MessageID: r.MessageID,
+		From:      int(r.From),
+		To:        int(r.To),
+		Domain:    r.Domain,
+		Clock:     clock,
+		Timestamp: r.Timestamp,
+		Time:      r.Time,
+	}, nil
+}
+
+// NewEventRow 이벤트 한 건을 트레이스 행으로 변환
+func NewEventRow(e vc.Event) EventRow {
+	clock := make([]int64, len(e.Clock))
+	for i, v := range e.Clock {
+		clock[i] = int64(v)
+	}
+	return EventRow{
+		Seq:       e.Seq,
+		Kind:      e.Kind.String(),
+		Process:   int32(e.Process),
+		Name:      e.Name,
+		MessageID: e.MessageID,
+		From:      int32(e.From),
+		To:        int32(e.To),
+		Domain:    e.Domain,
+		Clock:     clock,
+		Timestamp: e.Timestamp,
+		Time:      e.Time,
+	}
+}
@@ -0,0 +1,32 @@
+// Package logline 매니저 로그 한 줄("Process 3: Sent message ...")을 구조화된 로그 필드로 나눔
+package logline
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Line 나눈 로그 한 줄
+type Line struct {
+	Process int    // 로그를 남긴 프로세스 ID (없으면 -1)
+	Message string // "Process N: " 접두사와 끝의 줄바꿈을 뺀 내용
+}
+
+// Parse Printf 인자로 로그 한 줄을 만들어 프로세스 ID 와 내용으로 나눔
+func Parse(format string, v ...interface{}) Line {
+	msg := strings.TrimRight(fmt.Sprintf(format, v...), "\n")
+	rest, ok := strings.CutPrefix(msg, "Process ")
+	if !ok {
+		return Line{Process: -1, Message: msg}
+	}
+	idText, body, ok := strings.Cut(rest, ": ")
+	if !ok {
+		return Line{Process: -1, Message: msg}
+	}
+	id, err := strconv.Atoi(idText)
+	if err != nil {
+		return Line{Process: -1, Message: msg}
+	}
+	return Line{Process: id, Message: body}
+}
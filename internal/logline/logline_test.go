@@ -0,0 +1,21 @@
+package logline
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		format string
+		args   []interface{}
+		want   Line
+	}{
+		{"Process %d: Sent message to Process %d\n", []interface{}{3, 1}, Line{Process: 3, Message: "Sent message to Process 1"}},
+		{"Simulation: Seed %d\n", []interface{}{7}, Line{Process: -1, Message: "Simulation: Seed 7"}},
+		{"Process x: odd\n", nil, Line{Process: -1, Message: "Process x: odd"}},
+		{"Process 4 without colon", nil, Line{Process: -1, Message: "Process 4 without colon"}},
+	}
+	for _, c := range cases {
+		if got := Parse(c.format, c.args...); got != c.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", c.format, got, c.want)
+		}
+	}
+}
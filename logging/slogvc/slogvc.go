@@ -0,0 +1,117 @@
+// Package slogvc 애플리케이션 로그 레코드마다 현재 프로세스의 Vector Clock 을 붙이는 slog.Handler
+//
+//	logger := slog.New(slogvc.NewHandler(slog.NewJSONHandler(os.Stdout, nil), slogvc.WithProcess(p)))
+//	logger.Info("order placed", "order", id)
+//	// {"msg":"order placed","order":42,"vc":{"process":0,"clock":[3,1,0],"epoch":0}}
+//
+// 여러 노드의 로그를 모은 뒤 vc.clock 으로 인과 순서를 복원할 수 있다 (process.Compare 참고).
+// 한 로거를 여러 프로세스가 함께 쓰면 NewContext 로 요청마다 프로세스를 지정하고 *Context 메서드로 기록한다.
+package slogvc
+
+import (
+	"context"
+	"log/slog"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// DefaultKey 시계 속성 그룹의 기본 이름
+const DefaultKey = "vc"
+
+// Handler 레코드에 프로세스의 Vector Clock 속성 그룹을 붙여 다음 핸들러로 넘기는 slog.Handler
+type Handler struct {
+	next    slog.Handler
+	process *vc.Process // 컨텍스트에 프로세스가 없을 때 쓸 프로세스 (nil 이면 붙이지 않음)
+	key     string
+}
+
+var _ slog.Handler = (*Handler)(nil)
+
+// Option Handler 설정 옵션
+type Option func(*Handler)
+
+// WithProcess 컨텍스트에 프로세스가 없을 때 시계를 붙일 프로세스
+func WithProcess(p *vc.Process) Option {
+	return func(h *Handler) {
+		h.process = p
+	}
+}
+
+// WithKey 시계 속성 그룹 이름 (기본값 DefaultKey)
+func WithKey(key string) Option {
+	return func(h *Handler) {
+		h.key = key
+	}
+}
+
+// NewHandler next 로 기록하기 전에 시계를 붙이는 Handler 생성
+func NewHandler(next slog.Handler, opts ...Option) *Handler {
+	h := &Handler{next: next, key: DefaultKey}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// contextKey 컨텍스트에 프로세스를 담는 키
+type contextKey struct{}
+
+// NewContext 로그를 남기는 프로세스 p 를 담은 컨텍스트 (WithProcess 보다 우선)
+func NewContext(ctx context.Context, p *vc.Process) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext 컨텍스트에 담긴 프로세스
+func FromContext(ctx context.Context) (*vc.Process, bool) {
+	p, ok := ctx.Value(contextKey{}).(*vc.Process)
+	return p, ok && p != nil
+}
+
+// Enabled 다음 핸들러의 레벨 판단을 따름
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle 기록 시점의 시계를 속성 그룹으로 붙여 다음 핸들러로 넘김
+//
+// WithGroup 으로 그룹을 연 로거에서도 시계 그룹은 그 그룹 안에 들어간다.
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	p, ok := FromContext(ctx)
+	if !ok {
+		p = h.process
+	}
+	if p != nil {
+		r = r.Clone()
+		r.AddAttrs(Attr(h.key, p))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs 속성을 더한 Handler
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.next = h.next.WithAttrs(attrs)
+	return &c
+}
+
+// WithGroup 그룹을 연 Handler
+func (h *Handler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.next = h.next.WithGroup(name)
+	return &c
+}
+
+// Attr 프로세스 p 의 현재 시계 속성 그룹 (process, clock, epoch, 있으면 trace)
+//
+// Handler 없이 직접 붙일 때도 쓸 수 있다: logger.Info("msg", slogvc.Attr("vc", p))
+func Attr(key string, p *vc.Process) slog.Attr {
+	attrs := []interface{}{
+		slog.Int("process", p.ID),
+		slog.Any("clock", p.ClockMgr.GetClock(p.ID)),
+		slog.Int("epoch", p.ClockMgr.CurrentEpoch()),
+	}
+	if trace := p.TraceID(); trace != "" {
+		attrs = append(attrs, slog.String("trace", trace))
+	}
+	return slog.Group(key, attrs...)
+}
@@ -0,0 +1,92 @@
+package slogvc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// record JSON 로그 한 줄의 시계 그룹
+type record struct {
+	Msg string `json:"msg"`
+	VC  *struct {
+		Process int   `json:"process"`
+		Clock   []int `json:"clock"`
+		Epoch   int   `json:"epoch"`
+	} `json:"vc"`
+}
+
+func decode(t *testing.T, buf *bytes.Buffer) record {
+	t.Helper()
+	var r record
+	if err := json.Unmarshal(buf.Bytes(), &r); err != nil {
+		t.Fatalf("%s: %v", buf.String(), err)
+	}
+	buf.Reset()
+	return r
+}
+
+func TestHandlerAttachesProcessClock(t *testing.T) {
+	mgr := vc.NewVectorClockManager(2, vc.WithLogger(nil))
+	a := vc.NewProcess(0, mgr)
+	b := vc.NewProcess(1, mgr)
+	a.LocalEvent("start")
+
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil), WithProcess(a)))
+	logger.Info("default")
+	r := decode(t, &buf)
+	if r.VC == nil || r.VC.Process != 0 || !reflect.DeepEqual(r.VC.Clock, []int{1, 0}) {
+		t.Fatalf("record = %+v, want the clock of process 0", r)
+	}
+
+	// 컨텍스트의 프로세스가 WithProcess 보다 우선
+	logger.InfoContext(NewContext(context.Background(), b), "from context")
+	if r := decode(t, &buf); r.VC == nil || r.VC.Process != 1 || !reflect.DeepEqual(r.VC.Clock, []int{0, 0}) {
+		t.Fatalf("record = %+v, want the clock of process 1", r)
+	}
+}
+
+func TestHandlerWithoutProcessLeavesRecord(t *testing.T) {
+	var buf bytes.Buffer
+	slog.New(NewHandler(slog.NewJSONHandler(&buf, nil))).Info("plain")
+	if r := decode(t, &buf); r.VC != nil || r.Msg != "plain" {
+		t.Fatalf("record = %+v, want no clock group", r)
+	}
+}
+
+func TestHandlerKeepsGroupsAndKey(t *testing.T) {
+	mgr := vc.NewVectorClockManager(1, vc.WithLogger(nil))
+	p := vc.NewProcess(0, mgr)
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil), WithProcess(p), WithKey("clock")))
+	logger.WithGroup("req").With("id", 7).Info("grouped")
+
+	var r struct {
+		Req struct {
+			ID    int `json:"id"`
+			Clock struct {
+				Process int `json:"process"`
+			} `json:"clock"`
+		} `json:"req"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.Req.ID != 7 || !bytes.Contains(buf.Bytes(), []byte(`"clock":{"process":0`)) {
+		t.Fatalf("line = %s", buf.String())
+	}
+}
+
+func TestHandlerFollowsNextLevel(t *testing.T) {
+	var buf bytes.Buffer
+	h := NewHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	if h.Enabled(context.Background(), slog.LevelInfo) || !h.Enabled(context.Background(), slog.LevelError) {
+		t.Fatal("Enabled does not follow the next handler")
+	}
+}
@@ -0,0 +1,17 @@
+module github.com/seoyhaein/vectorclock/logging/zaplog
+
+go 1.22
+
+require (
+	github.com/seoyhaein/vectorclock v0.0.0
+	go.uber.org/zap v1.28.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
+	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
+	github.com/stretchr/testify v1.11.1 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+)
+
+replace github.com/seoyhaein/vectorclock => ../..
@@ -0,0 +1,61 @@
+// Package zaplog zap 로거를 process.Logger 로 쓰는 어댑터
+//
+//	mgr := process.NewVectorClockManager(3, process.WithLogger(zaplog.New(zapLogger)))
+//
+// "Process N: ..." 형식의 줄은 process 필드(N)와 나머지 내용으로 나뉘어 기록된다.
+package zaplog
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/seoyhaein/vectorclock/internal/logline"
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// Logger zap 로거를 감싼 process.Logger
+type Logger struct {
+	l     *zap.Logger
+	level zapcore.Level
+}
+
+var _ vc.Logger = (*Logger)(nil)
+
+// Option Logger 설정 옵션
+type Option func(*Logger)
+
+// WithLevel 기록할 레벨 (기본값 zapcore.InfoLevel)
+func WithLevel(level zapcore.Level) Option {
+	return func(z *Logger) {
+		z.level = level
+	}
+}
+
+// New zap 로거 l 로 기록하는 Logger 생성 (l 이 nil 이면 zap.NewNop)
+//
+// 호출 위치(caller)는 항상 매니저의 로그 함수가 되어 쓸모가 없으므로 기록하지 않는다.
+func New(l *zap.Logger, opts ...Option) *Logger {
+	if l == nil {
+		l = zap.NewNop()
+	}
+	z := &Logger{l: l.WithOptions(zap.WithCaller(false)), level: zapcore.InfoLevel}
+	for _, opt := range opts {
+		opt(z)
+	}
+	return z
+}
+
+// Printf 로그 한 줄 기록 (process.Logger)
+func (z *Logger) Printf(format string, v ...interface{}) {
+	ce := z.l.Check(z.level, "")
+	if ce == nil {
+		return
+	}
+	line := logline.Parse(format, v...)
+	ce.Message = line.Message
+	if line.Process >= 0 {
+		ce.Write(zap.Int("process", line.Process))
+		return
+	}
+	ce.Write()
+}
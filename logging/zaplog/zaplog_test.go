@@ -0,0 +1,48 @@
+package zaplog
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestLoggerSplitsProcessField(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	mgr := vc.NewVectorClockManager(2, vc.WithLogger(New(zap.New(core), WithLevel(zapcore.DebugLevel))))
+	a := vc.NewProcess(0, mgr)
+	vc.NewProcess(1, mgr)
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := logs.FilterField(zap.Int("process", 0)).All()
+	if len(entries) == 0 {
+		t.Fatalf("no entries with process=0 in %+v", logs.All())
+	}
+	e := entries[len(entries)-1]
+	if e.Level != zapcore.DebugLevel || e.Message != "Sent message to Process 1, Vector: [1 0]" || e.Caller.Defined {
+		t.Fatalf("entry = %+v", e)
+	}
+}
+
+func TestLoggerKeepsOtherLines(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	New(zap.New(core)).Printf("Simulation: Seed %d\n", 7)
+	entries := logs.All()
+	if len(entries) != 1 || entries[0].Message != "Simulation: Seed 7" || len(entries[0].Context) != 0 {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
+
+func TestLoggerHonoursLevel(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	New(zap.New(core)).Printf("Process 1: dropped\n")
+	if n := logs.Len(); n != 0 {
+		t.Fatalf("info line recorded %d times by a warn core", n)
+	}
+	New(nil).Printf("Process 1: discarded\n")
+}
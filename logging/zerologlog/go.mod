@@ -0,0 +1,16 @@
+module github.com/seoyhaein/vectorclock/logging/zerologlog
+
+go 1.22
+
+require (
+	github.com/rs/zerolog v1.34.0
+	github.com/seoyhaein/vectorclock v0.0.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.15 // indirect
+	github.com/mattn/go-isatty v0.0.22 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+)
+
+replace github.com/seoyhaein/vectorclock => ../..
@@ -0,0 +1,53 @@
+// Package zerologlog zerolog 로거를 process.Logger 로 쓰는 어댑터
+//
+//	mgr := process.NewVectorClockManager(3, process.WithLogger(zerologlog.New(zerolog.New(os.Stderr))))
+//
+// "Process N: ..." 형식의 줄은 process 필드(N)와 나머지 내용으로 나뉘어 기록된다.
+package zerologlog
+
+import (
+	"github.com/rs/zerolog"
+
+	"github.com/seoyhaein/vectorclock/internal/logline"
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// Logger zerolog 로거를 감싼 process.Logger
+type Logger struct {
+	l     zerolog.Logger
+	level zerolog.Level
+}
+
+var _ vc.Logger = (*Logger)(nil)
+
+// Option Logger 설정 옵션
+type Option func(*Logger)
+
+// WithLevel 기록할 레벨 (기본값 zerolog.InfoLevel)
+func WithLevel(level zerolog.Level) Option {
+	return func(z *Logger) {
+		z.level = level
+	}
+}
+
+// New zerolog 로거 l 로 기록하는 Logger 생성
+func New(l zerolog.Logger, opts ...Option) *Logger {
+	z := &Logger{l: l, level: zerolog.InfoLevel}
+	for _, opt := range opts {
+		opt(z)
+	}
+	return z
+}
+
+// Printf 로그 한 줄 기록 (process.Logger)
+func (z *Logger) Printf(format string, v ...interface{}) {
+	e := z.l.WithLevel(z.level)
+	if e == nil {
+		return
+	}
+	line := logline.Parse(format, v...)
+	if line.Process >= 0 {
+		e = e.Int("process", line.Process)
+	}
+	e.Msg(line.Message)
+}
@@ -0,0 +1,46 @@
+package zerologlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestLoggerSplitsProcessField(t *testing.T) {
+	var buf bytes.Buffer
+	mgr := vc.NewVectorClockManager(2, vc.WithLogger(New(zerolog.New(&buf), WithLevel(zerolog.WarnLevel))))
+	a := vc.NewProcess(0, mgr)
+	vc.NewProcess(1, mgr)
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+
+	var rec struct {
+		Level   string `json:"level"`
+		Process *int   `json:"process"`
+		Message string `json:"message"`
+	}
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if err := json.Unmarshal(lines[len(lines)-1], &rec); err != nil {
+		t.Fatalf("%s: %v", buf.String(), err)
+	}
+	if rec.Level != "warn" || rec.Process == nil || *rec.Process != 0 || rec.Message != "Sent message to Process 1, Vector: [1 0]" {
+		t.Fatalf("record = %+v", rec)
+	}
+}
+
+func TestLoggerHonoursLevel(t *testing.T) {
+	var buf bytes.Buffer
+	New(zerolog.New(&buf).Level(zerolog.ErrorLevel)).Printf("Process 1: dropped\n")
+	if buf.Len() != 0 {
+		t.Fatalf("info line written below the logger level: %s", buf.String())
+	}
+	New(zerolog.New(&buf)).Printf("Simulation: Seed %d\n", 7)
+	if !bytes.Contains(buf.Bytes(), []byte(`"message":"Simulation: Seed 7"`)) || bytes.Contains(buf.Bytes(), []byte("process")) {
+		t.Fatalf("line = %s", buf.String())
+	}
+}
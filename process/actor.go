@@ -0,0 +1,219 @@
+package process
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrUnknownProcess 레지스트리에 없는 프로세스로 전송하려는 경우
+	ErrUnknownProcess = errors.New("process: unknown process")
+	// ErrAlreadyRunning 이미 실행 중인 프로세스를 다시 시작하려는 경우
+	ErrAlreadyRunning = errors.New("process: already running")
+)
+
+// Outgoing Behavior 가 메시지 처리 결과로 보내려는 메시지
+type Outgoing struct {
+	To    int    // 받는 프로세스 ID
+	Event string // 메시지 내용
+	Reply bool   // 처리 중인 요청 메시지에 대한 응답 여부 (To 는 무시됨)
+}
+
+// Behavior 액터의 메시지 처리 함수
+//
+// 호출 시점에는 수신 메시지의 Clock 병합이 이미 끝나 있으며,
+// 반환한 메시지는 송신 이벤트로 시계를 증가시킨 뒤 대상 프로세스의 메일박스로 전송된다.
+type Behavior func(msg Message) []Outgoing
+
+// actorState 액터 실행 상태
+type actorState struct {
+	mu      sync.Mutex
+	running bool
+	started bool // Start 이후 Stop 하지 않음 (루프가 스스로 끝나면 running 만 false)
+	quit    chan struct{}
+	done    chan struct{}
+}
+
+// register 프로세스를 레지스트리에 등록
+func (vcm *VectorClockManager) register(p *Process) {
+	vcm.procMu.Lock()
+	defer vcm.procMu.Unlock()
+
+	if vcm.procs == nil {
+		vcm.procs = make(map[int]*Process)
+	}
+	if old, ok := vcm.procs[p.ID]; ok && old.ring != nil {
+		vcm.rings.Add(-1)
+	}
+	if p.ring != nil {
+		vcm.rings.Add(1)
+	}
+	vcm.procs[p.ID] = p
+}
+
+// Lookup 등록된 프로세스 조회
+func (vcm *VectorClockManager) Lookup(id int) (*Process, bool) {
+	vcm.procMu.RLock()
+	defer vcm.procMu.RUnlock()
+
+	p, ok := vcm.procs[id]
+	return p, ok
+}
+
+// Processes 등록된 모든 프로세스 반환 (ID 순서)
+func (vcm *VectorClockManager) Processes() []*Process {
+	vcm.procMu.RLock()
+	defer vcm.procMu.RUnlock()
+
+	procs := make([]*Process, 0, len(vcm.procs))
+	for _, p := range vcm.procs {
+		procs = append(procs, p)
+	}
+	sort.Slice(procs, func(i, j int) bool { return procs[i].ID < procs[j].ID })
+	return procs
+}
+
+// Send 레지스트리를 통해 대상 프로세스의 메일박스로 메시지 전송
+//
+// 대상이 레지스트리에 없으면 매니저의 Transport 로 보낸다.
+func (p *Process) Send(to int, event string) error {
+	mailbox, err := p.ClockMgr.mailbox(to)
+	if err != nil {
+		return err
+	}
+	return p.SendMessage(to, event, mailbox, false)
+}
+
+// Start 메일박스를 처리하는 수신 루프를 고루틴으로 실행
+//
+// 메시지마다 Clock 병합 후 behavior 를 호출하고, 반환된 메시지를 전송한다.
+// behavior 에서 발생한 panic 은 복구되어 해당 메시지만 건너뛰고 루프는 계속된다.
+// panic 발생 시 루프를 재시작하려면 Supervisor 를 사용한다.
+func (p *Process) Start(behavior Behavior) error {
+	return p.start(behavior, nil)
+}
+
+// start 수신 루프 실행 (crashed 가 nil 이 아니면 panic 시 루프를 종료하고 crashed 호출)
+func (p *Process) start(behavior Behavior, crashed func(r interface{})) error {
+	p.actor.mu.Lock()
+	defer p.actor.mu.Unlock()
+
+	if p.actor.running {
+		return ErrAlreadyRunning
+	}
+	p.actor.running = true
+	p.actor.started = true
+	p.actor.quit = make(chan struct{})
+	p.actor.done = make(chan struct{})
+
+	go p.run(behavior, p.actor.quit, p.actor.done, crashed)
+	return nil
+}
+
+// Stop 수신 루프를 멈추고 종료될 때까지 대기
+func (p *Process) Stop() {
+	p.actor.mu.Lock()
+	if !p.actor.running {
+		p.actor.mu.Unlock()
+		return
+	}
+	quit, done := p.actor.quit, p.actor.done
+	p.actor.running = false
+	p.actor.started = false
+	p.actor.mu.Unlock()
+
+	close(quit)
+	<-done
+}
+
+// Running 수신 루프 실행 여부
+func (p *Process) Running() bool {
+	p.actor.mu.Lock()
+	defer p.actor.mu.Unlock()
+	return p.actor.running
+}
+
+// run 수신 루프
+func (p *Process) run(behavior Behavior, quit <-chan struct{}, done chan<- struct{}, crashed func(r interface{})) {
+	// panic 이 나도 시계는 되돌리지 않음: 그 사이 보낸 메시지나 로컬 이벤트의 카운터 값을 다시 쓰게 됨
+	defer func() {
+		if crashed != nil {
+			if r := recover(); r != nil {
+				p.ClockMgr.setActive(p.ID, false)
+				p.actor.mu.Lock()
+				p.actor.running = false
+				p.actor.mu.Unlock()
+				close(done)
+				crashed(r)
+				return
+			}
+		}
+		close(done)
+	}()
+
+	for {
+		msg, ok, stopped := p.next(quit)
+		if stopped {
+			return
+		}
+		if !ok {
+			p.logf("Process %d: Channel closed\n", p.ID)
+			p.actor.mu.Lock()
+			p.actor.running = false
+			p.actor.mu.Unlock()
+			return
+		}
+		// 메시지를 처리하고 결과 메시지를 모두 보낼 때까지 활성 (종료 감지)
+		p.ClockMgr.setActive(p.ID, true)
+		if err := p.receive(msg); err != nil {
+			p.ClockMgr.setActive(p.ID, false)
+			continue
+		}
+		var outgoing []Outgoing
+		if crashed != nil {
+			outgoing = behavior(msg)
+		} else {
+			outgoing = p.invoke(behavior, msg)
+		}
+		for _, out := range outgoing {
+			var err error
+			if out.Reply {
+				err = p.Reply(msg, out.Event)
+			} else {
+				err = p.Send(out.To, out.Event)
+			}
+			if err != nil {
+				p.logf("Process %d: %v\n", p.ID, err)
+			}
+		}
+		p.ClockMgr.setActive(p.ID, false)
+	}
+}
+
+// next 수신 루프가 처리할 다음 메시지 (메일박스가 닫히면 ok 가 false, quit 이 닫히면 stopped)
+func (p *Process) next(quit <-chan struct{}) (msg Message, ok, stopped bool) {
+	if msg, ok := p.unstash(p.MessageCh); ok {
+		return msg, true, false
+	}
+	if p.ring != nil {
+		return p.ring.pop(quit)
+	}
+	select {
+	case <-quit:
+		return Message{}, false, true
+	case msg, ok = <-p.MessageCh:
+		return msg, ok, false
+	}
+}
+
+// invoke panic 을 복구하며 behavior 호출
+func (p *Process) invoke(behavior Behavior, msg Message) (out []Outgoing) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.logf("Process %d: behavior panicked on message %s: %v\n", p.ID, msg.MessageID, r)
+			out = nil
+		}
+	}()
+	return behavior(msg)
+}
@@ -0,0 +1,103 @@
+package process
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// waitMessage ch 에서 메시지 하나 (시간 안에 없으면 실패)
+func waitMessage(t *testing.T, ch <-chan Message) Message {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message within 5s")
+		return Message{}
+	}
+}
+
+func TestActorsExchangeThroughRegistry(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	ping := NewProcess(0, vcm)
+	pong := NewProcess(1, vcm)
+	got := make(chan Message, 1)
+
+	if err := pong.Start(func(msg Message) []Outgoing {
+		return []Outgoing{{To: msg.From, Event: "pong"}}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer pong.Stop()
+	if err := ping.Start(func(msg Message) []Outgoing {
+		got <- msg
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer ping.Stop()
+
+	if err := ping.Send(1, "ping"); err != nil {
+		t.Fatal(err)
+	}
+	reply := waitMessage(t, got)
+	if reply.From != 1 || reply.Event != "pong" {
+		t.Fatalf("reply = %+v, want pong from 1", reply)
+	}
+	// ping 송신 1, pong 수신 1 + 송신 1
+	if reply.Vector[0] != 1 || reply.Vector[1] != 2 {
+		t.Fatalf("reply vector = %v, want [1 2]", reply.Vector)
+	}
+	if err := ping.Send(7, "nobody"); !errors.Is(err, ErrUnknownProcess) {
+		t.Fatalf("Send to unregistered process = %v, want ErrUnknownProcess", err)
+	}
+}
+
+func TestActorSurvivesBehaviorPanic(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	sender := NewProcess(0, vcm)
+	actor := NewProcess(1, vcm)
+	got := make(chan Message, 2)
+	if err := actor.Start(func(msg Message) []Outgoing {
+		if msg.Event == "boom" {
+			panic("boom")
+		}
+		got <- msg
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer actor.Stop()
+
+	if err := actor.Start(func(Message) []Outgoing { return nil }); !errors.Is(err, ErrAlreadyRunning) {
+		t.Fatalf("second Start = %v, want ErrAlreadyRunning", err)
+	}
+	if err := sender.Send(1, "boom"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.Send(1, "after"); err != nil {
+		t.Fatal(err)
+	}
+	if msg := waitMessage(t, got); msg.Event != "after" {
+		t.Fatalf("got %q, want the message after the panic", msg.Event)
+	}
+	actor.Stop()
+	if actor.Running() {
+		t.Fatal("actor still running after Stop")
+	}
+}
+
+func TestProcessesListedInIDOrder(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	for _, id := range []int{2, 0, 1} {
+		NewProcess(id, vcm)
+	}
+	procs := vcm.Processes()
+	if len(procs) != 3 || procs[0].ID != 0 || procs[1].ID != 1 || procs[2].ID != 2 {
+		t.Fatalf("Processes() = %v, want IDs 0, 1, 2", procs)
+	}
+	if p, ok := vcm.Lookup(2); !ok || p.ID != 2 {
+		t.Fatalf("Lookup(2) = %v, %v", p, ok)
+	}
+}
@@ -0,0 +1,124 @@
+package process
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Round 애그리게이터가 모은 한 라운드의 입력
+type Round struct {
+	Number int       // 라운드 번호 (1 부터)
+	Inputs []Message // 송신자 ID 순서의 입력 (송신자마다 한 건)
+	Event  Event     // 라운드를 마친 결합 이벤트 (모든 입력의 시계를 병합한 뒤의 로컬 이벤트)
+}
+
+// RoundFunc 라운드의 입력이 모두 도착했을 때 호출 (반환한 메시지는 Behavior 와 같이 전송)
+type RoundFunc func(r Round) []Outgoing
+
+// Aggregator 여러 송신자의 메시지를 라운드 단위로 모으는 팬인 프로세스 (배리어, 라운드 기반 알고리즘)
+//
+// 송신자마다 k 번째로 받은 메시지가 k 번째 라운드의 입력이다. 모든 송신자의 입력이 도착하면
+// 결합 로컬 이벤트("round k")를 기록하고 RoundFunc 를 호출한다. 입력은 받을 때마다 시계에 병합되므로
+// 결합 이벤트의 시계는 그 라운드까지의 모든 입력 이후(happens-after)이다. 다음 라운드의 입력을 먼저 보낸 송신자의
+// 메시지는 그 라운드까지 보관하며, 송신자 목록에 없는 프로세스의 메시지는 병합만 하고 라운드에는 넣지 않는다.
+type Aggregator struct {
+	proc    *Process
+	senders []int // 송신자 ID (오름차순, 중복 없음)
+	onRound RoundFunc
+
+	mu      sync.Mutex
+	pending map[int][]Message // 송신자 ID -> 아직 라운드에 쓰지 않은 입력 (받은 순서)
+	round   int               // 마친 라운드 수
+}
+
+// NewAggregator 프로세스 p 를 senders 의 메시지를 모으는 Aggregator 로 구성 (onRound 는 nil 이어도 됨)
+func NewAggregator(p *Process, senders []int, onRound RoundFunc) *Aggregator {
+	ids := append([]int(nil), senders...)
+	sort.Ints(ids)
+	uniq := ids[:0]
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			uniq = append(uniq, id)
+		}
+	}
+	pending := make(map[int][]Message, len(uniq))
+	for _, id := range uniq {
+		pending[id] = nil
+	}
+	return &Aggregator{proc: p, senders: uniq, onRound: onRound, pending: pending}
+}
+
+// Process 애그리게이터 프로세스
+func (a *Aggregator) Process() *Process {
+	return a.proc
+}
+
+// Start 애그리게이터의 수신 루프 시작 (Supervisor 로 감독하려면 Behavior 를 넘김)
+func (a *Aggregator) Start() error {
+	return a.proc.Start(a.Behavior())
+}
+
+// Behavior 애그리게이터의 메시지 처리 함수
+func (a *Aggregator) Behavior() Behavior {
+	return a.handle
+}
+
+// Rounds 마친 라운드 수
+func (a *Aggregator) Rounds() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.round
+}
+
+// Waiting 현재 라운드의 입력을 아직 보내지 않은 송신자 ID (오름차순)
+func (a *Aggregator) Waiting() []int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var ids []int
+	for _, id := range a.senders {
+		if len(a.pending[id]) == 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// handle 입력을 보관하고 라운드가 채워지면 결합 이벤트 기록
+func (a *Aggregator) handle(msg Message) []Outgoing {
+	inputs, number, ok := a.collect(msg)
+	if !ok {
+		return nil
+	}
+	e := a.proc.LocalEvent(fmt.Sprintf("round %d", number))
+	if a.onRound == nil {
+		return nil
+	}
+	return a.onRound(Round{Number: number, Inputs: inputs, Event: e})
+}
+
+// collect 입력 보관 (모든 송신자의 입력이 모였으면 라운드 입력과 번호를 꺼냄)
+func (a *Aggregator) collect(msg Message) ([]Message, int, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	queue, ok := a.pending[msg.From]
+	if !ok {
+		return nil, 0, false
+	}
+	a.pending[msg.From] = append(queue, msg)
+	for _, id := range a.senders {
+		if len(a.pending[id]) == 0 {
+			return nil, 0, false
+		}
+	}
+
+	inputs := make([]Message, len(a.senders))
+	for i, id := range a.senders {
+		inputs[i] = a.pending[id][0]
+		a.pending[id] = a.pending[id][1:]
+	}
+	a.round++
+	return inputs, a.round, true
+}
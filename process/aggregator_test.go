@@ -0,0 +1,97 @@
+package process
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// waitRound ch 에서 라운드 하나 (시간 안에 없으면 실패)
+func waitRound(t *testing.T, ch <-chan Round) Round {
+	t.Helper()
+	select {
+	case r := <-ch:
+		return r
+	case <-time.After(5 * time.Second):
+		t.Fatal("no round within 5s")
+		return Round{}
+	}
+}
+
+func TestAggregatorCombinesOneInputPerSender(t *testing.T) {
+	vcm := NewVectorClockManager(4, WithLogger(nil))
+	s0 := NewProcess(0, vcm, WithMailboxSize(8))
+	s1 := NewProcess(1, vcm, WithMailboxSize(8))
+	outsider := NewProcess(3, vcm, WithMailboxSize(8))
+	rounds := make(chan Round, 4)
+	agg := NewAggregator(NewProcess(2, vcm, WithMailboxSize(8)), []int{1, 0, 1}, func(r Round) []Outgoing {
+		rounds <- r
+		return []Outgoing{{To: 3, Event: fmt.Sprintf("done %d", r.Number)}}
+	})
+	if err := agg.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer agg.Process().Stop()
+
+	// 0 이 두 라운드의 입력을 먼저 보내도 1 의 입력이 올 때까지 라운드는 끝나지 않음
+	for _, event := range []string{"a1", "a2"} {
+		if err := s0.Send(2, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := outsider.Send(2, "noise"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s1.Send(2, "b1"); err != nil {
+		t.Fatal(err)
+	}
+
+	r := waitRound(t, rounds)
+	if r.Number != 1 || len(r.Inputs) != 2 || r.Inputs[0].Event != "a1" || r.Inputs[1].Event != "b1" {
+		t.Fatalf("round = %+v, want round 1 with a1 and b1", r)
+	}
+	if r.Event.Name != "round 1" || r.Event.Kind != EventLocal {
+		t.Fatalf("combined event = %+v", r.Event)
+	}
+	for _, in := range r.Inputs {
+		if !HappenedBefore(in.Vector, r.Event.Clock) {
+			t.Fatalf("input %v does not happen before the combined event %v", in.Vector, r.Event.Clock)
+		}
+	}
+	if agg.Rounds() != 1 || !reflect.DeepEqual(agg.Waiting(), []int{1}) {
+		t.Fatalf("after round 1: %d rounds, waiting %v, want 1 and [1]", agg.Rounds(), agg.Waiting())
+	}
+	if msg := waitMessage(t, outsider.MessageCh); msg.From != 2 || msg.Event != "done 1" {
+		t.Fatalf("round output = %+v, want done 1 from 2", msg)
+	}
+
+	if err := s1.Send(2, "b2"); err != nil {
+		t.Fatal(err)
+	}
+	if r := waitRound(t, rounds); r.Number != 2 || r.Inputs[0].Event != "a2" || r.Inputs[1].Event != "b2" {
+		t.Fatalf("round = %+v, want round 2 with a2 and b2", r)
+	}
+	if got := agg.Waiting(); !reflect.DeepEqual(got, []int{0, 1}) {
+		t.Fatalf("Waiting() = %v, want [0 1]", got)
+	}
+}
+
+func TestAggregatorWithoutRoundFunc(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	s := NewProcess(0, vcm, WithMailboxSize(4))
+	agg := NewAggregator(NewProcess(1, vcm, WithMailboxSize(4)), []int{0}, nil)
+	behavior := agg.Behavior()
+	for i := 0; i < 3; i++ {
+		if err := s.Send(1, "m"); err != nil {
+			t.Fatal(err)
+		}
+		msg := <-agg.Process().MessageCh
+		if out := behavior(msg); out != nil {
+			t.Fatalf("behavior returned %v without a RoundFunc", out)
+		}
+	}
+	if n := agg.Rounds(); n != 3 {
+		t.Fatalf("Rounds() = %d, want 3", n)
+	}
+}
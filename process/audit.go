@@ -0,0 +1,198 @@
+package process
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AuditCause 시계 변경 원인
+type AuditCause int
+
+const (
+	// AuditLocal 로컬 이벤트 (내부 이벤트, 송신)로 자신의 항목 증가
+	AuditLocal AuditCause = iota
+	// AuditMerge 수신 메시지의 시계 병합 후 자신의 항목 증가
+	AuditMerge
+	// AuditAbsorb 자신의 항목을 증가시키지 않는 병합 (하트비트 시계 전파)
+	AuditAbsorb
+	// AuditCheckpoint 체크포인트 기준 시계만큼 감소 (Checkpoint)
+	AuditCheckpoint
+	// AuditReset 0 으로 재설정 (ResetEpoch)
+	AuditReset
+)
+
+// String 원인 이름 반환
+func (c AuditCause) String() string {
+	switch c {
+	case AuditLocal:
+		return "local"
+	case AuditMerge:
+		return "merge"
+	case AuditAbsorb:
+		return "absorb"
+	case AuditCheckpoint:
+		return "checkpoint"
+	case AuditReset:
+		return "reset"
+	default:
+		return fmt.Sprintf("AuditCause(%d)", int(c))
+	}
+}
+
+// MarshalText JSON 등에서 원인 이름으로 출력
+func (c AuditCause) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// AuditRecord 시계 변경 한 건
+type AuditRecord struct {
+	Seq       int64      `json:"seq"`                  // 매니저 전체에서의 기록 순서 (1 부터)
+	Process   int        `json:"process"`              // 시계를 가진 프로세스 ID
+	Peer      int        `json:"peer"`                 // 채널 시계면 상대 ID (기본 시계면 -1)
+	Cause     AuditCause `json:"cause"`                // 변경 원인
+	From      int        `json:"from"`                 // 병합한 메시지의 송신자 (병합이 아니거나 알 수 없으면 -1)
+	MessageID string     `json:"message_id,omitempty"` // 병합한 메시지 ID (병합이 아니거나 알 수 없으면 "")
+	Epoch     int        `json:"epoch"`                // 변경 후 에포크
+	Old       []int      `json:"old"`                  // 변경 전 시계
+	New       []int      `json:"new"`                  // 변경 후 시계
+	Time      time.Time  `json:"time"`                 // 기록 시각
+}
+
+// auditLog 매니저의 시계 변경 기록
+type auditLog struct {
+	enabled bool // 기록 여부 (WithAudit, 생성 후 바뀌지 않음)
+	limit   int  // 보관할 최대 기록 수 (0 이하면 무제한)
+
+	mu      sync.Mutex
+	seq     int64
+	records []AuditRecord
+}
+
+// WithAudit 모든 시계 변경(UpdateClock, 로컬 이벤트, 병합, 복원, 체크포인트, 재설정)을 기록
+//
+// 기록마다 원인(로컬 이벤트 / 메시지 X 의 병합 등), 변경 전후 시계, 시각이 남으며 AuditTrail 로 조회하고
+// WriteAudit 으로 내보내 사후 분석에 쓸 수 있다. limit 이 0 보다 크면 최근 limit 건만 보관한다.
+// 도메인 시계의 변경은 기록하지 않는다.
+func WithAudit(limit int) ManagerOption {
+	return func(vcm *VectorClockManager) {
+		vcm.audit.enabled = true
+		vcm.audit.limit = limit
+	}
+}
+
+// AuditTrail 시계 변경 기록 (기록 순서)
+func (vcm *VectorClockManager) AuditTrail() []AuditRecord {
+	vcm.audit.mu.Lock()
+	defer vcm.audit.mu.Unlock()
+	return append([]AuditRecord(nil), vcm.audit.kept()...)
+}
+
+// kept 보관 한도 안의 기록 (a.mu 보유 상태에서 호출)
+func (a *auditLog) kept() []AuditRecord {
+	if a.limit > 0 && len(a.records) > a.limit {
+		return a.records[len(a.records)-a.limit:]
+	}
+	return a.records
+}
+
+// AuditOf 특정 프로세스 시계의 변경 기록 (기록 순서, 채널 시계 포함)
+func (vcm *VectorClockManager) AuditOf(processID int) []AuditRecord {
+	vcm.audit.mu.Lock()
+	defer vcm.audit.mu.Unlock()
+
+	var records []AuditRecord
+	for _, r := range vcm.audit.kept() {
+		if r.Process == processID {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// WriteAudit 시계 변경 기록을 한 줄에 한 건씩 JSON 으로 출력 (JSON Lines)
+func (vcm *VectorClockManager) WriteAudit(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, r := range vcm.AuditTrail() {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditBefore 변경 전 시계 복사본 (기록하지 않으면 nil, vcm.Mu 또는 lockClock 보유 상태에서 호출)
+func (vcm *VectorClockManager) auditBefore(clock []int) []int {
+	if !vcm.audit.enabled {
+		return nil
+	}
+	return append([]int(nil), clock...)
+}
+
+// auditLocked 시계 변경 기록 (r 에 Old 를 채워 변경 후 clock 과 함께 전달, vcm.Mu 또는 lockClock 보유 상태에서 호출)
+func (vcm *VectorClockManager) auditLocked(r AuditRecord, clock []int) {
+	if !vcm.audit.enabled {
+		return
+	}
+	r.New = append([]int(nil), clock...)
+	r.Epoch = vcm.epoch
+	r.Time = vcm.nowLocked()
+
+	a := &vcm.audit
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.seq++
+	r.Seq = a.seq
+	a.records = append(a.records, r)
+	if a.limit > 0 && len(a.records) > 2*a.limit {
+		// 매번 잘라내지 않고 두 배가 되었을 때 한꺼번에 정리
+		a.records = append(a.records[:0:0], a.records[len(a.records)-a.limit:]...)
+	}
+}
+
+// auditClockLocked 기본 시계 변경 기록 (vcm.Mu 또는 lockClock 보유 상태에서 호출)
+func (vcm *VectorClockManager) auditClockLocked(processID int, cause AuditCause, from int, messageID string, old []int) {
+	vcm.auditLocked(AuditRecord{
+		Process: processID, Peer: -1, Cause: cause, From: from, MessageID: messageID, Old: old,
+	}, vcm.Clock[processID])
+}
+
+// auditClocks 모든 기본/채널 시계의 변경 전 복사본 (기록하지 않으면 nil, vcm.Mu 보유 상태에서 호출)
+func (vcm *VectorClockManager) auditClocks() map[ChannelKey][]int {
+	if !vcm.audit.enabled {
+		return nil
+	}
+	olds := make(map[ChannelKey][]int, len(vcm.Clock)+len(vcm.channels))
+	for id, clock := range vcm.Clock {
+		olds[ChannelKey{Owner: id, Peer: -1}] = append([]int(nil), clock...)
+	}
+	for key, clock := range vcm.channels {
+		olds[key] = append([]int(nil), clock...)
+	}
+	return olds
+}
+
+// auditClocksLocked auditClocks 로 복사한 모든 시계의 변경 기록 (프로세스, 상대 ID 순서, vcm.Mu 보유 상태에서 호출)
+func (vcm *VectorClockManager) auditClocksLocked(cause AuditCause, olds map[ChannelKey][]int) {
+	keys := make([]ChannelKey, 0, len(olds))
+	for key := range olds {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Owner != keys[j].Owner {
+			return keys[i].Owner < keys[j].Owner
+		}
+		return keys[i].Peer < keys[j].Peer
+	})
+	for _, key := range keys {
+		clock := vcm.channels[key]
+		if key.Peer < 0 {
+			clock = vcm.Clock[key.Owner]
+		}
+		vcm.auditLocked(AuditRecord{Process: key.Owner, Peer: key.Peer, Cause: cause, From: -1, Old: olds[key]}, clock)
+	}
+}
@@ -0,0 +1,146 @@
+package process
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAuditRecordsSendAndMerge(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithAudit(0))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+
+	trail := vcm.AuditTrail()
+	if len(trail) != 2 {
+		t.Fatalf("AuditTrail() = %+v, want send and merge", trail)
+	}
+	send, merge := trail[0], trail[1]
+	if send.Process != 0 || send.Cause != AuditLocal || !reflect.DeepEqual(send.Old, []int{0, 0}) || !reflect.DeepEqual(send.New, []int{1, 0}) {
+		t.Fatalf("send record = %+v", send)
+	}
+	if merge.Process != 1 || merge.Cause != AuditMerge || merge.From != 0 || merge.MessageID == "" ||
+		!reflect.DeepEqual(merge.Old, []int{0, 0}) || !reflect.DeepEqual(merge.New, []int{1, 1}) {
+		t.Fatalf("merge record = %+v", merge)
+	}
+	if send.Seq >= merge.Seq {
+		t.Fatalf("records out of order: %d, %d", send.Seq, merge.Seq)
+	}
+	if got := vcm.AuditOf(1); len(got) != 1 || got[0].Seq != merge.Seq {
+		t.Fatalf("AuditOf(1) = %+v, want the merge", got)
+	}
+}
+
+func TestAuditRecordsCheckpoint(t *testing.T) {
+	vcm := NewVectorClockManager(1, WithLogger(nil), WithAudit(0))
+	p := NewProcess(0, vcm)
+	p.LocalEvent("a")
+	vcm.Checkpoint()
+
+	trail := vcm.AuditOf(0)
+	last := trail[len(trail)-1]
+	if last.Cause != AuditCheckpoint || !reflect.DeepEqual(last.Old, []int{1}) || !reflect.DeepEqual(last.New, []int{0}) {
+		t.Fatalf("last record = %+v, want checkpoint [1] -> [0]", last)
+	}
+	if last.Epoch != 1 {
+		t.Fatalf("checkpoint record epoch = %d, want 1", last.Epoch)
+	}
+}
+
+func TestAuditKeepsLimit(t *testing.T) {
+	vcm := NewVectorClockManager(1, WithLogger(nil), WithAudit(2))
+	p := NewProcess(0, vcm)
+	for i := 0; i < 3; i++ {
+		p.LocalEvent("e")
+	}
+	trail := vcm.AuditTrail()
+	if len(trail) != 2 || trail[0].Seq != 2 || trail[1].Seq != 3 {
+		t.Fatalf("AuditTrail() = %+v, want the last two records", trail)
+	}
+}
+
+func TestWriteAuditEmitsJSONLines(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithAudit(0))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := vcm.WriteAudit(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var causes []string
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var r struct {
+			Cause string `json:"cause"`
+		}
+		if err := json.Unmarshal(sc.Bytes(), &r); err != nil {
+			t.Fatalf("line %q: %v", sc.Text(), err)
+		}
+		causes = append(causes, r.Cause)
+	}
+	if !reflect.DeepEqual(causes, []string{"local", "merge"}) {
+		t.Fatalf("causes = %v, want [local merge]", causes)
+	}
+}
+
+func TestAuditDisabledByDefault(t *testing.T) {
+	vcm := NewVectorClockManager(1, WithLogger(nil))
+	NewProcess(0, vcm).LocalEvent("e")
+	if trail := vcm.AuditTrail(); len(trail) != 0 {
+		t.Fatalf("AuditTrail() = %+v without WithAudit", trail)
+	}
+}
+
+func TestAuditRecordsUseManagerTime(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithAudit(0), WithTimeSource(TimeFunc(func() time.Time { return at })))
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	b := NewProcess(1, vcm, WithMailboxSize(4))
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+
+	trail := vcm.AuditTrail()
+	if len(trail) != 2 {
+		t.Fatalf("audit trail has %d records, want send and merge", len(trail))
+	}
+	for _, r := range trail {
+		if !r.Time.Equal(at) {
+			t.Fatalf("record %d (%v) at %v, want the manager time %v", r.Seq, r.Cause, r.Time, at)
+		}
+	}
+}
+
+func TestAuditRecordsUseVirtualTime(t *testing.T) {
+	vcm := NewVectorClockManager(1, WithLogger(nil), WithAudit(0), WithVirtualTime())
+	p := NewProcess(0, vcm, WithMailboxSize(4))
+	p.Sleep(time.Hour)
+	p.LocalEvent("later")
+
+	trail := vcm.AuditTrail()
+	if len(trail) != 1 {
+		t.Fatalf("audit trail has %d records, want 1", len(trail))
+	}
+	if got, want := trail[0].Time, vcm.Scheduler().Wall().Now(); !got.Equal(want) {
+		t.Fatalf("record at %v, want virtual time %v", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package process
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultSaturation 메일박스가 포화된 것으로 보는 기본 비율 (용량의 80%)
+const DefaultSaturation = 0.8
+
+// BackpressureKind 배압 알림 종류
+type BackpressureKind int
+
+const (
+	// BackpressureSaturated 메일박스 깊이가 포화 임계값 이상이 됨
+	BackpressureSaturated BackpressureKind = iota
+	// BackpressureRelieved 포화됐던 메일박스 깊이가 임계값 아래로 내려감
+	BackpressureRelieved
+	// BackpressureBlocked 메일박스가 가득 차 송신이 막힘 (받는 쪽이 꺼낼 때까지 또는 제한 시간까지 대기)
+	BackpressureBlocked
+)
+
+// String 종류 이름 반환
+func (k BackpressureKind) String() string {
+	switch k {
+	case BackpressureSaturated:
+		return "saturated"
+	case BackpressureRelieved:
+		return "relieved"
+	case BackpressureBlocked:
+		return "blocked"
+	default:
+		return fmt.Sprintf("BackpressureKind(%d)", int(k))
+	}
+}
+
+// BackpressureEvent 배압 알림
+type BackpressureEvent struct {
+	Kind     BackpressureKind
+	Process  int       // 메일박스 주인 (받는 프로세스 ID)
+	Sender   int       // 알림을 일으킨 메시지를 보낸 프로세스 ID
+	Depth    int       // 알림 시점의 메일박스 깊이
+	Capacity int       // 메일박스 버퍼 크기 (0 이면 버퍼 없음)
+	Time     time.Time // 알림 시각 (매니저 시계 기준)
+}
+
+// String 알림 요약
+func (e BackpressureEvent) String() string {
+	return fmt.Sprintf("mailbox of process %d %v (depth %d/%d, sender %d)",
+		e.Process, e.Kind, e.Depth, e.Capacity, e.Sender)
+}
+
+// backpressureState 배압 알림 상태
+type backpressureState struct {
+	threshold float64      // 포화 비율 (0 이면 DefaultSaturation)
+	saturated map[int]bool // 포화 상태인 메일박스 (vcm.term.mu 로 보호)
+
+	mu       sync.Mutex
+	watchers []func(BackpressureEvent)
+}
+
+// WithSaturationThreshold 메일박스가 포화된 것으로 보는 용량 비율 (0 < ratio <= 1, 기본값 DefaultSaturation)
+//
+// 버퍼 없는 메일박스는 송신자가 받는 쪽을 기다리기 시작하면 포화로 본다.
+func WithSaturationThreshold(ratio float64) ManagerOption {
+	return func(vcm *VectorClockManager) {
+		if ratio > 0 && ratio <= 1 {
+			vcm.pressure.threshold = ratio
+		}
+	}
+}
+
+// WatchBackpressure 메일박스 포화/해소와 막힌 송신 알림을 받을 함수 등록
+//
+// 알림은 상태가 바뀐 고루틴(송신자 또는 수신자)에서 잠금 없이 호출된다. 적응형 송신자나 스케줄러는
+// Saturated 에서 송신 속도를 줄이고 Relieved 에서 되돌릴 수 있다. fn 은 오래 막지 않아야 한다.
+func (vcm *VectorClockManager) WatchBackpressure(fn func(BackpressureEvent)) {
+	b := &vcm.pressure
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.watchers = append(b.watchers, fn)
+}
+
+// saturationLevel 용량 capacity 인 메일박스를 포화로 보는 깊이 (최소 1)
+func (b *backpressureState) saturationLevel(capacity int) int {
+	ratio := b.threshold
+	if ratio == 0 {
+		ratio = DefaultSaturation
+	}
+	level := int(math.Ceil(ratio * float64(capacity)))
+	if level < 1 {
+		level = 1
+	}
+	return level
+}
+
+// saturationLocked 메일박스 깊이 변화로 포화 상태가 바뀌었으면 알림 반환 (vcm.term.mu 보유 상태에서 호출)
+func (vcm *VectorClockManager) saturationLocked(target *Process, sender, depth int, now time.Time) (BackpressureEvent, bool) {
+	b := &vcm.pressure
+	capacity := target.mailboxCap()
+	full := depth >= b.saturationLevel(capacity)
+	if full == b.saturated[target.ID] {
+		return BackpressureEvent{}, false
+	}
+	if b.saturated == nil {
+		b.saturated = make(map[int]bool)
+	}
+	kind := BackpressureRelieved
+	if full {
+		kind = BackpressureSaturated
+		b.saturated[target.ID] = true
+	} else {
+		delete(b.saturated, target.ID)
+	}
+	return BackpressureEvent{
+		Kind:     kind,
+		Process:  target.ID,
+		Sender:   sender,
+		Depth:    depth,
+		Capacity: capacity,
+		Time:     now,
+	}, true
+}
+
+// notifyBackpressure 등록된 함수에 알림 전달
+func (vcm *VectorClockManager) notifyBackpressure(e BackpressureEvent) {
+	b := &vcm.pressure
+	b.mu.Lock()
+	watchers := make([]func(BackpressureEvent), len(b.watchers))
+	copy(watchers, b.watchers)
+	b.mu.Unlock()
+
+	for _, fn := range watchers {
+		fn(e)
+	}
+}
+
+// blocked 메일박스 targetCh 가 가득 차 송신이 막히게 됐음을 알림
+func (p *Process) blocked(targetCh chan<- Message, msg Message) {
+	p.ClockMgr.notifyBackpressure(BackpressureEvent{
+		Kind:     BackpressureBlocked,
+		Process:  msg.To,
+		Sender:   msg.From,
+		Depth:    len(targetCh),
+		Capacity: cap(targetCh),
+		Time:     p.ClockMgr.now(),
+	})
+}
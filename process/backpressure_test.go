@@ -0,0 +1,80 @@
+package process
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// pressureLog WatchBackpressure 로 받은 알림 기록
+type pressureLog struct {
+	mu     sync.Mutex
+	events []BackpressureEvent
+}
+
+func (l *pressureLog) add(e BackpressureEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+}
+
+func (l *pressureLog) kinds() []BackpressureKind {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	kinds := make([]BackpressureKind, len(l.events))
+	for i, e := range l.events {
+		kinds[i] = e.Kind
+	}
+	return kinds
+}
+
+func TestBackpressureSaturatesAndRelieves(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithSaturationThreshold(0.5))
+	log := &pressureLog{}
+	vcm.WatchBackpressure(log.add)
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm, WithMailboxSize(4))
+
+	for _, event := range []string{"m1", "m2", "m3"} {
+		if err := a.Send(1, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := log.kinds(); len(got) != 1 || got[0] != BackpressureSaturated {
+		t.Fatalf("notifications after 3 sends = %v, want [saturated]", got)
+	}
+	if e := log.events[0]; e.Process != 1 || e.Sender != 0 || e.Depth != 2 || e.Capacity != 4 {
+		t.Fatalf("saturated event = %v", e)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := b.ReceiveMessages(b.MessageCh); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := log.kinds(); len(got) != 2 || got[1] != BackpressureRelieved {
+		t.Fatalf("notifications after draining = %v, want [saturated relieved]", got)
+	}
+}
+
+func TestBackpressureReportsBlockedSender(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	log := &pressureLog{}
+	vcm.WatchBackpressure(log.add)
+	a := NewProcess(0, vcm, WithSendTimeout(time.Millisecond))
+	NewProcess(1, vcm, WithMailboxSize(1))
+
+	if err := a.Send(1, "m1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Send(1, "m2"); err == nil {
+		t.Fatal("send to a full mailbox succeeded")
+	}
+	got := log.kinds()
+	if len(got) != 2 || got[0] != BackpressureSaturated || got[1] != BackpressureBlocked {
+		t.Fatalf("notifications = %v, want [saturated blocked]", got)
+	}
+	if e := log.events[1]; e.Depth != 1 || e.Capacity != 1 || e.Sender != 0 {
+		t.Fatalf("blocked event = %v", e)
+	}
+}
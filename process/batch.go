@@ -0,0 +1,160 @@
+package process
+
+import (
+	"errors"
+	"sort"
+)
+
+// ReceiveBatch 메일박스에 쌓인 메시지를 모두 꺼내 한 번에 처리
+//
+// 최소 한 건이 도착할 때까지 기다린 뒤, 그 시점에 쌓여 있는 메시지를 모두 꺼내
+// 인과 순서(벡터 합이 작은 순서 = happens-before 의 선형 확장)로 정렬하고,
+// 한 번의 잠금 구간에서 각 메시지의 Clock 을 순서대로 병합한다.
+// 반환값은 병합한 순서의 메시지 목록이며, 병합하지 못한 메시지의 에러는 합쳐서 반환한다.
+func (p *Process) ReceiveBatch(messageCh <-chan Message) ([]Message, error) {
+	first, ok, err := p.await(messageCh)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		p.logf("Process %d: Channel closed\n", p.ID)
+		return nil, nil
+	}
+	batch := []Message{first}
+	for {
+		msg, ok := p.unstash(messageCh)
+		if !ok {
+			break
+		}
+		batch = append(batch, msg)
+	}
+	if r := p.ringOf(messageCh); r != nil {
+		for {
+			msg, ok, _ := r.tryPop()
+			if !ok {
+				break
+			}
+			batch = append(batch, msg)
+		}
+	}
+drain:
+	for {
+		select {
+		case msg, ok := <-messageCh:
+			if !ok {
+				break drain
+			}
+			batch = append(batch, msg)
+		default:
+			break drain
+		}
+	}
+
+	p.lock()
+	defer p.Mu.Unlock()
+
+	var errs []error
+	var domainMsgs, baseMsgs []Message
+	var vectors [][]int
+	var epochs []int // vectors 를 변환한 에포크
+	for _, msg := range batch {
+		p.ack(msg)
+		if err := p.validate(msg); err != nil {
+			errs = append(errs, p.reject(msg, err))
+			continue
+		}
+		if err := p.checkDuplicate(msg); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if msg.Domain != "" {
+			if err := p.receiveDomain(msg); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			domainMsgs = append(domainMsgs, msg)
+			continue
+		}
+		vector, at, err := p.currentVector(msg)
+		if err != nil {
+			errs = append(errs, p.reject(msg, err))
+			continue
+		}
+		baseMsgs = append(baseMsgs, msg)
+		epochs = append(epochs, at)
+		vectors = append(vectors, vector)
+	}
+
+	// 기본 시계 메시지를 인과 순서로 정렬 (도메인 메시지는 이미 처리됨)
+	order := make([]int, len(baseMsgs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return sum(vectors[order[i]]) < sum(vectors[order[j]]) })
+
+	ordered := domainMsgs
+	sorted := make([][]int, len(order))
+	single := true // 모두 같은 에포크로 변환됨
+	for i, idx := range order {
+		ordered = append(ordered, baseMsgs[idx])
+		sorted[i] = vectors[idx]
+		single = single && epochs[idx] == epochs[0]
+	}
+
+	// 한 번에 병합하지 못하면(채널별 시계, 변환 중 에포크 변경) 메시지마다 에포크를 확인하며 순서대로 병합
+	if len(order) > 0 && (p.ClockMgr.Mode == ClockPerChannel || !single ||
+		!p.ClockMgr.mergeBatch(p.ID, epochs[0], ordered[len(domainMsgs):], sorted)) {
+		for i, idx := range order {
+			if _, err := p.mergeMessage(baseMsgs[idx], sorted[i], epochs[idx]); err != nil {
+				errs = append(errs, p.reject(baseMsgs[idx], err))
+			}
+		}
+	}
+	for _, msg := range ordered {
+		p.observe(msg)
+	}
+
+	p.logf("Process %d: Received batch of %d messages, Vector: %v\n",
+		p.ID, len(ordered), p.ClockMgr.GetClock(p.ID))
+	return ordered, errors.Join(errs...)
+}
+
+// mergeBatch 한 번의 잠금으로 여러 수신 시계를 순서대로 병합
+//
+// 각 시계마다 UpdateClock 과 같은 규칙(새로운 정보가 있을 때만 병합 후 자신의 항목 증가)을 적용한다.
+// 시계는 에포크 epoch 기준이어야 하며, 현재 에포크가 바뀌었으면 아무것도 병합하지 않고 false 를 반환한다.
+func (vcm *VectorClockManager) mergeBatch(processID, epoch int, msgs []Message, vectors [][]int) bool {
+	vcm.lockClock(processID)
+	defer vcm.unlockClock(processID)
+
+	if epoch != vcm.epoch {
+		return false
+	}
+
+	clock := vcm.Clock[processID]
+	for k, received := range vectors {
+		if !canMerge(clock, received) {
+			continue
+		}
+		old := vcm.auditBefore(clock)
+		for i := 0; i < len(received) && i < len(clock); i++ {
+			if received[i] > clock[i] {
+				clock[i] = received[i]
+			}
+		}
+		clock[processID]++
+		vcm.counters(processID).merges.Add(1)
+		vcm.auditClockLocked(processID, AuditMerge, msgs[k].From, msgs[k].MessageID, old)
+	}
+	vcm.publishLocked(processID)
+	return true
+}
+
+// sum 벡터 원소의 합
+func sum(v []int) int {
+	total := 0
+	for _, x := range v {
+		total += x
+	}
+	return total
+}
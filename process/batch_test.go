@@ -0,0 +1,38 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReceiveBatchMergesInCausalOrder(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	p0 := NewProcess(0, vcm)
+	p1 := NewProcess(1, vcm)
+	p2 := NewProcess(2, vcm)
+
+	toP2 := make(chan Message, 1)
+	p0.SendMessage(2, "a", toP2, false)
+	a := <-toP2
+	p0.SendMessage(1, "x", p1.MessageCh, false)
+	if err := p1.ReceiveMessages(p1.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	p1.SendMessage(2, "b", toP2, false)
+	b := <-toP2
+
+	// b 가 a 보다 먼저 메일박스에 쌓임
+	mailbox := make(chan Message, 2)
+	mailbox <- b
+	mailbox <- a
+	got, err := p2.ReceiveBatch(mailbox)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Event != "a" || got[1].Event != "b" {
+		t.Fatalf("batch order = %v, want [a b]", got)
+	}
+	if clock := vcm.GetClock(2); !reflect.DeepEqual(clock, []int{2, 2, 2}) {
+		t.Fatalf("clock = %v, want [2 2 2]", clock)
+	}
+}
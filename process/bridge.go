@@ -0,0 +1,106 @@
+package process
+
+import "fmt"
+
+// BridgeCrossing 브리지 프로세스가 한 도메인의 인과 정보를 다른 도메인으로 넘긴 기록
+type BridgeCrossing struct {
+	Process   int    // 브리지 프로세스 ID
+	From      string // 원본 도메인
+	To        string // 대상 도메인
+	FromClock []int  // 넘기는 시점의 원본 도메인 시계
+	ToClock   []int  // 넘긴 직후의 대상 도메인 시계
+}
+
+// Bridge 두 도메인에 모두 속한 프로세스가 from 도메인의 인과 정보를 to 도메인으로 전달
+//
+// 도메인마다 멤버 집합과 카운터가 다르므로 항목 값을 그대로 옮길 수는 없다.
+// 대신 to 도메인에서 브리지 자신의 항목을 증가시키고 (from 시계, to 시계) 쌍을 기록해 두어,
+// 이후 to 도메인에서 이 이벤트를 아는 모든 이벤트가 from 시계 이전의 이벤트 뒤에 있음을
+// TranslateDomainClock / HappenedBeforeAcross 로 판단할 수 있게 한다.
+func (vcm *VectorClockManager) Bridge(processID int, from, to string) (BridgeCrossing, error) {
+	fromClock, err := vcm.GetDomainClock(from, processID)
+	if err != nil {
+		return BridgeCrossing{}, err
+	}
+	if err := vcm.UpdateDomainClock(to, processID, nil); err != nil {
+		return BridgeCrossing{}, err
+	}
+	toClock, err := vcm.GetDomainClock(to, processID)
+	if err != nil {
+		return BridgeCrossing{}, err
+	}
+
+	crossing := BridgeCrossing{Process: processID, From: from, To: to, FromClock: fromClock, ToClock: toClock}
+	vcm.lockManager()
+	vcm.crossings = append(vcm.crossings, crossing)
+	vcm.Mu.Unlock()
+	return crossing, nil
+}
+
+// Crossings 기록된 도메인 건너감 목록
+func (vcm *VectorClockManager) Crossings() []BridgeCrossing {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+	return append([]BridgeCrossing(nil), vcm.crossings...)
+}
+
+// TranslateDomainClock from 도메인의 시계를 to 도메인의 브리지 임계값 벡터로 변환
+//
+// 결과의 i 번째 값이 k(>0) 이면, to 도메인의 이벤트 b 가 b[i] >= k 를 만족할 때
+// b 는 clock 이후에 일어난 것이다 (i 번째 멤버가 clock 이후에 건너간 브리지).
+// 0 인 항목은 해당 멤버를 통한 인과 경로가 없음을 뜻한다.
+func (vcm *VectorClockManager) TranslateDomainClock(clock []int, from, to string) ([]int, error) {
+	if _, err := vcm.DomainMembers(from); err != nil {
+		return nil, err
+	}
+	toMembers, err := vcm.DomainMembers(to)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := make([]int, len(toMembers))
+	for _, c := range vcm.Crossings() {
+		if c.From != from || c.To != to {
+			continue
+		}
+		if order := Compare(clock, c.FromClock); order != Before && order != Equal {
+			continue
+		}
+		idx := indexOf(toMembers, c.Process)
+		if idx < 0 {
+			continue
+		}
+		if v := c.ToClock[idx]; threshold[idx] == 0 || v < threshold[idx] {
+			threshold[idx] = v
+		}
+	}
+	return threshold, nil
+}
+
+// HappenedBeforeAcross from 도메인의 이벤트 a 가 to 도메인의 이벤트 b 보다 인과적으로 앞서는지 여부
+//
+// 같은 도메인이면 일반 비교를 하고, 다른 도메인이면 브리지 건너감 기록을 사용한다
+// (한 번의 건너감만 고려).
+func (vcm *VectorClockManager) HappenedBeforeAcross(from string, a []int, to string, b []int) (bool, error) {
+	if from == to {
+		return HappenedBefore(a, b), nil
+	}
+	threshold, err := vcm.TranslateDomainClock(a, from, to)
+	if err != nil {
+		return false, err
+	}
+	for i, k := range threshold {
+		if k > 0 && i < len(b) && b[i] >= k {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ForwardDomain from 도메인의 인과 정보를 domain 으로 넘긴 뒤 domain 메시지 전송 (브리지 프로세스용)
+func (p *Process) ForwardDomain(to int, from, domain, event string) error {
+	if _, err := p.ClockMgr.Bridge(p.ID, from, domain); err != nil {
+		return fmt.Errorf("process: bridge %s -> %s: %w", from, domain, err)
+	}
+	return p.SendDomain(to, domain, event)
+}
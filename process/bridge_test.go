@@ -0,0 +1,44 @@
+package process
+
+import "testing"
+
+func TestBridgeOrdersEventsAcrossDomains(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	p0, p1, p2 := NewProcess(0, vcm), NewProcess(1, vcm), NewProcess(2, vcm)
+	if err := vcm.AddDomain("a", []int{0, 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := vcm.AddDomain("b", []int{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a 에서 0 -> 1, 1 이 b 로 넘겨 2 에게 전송
+	if err := p0.SendDomain(1, "a", "write"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p1.ReceiveMessages(p1.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	write, _ := vcm.GetDomainClock("a", 0)
+	if err := p1.ForwardDomain(2, "a", "b", "replicate"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.ReceiveMessages(p2.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	after, _ := vcm.GetDomainClock("b", 2)
+
+	if ok, err := vcm.HappenedBeforeAcross("a", write, "b", after); err != nil || !ok {
+		t.Fatalf("HappenedBeforeAcross = %v, %v, want true", ok, err)
+	}
+	// 브리지 이전의 b 이벤트는 a 의 쓰기 뒤가 아님
+	if ok, err := vcm.HappenedBeforeAcross("a", write, "b", []int{0, 0}); err != nil || ok {
+		t.Fatalf("HappenedBeforeAcross with an earlier event = %v, %v, want false", ok, err)
+	}
+	if n := len(vcm.Crossings()); n != 1 {
+		t.Fatalf("Crossings() has %d entries, want 1", n)
+	}
+	if err := p0.ForwardDomain(2, "a", "b", "x"); err == nil {
+		t.Fatal("process outside b bridged into it")
+	}
+}
@@ -0,0 +1,151 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrImpossibleClock 수신한 시계가 정상적인 송신자라면 만들 수 없는 값인 경우
+	ErrImpossibleClock = errors.New("process: impossible clock")
+	// ErrQuarantined 격리된 송신자의 메시지인 경우
+	ErrQuarantined = errors.New("process: sender quarantined")
+)
+
+// Quarantine 격리된 송신자 정보
+type Quarantine struct {
+	Process int       // 격리된 프로세스 ID
+	Reason  error     // 격리 사유 (처음 발견된 불가능한 시계)
+	Time    time.Time // 격리 시각
+}
+
+// quarantineState 매니저의 송신자 격리 상태
+type quarantineState struct {
+	mu      sync.Mutex
+	enabled bool               // 시계 검증 여부 (WithClockValidation)
+	maxJump int                // 한 메시지로 늘어날 수 있는 항목 값의 최대 차이 (0 이하면 검사하지 않음)
+	senders map[int]Quarantine // 격리된 송신자
+}
+
+// WithClockValidation 수신한 시계를 검증해 불가능한 시계를 보낸 송신자를 격리
+//
+// 받는 프로세스 자신의 항목이 자신의 카운터보다 크거나(자신만 늘릴 수 있는 값), 음수 항목이 있거나,
+// maxJump 가 0 보다 크고 어떤 항목이 받는 쪽 시계보다 maxJump 넘게 앞서면(그만큼의 메시지가 오갔을 수 없음)
+// 메시지를 ErrImpossibleClock 으로 거부하고 송신자를 격리한다. 격리된 송신자의 메시지는 이 매니저의
+// 모든 프로세스가 병합하지 않고 ErrQuarantined 로 거부하므로(둘 다 DeadLetterInvalid), 버그가 있는 노드 하나가
+// 모두의 인과 상태를 오염시키지 못한다. 격리는 Release 로 해제한다.
+func WithClockValidation(maxJump int) ManagerOption {
+	return func(vcm *VectorClockManager) {
+		vcm.quarantine.enabled = true
+		vcm.quarantine.maxJump = maxJump
+	}
+}
+
+// Quarantined 격리된 송신자 목록 (ID 순서)
+func (vcm *VectorClockManager) Quarantined() []Quarantine {
+	q := &vcm.quarantine
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	list := make([]Quarantine, 0, len(q.senders))
+	for _, s := range q.senders {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Process < list[j].Process })
+	return list
+}
+
+// IsQuarantined 송신자 id 의 격리 여부
+func (vcm *VectorClockManager) IsQuarantined(id int) bool {
+	q := &vcm.quarantine
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	_, ok := q.senders[id]
+	return ok
+}
+
+// QuarantineProcess 송신자 id 를 직접 격리 (이미 격리되어 있으면 사유를 유지)
+func (vcm *VectorClockManager) QuarantineProcess(id int, reason error) {
+	at := vcm.now()
+	q := &vcm.quarantine
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.senders[id]; ok {
+		return
+	}
+	if q.senders == nil {
+		q.senders = make(map[int]Quarantine)
+	}
+	q.senders[id] = Quarantine{Process: id, Reason: reason, Time: at}
+	vcm.logf("Quarantined process %d: %v\n", id, reason)
+}
+
+// Release 송신자 id 의 격리 해제 (격리되어 있었는지 반환)
+func (vcm *VectorClockManager) Release(id int) bool {
+	q := &vcm.quarantine
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.senders[id]; !ok {
+		return false
+	}
+	delete(q.senders, id)
+	vcm.logf("Released process %d from quarantine\n", id)
+	return true
+}
+
+// checkQuarantine 격리된 송신자의 메시지 거부
+func (p *Process) checkQuarantine(msg Message) error {
+	q := &p.ClockMgr.quarantine
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.senders[msg.From]; ok {
+		return fmt.Errorf("%w: message %s from %d", ErrQuarantined, msg.MessageID, msg.From)
+	}
+	return nil
+}
+
+// checkClock 기본 시계 메시지의 시계가 가능한 값인지 검사하고, 아니면 송신자를 격리 (p.Mu 보유 상태에서 호출)
+func (p *Process) checkClock(msg Message) error {
+	q := &p.ClockMgr.quarantine
+	if !q.enabled || msg.From == p.ID {
+		return nil
+	}
+	vector, _, err := p.currentVector(msg)
+	if err != nil {
+		return nil // 에포크 검증에서 거부
+	}
+	if err := impossible(p.ID, msg.From, vector, p.clockFor(msg.From), q.maxJump); err != nil {
+		err = fmt.Errorf("%w: message %s from %d: %v", ErrImpossibleClock, msg.MessageID, msg.From, err)
+		p.ClockMgr.QuarantineProcess(msg.From, err)
+		return err
+	}
+	return nil
+}
+
+// impossible 수신자 me 가 현재 시계 local 로 송신자 from 의 시계 received 를 받을 수 없는 이유 (가능하면 nil)
+func impossible(me, from int, received, local []int, maxJump int) error {
+	for i, v := range received {
+		if v < 0 {
+			return fmt.Errorf("entry %d is negative (%d)", i, v)
+		}
+	}
+	if me < len(received) && me < len(local) && received[me] > local[me] {
+		return fmt.Errorf("entry for receiver %d is %d, ahead of its own counter %d", me, received[me], local[me])
+	}
+	if maxJump <= 0 {
+		return nil
+	}
+	for i, v := range received {
+		if i < len(local) && v-local[i] > maxJump {
+			return fmt.Errorf("entry %d jumps from %d to %d (max %d)", i, local[i], v, maxJump)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package process
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestImpossibleClockQuarantinesSender(t *testing.T) {
+	vcm := NewVectorClockManager(3, WithLogger(nil), WithClockValidation(0))
+	p := NewProcess(0, vcm)
+	NewProcess(1, vcm)
+	NewProcess(2, vcm)
+
+	// 0 이 아직 아무것도 하지 않았는데 0 의 항목이 3 인 시계
+	forged := Message{From: 1, To: 0, Event: "forged", Vector: []int{3, 1, 0}, MessageID: "1-forged"}
+	if err := p.receive(forged); !errors.Is(err, ErrImpossibleClock) {
+		t.Fatalf("receive = %v, want ErrImpossibleClock", err)
+	}
+	if got := vcm.GetClock(0); !reflect.DeepEqual(got, []int{0, 0, 0}) {
+		t.Fatalf("clock after forged message = %v, want unchanged", got)
+	}
+	if !vcm.IsQuarantined(1) {
+		t.Fatal("sender of an impossible clock is not quarantined")
+	}
+	if q := vcm.Quarantined(); len(q) != 1 || q[0].Process != 1 || !errors.Is(q[0].Reason, ErrImpossibleClock) {
+		t.Fatalf("Quarantined() = %+v", q)
+	}
+
+	// 격리된 송신자의 정상 시계도 거부하고 다른 송신자는 받음
+	genuine := Message{From: 1, To: 0, Event: "genuine", Vector: []int{0, 1, 0}, MessageID: "1-genuine"}
+	if err := p.receive(genuine); !errors.Is(err, ErrQuarantined) {
+		t.Fatalf("receive from quarantined sender = %v, want ErrQuarantined", err)
+	}
+	if err := p.receive(Message{From: 2, To: 0, Event: "other", Vector: []int{0, 0, 1}, MessageID: "2-other"}); err != nil {
+		t.Fatalf("receive from another sender = %v", err)
+	}
+	if n := p.DeadLetterCount(); n != 2 {
+		t.Fatalf("DeadLetterCount() = %d, want 2", n)
+	}
+
+	if !vcm.Release(1) || vcm.Release(1) {
+		t.Fatal("Release should succeed once")
+	}
+	if err := p.receive(genuine); err != nil {
+		t.Fatalf("receive after Release = %v", err)
+	}
+}
+
+func TestClockValidationLimitsJumps(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithClockValidation(2))
+	p := NewProcess(0, vcm)
+	NewProcess(1, vcm)
+
+	if err := p.receive(Message{From: 1, To: 0, Vector: []int{0, 2}, MessageID: "1-a"}); err != nil {
+		t.Fatalf("receive within maxJump = %v", err)
+	}
+	if err := p.receive(Message{From: 1, To: 0, Vector: []int{0, 9}, MessageID: "1-b"}); !errors.Is(err, ErrImpossibleClock) {
+		t.Fatalf("receive past maxJump = %v, want ErrImpossibleClock", err)
+	}
+}
+
+func TestNegativeEntryIsImpossible(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithClockValidation(0))
+	p := NewProcess(0, vcm)
+	NewProcess(1, vcm)
+	if err := p.receive(Message{From: 1, To: 0, Vector: []int{0, -1}, MessageID: "1-a"}); !errors.Is(err, ErrImpossibleClock) {
+		t.Fatalf("receive = %v, want ErrImpossibleClock", err)
+	}
+}
+
+func TestClocksAreNotValidatedByDefault(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	p := NewProcess(0, vcm)
+	NewProcess(1, vcm)
+	if err := p.receive(Message{From: 1, To: 0, Vector: []int{5, 1}, MessageID: "1-a"}); err != nil {
+		t.Fatalf("receive = %v", err)
+	}
+	if vcm.IsQuarantined(1) {
+		t.Fatal("quarantined without WithClockValidation")
+	}
+}
+
+func TestQuarantineUsesManagerTime(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithClockValidation(2),
+		WithTimeSource(TimeFunc(func() time.Time { return at })))
+	p := NewProcess(0, vcm, WithMailboxSize(4))
+
+	// 1 은 아직 아무것도 보내지 않았는데 자신의 항목이 10 만큼 앞선 시계
+	if err := vcm.Deliver(Message{From: 1, To: 0, Event: "forged", Vector: []int{0, 10}, MessageID: "forged"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.ReceiveMessages(p.MessageCh); !errors.Is(err, ErrImpossibleClock) {
+		t.Fatalf("ReceiveMessages = %v, want ErrImpossibleClock", err)
+	}
+	q := vcm.Quarantined()
+	if len(q) != 1 || q[0].Process != 1 {
+		t.Fatalf("quarantined %+v, want process 1", q)
+	}
+	if !q[0].Time.Equal(at) {
+		t.Fatalf("quarantined at %v, want the manager time %v", q[0].Time, at)
+	}
+}
@@ -0,0 +1,154 @@
+package process
+
+import (
+	"sort"
+	"time"
+)
+
+// transitLog 메일박스에 들어가 아직 꺼내지지 않은 메시지 (vcm.term.mu 로 보호)
+type transitLog struct {
+	seq      uint64
+	messages map[string][]transitEntry // 메시지 ID -> 같은 ID 의 메시지 (들어간 순서)
+}
+
+// transitEntry 전송 중 메시지와 들어간 순서
+type transitEntry struct {
+	seq uint64
+	msg Message
+}
+
+// add 전송 중 메시지 추가
+func (l *transitLog) add(msg Message) {
+	if l.messages == nil {
+		l.messages = make(map[string][]transitEntry)
+	}
+	l.seq++
+	l.messages[msg.MessageID] = append(l.messages[msg.MessageID], transitEntry{seq: l.seq, msg: msg})
+}
+
+// remove 같은 ID 의 메시지 중 가장 먼저 들어간 것 제거
+func (l *transitLog) remove(msg Message) {
+	entries := l.messages[msg.MessageID]
+	switch len(entries) {
+	case 0:
+	case 1:
+		delete(l.messages, msg.MessageID)
+	default:
+		l.messages[msg.MessageID] = entries[1:]
+	}
+}
+
+// list 전송 중 메시지 (들어간 순서)
+func (l *transitLog) list() []Message {
+	var entries []transitEntry
+	for _, e := range l.messages {
+		entries = append(entries, e...)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].seq < entries[j].seq })
+	msgs := make([]Message, len(entries))
+	for i, e := range entries {
+		msgs[i] = e.msg
+	}
+	return msgs
+}
+
+// GlobalState 한 시점의 전역 상태 (모든 프로세스의 시계와 전송 중인 메시지)
+type GlobalState struct {
+	Time     time.Time
+	Epoch    int
+	Clocks   map[int][]int        // 프로세스 ID -> Vector Clock
+	Channels map[ChannelKey][]int // 채널별 Vector Clock (ClockPerChannel, 아니면 비어 있음)
+	Transit  []Message            // 메일박스(응답 채널 포함)에 들어가 아직 꺼내지지 않은 메시지 (들어간 순서)
+}
+
+// CaptureState 모든 시계와 전송 중인 메시지를 같은 시점에 기록
+//
+// 전송 중 메시지 기록과 시계를 함께 잠근 채 복사하므로, 그 사이에 메시지가 들어가거나 나가거나
+// 시계가 바뀌지 않는다. 송신은 시계를 증가시킨 뒤 메일박스에 넣고 수신은 메일박스에서 꺼낸 뒤
+// 병합하므로, 기록된 어떤 수신도 그 송신보다 먼저 기록되지 않는다(일관된 절단). 다만 그 순간
+// 시계 증가와 메일박스 사이, 또는 메일박스와 병합 사이에 있던 메시지는 Transit 에 나타나지 않는다.
+// 모든 프로세스가 멈춘 상태(시뮬레이션의 단계 사이 등)에서는 정확한 전역 상태이다.
+func (vcm *VectorClockManager) CaptureState() GlobalState {
+	vcm.term.mu.Lock()
+	defer vcm.term.mu.Unlock()
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	s := GlobalState{
+		Time:     time.Now(),
+		Epoch:    vcm.epoch,
+		Clocks:   make(map[int][]int, len(vcm.Clock)),
+		Channels: make(map[ChannelKey][]int, len(vcm.channels)),
+		Transit:  vcm.term.transit.list(),
+	}
+	for i, m := range s.Transit {
+		if m.buf != nil {
+			// 수신 후 풀로 돌아갈 Vector 이므로 복사해서 보관
+			s.Transit[i].Vector = append([]int(nil), m.Vector...)
+			s.Transit[i].buf = nil
+		}
+	}
+	for id, clock := range vcm.Clock {
+		s.Clocks[id] = append([]int(nil), clock...)
+	}
+	for key, clock := range vcm.channels {
+		s.Channels[key] = append([]int(nil), clock...)
+	}
+	return s
+}
+
+// InChannel from 에서 to 로 가는 중인 메시지 (들어간 순서)
+func (s GlobalState) InChannel(from, to int) []Message {
+	var msgs []Message
+	for _, m := range s.Transit {
+		if m.From == from && m.To == to {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs
+}
+
+// Mailbox 프로세스 to 에게 가는 중인 메시지 (들어간 순서)
+func (s GlobalState) Mailbox(to int) []Message {
+	var msgs []Message
+	for _, m := range s.Transit {
+		if m.To == to {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs
+}
+
+// MessageSummary 메일박스에 있는 메시지 요약 (PeekAll)
+type MessageSummary = MessageState
+
+// transitTo 프로세스 to 에게 가는 중인 메시지 (들어간 순서)
+func (vcm *VectorClockManager) transitTo(to int) []Message {
+	vcm.term.mu.Lock()
+	defer vcm.term.mu.Unlock()
+
+	var msgs []Message
+	for _, m := range vcm.term.transit.list() {
+		if m.To == to {
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs
+}
+
+// Pending 메일박스(응답 대기 채널 포함)에 들어가 아직 꺼내지 않은 메시지 수
+//
+// 꺼낸 뒤 인과 순서를 기다리며 보류 중인 메시지(State 의 Pending)는 포함하지 않는다.
+func (p *Process) Pending() int {
+	return len(p.ClockMgr.transitTo(p.ID))
+}
+
+// PeekAll 메일박스에 있는 메시지를 꺼내지 않고 요약 (들어간 순서)
+func (p *Process) PeekAll() []MessageSummary {
+	msgs := p.ClockMgr.transitTo(p.ID)
+	summaries := make([]MessageSummary, len(msgs))
+	for i, m := range msgs {
+		summaries[i] = messageState(m)
+	}
+	return summaries
+}
@@ -0,0 +1,80 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCaptureStateRecordsTransit(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	procs := make([]*Process, 3)
+	for i := range procs {
+		procs[i] = NewProcess(i, vcm, WithMailboxSize(4))
+	}
+	if err := procs[0].Send(1, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := procs[0].Send(2, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := procs[2].Send(1, "c"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := vcm.CaptureState()
+	if len(s.Transit) != 3 || s.Transit[0].Event != "a" || s.Transit[2].Event != "c" {
+		t.Fatalf("Transit = %v, want [a b c]", s.Transit)
+	}
+	if got := s.InChannel(0, 1); len(got) != 1 || got[0].Event != "a" {
+		t.Fatalf("InChannel(0, 1) = %v, want [a]", got)
+	}
+	if got := s.Mailbox(1); len(got) != 2 {
+		t.Fatalf("Mailbox(1) = %v, want a and c", got)
+	}
+	if got := s.Clocks[0]; !reflect.DeepEqual(got, []int{2, 0, 0}) {
+		t.Fatalf("clock of 0 = %v, want [2 0 0]", got)
+	}
+
+	if err := procs[1].ReceiveMessages(procs[1].MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	s = vcm.CaptureState()
+	if got := s.Mailbox(1); len(got) != 1 || got[0].Event != "c" {
+		t.Fatalf("Mailbox(1) after a receive = %v, want [c]", got)
+	}
+	if got := s.Clocks[1]; !reflect.DeepEqual(got, []int{1, 1, 0}) {
+		t.Fatalf("clock of 1 = %v, want [1 1 0]", got)
+	}
+}
+
+func TestPendingAndPeekAllLeaveMailbox(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	p0 := NewProcess(0, vcm, WithMailboxSize(4))
+	p1 := NewProcess(1, vcm, WithMailboxSize(4))
+	if err := p0.Send(1, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p0.Send(1, "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := p1.Pending(); n != 2 {
+		t.Fatalf("Pending() = %d, want 2", n)
+	}
+	peek := p1.PeekAll()
+	if len(peek) != 2 || peek[0].Event != "a" || peek[1].Event != "b" || peek[0].From != 0 {
+		t.Fatalf("PeekAll() = %+v, want a then b from 0", peek)
+	}
+	if !reflect.DeepEqual(peek[1].Vector, []int{2, 0}) {
+		t.Fatalf("PeekAll()[1].Vector = %v, want [2 0]", peek[1].Vector)
+	}
+	if len(p1.MessageCh) != 2 || p0.Pending() != 0 {
+		t.Fatalf("peeking changed the mailboxes (%d, %d)", len(p1.MessageCh), p0.Pending())
+	}
+	if err := p1.ReceiveMessages(p1.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if n := p1.Pending(); n != 1 {
+		t.Fatalf("Pending() after a receive = %d, want 1", n)
+	}
+}
@@ -0,0 +1,408 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+)
+
+// DeliveryAlgorithm 인과 순서 전달 알고리즘
+type DeliveryAlgorithm int
+
+const (
+	// DeliveryBSS Birman-Schiper-Stephenson: 브로드캐스트 벡터 하나를 메시지에 실어 보냄
+	DeliveryBSS DeliveryAlgorithm = iota
+	// DeliverySES Schiper-Eggli-Sandoz: 목적지별 벡터 집합을 메시지에 실어 보냄
+	DeliverySES
+)
+
+// ErrUnicastUnsupported BSS 에서 1:1 인과 전송을 요청한 경우
+var ErrUnicastUnsupported = errors.New("process: causal unicast requires DeliverySES")
+
+// String 알고리즘 이름 반환
+func (a DeliveryAlgorithm) String() string {
+	switch a {
+	case DeliveryBSS:
+		return "BSS"
+	case DeliverySES:
+		return "SES"
+	default:
+		return fmt.Sprintf("DeliveryAlgorithm(%d)", int(a))
+	}
+}
+
+// WithDelivery 시뮬레이션에서 사용할 인과 전달 알고리즘 지정 (기본값 DeliveryBSS)
+func WithDelivery(alg DeliveryAlgorithm) ManagerOption {
+	return func(vcm *VectorClockManager) {
+		vcm.Delivery = alg
+	}
+}
+
+// DeliveryStats 인과 전달 오버헤드 통계
+type DeliveryStats struct {
+	Algorithm   DeliveryAlgorithm // 사용 중인 알고리즘
+	Messages    int64             // 전송된 인과 메시지 수
+	Piggybacked int64             // 메시지에 실린 정수(시계 원소) 총 개수
+	Buffered    int64             // 도착 즉시 전달하지 못하고 버퍼링된 횟수
+}
+
+// deliveryCounters 매니저 내부의 통계 카운터
+type deliveryCounters struct {
+	messages    atomic.Int64
+	piggybacked atomic.Int64
+	buffered    atomic.Int64
+}
+
+// causalState 프로세스별 인과 전달 상태
+type causalState struct {
+	delivered  []int            // BSS: 송신자별 전달 완료된 브로드캐스트 수
+	destClocks map[int][]int    // SES: 목적지별로 알려진 최소 벡터 (V_P)
+	topics     map[string][]int // BSS: 토픽별 전달 완료된 발행 수
+	buffer     []Message        // 아직 전달할 수 없는 메시지
+	epoch      int              // destClocks 가 기준으로 하는 에포크
+}
+
+// DeliveryStats 현재까지의 인과 전달 통계 반환
+func (vcm *VectorClockManager) DeliveryStats() DeliveryStats {
+	return DeliveryStats{
+		Algorithm:   vcm.Delivery,
+		Messages:    vcm.stats.messages.Load(),
+		Piggybacked: vcm.stats.piggybacked.Load(),
+		Buffered:    vcm.stats.buffered.Load(),
+	}
+}
+
+// Size Vector Clock 항목 수 (관리 중인 프로세스 수)
+func (vcm *VectorClockManager) Size() int {
+	vcm.rlockManager()
+	defer vcm.Mu.RUnlock()
+	return len(vcm.Clock)
+}
+
+// PiggybackSize 메시지에 실린 인과 순서 메타데이터 크기 (정수 개수)
+func PiggybackSize(msg Message) int {
+	size := len(msg.Causal)
+	if msg.DestClocks != nil {
+		size += len(msg.Vector)
+		for _, v := range msg.DestClocks {
+			size += 1 + len(v) // 목적지 ID + 벡터
+		}
+	}
+	return size
+}
+
+// initCausal 인과 전달 상태 초기화 (p.Mu 보유 상태에서 호출)
+func (p *Process) initCausal() {
+	if p.causal.delivered == nil {
+		p.causal.delivered = make([]int, p.ClockMgr.Size())
+		p.causal.destClocks = make(map[int][]int)
+		p.causal.epoch = p.ClockMgr.CurrentEpoch()
+	}
+	p.syncCausalEpoch()
+}
+
+// causalVector 토픽의 BSS 전달 벡터 ("" 이면 기본 브로드캐스트, p.Mu 보유 상태에서 호출)
+func (p *Process) causalVector(topic string) []int {
+	if topic == "" {
+		return p.causal.delivered
+	}
+	if p.causal.topics == nil {
+		p.causal.topics = make(map[string][]int)
+	}
+	v, ok := p.causal.topics[topic]
+	if !ok {
+		// 구독 시점까지 발행된 메시지는 받지 않으므로 그 수만큼 전달된 것으로 시작
+		v = p.ClockMgr.subscriptionBase(topic, p.ID)
+		p.causal.topics[topic] = v
+	}
+	return v
+}
+
+// growCausal BSS 메시지의 전달 벡터가 짧으면 메시지 벡터 크기까지 늘림 (p.Mu 보유 상태에서 호출)
+//
+// 전달 벡터는 처음 받을 때의 그룹 크기로 만들어지므로 그 뒤에 늘어난 그룹의 송신자 항목이 없을 수 있다.
+// 그룹보다 긴 벡터나 송신자 자신의 항목이 없는 벡터는 전달 순서를 판단할 수 없으므로 에러를 반환한다.
+func (p *Process) growCausal(m Message) error {
+	if m.Causal == nil {
+		return nil
+	}
+	if n := p.ClockMgr.Size(); len(m.Causal) > n || m.From >= len(m.Causal) {
+		return fmt.Errorf("%w: causal vector has %d entries for sender %d in a group of %d",
+			ErrInvalidMessage, len(m.Causal), m.From, n)
+	}
+	delivered := p.causalVector(m.Topic)
+	if len(delivered) >= len(m.Causal) {
+		return nil
+	}
+	delivered = append(delivered, make([]int, len(m.Causal)-len(delivered))...)
+	if m.Topic == "" {
+		p.causal.delivered = delivered
+	} else {
+		p.causal.topics[m.Topic] = delivered
+	}
+	return nil
+}
+
+// Broadcast 인과 순서를 보장하는 브로드캐스트
+//
+// BSS 에서는 전체 그룹에 대한 브로드캐스트를 가정하므로 targets 에 자신을 제외한
+// 모든 프로세스가 포함되어야 한다. SES 에서는 목적지마다 개별 인과 전송을 수행한다.
+func (p *Process) Broadcast(event string, targets map[int]chan<- Message) {
+	ids := make([]int, 0, len(targets))
+	for id := range targets {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	if p.ClockMgr.Delivery == DeliverySES {
+		p.sendSES("", event, ids, targets)
+		return
+	}
+	p.broadcastBSS("", event, ids, targets, 0)
+}
+
+// broadcastBSS 하나의 송신 이벤트로 BSS 브로드캐스트 (seq 가 0 보다 크면 자신의 항목을 seq 로 지정)
+func (p *Process) broadcastBSS(topic, event string, ids []int, targets map[int]chan<- Message, seq int) {
+	// BSS: 브로드캐스트 한 번 = 로컬 이벤트 한 번
+	p.lock()
+	p.initCausal()
+	delivered := p.causalVector(topic)
+	if seq > 0 {
+		delivered[p.ID] = seq
+	} else {
+		delivered[p.ID]++
+	}
+	stamp := make([]int, len(delivered))
+	copy(stamp, delivered)
+	p.Mu.Unlock()
+
+	currentClock, epoch := p.ClockMgr.advance(p.ID)
+
+	for _, to := range ids {
+		msg := p.newMessage(to, event, currentClock, epoch)
+		msg.Topic = topic
+		msg.Causal = stamp
+		p.ClockMgr.stats.messages.Add(1)
+		p.ClockMgr.stats.piggybacked.Add(int64(PiggybackSize(msg)))
+
+		if err := p.push(targets[to], msg); err != nil {
+			continue
+		}
+		p.logf("Process %d: Broadcast message to Process %d, Causal: %v\n", p.ID, to, stamp)
+	}
+}
+
+// SendCausal SES 인과 순서 1:1 전송
+//
+// 메시지에는 송신 시점의 Vector Clock 과 함께 송신자가 알고 있는 목적지별 벡터 집합이 실린다.
+func (p *Process) SendCausal(to int, event string, targetCh chan<- Message) error {
+	if p.ClockMgr.Delivery != DeliverySES {
+		return ErrUnicastUnsupported
+	}
+	p.sendSES("", event, []int{to}, map[int]chan<- Message{to: targetCh})
+	return nil
+}
+
+// sendSES 하나의 송신 이벤트로 여러 목적지에 SES 메시지 전송
+//
+// 같은 이벤트로 보낸 다른 목적지의 메시지도 서로의 벡터 집합에 포함시켜,
+// 브로드캐스트가 BSS 와 같은 인과 관계를 갖도록 한다.
+func (p *Process) sendSES(topic, event string, ids []int, targets map[int]chan<- Message) {
+	p.lock()
+	p.initCausal()
+
+	currentClock, epoch := p.ClockMgr.advance(p.ID)
+
+	msgs := make([]Message, 0, len(ids))
+	for _, to := range ids {
+		destClocks := make(map[int][]int, len(p.causal.destClocks)+len(ids))
+		for dest, v := range p.causal.destClocks {
+			destClocks[dest] = append([]int(nil), v...)
+		}
+		for _, other := range ids {
+			if other != to {
+				destClocks[other] = append([]int(nil), currentClock...)
+			}
+		}
+		msg := p.newMessage(to, event, currentClock, epoch)
+		msg.Topic = topic
+		msg.DestClocks = destClocks
+		msgs = append(msgs, msg)
+	}
+	// 이후 목적지로 가는 메시지는 이번 메시지 이후에만 전달되어야 함
+	for _, to := range ids {
+		p.causal.destClocks[to] = append([]int(nil), currentClock...)
+	}
+	p.Mu.Unlock()
+
+	for _, msg := range msgs {
+		p.ClockMgr.stats.messages.Add(1)
+		p.ClockMgr.stats.piggybacked.Add(int64(PiggybackSize(msg)))
+
+		if err := p.push(targets[msg.To], msg); err != nil {
+			continue
+		}
+		p.logf("Process %d: Sent causal message to Process %d, Vector: %v\n", p.ID, msg.To, msg.Vector)
+	}
+}
+
+// DeliverCausal 메시지를 한 번 수신하고, 인과 순서상 전달 가능한 메시지를 모두 전달
+//
+// 반환값은 이번 호출에서 전달된 메시지들이며 (전달 순서대로), 아직 선행 메시지가
+// 도착하지 않은 메시지는 내부 버퍼에 보관되었다가 이후 호출에서 전달된다.
+// 교착 상태가 감지되면 아무것도 전달하지 않고 반환한다.
+func (p *Process) DeliverCausal(messageCh <-chan Message) []Message {
+	msg, ok, err := p.await(messageCh)
+	if err != nil {
+		return nil
+	}
+	if !ok {
+		p.logf("Process %d: Channel closed\n", p.ID)
+		return nil
+	}
+
+	p.ack(msg)
+	p.lock()
+	defer p.Mu.Unlock()
+	p.initCausal()
+
+	// 재설정 이전 에포크의 메시지는 전달 순서를 판단할 수 없으므로 거부
+	if err := p.validate(msg); err != nil {
+		_ = p.reject(msg, err)
+		return nil
+	}
+	if err := p.checkDuplicate(msg); err != nil {
+		return nil
+	}
+	if _, _, err := p.currentVector(msg); err != nil {
+		_ = p.reject(msg, err)
+		return nil
+	}
+	if err := p.growCausal(msg); err != nil {
+		_ = p.reject(msg, err)
+		return nil
+	}
+
+	p.causal.buffer = append(p.causal.buffer, msg)
+	var delivered []Message
+	for {
+		idx := -1
+		for i, m := range p.causal.buffer {
+			if p.deliverable(m) {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			break
+		}
+		m := p.causal.buffer[idx]
+		p.causal.buffer = append(p.causal.buffer[:idx], p.causal.buffer[idx+1:]...)
+		if p.deliver(m) {
+			delivered = append(delivered, m)
+		}
+	}
+
+	if containsMessage(p.causal.buffer, msg.MessageID) {
+		p.ClockMgr.stats.buffered.Add(1)
+		p.logf("Process %d: Buffered message from %d (%d pending)\n", p.ID, msg.From, len(p.causal.buffer))
+	}
+	return delivered
+}
+
+// PendingCausal 인과 순서 대기 중인 메시지 수
+func (p *Process) PendingCausal() int {
+	p.lock()
+	defer p.Mu.Unlock()
+	return len(p.causal.buffer)
+}
+
+// deliverable 메시지 전달 조건 검사 (p.Mu 보유 상태에서 호출)
+func (p *Process) deliverable(m Message) bool {
+	if m.Causal == nil {
+		// SES: 나에게 향한 선행 메시지의 벡터가 현재 시계 이하여야 함
+		v, ok := m.DestClocks[p.ID]
+		if !ok {
+			return true
+		}
+		v, err := p.ClockMgr.Translate(v, m.Epoch, p.ClockMgr.CurrentEpoch())
+		if err != nil {
+			return true // 더 이상 순서를 제약하지 않음
+		}
+		current := p.ClockMgr.GetClock(p.ID)
+		for i := 0; i < len(v) && i < len(current); i++ {
+			if v[i] > current[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	// BSS: 송신자의 다음 브로드캐스트이고, 송신자가 알던 다른 브로드캐스트는 모두 전달됨
+	delivered := p.causalVector(m.Topic)
+	for k, v := range m.Causal {
+		if k == m.From {
+			if v != delivered[k]+1 {
+				return false
+			}
+		} else if v > delivered[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// deliver 메시지 전달 처리 (p.Mu 보유 상태에서 호출, 병합하지 못해 거부했으면 false)
+func (p *Process) deliver(m Message) bool {
+	// 병합하지 못한 메시지는 거부되므로 전달 벡터와 목적지 벡터에 반영하지 않음
+	if err := p.ClockMgr.mergeClock(p.ID, m.Epoch, m.Vector, m.From, m.MessageID); err != nil {
+		_ = p.reject(m, err)
+		return false
+	}
+	if m.Causal == nil {
+		for dest, v := range m.DestClocks {
+			if dest == p.ID {
+				continue
+			}
+			v, err := p.ClockMgr.Translate(v, m.Epoch, p.causal.epoch)
+			if err != nil {
+				continue
+			}
+			p.causal.destClocks[dest] = mergeMax(p.causal.destClocks[dest], v)
+		}
+	} else if delivered := p.causalVector(m.Topic); m.From >= 0 && m.From < len(delivered) {
+		delivered[m.From]++
+	}
+
+	p.observe(m)
+	p.logf("Process %d: Delivered message from %d, Vector: %v\n",
+		p.ID, m.From, p.ClockMgr.GetClock(p.ID))
+	return true
+}
+
+// mergeMax 두 벡터의 원소별 최대값
+func mergeMax(a, b []int) []int {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	out := make([]int, n)
+	copy(out, a)
+	for i, v := range b {
+		if v > out[i] {
+			out[i] = v
+		}
+	}
+	return out
+}
+
+// containsMessage 메시지 목록에 해당 ID 가 있는지 여부
+func containsMessage(msgs []Message, id string) bool {
+	for _, m := range msgs {
+		if m.MessageID == id {
+			return true
+		}
+	}
+	return false
+}
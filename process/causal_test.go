@@ -0,0 +1,202 @@
+package process
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestSendCausalRequiresSES(t *testing.T) {
+	_, procs, _ := newCausalGroup(2, DeliveryBSS)
+	if err := procs[0].SendCausal(1, "m", procs[1].MessageCh); !errors.Is(err, ErrUnicastUnsupported) {
+		t.Fatalf("SendCausal with BSS = %v, want ErrUnicastUnsupported", err)
+	}
+}
+
+func TestSESUnicastWaitsForEarlierMessage(t *testing.T) {
+	_, procs, _ := newCausalGroup(3, DeliverySES)
+	p0, p1, p2 := procs[0], procs[1], procs[2]
+
+	// p0 -> p2 (보류), p0 -> p1, p1 -> p2 순서로 보내고 p1 의 메시지가 먼저 도착
+	hold := make(chan Message, 1)
+	if err := p0.SendCausal(2, "first", hold); err != nil {
+		t.Fatal(err)
+	}
+	if err := p0.SendCausal(1, "second", p1.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if got := p1.DeliverCausal(p1.MessageCh); len(got) != 1 {
+		t.Fatalf("p1 delivered %v, want second", got)
+	}
+	if err := p1.SendCausal(2, "third", p2.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if got := p2.DeliverCausal(p2.MessageCh); len(got) != 0 {
+		t.Fatalf("p2 delivered %v before first", got)
+	}
+	p2.MessageCh <- <-hold
+	got := p2.DeliverCausal(p2.MessageCh)
+	if len(got) != 2 || got[0].Event != "first" || got[1].Event != "third" {
+		t.Fatalf("p2 delivered %v, want [first third]", got)
+	}
+}
+
+func TestDeliveryStatsCountPiggyback(t *testing.T) {
+	for _, delivery := range []DeliveryAlgorithm{DeliveryBSS, DeliverySES} {
+		t.Run(delivery.String(), func(t *testing.T) {
+			vcm, procs, targets := newCausalGroup(3, delivery)
+			procs[0].Broadcast("m", targets(0))
+			stats := vcm.DeliveryStats()
+			if stats.Algorithm != delivery || stats.Messages != 2 {
+				t.Fatalf("stats = %+v, want 2 %s messages", stats, delivery)
+			}
+			if stats.Piggybacked <= 0 {
+				t.Fatalf("stats = %+v, want piggybacked metadata", stats)
+			}
+		})
+	}
+}
+
+// newCausalGroup n 개 프로세스와 서로의 메일박스 (자신 제외 브로드캐스트 대상)
+func newCausalGroup(n int, delivery DeliveryAlgorithm) (*VectorClockManager, []*Process, func(from int) map[int]chan<- Message) {
+	vcm := NewVectorClockManager(n, WithLogger(nil), WithDelivery(delivery))
+	procs := make([]*Process, n)
+	for i := range procs {
+		procs[i] = NewProcess(i, vcm, WithMailboxSize(256))
+	}
+	targets := func(from int) map[int]chan<- Message {
+		m := make(map[int]chan<- Message, n-1)
+		for _, p := range procs {
+			if p.ID != from {
+				m[p.ID] = p.MessageCh
+			}
+		}
+		return m
+	}
+	return vcm, procs, targets
+}
+
+func testCausalReordering(t *testing.T, delivery DeliveryAlgorithm) {
+	_, procs, targets := newCausalGroup(3, delivery)
+	p0, p1, p2 := procs[0], procs[1], procs[2]
+
+	// p0 -> p2 메시지를 붙잡아 두고, 그 뒤에 일어난 p1 의 메시지를 먼저 도착시킴
+	hold := make(chan Message, 1)
+	t0 := targets(0)
+	t0[2] = hold
+	p0.Broadcast("m1", t0)
+	if got := p1.DeliverCausal(p1.MessageCh); len(got) != 1 || got[0].Event != "m1" {
+		t.Fatalf("p1 delivered %v, want m1", got)
+	}
+	p1.Broadcast("m2", targets(1))
+
+	if got := p2.DeliverCausal(p2.MessageCh); len(got) != 0 {
+		t.Fatalf("p2 delivered %v before its causal predecessor", got)
+	}
+	if n := p2.PendingCausal(); n != 1 {
+		t.Fatalf("PendingCausal() = %d, want 1", n)
+	}
+	p2.MessageCh <- <-hold
+	got := p2.DeliverCausal(p2.MessageCh)
+	if len(got) != 2 || got[0].Event != "m1" || got[1].Event != "m2" {
+		t.Fatalf("p2 delivered %v, want [m1 m2]", got)
+	}
+}
+
+func TestBSSDeliversInCausalOrder(t *testing.T) {
+	testCausalReordering(t, DeliveryBSS)
+}
+
+func TestSESDeliversInCausalOrder(t *testing.T) {
+	testCausalReordering(t, DeliverySES)
+}
+
+func TestBSSRejectsOversizedCausalVector(t *testing.T) {
+	_, procs, _ := newCausalGroup(3, DeliveryBSS)
+	hold := make(chan Message, 2)
+	procs[0].Broadcast("m", map[int]chan<- Message{1: hold, 2: procs[2].MessageCh})
+	msg := <-hold
+	msg.Causal = append(append([]int(nil), msg.Causal...), 0, 0)
+	procs[1].MessageCh <- msg
+
+	if got := procs[1].DeliverCausal(procs[1].MessageCh); len(got) != 0 {
+		t.Fatalf("delivered %v", got)
+	}
+	if n := procs[1].PendingCausal(); n != 0 {
+		t.Fatalf("oversized message buffered forever (%d pending)", n)
+	}
+	letters := procs[1].DeadLetters()
+	if len(letters) != 1 || !errors.Is(letters[0].Err, ErrInvalidMessage) {
+		t.Fatalf("dead letters = %+v, want one invalid message", letters)
+	}
+}
+
+// TestCausalDeliveryUnderConcurrency 모두가 동시에 브로드캐스트하고 받으며 전달 순서가 happens-before 를 지키는지 확인
+func TestCausalDeliveryUnderConcurrency(t *testing.T) {
+	for _, delivery := range []DeliveryAlgorithm{DeliveryBSS, DeliverySES} {
+		t.Run(delivery.String(), func(t *testing.T) {
+			const n, rounds = 4, 25
+			_, procs, targets := newCausalGroup(n, delivery)
+			logs := make([][]Message, n)
+
+			var wg sync.WaitGroup
+			for i, p := range procs {
+				wg.Add(1)
+				go func(i int, p *Process) {
+					defer wg.Done()
+					want := rounds * (n - 1)
+					for r := 0; r < rounds || len(logs[i]) < want; r++ {
+						if r < rounds {
+							p.Broadcast("m", targets(p.ID))
+						}
+						if len(logs[i]) < want {
+							logs[i] = append(logs[i], p.DeliverCausal(p.MessageCh)...)
+						}
+					}
+				}(i, p)
+			}
+			wg.Wait()
+
+			for i, log := range logs {
+				if len(log) != rounds*(n-1) {
+					t.Fatalf("process %d delivered %d messages, want %d", i, len(log), rounds*(n-1))
+				}
+				for a := range log {
+					for b := a + 1; b < len(log); b++ {
+						if Compare(log[b].Vector, log[a].Vector) == Before {
+							t.Fatalf("process %d delivered %s before its predecessor %s", i, log[a].MessageID, log[b].MessageID)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCausalMergeFailureIsNotCountedAsDelivered(t *testing.T) {
+	_, procs, targets := newCausalGroup(2, DeliveryBSS)
+	p0, p1 := procs[0], procs[1]
+	p0.Broadcast("m1", targets(0))
+	msg := <-p1.MessageCh
+
+	// 전달 조건은 만족하지만 병합할 수 없는 메시지 (매니저는 아직 에포크 0)
+	bad := msg
+	bad.Epoch = 1
+	p1.lock()
+	p1.initCausal()
+	ok := p1.deliver(bad)
+	delivered := append([]int(nil), p1.causal.delivered...)
+	p1.Mu.Unlock()
+	if ok {
+		t.Fatal("deliver reported success for a message whose merge failed")
+	}
+	if delivered[0] != 0 {
+		t.Fatalf("delivery vector = %v after rejected merge, want sender entry 0", delivered)
+	}
+
+	// 같은 브로드캐스트가 정상으로 도착하면 그대로 전달됨
+	p1.MessageCh <- msg
+	if got := p1.DeliverCausal(p1.MessageCh); len(got) != 1 || got[0].Event != "m1" {
+		t.Fatalf("delivered %v after rejected merge, want m1", got)
+	}
+}
@@ -0,0 +1,157 @@
+package process
+
+import "fmt"
+
+// ClockMode Vector Clock 유지 단위
+type ClockMode int
+
+const (
+	// ClockPerProcess 프로세스마다 하나의 Vector Clock 유지 (기본값)
+	ClockPerProcess ClockMode = iota
+	// ClockPerChannel 통신 채널(자신, 상대) 마다 별도의 Vector Clock 유지
+	ClockPerChannel
+)
+
+// String 모드 이름 반환
+func (m ClockMode) String() string {
+	switch m {
+	case ClockPerProcess:
+		return "per-process"
+	case ClockPerChannel:
+		return "per-channel"
+	default:
+		return fmt.Sprintf("ClockMode(%d)", int(m))
+	}
+}
+
+// ParseClockMode 모드 이름(String 의 결과)을 ClockMode 로 (알 수 없으면 false)
+func ParseClockMode(name string) (ClockMode, bool) {
+	for m := ClockPerProcess; m <= ClockPerChannel; m++ {
+		if m.String() == name {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+// ChannelKey 채널 식별자 (Owner 가 보유한 Peer 방향 채널)
+type ChannelKey struct {
+	Owner int // 시계를 보유한 프로세스 ID
+	Peer  int // 통신 상대 프로세스 ID
+}
+
+// WithClockMode Vector Clock 유지 단위 지정 (기본값 ClockPerProcess)
+func WithClockMode(mode ClockMode) ManagerOption {
+	return func(vcm *VectorClockManager) {
+		vcm.Mode = mode
+	}
+}
+
+// channelClockLocked 채널 시계 반환, 없으면 생성 (vcm.Mu 보유 상태에서 호출)
+func (vcm *VectorClockManager) channelClockLocked(key ChannelKey) []int {
+	if vcm.channels == nil {
+		vcm.channels = make(map[ChannelKey][]int)
+	}
+	clock, ok := vcm.channels[key]
+	if !ok {
+		clock = make([]int, len(vcm.Clock))
+		vcm.channels[key] = clock
+	}
+	return clock
+}
+
+// UpdateChannelClock owner 가 보유한 peer 채널의 Vector Clock 업데이트
+func (vcm *VectorClockManager) UpdateChannelClock(owner, peer int, receivedClock []int) {
+	vcm.updateChannelClock(owner, peer, -1, receivedClock, "")
+}
+
+// updateChannelClock UpdateChannelClock 과 같지만 현재 에포크가 epoch 일 때만 갱신하고 감사 기록에 병합한 메시지 ID 를 남김
+// (epoch 가 음수면 확인하지 않음, 갱신했으면 true)
+func (vcm *VectorClockManager) updateChannelClock(owner, peer, epoch int, receivedClock []int, messageID string) bool {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	if epoch >= 0 && epoch != vcm.epoch {
+		return false
+	}
+
+	clock := vcm.channelClockLocked(ChannelKey{Owner: owner, Peer: peer})
+	old := vcm.auditBefore(clock)
+	for i := 0; i < len(receivedClock) && i < len(clock); i++ {
+		if receivedClock[i] > clock[i] {
+			clock[i] = receivedClock[i]
+		}
+	}
+	clock[owner]++
+	vcm.counters(owner).merges.Add(1)
+	vcm.auditLocked(AuditRecord{Process: owner, Peer: peer, Cause: AuditMerge, From: peer, MessageID: messageID, Old: old}, clock)
+	return true
+}
+
+// GetChannelClock owner 가 보유한 peer 채널의 Vector Clock 반환
+func (vcm *VectorClockManager) GetChannelClock(owner, peer int) []int {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	clock := vcm.channelClockLocked(ChannelKey{Owner: owner, Peer: peer})
+	clockCopy := make([]int, len(clock))
+	copy(clockCopy, clock)
+	return clockCopy
+}
+
+// ChannelClocks owner 가 보유한 모든 채널의 Vector Clock 반환 (상대 ID -> Vector Clock)
+func (vcm *VectorClockManager) ChannelClocks(owner int) map[int][]int {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	clocks := make(map[int][]int)
+	for key, clock := range vcm.channels {
+		if key.Owner == owner {
+			clocks[key.Peer] = append([]int(nil), clock...)
+		}
+	}
+	return clocks
+}
+
+// tick 송신 이벤트로 시계를 증가시키고 메시지에 실을 시계와 에포크 반환
+func (p *Process) tick(to int) ([]int, int) {
+	if p.ClockMgr.Mode == ClockPerChannel {
+		return p.ClockMgr.advanceChannel(p.ID, to)
+	}
+	return p.ClockMgr.advance(p.ID)
+}
+
+// merge 송신자 from 의 메시지 messageID 에 실린 시계를 병합 (수신 이벤트로 시계 증가, 현재 에포크가 epoch 가 아니면 false)
+func (p *Process) merge(from int, messageID string, receivedClock []int, epoch int) bool {
+	if p.ClockMgr.Mode == ClockPerChannel {
+		return p.ClockMgr.updateChannelClock(p.ID, from, epoch, receivedClock, messageID)
+	}
+	return p.ClockMgr.updateClockIn(p.ID, epoch, receivedClock, from, messageID)
+}
+
+// mergeMessage 에포크 epoch 기준으로 변환된 메시지 시계에 새로운 정보가 있으면 병합 (병합했으면 true)
+//
+// 변환한 뒤 병합하기 전에 에포크가 바뀌었으면 병합하지 않고 새 에포크 기준으로 다시 변환한다
+// (그 사이 재설정이 있었으면 EpochError).
+func (p *Process) mergeMessage(msg Message, vector []int, epoch int) (bool, error) {
+	for {
+		if !p.canMergeFrom(msg.From, vector) {
+			return false, nil
+		}
+		if p.merge(msg.From, msg.MessageID, vector, epoch) {
+			return true, nil
+		}
+		var err error
+		if vector, epoch, err = p.currentVector(msg); err != nil {
+			return false, err
+		}
+	}
+}
+
+// clockFor 상대 peer 와의 통신에 사용하는 현재 시계 반환
+func (p *Process) clockFor(peer int) []int {
+	if p.ClockMgr.Mode == ClockPerChannel {
+		return p.ClockMgr.GetChannelClock(p.ID, peer)
+	}
+	return p.ClockMgr.GetClock(p.ID)
+}
@@ -0,0 +1,60 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPerChannelClocksAreIndependent(t *testing.T) {
+	vcm := NewVectorClockManager(3, WithClockMode(ClockPerChannel))
+	p0 := NewProcess(0, vcm)
+	p1 := NewProcess(1, vcm)
+	p2 := NewProcess(2, vcm)
+
+	p0.SendMessage(1, "to p1", p1.MessageCh, false)
+	p1.ReceiveMessages(p1.MessageCh)
+	p0.SendMessage(2, "to p2", p2.MessageCh, false)
+	p2.ReceiveMessages(p2.MessageCh)
+
+	if got, want := vcm.GetChannelClock(0, 1), []int{1, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("channel 0->1 = %v, want %v", got, want)
+	}
+	// 0->2 채널은 0->1 송신을 세지 않음
+	if got, want := vcm.GetChannelClock(0, 2), []int{1, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("channel 0->2 = %v, want %v", got, want)
+	}
+	if got, want := vcm.GetChannelClock(1, 0), []int{1, 1, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("channel 1->0 = %v, want %v", got, want)
+	}
+	if got := vcm.GetClock(0); !reflect.DeepEqual(got, []int{0, 0, 0}) {
+		t.Fatalf("per-process clock changed to %v in per-channel mode", got)
+	}
+
+	clocks := vcm.ChannelClocks(0)
+	if len(clocks) != 2 || clocks[1] == nil || clocks[2] == nil {
+		t.Fatalf("ChannelClocks(0) = %v, want channels to 1 and 2", clocks)
+	}
+}
+
+func TestClockModeString(t *testing.T) {
+	for mode, want := range map[ClockMode]string{
+		ClockPerProcess: "per-process",
+		ClockPerChannel: "per-channel",
+		ClockMode(7):    "ClockMode(7)",
+	} {
+		if got := mode.String(); got != want {
+			t.Errorf("%d.String() = %q, want %q", int(mode), got, want)
+		}
+	}
+}
+
+func TestParseClockMode(t *testing.T) {
+	for _, m := range []ClockMode{ClockPerProcess, ClockPerChannel} {
+		if got, ok := ParseClockMode(m.String()); !ok || got != m {
+			t.Fatalf("ParseClockMode(%q) = %v, %v", m.String(), got, ok)
+		}
+	}
+	if _, ok := ParseClockMode("per-domain"); ok {
+		t.Fatal("ParseClockMode accepted an unknown mode")
+	}
+}
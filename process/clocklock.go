@@ -0,0 +1,83 @@
+package process
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// clockStripes 프로세스 시계 잠금 테이블 크기 (ID 를 이 수로 나눈 나머지가 같은 프로세스끼리만 잠금을 공유)
+const clockStripes = 64
+
+// clockStripe 캐시 라인 하나를 차지하는 잠금 (이웃 잠금과 false sharing 방지)
+type clockStripe struct {
+	sync.Mutex
+	_ [56]byte
+}
+
+// clockLocks 프로세스 ID 로 나눈 시계 잠금 테이블
+//
+// 프로세스 하나의 기본 시계만 바꾸는 경로(송신, 수신, 복원)는 vcm.Mu 의 읽기 잠금과
+// 그 프로세스의 잠금만 잡으므로, 서로 다른 프로세스의 갱신은 서로 기다리지 않는다.
+// 여러 시계나 에포크·채널·도메인 상태를 바꾸는 경로는 vcm.Mu 를 쓰기 잠금으로 잡아 모든 갱신을 막고,
+// 에포크 번호와 기준 시계를 읽기만 하는 경로(수신 병합의 에포크 변환, SES 전달 조건)는 읽기 잠금만 잡는다.
+type clockLocks [clockStripes]clockStripe
+
+// lockClock 프로세스 processID 의 기본 시계 하나만 다룰 때의 잠금 (unlockClock 으로 해제)
+//
+// 시계 슬라이스의 내용만 바꿀 수 있고, Clock 맵의 항목을 바꾸거나 추가하려면 vcm.Mu 쓰기 잠금이 필요하다.
+func (vcm *VectorClockManager) lockClock(processID int) {
+	stripe := &vcm.clockMu[uint(processID)%clockStripes]
+	if !vcm.locks.enabled {
+		vcm.Mu.RLock()
+		stripe.Lock()
+		return
+	}
+	vcm.rlockManager()
+	acquire(&vcm.counters(processID).clockLock, &stripe.Mutex)
+}
+
+// unlockClock lockClock 해제
+func (vcm *VectorClockManager) unlockClock(processID int) {
+	vcm.clockMu[uint(processID)%clockStripes].Unlock()
+	vcm.Mu.RUnlock()
+}
+
+// publishedClock 프로세스 시계의 게시된 불변 복사본 ([]int, 시계가 바뀔 때마다 새 슬라이스로 교체)
+type publishedClock struct {
+	v atomic.Value
+}
+
+// publishLocked 프로세스 processID 의 현재 시계를 새 스냅샷으로 게시하고 반환 (시계를 바꾼 잠금을 보유한 상태에서 호출)
+//
+// 같은 시계의 갱신은 잠금으로 직렬화되므로 게시되는 스냅샷은 항상 최신이며 뒤로 가지 않는다.
+// 스냅샷은 게시 후 바뀌지 않으므로 GetClock, 송신 메시지, 이벤트 기록이 복사 없이 함께 쓴다.
+func (vcm *VectorClockManager) publishLocked(processID int) []int {
+	clock, ok := vcm.Clock[processID]
+	if !ok {
+		return nil
+	}
+	entry, _ := vcm.published.Load(processID)
+	if entry == nil {
+		entry, _ = vcm.published.LoadOrStore(processID, new(publishedClock))
+	}
+	snapshot := append([]int(nil), clock...)
+	entry.(*publishedClock).v.Store(snapshot)
+	return snapshot
+}
+
+// publishAllLocked 모든 프로세스 시계를 게시 (vcm.Mu 쓰기 잠금 보유 상태에서 호출)
+func (vcm *VectorClockManager) publishAllLocked() {
+	for id := range vcm.Clock {
+		vcm.publishLocked(id)
+	}
+}
+
+// loadClock 게시된 시계 스냅샷 (잠금 없음, 게시된 적이 없으면 false, 반환값은 바꾸면 안 됨)
+func (vcm *VectorClockManager) loadClock(processID int) ([]int, bool) {
+	entry, ok := vcm.published.Load(processID)
+	if !ok {
+		return nil, false
+	}
+	clock, ok := entry.(*publishedClock).v.Load().([]int)
+	return clock, ok
+}
@@ -0,0 +1,232 @@
+package process
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClockLocksOfOtherProcessesDoNotBlock(t *testing.T) {
+	vcm := NewVectorClockManager(clockStripes+2, WithLogger(nil))
+	update := func(id int) chan struct{} {
+		done := make(chan struct{})
+		go func() {
+			vcm.UpdateClock(id, make([]int, clockStripes+2))
+			close(done)
+		}()
+		return done
+	}
+
+	vcm.lockClock(0)
+	select {
+	case <-update(1):
+	case <-time.After(5 * time.Second):
+		t.Fatal("updating process 1 waited for the lock of process 0")
+	}
+
+	// 같은 줄(stripe)의 프로세스는 잠금을 공유
+	shared := update(clockStripes)
+	select {
+	case <-shared:
+		t.Fatal("updating a process on the same stripe did not wait for the lock")
+	case <-time.After(20 * time.Millisecond):
+	}
+	vcm.unlockClock(0)
+	<-shared
+	if got := vcm.GetClock(clockStripes)[clockStripes]; got != 1 {
+		t.Fatalf("clock of process %d = %d after one update, want 1", clockStripes, got)
+	}
+}
+
+func TestGetClockDoesNotWaitForUpdates(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	NewProcess(1, vcm, WithMailboxSize(4))
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+
+	vcm.lockClock(0)
+	done := make(chan []int, 1)
+	go func() { done <- vcm.GetClock(0) }()
+	select {
+	case got := <-done:
+		if !reflect.DeepEqual(got, []int{1, 0}) {
+			t.Fatalf("GetClock(0) = %v, want [1 0]", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetClock(0) waited for the clock lock")
+	}
+	vcm.unlockClock(0)
+}
+
+func TestPublishedClocksFollowEveryUpdate(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	b := NewProcess(1, vcm, WithMailboxSize(4))
+
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if got := vcm.GetClock(1); !reflect.DeepEqual(got, []int{1, 1}) {
+		t.Fatalf("GetClock(1) after receive = %v, want [1 1]", got)
+	}
+	vcm.Checkpoint()
+	vcm.Mu.RLock()
+	want := append([]int(nil), vcm.Clock[1]...)
+	vcm.Mu.RUnlock()
+	if got := vcm.GetClock(1); !reflect.DeepEqual(got, want) {
+		t.Fatalf("GetClock(1) after checkpoint = %v, want %v", got, want)
+	}
+	if got := vcm.GetClock(7); len(got) != 0 {
+		t.Fatalf("GetClock of an unknown process = %v, want empty", got)
+	}
+}
+
+func TestGetClockDoesNotAllocate(t *testing.T) {
+	vcm := NewVectorClockManager(4, WithLogger(nil))
+	if n := testing.AllocsPerRun(100, func() { _ = vcm.GetClock(2) }); n != 0 {
+		t.Fatalf("GetClock allocated %v times per call, want 0", n)
+	}
+}
+
+// managerWrites fn 동안 vcm.Mu 쓰기 잠금을 잡은 횟수 (WithLockProfiling)
+func managerWrites(vcm *VectorClockManager, fn func()) int64 {
+	before := vcm.LockMetrics().Manager.Acquired
+	fn()
+	return vcm.LockMetrics().Manager.Acquired - before
+}
+
+func TestMessagePathsAvoidManagerWriteLock(t *testing.T) {
+	for _, delivery := range []DeliveryAlgorithm{DeliveryBSS, DeliverySES} {
+		t.Run(delivery.String(), func(t *testing.T) {
+			vcm, procs, targets := newCausalGroup(3, delivery)
+			vcm.locks.enabled = true
+			p0, p1 := procs[0], procs[1]
+			// 체크포인트 이전 에포크의 메시지도 변환만 하면 되므로 쓰기 잠금이 필요 없음
+			if err := p0.Send(1, "old"); err != nil {
+				t.Fatal(err)
+			}
+			vcm.Checkpoint()
+			vcm.Scheduler() // 하트비트를 시작할 때 한 번 만드는 스케줄러
+
+			if n := managerWrites(vcm, func() {
+				if err := p1.ReceiveMessages(p1.MessageCh); err != nil {
+					t.Fatal(err)
+				}
+				if err := p0.Send(1, "plain"); err != nil {
+					t.Fatal(err)
+				}
+				if err := p1.ReceiveMessages(p1.MessageCh); err != nil {
+					t.Fatal(err)
+				}
+				p0.Broadcast("causal", targets(0))
+				if got := p1.DeliverCausal(p1.MessageCh); len(got) != 1 {
+					t.Fatalf("delivered %v", got)
+				}
+				p0.sendHeartbeat(nil)
+				vcm.CurrentEpoch()
+				vcm.Size()
+			}); n != 0 {
+				t.Fatalf("message paths took the manager write lock %d times", n)
+			}
+		})
+	}
+}
+
+func TestPublishedClocksAreImmutableSnapshots(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	NewProcess(1, vcm, WithMailboxSize(4))
+
+	before := vcm.GetClock(0)
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(before, []int{0, 0}) {
+		t.Fatalf("earlier snapshot changed to %v", before)
+	}
+	after := vcm.GetClock(0)
+	if !reflect.DeepEqual(after, []int{1, 0}) {
+		t.Fatalf("GetClock(0) = %v, want [1 0]", after)
+	}
+	copied := vcm.GetClockCopy(0)
+	copied[0] = 99
+	if vcm.GetClock(0)[0] != 1 {
+		t.Fatal("changing GetClockCopy result changed the clock")
+	}
+}
+
+// TestStripedClocksUnderConcurrency 서로 다른 프로세스의 송수신과 매니저 전체 경로(스냅샷, 체크포인트, Grow)를
+// 동시에 실행 (-race 로 실행해야 의미가 있음)
+func TestStripedClocksUnderConcurrency(t *testing.T) {
+	const n, rounds = 8, 200
+	vcm := NewVectorClockManager(n, WithLogger(nil))
+	procs := make([]*Process, n)
+	for i := range procs {
+		procs[i] = NewProcess(i, vcm, WithMailboxSize(rounds))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i += 2 {
+		sender, receiver := procs[i], procs[i+1]
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for r := 0; r < rounds; r++ {
+				if err := sender.Send(receiver.ID, "m"); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for r := 0; r < rounds; r++ {
+				if err := receiver.ReceiveMessages(receiver.MessageCh); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	readers.Add(1)
+	go func() {
+		defer readers.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			s := vcm.Snapshot()
+			for id, clock := range s.Clocks {
+				if len(clock) < n {
+					t.Errorf("snapshot clock %d has %d entries", id, len(clock))
+					return
+				}
+			}
+			_ = vcm.GetClock(i % n)
+			if i%50 == 0 {
+				vcm.Checkpoint()
+			}
+		}
+	}()
+	wg.Wait()
+	close(stop)
+	readers.Wait()
+
+	for i := 0; i < n; i += 2 {
+		// 체크포인트로 뺀 값을 더하면 송신자 항목은 보낸 수와 같음
+		abs := vcm.Absolute(vcm.CurrentEpoch(), vcm.GetClock(i+1))
+		if abs[i] != rounds {
+			t.Fatalf("receiver %d saw %d sends from %d, want %d", i+1, abs[i], i, rounds)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package process
+
+// Ordering 두 Vector Clock 사이의 인과 관계
+type Ordering int
+
+const (
+	// Equal 두 시계가 같음
+	Equal Ordering = iota
+	// Before a 가 b 보다 인과적으로 앞섬 (a -> b)
+	Before
+	// After a 가 b 보다 인과적으로 뒤임 (b -> a)
+	After
+	// Concurrent 두 시계 사이에 인과 관계가 없음
+	Concurrent
+)
+
+// String 관계 이름 반환
+func (o Ordering) String() string {
+	switch o {
+	case Equal:
+		return "equal"
+	case Before:
+		return "before"
+	case After:
+		return "after"
+	case Concurrent:
+		return "concurrent"
+	default:
+		return "unknown"
+	}
+}
+
+// Compare 두 Vector Clock 비교 (길이가 다르면 부족한 원소는 0 으로 간주)
+func Compare(a, b []int) Ordering {
+	less, greater := false, false
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x < y {
+			less = true
+		} else if x > y {
+			greater = true
+		}
+	}
+	switch {
+	case less && greater:
+		return Concurrent
+	case less:
+		return Before
+	case greater:
+		return After
+	default:
+		return Equal
+	}
+}
+
+// HappenedBefore a -> b 여부
+func HappenedBefore(a, b []int) bool {
+	return Compare(a, b) == Before
+}
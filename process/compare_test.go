@@ -0,0 +1,25 @@
+package process
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		a, b []int
+		want Ordering
+	}{
+		{[]int{1, 2}, []int{1, 2}, Equal},
+		{[]int{1, 0}, []int{1, 1}, Before},
+		{[]int{2, 1}, []int{1, 1}, After},
+		{[]int{1, 0}, []int{0, 1}, Concurrent},
+		{[]int{1}, []int{1, 0}, Equal}, // 부족한 원소는 0
+		{[]int{1}, []int{1, 1}, Before},
+	}
+	for _, c := range cases {
+		if got := Compare(c.a, c.b); got != c.want {
+			t.Errorf("Compare(%v, %v) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+	if !HappenedBefore([]int{0, 1}, []int{1, 1}) || HappenedBefore([]int{1, 1}, []int{1, 1}) {
+		t.Fatal("HappenedBefore disagrees with Compare")
+	}
+}
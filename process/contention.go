@@ -0,0 +1,162 @@
+package process
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LockStats 잠금(또는 같은 종류의 잠금 묶음)을 얻으려고 기다린 통계
+type LockStats struct {
+	Acquired  int64         `json:"acquired"`    // 잠금을 얻은 횟수
+	Contended int64         `json:"contended"`   // 바로 얻지 못하고 기다린 횟수
+	Wait      time.Duration `json:"wait_ns"`     // 기다린 누적 시간
+	MaxWait   time.Duration `json:"max_wait_ns"` // 가장 오래 기다린 시간
+}
+
+// add 다른 통계 o 를 더함 (MaxWait 는 최대값)
+func (s *LockStats) add(o LockStats) {
+	s.Acquired += o.Acquired
+	s.Contended += o.Contended
+	s.Wait += o.Wait
+	if o.MaxWait > s.MaxWait {
+		s.MaxWait = o.MaxWait
+	}
+}
+
+// LockMetrics 잠금 경합 통계 (WithLockProfiling)
+//
+// Manager 의 Wait 가 크면 여러 시계를 다루는 경로(에포크, 채널, 도메인, 스냅샷)가 모든 갱신을 막고 있다는 뜻이고,
+// ManagerRead 의 Wait 가 크면 송수신이 그런 경로를 기다리고 있다는 뜻이다.
+// Clock / Process 는 프로세스별 잠금을 기다린 시간으로, 같은 프로세스에 송수신이 몰릴 때 커진다.
+type LockMetrics struct {
+	Manager     LockStats         `json:"manager"`      // vcm.Mu 쓰기 잠금
+	ManagerRead LockStats         `json:"manager_read"` // vcm.Mu 읽기 잠금 (프로세스 시계 하나를 다루는 경로)
+	Clock       LockStats         `json:"clock"`        // 프로세스 시계 잠금 (lockClock) 합계
+	Process     LockStats         `json:"process"`      // Process.Mu 합계
+	Clocks      map[int]LockStats `json:"clocks"`       // 프로세스 ID -> 시계 잠금
+	Processes   map[int]LockStats `json:"processes"`    // 프로세스 ID -> Process.Mu
+}
+
+// lockCounter 잠금 하나의 대기 카운터 (잠금 없이 증가)
+type lockCounter struct {
+	acquired, contended, waitNanos, maxWait atomic.Int64
+}
+
+// record 잠금을 한 번 얻음 (기다렸으면 그 시간)
+func (c *lockCounter) record(wait time.Duration, contended bool) {
+	c.acquired.Add(1)
+	if !contended {
+		return
+	}
+	c.contended.Add(1)
+	c.waitNanos.Add(int64(wait))
+	for {
+		max := c.maxWait.Load()
+		if int64(wait) <= max || c.maxWait.CompareAndSwap(max, int64(wait)) {
+			return
+		}
+	}
+}
+
+// stats 현재 카운터 값
+func (c *lockCounter) stats() LockStats {
+	return LockStats{
+		Acquired:  c.acquired.Load(),
+		Contended: c.contended.Load(),
+		Wait:      time.Duration(c.waitNanos.Load()),
+		MaxWait:   time.Duration(c.maxWait.Load()),
+	}
+}
+
+// acquire 먼저 TryLock 으로 시도하고, 실패하면 기다린 시간을 재며 Lock
+//
+// 경합이 없으면 시각을 읽지 않으므로 계측 비용은 원자적 증가 하나뿐이다.
+func acquire(c *lockCounter, mu *sync.Mutex) {
+	if mu.TryLock() {
+		c.record(0, false)
+		return
+	}
+	start := time.Now()
+	mu.Lock()
+	c.record(time.Since(start), true)
+}
+
+// contentionState 매니저 잠금의 대기 카운터 (프로세스별 카운터는 counters 에 있음)
+type contentionState struct {
+	enabled     bool // WithLockProfiling (생성 후 바뀌지 않음)
+	manager     lockCounter
+	managerRead lockCounter
+}
+
+// WithLockProfiling 매니저와 프로세스 잠금을 기다린 시간 계측 (LockMetrics, PublishExpvar 의 "locks")
+//
+// 중앙 잠금(vcm.Mu)이 병목인지 확인할 때 켠다. 끄면(기본값) 잠금 경로에 분기 하나만 더해진다.
+func WithLockProfiling() ManagerOption {
+	return func(vcm *VectorClockManager) {
+		vcm.locks.enabled = true
+	}
+}
+
+// lockManager vcm.Mu 쓰기 잠금 (계측 중이면 기다린 시간 기록, vcm.Mu.Unlock 으로 해제)
+func (vcm *VectorClockManager) lockManager() {
+	if !vcm.locks.enabled {
+		vcm.Mu.Lock()
+		return
+	}
+	if vcm.Mu.TryLock() {
+		vcm.locks.manager.record(0, false)
+		return
+	}
+	start := time.Now()
+	vcm.Mu.Lock()
+	vcm.locks.manager.record(time.Since(start), true)
+}
+
+// rlockManager vcm.Mu 읽기 잠금 (계측 중이면 기다린 시간 기록, vcm.Mu.RUnlock 으로 해제)
+func (vcm *VectorClockManager) rlockManager() {
+	if !vcm.locks.enabled {
+		vcm.Mu.RLock()
+		return
+	}
+	if vcm.Mu.TryRLock() {
+		vcm.locks.managerRead.record(0, false)
+		return
+	}
+	start := time.Now()
+	vcm.Mu.RLock()
+	vcm.locks.managerRead.record(time.Since(start), true)
+}
+
+// lock p.Mu 잠금 (계측 중이면 기다린 시간 기록, p.Mu.Unlock 으로 해제)
+func (p *Process) lock() {
+	if !p.ClockMgr.locks.enabled {
+		p.Mu.Lock()
+		return
+	}
+	acquire(&p.ClockMgr.counters(p.ID).procLock, &p.Mu)
+}
+
+// LockMetrics 잠금 경합 통계 (WithLockProfiling 을 쓰지 않았으면 모두 0)
+func (vcm *VectorClockManager) LockMetrics() LockMetrics {
+	lm := LockMetrics{
+		Manager:     vcm.locks.manager.stats(),
+		ManagerRead: vcm.locks.managerRead.stats(),
+		Clocks:      make(map[int]LockStats),
+		Processes:   make(map[int]LockStats),
+	}
+	m := &vcm.metrics
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for id, c := range m.procs {
+		if s := c.clockLock.stats(); s.Acquired > 0 {
+			lm.Clocks[id] = s
+			lm.Clock.add(s)
+		}
+		if s := c.procLock.stats(); s.Acquired > 0 {
+			lm.Processes[id] = s
+			lm.Process.add(s)
+		}
+	}
+	return lm
+}
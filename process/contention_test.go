@@ -0,0 +1,76 @@
+package process
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLockMetricsAreZeroWithoutProfiling(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	NewProcess(1, vcm, WithMailboxSize(4))
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	lm := vcm.LockMetrics()
+	if lm.Manager.Acquired != 0 || lm.ManagerRead.Acquired != 0 || len(lm.Clocks) != 0 || len(lm.Processes) != 0 {
+		t.Fatalf("LockMetrics() = %+v without WithLockProfiling", lm)
+	}
+}
+
+func TestLockProfilingRecordsContention(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithLockProfiling())
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	NewProcess(1, vcm, WithMailboxSize(4))
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if s := vcm.LockMetrics().Clocks[0]; s.Acquired == 0 {
+		t.Fatalf("clock lock of process 0 = %+v after a send, want it acquired", s)
+	}
+
+	// 프로세스 1 의 시계 잠금을 잡은 동안의 갱신은 기다려야 함
+	vcm.lockClock(1)
+	done := make(chan struct{})
+	go func() {
+		vcm.UpdateClock(1, []int{0, 0})
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	vcm.unlockClock(1)
+	<-done
+
+	lm := vcm.LockMetrics()
+	s := lm.Clocks[1]
+	if s.Contended == 0 || s.Wait <= 0 || s.MaxWait <= 0 || s.MaxWait > s.Wait {
+		t.Fatalf("clock lock of process 1 = %+v, want one contended wait", s)
+	}
+	if lm.Clock.Acquired != lm.Clocks[0].Acquired+lm.Clocks[1].Acquired || lm.Clock.Contended < s.Contended {
+		t.Fatalf("clock total %+v does not sum %+v", lm.Clock, lm.Clocks)
+	}
+	if lm.ManagerRead.Acquired < lm.Clock.Acquired {
+		t.Fatalf("manager read lock acquired %d times for %d clock locks", lm.ManagerRead.Acquired, lm.Clock.Acquired)
+	}
+}
+
+func TestPublishExpvarIncludesLocks(t *testing.T) {
+	vcm := NewVectorClockManager(1, WithLogger(nil), WithLockProfiling())
+	NewProcess(0, vcm, WithMailboxSize(4))
+	vcm.Checkpoint()
+	name := fmt.Sprintf("vectorclock_test_locks_%d", expvarRuns.Add(1))
+	if err := vcm.PublishExpvar(name); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Locks *LockMetrics `json:"locks"`
+	}
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Locks == nil || doc.Locks.Manager.Acquired == 0 {
+		t.Fatalf("published locks = %+v, want the checkpoint's manager lock", doc.Locks)
+	}
+}
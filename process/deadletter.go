@@ -0,0 +1,185 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrMailboxFull 대상 메일박스가 가득 차 제한 시간 안에 보내지 못한 경우
+	ErrMailboxFull = errors.New("process: mailbox full")
+	// ErrChannelClosed 대상 채널이 닫혀 있는 경우
+	ErrChannelClosed = errors.New("process: channel closed")
+	// ErrInvalidMessage 수신한 메시지가 검증을 통과하지 못한 경우
+	ErrInvalidMessage = errors.New("process: invalid message")
+)
+
+// DeadLetterReason 메시지를 전달하지 못한 이유
+type DeadLetterReason int
+
+const (
+	// DeadLetterMailboxFull 메일박스가 가득 참 (송신 측에서 기록)
+	DeadLetterMailboxFull DeadLetterReason = iota
+	// DeadLetterChannelClosed 채널이 닫혀 있음 (송신 측에서 기록)
+	DeadLetterChannelClosed
+	// DeadLetterInvalid 검증 실패 (수신 측에서 기록)
+	DeadLetterInvalid
+	// DeadLetterEvicted 링 버퍼 메일박스가 가득 차 새 메시지에 밀려남 (밀어낸 송신 측에서 기록, OverflowDropOldest)
+	DeadLetterEvicted
+	// DeadLetterUnclaimed 요청자가 기다리지 않는 응답 (제한 시간이 지났거나 이미 응답함)
+	DeadLetterUnclaimed
+	// DeadLetterWindowFull 흐름 제어 윈도우에 자리가 나지 않음 (송신 측에서 기록, WithWindow)
+	DeadLetterWindowFull
+)
+
+// String 이유 이름 반환
+func (r DeadLetterReason) String() string {
+	switch r {
+	case DeadLetterMailboxFull:
+		return "mailbox-full"
+	case DeadLetterChannelClosed:
+		return "channel-closed"
+	case DeadLetterInvalid:
+		return "invalid"
+	case DeadLetterEvicted:
+		return "evicted"
+	case DeadLetterUnclaimed:
+		return "unclaimed"
+	case DeadLetterWindowFull:
+		return "window-full"
+	default:
+		return fmt.Sprintf("DeadLetterReason(%d)", int(r))
+	}
+}
+
+// DeadLetter 전달하지 못한 메시지
+type DeadLetter struct {
+	Message Message          // 전달하지 못한 메시지
+	Reason  DeadLetterReason // 이유
+	Err     error            // 상세 에러
+	Time    time.Time        // 기록 시각
+}
+
+// deadLetterQueue 프로세스별 dead-letter 큐
+type deadLetterQueue struct {
+	mu      sync.Mutex
+	letters []DeadLetter
+}
+
+// WithSendTimeout 대상 메일박스가 가득 찼을 때 기다릴 최대 시간 지정 (0 이면 무한정 대기)
+//
+// 제한 시간이 지나면 메시지는 송신자의 dead-letter 큐로 옮겨지고 ErrMailboxFull 이 반환된다.
+func WithSendTimeout(d time.Duration) ProcessOption {
+	return func(p *Process) {
+		p.sendTimeout = max(d, 0)
+	}
+}
+
+// DeadLetters dead-letter 큐의 메시지 목록 (오래된 순)
+func (p *Process) DeadLetters() []DeadLetter {
+	p.dlq.mu.Lock()
+	defer p.dlq.mu.Unlock()
+	return append([]DeadLetter(nil), p.dlq.letters...)
+}
+
+// DrainDeadLetters dead-letter 큐를 비우고 그동안 쌓인 메시지 반환
+func (p *Process) DrainDeadLetters() []DeadLetter {
+	p.dlq.mu.Lock()
+	defer p.dlq.mu.Unlock()
+
+	letters := p.dlq.letters
+	p.dlq.letters = nil
+	return letters
+}
+
+// DeadLetterCount dead-letter 큐의 메시지 수
+func (p *Process) DeadLetterCount() int {
+	p.dlq.mu.Lock()
+	defer p.dlq.mu.Unlock()
+	return len(p.dlq.letters)
+}
+
+// deadLetter dead-letter 큐에 메시지를 기록하고 err 반환
+func (p *Process) deadLetter(msg Message, reason DeadLetterReason, err error) error {
+	p.dlq.mu.Lock()
+	p.dlq.letters = append(p.dlq.letters, DeadLetter{Message: msg, Reason: reason, Err: err, Time: p.clockNow()})
+	p.dlq.mu.Unlock()
+
+	if reason != DeadLetterInvalid {
+		p.recordDrop(msg)
+	}
+	p.logf("Process %d: Dead-lettered message %s (%s): %v\n", p.ID, msg.MessageID, reason, err)
+	return err
+}
+
+// reject 수신 검증에 실패한 메시지를 dead-letter 큐로 보내고 err 반환
+func (p *Process) reject(msg Message, err error) error {
+	p.ClockMgr.counters(p.ID).rejected.Add(1)
+	return p.deadLetter(msg, DeadLetterInvalid, err)
+}
+
+// validate 수신 메시지 검증 (서명, 송신자 격리, 기본 시계 메시지의 송신자와 시계 크기, 시계 값)
+func (p *Process) validate(msg Message) error {
+	if err := p.verify(msg); err != nil {
+		return err
+	}
+	if err := p.checkQuarantine(msg); err != nil {
+		return err
+	}
+	if msg.Domain != "" {
+		return nil // 도메인 시계는 도메인 멤버십으로 검증
+	}
+	n := p.ClockMgr.Size()
+	if msg.From < 0 || msg.From >= n {
+		return fmt.Errorf("%w: sender %d out of range [0, %d)", ErrInvalidMessage, msg.From, n)
+	}
+	if len(msg.Vector) > n {
+		// 짧은 시계는 아직 Grow 하지 않은 노드가 보낸 것이므로 없는 항목을 0 으로 봄
+		return fmt.Errorf("%w: vector has %d entries, want at most %d", ErrInvalidMessage, len(msg.Vector), n)
+	}
+	return p.checkClock(msg)
+}
+
+// offer 채널로 메시지 전송 (닫힌 채널, 제한 시간 초과는 dead-letter 처리, 링 버퍼 메일박스면 offerRing)
+//
+// 가득 찬 메일박스는 timeout 이 0 이면 무한정, 양수면 그 시간까지 기다리고, 음수면 기다리지 않고 dead-letter 처리한다.
+func (p *Process) offer(targetCh chan<- Message, msg Message, timeout time.Duration) (err error) {
+	// 받는 쪽이 꺼내기 전에 전송 중으로 세어야 종료를 잘못 감지하지 않음
+	p.ClockMgr.enter(msg)
+	defer func() {
+		if r := recover(); r != nil {
+			err = p.deadLetter(msg, DeadLetterChannelClosed,
+				fmt.Errorf("%w: mailbox of process %d", ErrChannelClosed, msg.To))
+		}
+		if err != nil {
+			p.ClockMgr.leave(msg)
+		}
+	}()
+
+	if target := p.ClockMgr.ringFor(targetCh, msg.To); target != nil {
+		return p.offerRing(target, msg, timeout)
+	}
+	select {
+	case targetCh <- msg:
+		return nil
+	default:
+		p.blocked(targetCh, msg)
+	}
+	switch {
+	case timeout < 0:
+		return p.deadLetter(msg, DeadLetterMailboxFull,
+			fmt.Errorf("%w: mailbox of process %d", ErrMailboxFull, msg.To))
+	case timeout == 0:
+		targetCh <- msg
+		return nil
+	}
+	select {
+	case targetCh <- msg:
+		return nil
+	case <-p.ClockMgr.timer(timeout):
+		return p.deadLetter(msg, DeadLetterMailboxFull,
+			fmt.Errorf("%w: mailbox of process %d after %v", ErrMailboxFull, msg.To, timeout))
+	}
+}
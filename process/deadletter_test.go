@@ -0,0 +1,61 @@
+package process
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSendTimeoutDeadLettersMessage(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	sender := NewProcess(0, vcm, WithSendTimeout(10*time.Millisecond))
+	NewProcess(1, vcm)
+
+	if err := sender.Send(1, "fits"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.Send(1, "overflow"); !errors.Is(err, ErrMailboxFull) {
+		t.Fatalf("Send to full mailbox = %v, want ErrMailboxFull", err)
+	}
+	letters := sender.DeadLetters()
+	if len(letters) != 1 || letters[0].Reason != DeadLetterMailboxFull || letters[0].Message.Event != "overflow" {
+		t.Fatalf("dead letters = %+v", letters)
+	}
+	if got := sender.DrainDeadLetters(); len(got) != 1 || sender.DeadLetterCount() != 0 {
+		t.Fatalf("DrainDeadLetters() = %v, left %d", got, sender.DeadLetterCount())
+	}
+}
+
+func TestSendToClosedChannelDeadLettersMessage(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	sender := NewProcess(0, vcm)
+	NewProcess(1, vcm)
+	closed := make(chan Message)
+	close(closed)
+
+	if err := sender.SendMessage(1, "m", closed, false); !errors.Is(err, ErrChannelClosed) {
+		t.Fatalf("SendMessage to closed channel = %v, want ErrChannelClosed", err)
+	}
+	if letters := sender.DeadLetters(); len(letters) != 1 || letters[0].Reason != DeadLetterChannelClosed {
+		t.Fatalf("dead letters = %+v", letters)
+	}
+}
+
+func TestInvalidMessageIsDeadLetteredByReceiver(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	receiver := NewProcess(1, vcm)
+
+	if err := receiver.receive(Message{From: 5, To: 1, Vector: []int{1, 0}}); !errors.Is(err, ErrInvalidMessage) {
+		t.Fatalf("receive = %v, want ErrInvalidMessage", err)
+	}
+	letters := receiver.DeadLetters()
+	if len(letters) != 1 || letters[0].Reason != DeadLetterInvalid || !errors.Is(letters[0].Err, ErrInvalidMessage) {
+		t.Fatalf("dead letters = %+v", letters)
+	}
+	if got := vcm.GetClock(1); got[0] != 0 || got[1] != 0 {
+		t.Fatalf("invalid message changed the clock to %v", got)
+	}
+	if DeadLetterInvalid.String() != "invalid" {
+		t.Fatalf("DeadLetterInvalid.String() = %q", DeadLetterInvalid)
+	}
+}
@@ -0,0 +1,186 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrDeadlock 모든 프로세스가 서로를 기다리며 멈춘 경우
+var ErrDeadlock = errors.New("process: deadlock")
+
+// WaitKind 프로세스가 기다리는 대상 종류
+type WaitKind int
+
+const (
+	// WaitReceive 메일박스 수신 대기 (ReceiveMessages 등)
+	WaitReceive WaitKind = iota
+	// WaitReply 요청에 대한 응답 대기 (Call, SendSync)
+	WaitReply
+	// WaitLock 분산 임계 구역 진입 허락 대기 (Lock)
+	WaitLock
+	// WaitSleep 정해진 시간 동안 대기 (Sleep, 스스로 깨어나므로 교착 상태가 아님)
+	WaitSleep
+	// WaitWindow 흐름 제어 윈도우의 확인 응답 대기 (WithWindow)
+	WaitWindow
+)
+
+// String 종류 이름 반환
+func (k WaitKind) String() string {
+	switch k {
+	case WaitReceive:
+		return "receive"
+	case WaitReply:
+		return "reply"
+	case WaitLock:
+		return "lock"
+	case WaitSleep:
+		return "sleep"
+	case WaitWindow:
+		return "window"
+	default:
+		return fmt.Sprintf("WaitKind(%d)", int(k))
+	}
+}
+
+// WaitFor 교착 상태에서 한 프로세스의 대기 정보
+type WaitFor struct {
+	Process int      // 기다리는 프로세스 ID
+	Kind    WaitKind // 기다리는 대상 종류
+	On      []int    // 기다리는 상대 ID (nil 이면 누구든)
+	Clock   []int    // 교착 상태를 감지한 시점의 Vector Clock
+}
+
+// DeadlockError 교착 상태 보고 (대기 그래프와 각 프로세스의 시계)
+type DeadlockError struct {
+	Waits []WaitFor // 대기 중인 프로세스 (ID 순서)
+}
+
+func (e *DeadlockError) Error() string {
+	var b strings.Builder
+	b.WriteString("process: deadlock: all processes blocked with no messages in flight")
+	for _, w := range e.Waits {
+		on := "any"
+		if w.On != nil {
+			on = fmt.Sprint(w.On)
+		}
+		fmt.Fprintf(&b, "\n  process %d waits for %s from %s, Vector: %v", w.Process, w.Kind, on, w.Clock)
+	}
+	return b.String()
+}
+
+// Unwrap errors.Is(err, ErrDeadlock) 지원
+func (e *DeadlockError) Unwrap() error {
+	return ErrDeadlock
+}
+
+// waiter 대기 중인 프로세스
+type waiter struct {
+	kind    WaitKind
+	on      []int
+	abort   chan struct{}   // 교착 상태 감지 시 닫힘
+	err     *DeadlockError  // 감지된 교착 상태
+	timeout <-chan struct{} // 제한 시간이 지나면 닫힘 (blockTimeout, 제한 없으면 nil)
+}
+
+// block 프로세스를 대기 상태로 등록 (반환된 waiter 의 abort 가 닫히면 교착 상태)
+//
+// 등록 즉시 교착 상태를 검사하며, 대기가 끝나면 unblock 을 호출해야 한다.
+func (vcm *VectorClockManager) block(processID int, kind WaitKind, on []int) *waiter {
+	return vcm.blockTimeout(processID, kind, on, 0)
+}
+
+// unblock 대기 상태 해제
+func (vcm *VectorClockManager) unblock(processID int) {
+	vcm.term.mu.Lock()
+	defer vcm.term.mu.Unlock()
+	delete(vcm.term.waiting, processID)
+}
+
+// checkDeadlockLocked 정지 상태면 가상 시간을 진행하거나, 교착 상태면 대기 중인 모든 프로세스를 깨움
+// (vcm.term.mu 보유 상태에서 호출)
+//
+// 전송 중 메시지가 없고 등록된 모든 프로세스가 대기 중이거나 메시지를 기다리며 쉬고 있는 액터이면
+// 정지 상태다. 가상 시간(WithVirtualTime)에서 예약된 작업이 남아 있으면 다음 작업 시각으로 진행하고,
+// 아니면 대기 중인 프로세스가 하나 이상이고 그중 자는(Sleep) 프로세스가 없을 때 교착 상태로 본다.
+func (vcm *VectorClockManager) checkDeadlockLocked() {
+	t := &vcm.term
+	if t.inFlight != 0 || (len(t.waiting) == 0 && !vcm.autoAdvance) || t.advancing {
+		return
+	}
+	for _, p := range vcm.Processes() {
+		if _, ok := t.waiting[p.ID]; ok {
+			continue
+		}
+		if t.active[p.ID] || !p.Running() {
+			return
+		}
+	}
+
+	if vcm.autoAdvance && vcm.scheduler.Pending() > 0 {
+		t.advancing = true
+		go vcm.advanceVirtual()
+		return
+	}
+	if len(t.waiting) == 0 {
+		return
+	}
+	for _, w := range t.waiting {
+		if w.kind == WaitSleep {
+			return
+		}
+	}
+
+	ids := make([]int, 0, len(t.waiting))
+	for id := range t.waiting {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	report := &DeadlockError{}
+	for _, id := range ids {
+		w := t.waiting[id]
+		report.Waits = append(report.Waits, WaitFor{
+			Process: id,
+			Kind:    w.kind,
+			On:      append([]int(nil), w.on...),
+			Clock:   vcm.GetClockCopy(id),
+		})
+	}
+	for _, id := range ids {
+		w := t.waiting[id]
+		w.err = report
+		close(w.abort)
+		delete(t.waiting, id)
+	}
+	vcm.logf("%v\n", report)
+}
+
+// await 메일박스에서 메시지 한 건을 기다림 (보관된 메시지가 있으면 먼저, 교착 상태가 감지되면 *DeadlockError)
+func (p *Process) await(messageCh <-chan Message) (Message, bool, error) {
+	if msg, ok := p.unstash(messageCh); ok {
+		return msg, true, nil
+	}
+	return p.awaitMailbox(messageCh)
+}
+
+// awaitMailbox 보관된 메시지와 관계없이 메일박스에서 메시지 한 건을 기다림
+func (p *Process) awaitMailbox(messageCh <-chan Message) (Message, bool, error) {
+	w := p.ClockMgr.block(p.ID, WaitReceive, nil)
+	defer p.ClockMgr.unblock(p.ID)
+
+	if r := p.ringOf(messageCh); r != nil {
+		msg, ok, aborted := r.pop(w.abort)
+		if aborted {
+			return Message{}, false, w.err
+		}
+		return msg, ok, nil
+	}
+	select {
+	case msg, ok := <-messageCh:
+		return msg, ok, nil
+	case <-w.abort:
+		return Message{}, false, w.err
+	}
+}
@@ -0,0 +1,87 @@
+package process
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReceiveReportsDeadlock(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	ps := []*Process{NewProcess(0, vcm, WithMailboxSize(4)), NewProcess(1, vcm, WithMailboxSize(4))}
+	if err := ps[0].Send(1, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ps[1].ReceiveMessages(ps[1].MessageCh); err != nil {
+		t.Fatal(err)
+	}
+
+	errs := make([]error, len(ps))
+	var wg sync.WaitGroup
+	for i, p := range ps {
+		wg.Add(1)
+		go func(i int, p *Process) {
+			defer wg.Done()
+			errs[i] = p.ReceiveMessages(p.MessageCh)
+		}(i, p)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		var dl *DeadlockError
+		if !errors.As(err, &dl) || !errors.Is(err, ErrDeadlock) {
+			t.Fatalf("process %d: ReceiveMessages = %v, want *DeadlockError", i, err)
+		}
+		if len(dl.Waits) != len(ps) {
+			t.Fatalf("process %d: report has %d waits, want %d", i, len(dl.Waits), len(ps))
+		}
+		for j, w := range dl.Waits {
+			if w.Process != j || w.Kind != WaitReceive || w.On != nil {
+				t.Fatalf("wait %d = %+v", j, w)
+			}
+			if want := vcm.GetClockCopy(j); !reflect.DeepEqual(w.Clock, want) {
+				t.Fatalf("wait %d clock = %v, want %v", j, w.Clock, want)
+			}
+		}
+	}
+}
+
+func TestIdleActorDoesNotPreventDeadlock(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	waiter := NewProcess(0, vcm, WithMailboxSize(4))
+	idle := NewProcess(1, vcm, WithMailboxSize(4))
+	if err := idle.Start(func(Message) []Outgoing { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	defer idle.Stop()
+
+	err := waiter.ReceiveMessages(waiter.MessageCh)
+	var dl *DeadlockError
+	if !errors.As(err, &dl) {
+		t.Fatalf("ReceiveMessages = %v, want *DeadlockError", err)
+	}
+	if len(dl.Waits) != 1 || dl.Waits[0].Process != 0 {
+		t.Fatalf("waits = %+v, want only process 0", dl.Waits)
+	}
+}
+
+func TestReceiveWaitsForLateMessage(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	b := NewProcess(1, vcm, WithMailboxSize(4))
+
+	got := make(chan error, 1)
+	go func() { got <- b.ReceiveMessages(b.MessageCh) }()
+	time.Sleep(10 * time.Millisecond) // b 가 대기 중으로 등록될 때까지
+	if err := a.Send(1, "ping"); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-got; err != nil {
+		t.Fatalf("ReceiveMessages = %v, want nil", err)
+	}
+	if got := vcm.GetClockCopy(1)[0]; got != 1 {
+		t.Fatalf("receiver entry for sender = %d, want 1", got)
+	}
+}
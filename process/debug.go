@@ -0,0 +1,55 @@
+package process
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// DebugPath 디버그 핸들러의 기본 경로
+const DebugPath = "/debug/vectorclock"
+
+// Handler 매니저의 현재 상태를 JSON 으로 제공하는 HTTP 핸들러
+//
+// ?process=ID 를 주면 해당 프로세스의 상태만 반환한다.
+func (vcm *VectorClockManager) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body interface{}
+		if q := r.URL.Query().Get("process"); q != "" {
+			id, err := strconv.Atoi(q)
+			if err != nil {
+				http.Error(w, "invalid process id: "+q, http.StatusBadRequest)
+				return
+			}
+			p, ok := vcm.Lookup(id)
+			if !ok {
+				http.Error(w, ErrUnknownProcess.Error()+": "+q, http.StatusNotFound)
+				return
+			}
+			body = p.state(vcm.Events())
+		} else {
+			body = vcm.State()
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(body)
+	})
+}
+
+// RegisterDebugHandler mux 의 DebugPath 에 디버그 핸들러 등록 (mux 가 nil 이면 http.DefaultServeMux)
+//
+// 예: mgr.RegisterDebugHandler(nil) 후 http.ListenAndServe(":6060", nil)
+func (vcm *VectorClockManager) RegisterDebugHandler(mux *http.ServeMux) {
+	if mux == nil {
+		mux = http.DefaultServeMux
+	}
+	mux.Handle(DebugPath, vcm.Handler())
+}
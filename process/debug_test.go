@@ -0,0 +1,47 @@
+package process
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDebugHandlerServesManagerAndProcessState(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	NewProcess(0, vcm)
+	NewProcess(1, vcm)
+	mux := http.NewServeMux()
+	vcm.RegisterDebugHandler(mux)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	rec := get(DebugPath)
+	var s ManagerState
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &s) != nil || len(s.Processes) != 2 {
+		t.Fatalf("GET %s = %d %s", DebugPath, rec.Code, rec.Body)
+	}
+	rec = get(DebugPath + "?process=1")
+	var ps ProcessState
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &ps) != nil || ps.ID != 1 {
+		t.Fatalf("GET ?process=1 = %d %s", rec.Code, rec.Body)
+	}
+	for target, code := range map[string]int{
+		DebugPath + "?process=x": http.StatusBadRequest,
+		DebugPath + "?process=9": http.StatusNotFound,
+	} {
+		if rec := get(target); rec.Code != code {
+			t.Errorf("GET %s = %d, want %d", target, rec.Code, code)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, DebugPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "GET, HEAD" {
+		t.Fatalf("POST = %d, Allow %q", rec.Code, rec.Header().Get("Allow"))
+	}
+}
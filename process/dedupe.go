@@ -0,0 +1,144 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrDuplicateMessage 이미 받은 메시지 ID 를 다시 받은 경우 (WithDedupe)
+var ErrDuplicateMessage = errors.New("process: duplicate message")
+
+// DedupeWindow 수신 측 중복 제거 창 (기억하는 메시지 ID 의 범위)
+//
+// 세 제한은 함께 쓸 수 있으며, 하나라도 넘은 ID 는 잊는다. 잊은 ID 의 메시지가 다시 오면 중복으로 걸러내지 못하므로
+// 재전송이 일어날 수 있는 기간보다 넉넉하게 잡는다. 모두 0 이면 받은 ID 를 모두 기억한다.
+type DedupeWindow struct {
+	Max    int           // 기억할 최대 ID 수 (넘으면 가장 먼저 받은 ID 부터 잊음, 0 이면 제한 없음)
+	TTL    time.Duration // 받은 지 이보다 오래된 ID 는 잊음 (프로세스 시각 기준, 0 이면 제한 없음)
+	Stable bool          // 인과적으로 안정된(모든 프로세스가 아는) 메시지의 ID 는 Compact 때 잊음
+}
+
+// dedupeEntry 기억하는 메시지 ID
+type dedupeEntry struct {
+	id    string
+	at    time.Time // 받은 시각
+	from  int       // 송신자 (기본 시계 메시지가 아니면 -1)
+	seq   int       // 송신 이벤트의 송신자 항목 값
+	epoch int       // 송신 시계의 에포크
+}
+
+// dedupeState 수신한 메시지 ID 창 (프로세스별 설정)
+type dedupeState struct {
+	mu      sync.Mutex
+	window  DedupeWindow
+	seen    map[string]struct{}
+	entries []dedupeEntry // 받은 순서 (entries[head:] 가 기억하는 ID, 앞이 가장 오래됨)
+	head    int
+}
+
+// WithDedupe 받은 메시지 ID 를 창 w 안에서 기억하고 같은 ID 의 메시지는 병합하지 않음 (ErrDuplicateMessage)
+//
+// 재전송이나 재시도로 같은 메시지가 두 번 올 수 있을 때 쓴다. 중복은 Metrics 의 Duplicates 로 센다.
+func WithDedupe(w DedupeWindow) ProcessOption {
+	return func(p *Process) {
+		p.dedupe = &dedupeState{window: w, seen: make(map[string]struct{})}
+	}
+}
+
+// checkDuplicate 이미 받은 메시지이면 ErrDuplicateMessage, 처음이면 기억 (검증을 통과한 메시지에 대해 호출)
+func (p *Process) checkDuplicate(msg Message) error {
+	d := p.dedupe
+	if d == nil || msg.MessageID == "" {
+		return nil
+	}
+	now := p.Now()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.expireLocked(now)
+	if _, ok := d.seen[msg.MessageID]; ok {
+		p.ClockMgr.counters(p.ID).duplicates.Add(1)
+		p.logf("Process %d: Dropped duplicate message %s from %d\n", p.ID, msg.MessageID, msg.From)
+		return fmt.Errorf("%w: %s from process %d", ErrDuplicateMessage, msg.MessageID, msg.From)
+	}
+	e := dedupeEntry{id: msg.MessageID, at: now, from: -1, epoch: msg.Epoch}
+	if msg.Domain == "" && msg.From >= 0 && msg.From < len(msg.Vector) {
+		e.from, e.seq = msg.From, msg.Vector[msg.From]
+	}
+	d.seen[msg.MessageID] = struct{}{}
+	d.entries = append(d.entries, e)
+	if max := d.window.Max; max > 0 && d.lenLocked() > max {
+		d.dropLocked(d.lenLocked() - max)
+	}
+	return nil
+}
+
+// expireLocked TTL 이 지난 ID 를 잊음 (d.mu 보유 상태에서 호출)
+func (d *dedupeState) expireLocked(now time.Time) {
+	if d.window.TTL <= 0 {
+		return
+	}
+	n := 0
+	for d.head+n < len(d.entries) && now.Sub(d.entries[d.head+n].at) > d.window.TTL {
+		n++
+	}
+	d.dropLocked(n)
+}
+
+// lenLocked 기억하는 ID 수 (d.mu 보유 상태에서 호출)
+func (d *dedupeState) lenLocked() int {
+	return len(d.entries) - d.head
+}
+
+// dropLocked 가장 오래된 n 개 ID 를 잊음 (d.mu 보유 상태에서 호출)
+func (d *dedupeState) dropLocked(n int) {
+	if n <= 0 {
+		return
+	}
+	for i := d.head; i < d.head+n; i++ {
+		delete(d.seen, d.entries[i].id)
+		d.entries[i] = dedupeEntry{}
+	}
+	d.head += n
+	// 앞쪽 빈자리가 절반을 넘으면 당겨서 메모리 회수
+	if d.head > len(d.entries)/2 {
+		d.entries = append(d.entries[:0], d.entries[d.head:]...)
+		d.head = 0
+	}
+}
+
+// DedupeSize 중복 제거 창이 기억하는 메시지 ID 수 (WithDedupe 를 쓰지 않았으면 0)
+func (p *Process) DedupeSize() int {
+	d := p.dedupe
+	if d == nil {
+		return 0
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.lenLocked()
+}
+
+// compactDedupeLocked 프로세스의 중복 제거 창에서 전역 워터마크 아래의 ID 를 잊고 잊은 수 반환 (vcm.Mu 쓰기 잠금 보유 상태에서 호출)
+func (vcm *VectorClockManager) compactDedupeLocked(p *Process, global []int) int {
+	d := p.dedupe
+	if d == nil || !d.window.Stable {
+		return 0
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	kept := d.entries[:0]
+	for _, e := range d.entries[d.head:] {
+		if e.from >= 0 && vcm.stableEntryLocked(global, e.from, e.seq, e.epoch) {
+			delete(d.seen, e.id)
+			continue
+		}
+		kept = append(kept, e)
+	}
+	removed := d.lenLocked() - len(kept)
+	clear(d.entries[len(kept):])
+	d.entries, d.head = kept, 0
+	return removed
+}
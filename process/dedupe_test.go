@@ -0,0 +1,150 @@
+package process
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// sentMessage from 이 to 에게 보낸 메시지를 메일박스 대신 붙잡아 반환
+func sentMessage(t *testing.T, from *Process, to int, event string) Message {
+	t.Helper()
+	hold := make(chan Message, 1)
+	if err := from.SendMessage(to, event, hold, false); err != nil {
+		t.Fatal(err)
+	}
+	return <-hold
+}
+
+// redeliver 메시지를 메일박스에 넣고 받음
+func redeliver(p *Process, msg Message) error {
+	p.MessageCh <- msg
+	return p.ReceiveMessages(p.MessageCh)
+}
+
+func TestDedupeDropsRepeatedMessage(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm, WithMailboxSize(4), WithDedupe(DedupeWindow{}))
+	msg := sentMessage(t, a, 1, "m")
+
+	if err := redeliver(b, msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := redeliver(b, msg); !errors.Is(err, ErrDuplicateMessage) {
+		t.Fatalf("second delivery = %v, want ErrDuplicateMessage", err)
+	}
+	if got := vcm.GetClock(1); !reflect.DeepEqual(got, []int{1, 1}) {
+		t.Fatalf("clock = %v, want the message merged once", got)
+	}
+	if m := b.Metrics(); m.Duplicates != 1 || m.Merges != 1 {
+		t.Fatalf("metrics = %+v, want one duplicate and one merge", m)
+	}
+	if n := b.DedupeSize(); n != 1 {
+		t.Fatalf("DedupeSize() = %d, want 1", n)
+	}
+}
+
+func TestDedupeWithoutWindowAcceptsRepeats(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm, WithMailboxSize(4))
+	msg := sentMessage(t, a, 1, "m")
+	for i := 0; i < 2; i++ {
+		if err := redeliver(b, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := b.DedupeSize(); n != 0 {
+		t.Fatalf("DedupeSize() = %d without WithDedupe", n)
+	}
+}
+
+func TestDedupeForgetsBeyondMax(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm, WithMailboxSize(4), WithDedupe(DedupeWindow{Max: 2}))
+	var msgs []Message
+	for _, event := range []string{"m1", "m2", "m3"} {
+		msg := sentMessage(t, a, 1, event)
+		if err := redeliver(b, msg); err != nil {
+			t.Fatal(err)
+		}
+		msgs = append(msgs, msg)
+	}
+	if n := b.DedupeSize(); n != 2 {
+		t.Fatalf("DedupeSize() = %d, want 2", n)
+	}
+	if err := redeliver(b, msgs[2]); !errors.Is(err, ErrDuplicateMessage) {
+		t.Fatalf("newest repeat = %v, want ErrDuplicateMessage", err)
+	}
+	if err := redeliver(b, msgs[0]); err != nil {
+		t.Fatalf("repeat of a forgotten ID = %v, want it accepted", err)
+	}
+}
+
+func TestDedupeForgetsAfterTTL(t *testing.T) {
+	now := time.Unix(1000, 0)
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm, WithMailboxSize(4), WithDedupe(DedupeWindow{TTL: time.Minute}),
+		WithProcessTimeSource(TimeFunc(func() time.Time { return now })))
+	msg := sentMessage(t, a, 1, "m")
+	if err := redeliver(b, msg); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(30 * time.Second)
+	if err := redeliver(b, msg); !errors.Is(err, ErrDuplicateMessage) {
+		t.Fatalf("repeat within the TTL = %v, want ErrDuplicateMessage", err)
+	}
+	now = now.Add(2 * time.Minute)
+	if err := redeliver(b, msg); err != nil {
+		t.Fatalf("repeat after the TTL = %v, want it accepted", err)
+	}
+}
+
+func TestCompactForgetsStableIDs(t *testing.T) {
+	vcm := NewVectorClockManager(3, WithLogger(nil))
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	b := NewProcess(1, vcm, WithMailboxSize(4), WithDedupe(DedupeWindow{Stable: true}))
+	c := NewProcess(2, vcm, WithMailboxSize(4))
+	if err := redeliver(b, sentMessage(t, a, 1, "ping")); err != nil {
+		t.Fatal(err)
+	}
+	vcm.Compact()
+	if n := b.DedupeSize(); n != 1 {
+		t.Fatalf("DedupeSize() = %d before p2 knows about the ping, want 1", n)
+	}
+
+	// p1 이 p2 에게 전하면 모든 프로세스가 ping 을 알게 되어 안정됨
+	if err := b.Send(2, "relay"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ReceiveMessages(c.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	vcm.Compact()
+	if n := b.DedupeSize(); n != 0 {
+		t.Fatalf("DedupeSize() = %d after the ping became stable, want 0", n)
+	}
+}
+
+func TestDeliverCausalSkipsDuplicates(t *testing.T) {
+	_, procs, targets := newCausalGroup(2, DeliveryBSS)
+	WithDedupe(DedupeWindow{})(procs[1])
+	hold := make(chan Message, 1)
+	to := targets(0)
+	to[1] = hold
+	procs[0].Broadcast("m", to)
+	msg := <-hold
+
+	procs[1].MessageCh <- msg
+	if got := procs[1].DeliverCausal(procs[1].MessageCh); len(got) != 1 {
+		t.Fatalf("delivered %v, want the message", got)
+	}
+	procs[1].MessageCh <- msg
+	if got := procs[1].DeliverCausal(procs[1].MessageCh); len(got) != 0 {
+		t.Fatalf("delivered %v again", got)
+	}
+}
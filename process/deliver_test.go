@@ -0,0 +1,105 @@
+package process
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// remoteMessage 다른 노드에서 받은 것처럼 만든 메시지
+func remoteMessage(vcm *VectorClockManager, to int, id string) Message {
+	return Message{From: 1, To: to, Vector: make([]int, vcm.Size()), Event: "remote", MessageID: id}
+}
+
+func TestDeliverDoesNotBlockOnFullMailbox(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	target := NewProcess(0, vcm, WithMailboxSize(1))
+	if err := vcm.Deliver(remoteMessage(vcm, 0, "1-1")); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- vcm.Deliver(remoteMessage(vcm, 0, "1-2")) }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrMailboxFull) {
+			t.Fatalf("got %v, want ErrMailboxFull", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Deliver blocked on a full mailbox")
+	}
+	if letters := target.DeadLetters(); len(letters) != 1 || letters[0].Reason != DeadLetterMailboxFull {
+		t.Fatalf("dead letters = %+v, want one mailbox-full letter on the target", letters)
+	}
+	if n := vcm.InFlight(); n != 1 {
+		t.Fatalf("InFlight() = %d, want 1 (only the queued message)", n)
+	}
+}
+
+func TestDeliverWaitsForTargetSendTimeout(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	target := NewProcess(0, vcm, WithMailboxSize(1), WithSendTimeout(time.Second))
+	if err := vcm.Deliver(remoteMessage(vcm, 0, "1-1")); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- vcm.Deliver(remoteMessage(vcm, 0, "1-2")) }()
+	time.Sleep(10 * time.Millisecond)
+	// 제한 시간 안에 자리가 나면 전달됨
+	<-target.MessageCh
+	if err := <-done; err != nil {
+		t.Fatalf("Deliver after space freed: %v", err)
+	}
+}
+
+func TestDeliverAppliesRingOverflowPolicy(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	target := NewProcess(0, vcm, WithRingMailbox(1, OverflowDropOldest))
+	for _, id := range []string{"1-1", "1-2"} {
+		if err := vcm.Deliver(remoteMessage(vcm, 0, id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	letters := target.DeadLetters()
+	if len(letters) != 1 || letters[0].Reason != DeadLetterEvicted || letters[0].Message.MessageID != "1-1" {
+		t.Fatalf("dead letters = %+v, want 1-1 evicted", letters)
+	}
+
+	blocking := NewProcess(1, vcm, WithRingMailbox(1, OverflowBlock))
+	if err := vcm.Deliver(remoteMessage(vcm, 1, "0-1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := vcm.Deliver(remoteMessage(vcm, 1, "0-2")); !errors.Is(err, ErrMailboxFull) {
+		t.Fatalf("got %v, want ErrMailboxFull from a full blocking ring", err)
+	}
+	if n := blocking.DeadLetterCount(); n != 1 {
+		t.Fatalf("DeadLetterCount() = %d, want 1", n)
+	}
+}
+
+func TestLastMessageID(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm, WithMailboxSize(1))
+	b := NewProcess(1, vcm, WithMailboxSize(1))
+	if id := a.LastMessageID(); id != "" {
+		t.Fatalf("LastMessageID() = %q before sending", id)
+	}
+	if err := a.Send(1, "first"); err != nil {
+		t.Fatal(err)
+	}
+	msg := <-b.MessageCh
+	if id := a.LastMessageID(); id != msg.MessageID {
+		t.Fatalf("LastMessageID() = %q, want %q", id, msg.MessageID)
+	}
+	// 닫힌 채널로 보내 dead-letter 가 된 메시지도 마지막 송신
+	closed := make(chan Message)
+	close(closed)
+	if err := a.SendMessage(1, "lost", closed, false); err == nil {
+		t.Fatal("send to a closed channel succeeded")
+	}
+	letters := a.DeadLetters()
+	if id := a.LastMessageID(); len(letters) != 1 || id != letters[0].Message.MessageID {
+		t.Fatalf("LastMessageID() = %q, dead letters %+v", id, letters)
+	}
+}
@@ -0,0 +1,159 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	// ErrUnknownDomain 존재하지 않는 시계 도메인을 참조하는 경우
+	ErrUnknownDomain = errors.New("process: unknown clock domain")
+	// ErrNotDomainMember 도메인 멤버가 아닌 프로세스가 도메인 시계를 사용하려는 경우
+	ErrNotDomainMember = errors.New("process: not a member of clock domain")
+)
+
+// clockDomain 이름 있는 독립 시계 도메인
+type clockDomain struct {
+	members []int         // 멤버 ID (인덱스 = 도메인 시계 항목)
+	clock   map[int][]int // 멤버별 도메인 Vector Clock (프로세스 ID -> Vector Clock)
+}
+
+// AddDomain 멤버 집합을 가진 시계 도메인 추가 (예: 복제 객체마다 하나)
+//
+// 도메인 시계의 i 번째 항목은 members[i] 프로세스의 이벤트 수이며,
+// 기본 시계(Clock)나 다른 도메인과는 독립적으로 증가/병합된다.
+func (vcm *VectorClockManager) AddDomain(name string, members []int) error {
+	if name == "" {
+		return fmt.Errorf("process: clock domain name must not be empty")
+	}
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	if vcm.domains == nil {
+		vcm.domains = make(map[string]*clockDomain)
+	}
+	if _, ok := vcm.domains[name]; ok {
+		return fmt.Errorf("process: clock domain %q already exists", name)
+	}
+	d := &clockDomain{members: append([]int(nil), members...), clock: make(map[int][]int, len(members))}
+	for _, id := range members {
+		d.clock[id] = make([]int, len(members))
+	}
+	vcm.domains[name] = d
+	return nil
+}
+
+// Domains 등록된 시계 도메인 이름 (정렬)
+func (vcm *VectorClockManager) Domains() []string {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	names := make([]string, 0, len(vcm.domains))
+	for name := range vcm.domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DomainMembers 도메인 멤버 ID (도메인 시계 항목 순서)
+func (vcm *VectorClockManager) DomainMembers(name string) ([]int, error) {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	d, ok := vcm.domains[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDomain, name)
+	}
+	return append([]int(nil), d.members...), nil
+}
+
+// UpdateDomainClock 도메인 안에서 특정 프로세스의 Vector Clock 업데이트
+func (vcm *VectorClockManager) UpdateDomainClock(name string, processID int, receivedClock []int) error {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	d, clock, err := vcm.domainClockLocked(name, processID)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(receivedClock) && i < len(clock); i++ {
+		if receivedClock[i] > clock[i] {
+			clock[i] = receivedClock[i]
+		}
+	}
+	clock[indexOf(d.members, processID)]++
+	return nil
+}
+
+// GetDomainClock 도메인 안에서 특정 프로세스의 Vector Clock 반환
+func (vcm *VectorClockManager) GetDomainClock(name string, processID int) ([]int, error) {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	_, clock, err := vcm.domainClockLocked(name, processID)
+	if err != nil {
+		return nil, err
+	}
+	return append([]int(nil), clock...), nil
+}
+
+// domainClockLocked 도메인과 멤버 시계 조회 (vcm.Mu 보유 상태에서 호출)
+func (vcm *VectorClockManager) domainClockLocked(name string, processID int) (*clockDomain, []int, error) {
+	d, ok := vcm.domains[name]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnknownDomain, name)
+	}
+	clock, ok := d.clock[processID]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: process %d in %s", ErrNotDomainMember, processID, name)
+	}
+	return d, clock, nil
+}
+
+// SendDomain 도메인 시계만 갱신하는 메시지를 레지스트리를 통해 전송
+func (p *Process) SendDomain(to int, domain, event string) error {
+	mailbox, err := p.ClockMgr.mailbox(to)
+	if err != nil {
+		return err
+	}
+	if err := p.ClockMgr.UpdateDomainClock(domain, p.ID, nil); err != nil {
+		return err
+	}
+	clock, err := p.ClockMgr.GetDomainClock(domain, p.ID)
+	if err != nil {
+		return err
+	}
+
+	msg := p.newMessage(to, event, clock, p.ClockMgr.CurrentEpoch())
+	msg.Domain = domain
+	if err := p.push(mailbox, msg); err != nil {
+		return err
+	}
+
+	p.logf("Process %d: Sent message to Process %d, Domain: %s, Vector: %v\n", p.ID, to, domain, msg.Vector)
+	return nil
+}
+
+// receiveDomain 도메인 메시지 수신 처리 (p.Mu 보유 상태에서 호출)
+func (p *Process) receiveDomain(msg Message) error {
+	current, err := p.ClockMgr.GetDomainClock(msg.Domain, p.ID)
+	if err != nil {
+		return p.reject(msg, err)
+	}
+	if !canMerge(current, msg.Vector) {
+		p.logf("Process %d: Received message from %d, Domain: %s, Vector: %v\n",
+			p.ID, msg.From, msg.Domain, current)
+		p.observe(msg)
+		return nil
+	}
+	if err := p.ClockMgr.UpdateDomainClock(msg.Domain, p.ID, msg.Vector); err != nil {
+		return err
+	}
+	current, _ = p.ClockMgr.GetDomainClock(msg.Domain, p.ID)
+	p.logf("Process %d: Received and merged message from %d, Domain: %s, Vector: %v\n",
+		p.ID, msg.From, msg.Domain, current)
+	p.observe(msg)
+	return nil
+}
@@ -0,0 +1,51 @@
+package process
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDomainClocksAreIndependent(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	p0, p1 := NewProcess(0, vcm), NewProcess(1, vcm)
+	NewProcess(2, vcm)
+	if err := vcm.AddDomain("orders", []int{1, 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := vcm.AddDomain("orders", []int{2}); err == nil {
+		t.Fatal("AddDomain accepted a duplicate name")
+	}
+
+	if err := p0.SendDomain(1, "orders", "put"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p1.ReceiveMessages(p1.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	// 도메인 항목 순서는 멤버 순서 [1, 0]
+	if got, err := vcm.GetDomainClock("orders", 1); err != nil || !reflect.DeepEqual(got, []int{1, 1}) {
+		t.Fatalf("orders clock of 1 = %v, %v, want [1 1]", got, err)
+	}
+	if got := vcm.GetClock(1); !reflect.DeepEqual(got, []int{0, 0, 0}) {
+		t.Fatalf("default clock of 1 = %v, want untouched", got)
+	}
+	if got := vcm.Domains(); !reflect.DeepEqual(got, []string{"orders"}) {
+		t.Fatalf("Domains() = %v", got)
+	}
+}
+
+func TestDomainRejectsNonMembers(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	p2 := NewProcess(2, vcm)
+	NewProcess(0, vcm)
+	if err := vcm.AddDomain("orders", []int{0, 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p2.SendDomain(0, "orders", "x"); !errors.Is(err, ErrNotDomainMember) {
+		t.Fatalf("SendDomain from non-member = %v, want ErrNotDomainMember", err)
+	}
+	if _, err := vcm.GetDomainClock("missing", 0); !errors.Is(err, ErrUnknownDomain) {
+		t.Fatalf("GetDomainClock(missing) = %v, want ErrUnknownDomain", err)
+	}
+}
@@ -0,0 +1,185 @@
+package process
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+)
+
+// dumpRecentEvents 상태 덤프에 포함하는 프로세스별 최근 이벤트 수
+const dumpRecentEvents = 20
+
+// ManagerState 매니저 전체 상태 스냅샷 (DumpState / 디버그 엔드포인트 출력)
+type ManagerState struct {
+	Time      time.Time      `json:"time"`
+	Epoch     int            `json:"epoch"`
+	Mode      string         `json:"mode"`
+	Delivery  string         `json:"delivery"`
+	InFlight  int            `json:"in_flight"`
+	Active    []int          `json:"active"`
+	Clocks    map[int][]int  `json:"clocks"`
+	Domains   []string       `json:"domains,omitempty"`
+	Groups    []string       `json:"groups,omitempty"`
+	Topics    []string       `json:"topics,omitempty"`
+	Processes []ProcessState `json:"processes"`
+}
+
+// ProcessState 프로세스 상태 스냅샷
+type ProcessState struct {
+	ID           int            `json:"id"`
+	Clock        []int          `json:"clock"`
+	Running      bool           `json:"running"`
+	MailboxDepth int            `json:"mailbox_depth"`
+	MailboxCap   int            `json:"mailbox_cap"`
+	Pending      []MessageState `json:"pending,omitempty"`
+	DeadLetters  int            `json:"dead_letters"`
+	TraceID      string         `json:"trace_id,omitempty"`
+	Recent       []EventState   `json:"recent,omitempty"`
+}
+
+// MessageState 메시지 요약 (채널 등 직렬화할 수 없는 필드 제외)
+type MessageState struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Event  string `json:"event"`
+	Vector []int  `json:"vector"`
+	Epoch  int    `json:"epoch"`
+	Topic  string `json:"topic,omitempty"`
+	Domain string `json:"domain,omitempty"`
+}
+
+// EventState 이벤트 요약
+type EventState struct {
+	Seq       int64     `json:"seq"`
+	Kind      string    `json:"kind"`
+	Name      string    `json:"name,omitempty"`
+	MessageID string    `json:"message_id,omitempty"`
+	From      int       `json:"from"`
+	To        int       `json:"to"`
+	Clock     []int     `json:"clock"`
+	Timestamp int64     `json:"timestamp,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// State 매니저와 등록된 모든 프로세스의 현재 상태 스냅샷
+func (vcm *VectorClockManager) State() ManagerState {
+	s := ManagerState{
+		Time:     time.Now(),
+		Epoch:    vcm.CurrentEpoch(),
+		Mode:     vcm.Mode.String(),
+		Delivery: vcm.Delivery.String(),
+		InFlight: vcm.InFlight(),
+		Active:   vcm.Active(),
+		Clocks:   make(map[int][]int),
+		Domains:  vcm.Domains(),
+		Groups:   vcm.Groups(),
+		Topics:   vcm.Topics(),
+	}
+
+	vcm.lockManager()
+	for id, clock := range vcm.Clock {
+		s.Clocks[id] = append([]int(nil), clock...)
+	}
+	vcm.Mu.Unlock()
+
+	events := vcm.Events()
+	for _, p := range vcm.Processes() {
+		s.Processes = append(s.Processes, p.state(events))
+	}
+	return s
+}
+
+// state 프로세스 상태 스냅샷 (events 에서 최근 이벤트를 고름)
+func (p *Process) state(events []Event) ProcessState {
+	ps := ProcessState{
+		ID:           p.ID,
+		Clock:        p.ClockMgr.GetClockCopy(p.ID),
+		Running:      p.Running(),
+		MailboxDepth: p.mailboxLen(),
+		MailboxCap:   p.mailboxCap(),
+		DeadLetters:  p.DeadLetterCount(),
+		TraceID:      p.TraceID(),
+	}
+
+	p.lock()
+	for _, m := range p.causal.buffer {
+		ps.Pending = append(ps.Pending, messageState(m))
+	}
+	p.Mu.Unlock()
+
+	for i := len(events) - 1; i >= 0 && len(ps.Recent) < dumpRecentEvents; i-- {
+		if e := events[i]; e.Process == p.ID {
+			ps.Recent = append(ps.Recent, EventState{
+				Seq:       e.Seq,
+				Kind:      e.Kind.String(),
+				Name:      e.Name,
+				MessageID: e.MessageID,
+				From:      e.From,
+				To:        e.To,
+				Clock:     e.Clock,
+				Timestamp: e.Timestamp,
+				Time:      e.Time,
+			})
+		}
+	}
+	// 오래된 순으로 정렬
+	sort.Slice(ps.Recent, func(i, j int) bool { return ps.Recent[i].Seq < ps.Recent[j].Seq })
+	return ps
+}
+
+// messageState 메시지 요약 생성
+func messageState(m Message) MessageState {
+	return MessageState{
+		ID:     m.MessageID,
+		Kind:   m.Kind.String(),
+		From:   m.From,
+		To:     m.To,
+		Event:  m.Event,
+		Vector: m.Vector,
+		Epoch:  m.Epoch,
+		Topic:  m.Topic,
+		Domain: m.Domain,
+	}
+}
+
+// DumpState 모든 프로세스의 시계, 메일박스 깊이, 대기 메시지, 최근 이벤트를 JSON 문서로 출력
+//
+// 짧은 잠금만 사용하므로 실행 중 언제든 (시그널 처리 고루틴에서도) 호출할 수 있다.
+func (vcm *VectorClockManager) DumpState(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(vcm.State())
+}
+
+// DumpOnSignal sig 를 받을 때마다 w 에 상태를 덤프 (반환된 함수로 중지)
+//
+// 예: stop := mgr.DumpOnSignal(os.Stderr, syscall.SIGUSR1)
+func (vcm *VectorClockManager) DumpOnSignal(w io.Writer, sig ...os.Signal) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig...)
+	done := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ch:
+				if err := vcm.DumpState(w); err != nil {
+					fmt.Fprintf(os.Stderr, "process: dump state: %v\n", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
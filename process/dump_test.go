@@ -0,0 +1,40 @@
+package process
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDumpStateReportsClocksAndRecentEvents(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+	a.SetTraceID("req-1")
+	if err := a.Send(1, "hello"); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := vcm.DumpState(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var s ManagerState
+	if err := json.Unmarshal(buf.Bytes(), &s); err != nil {
+		t.Fatalf("dump is not JSON: %v\n%s", err, buf.Bytes())
+	}
+	if s.InFlight != 1 || !reflect.DeepEqual(s.Clocks[0], []int{1, 0}) {
+		t.Fatalf("in flight %d, clocks %v", s.InFlight, s.Clocks)
+	}
+	if len(s.Processes) != 2 {
+		t.Fatalf("dumped %d processes, want 2", len(s.Processes))
+	}
+	sender, receiver := s.Processes[0], s.Processes[1]
+	if sender.TraceID != "req-1" || len(sender.Recent) != 1 || sender.Recent[0].Kind != "send" {
+		t.Fatalf("sender state = %+v", sender)
+	}
+	if receiver.MailboxDepth != 1 || receiver.MailboxCap != cap(b.MessageCh) {
+		t.Fatalf("receiver mailbox %d/%d", receiver.MailboxDepth, receiver.MailboxCap)
+	}
+}
@@ -0,0 +1,324 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEpochMismatch 시계 재설정으로 서로 비교할 수 없는 에포크의 시계를 병합하려는 경우
+var ErrEpochMismatch = errors.New("process: epoch mismatch")
+
+// EpochError 에포크가 맞지 않아 시계를 병합/변환할 수 없음
+type EpochError struct {
+	From int // 시계가 속한 에포크
+	To   int // 병합/변환 대상 에포크
+}
+
+// Error 에러 메시지
+func (e *EpochError) Error() string {
+	return fmt.Sprintf("process: clock from epoch %d cannot be merged into epoch %d (clocks were reset in between)", e.From, e.To)
+}
+
+// Unwrap errors.Is(err, ErrEpochMismatch) 지원
+func (e *EpochError) Unwrap() error {
+	return ErrEpochMismatch
+}
+
+// epochInfo 에포크별 기준 시계
+type epochInfo struct {
+	base     []int         // 에포크 0 기준 누적 기준 시계 (이 에포크의 0 에 해당하는 절대 시계)
+	reset    bool          // ResetEpoch 로 시작된 에포크 여부 (이전 에포크와 비교 불가)
+	snapshot map[int][]int // 재설정 직전 모든 프로세스의 Vector Clock
+}
+
+// CurrentEpoch 현재 에포크 번호
+func (vcm *VectorClockManager) CurrentEpoch() int {
+	vcm.rlockManager()
+	defer vcm.Mu.RUnlock()
+	return vcm.epoch
+}
+
+// Checkpoint 모든 프로세스가 합의한 체크포인트 시계를 기준으로 카운터를 재설정
+//
+// 체크포인트 시계는 모든 프로세스의 Vector Clock 원소별 최소값(인과적으로 안정된 시계)이며,
+// 모든 시계에서 이 값을 빼고 에포크를 하나 증가시킨다. 이전 에포크의 시계는 저장된 기준
+// 시계를 통해 Translate / CompareEpochs 로 계속 비교할 수 있다.
+func (vcm *VectorClockManager) Checkpoint() (epoch int, base []int) {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	base = make([]int, len(vcm.Clock))
+	first := true
+	for _, clock := range vcm.Clock {
+		for i := range base {
+			if first || clock[i] < base[i] {
+				base[i] = clock[i]
+			}
+		}
+		first = false
+	}
+
+	olds := vcm.auditClocks()
+	for _, clock := range vcm.Clock {
+		subtractBase(clock, base)
+	}
+	vcm.publishAllLocked()
+	for _, clock := range vcm.channels {
+		subtractBase(clock, base)
+	}
+	defer vcm.auditClocksLocked(AuditCheckpoint, olds)
+
+	prev := vcm.epochBaseLocked(vcm.epoch)
+	cumulative := make([]int, len(base))
+	for i := range cumulative {
+		cumulative[i] = prev[i] + base[i]
+	}
+	vcm.epochs = append(vcm.epochs, epochInfo{base: cumulative})
+	vcm.epoch++
+	vcm.markEpochLocked()
+	return vcm.epoch, base
+}
+
+// ResetEpoch 모든 Vector Clock 을 원자적으로 스냅샷한 뒤 0 으로 재설정하고 에포크를 증가
+//
+// 반환되는 스냅샷은 재설정 직전의 프로세스별 Vector Clock 이다. 재설정 이전 에포크의 시계는
+// 이후 에포크의 시계와 비교/병합할 수 없으며, 그런 메시지의 병합은 EpochError 로 거부된다.
+func (vcm *VectorClockManager) ResetEpoch() (epoch int, snapshot map[int][]int) {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	olds := vcm.auditClocks()
+	defer vcm.auditClocksLocked(AuditReset, olds)
+	snapshot = make(map[int][]int, len(vcm.Clock))
+	for id, clock := range vcm.Clock {
+		snapshot[id] = append([]int(nil), clock...)
+		for i := range clock {
+			clock[i] = 0
+		}
+	}
+	vcm.publishAllLocked()
+	for _, clock := range vcm.channels {
+		for i := range clock {
+			clock[i] = 0
+		}
+	}
+
+	vcm.epochs = append(vcm.epochs, epochInfo{
+		base:     make([]int, len(vcm.Clock)),
+		reset:    true,
+		snapshot: snapshot,
+	})
+	vcm.epoch++
+	vcm.markEpochLocked()
+	return vcm.epoch, snapshot
+}
+
+// EpochSnapshot ResetEpoch 로 시작된 에포크의 재설정 직전 스냅샷 반환 (없으면 nil)
+func (vcm *VectorClockManager) EpochSnapshot(epoch int) map[int][]int {
+	vcm.rlockManager()
+	defer vcm.Mu.RUnlock()
+
+	if epoch < 0 || epoch >= len(vcm.epochs) || !vcm.epochs[epoch].reset {
+		return nil
+	}
+	snapshot := make(map[int][]int, len(vcm.epochs[epoch].snapshot))
+	for id, clock := range vcm.epochs[epoch].snapshot {
+		snapshot[id] = append([]int(nil), clock...)
+	}
+	return snapshot
+}
+
+// MergeClock 에포크를 확인한 뒤 특정 프로세스의 Vector Clock 에 수신 시계를 병합
+//
+// 체크포인트로 나뉜 에포크의 시계는 현재 에포크로 변환되어 병합되며,
+// 재설정(ResetEpoch)으로 나뉜 에포크의 시계는 EpochError 를 반환한다.
+func (vcm *VectorClockManager) MergeClock(processID, epoch int, receivedClock []int) error {
+	return vcm.mergeClock(processID, epoch, receivedClock, -1, "")
+}
+
+// mergeClock MergeClock 과 같지만 감사 기록에 병합한 메시지(송신자 from, ID messageID)를 남김
+//
+// 변환한 에포크가 병합 시점의 현재 에포크가 아니면(그 사이 Checkpoint / ResetEpoch) 다시 변환한다.
+func (vcm *VectorClockManager) mergeClock(processID, epoch int, receivedClock []int, from int, messageID string) error {
+	for {
+		current := vcm.CurrentEpoch()
+		vector, err := vcm.Translate(receivedClock, epoch, current)
+		if err != nil {
+			return err
+		}
+		if vcm.updateClockIn(processID, current, vector, from, messageID) {
+			return nil
+		}
+	}
+}
+
+// EpochBase 에포크의 누적 기준 시계 반환 (해당 에포크의 시계 + 기준 시계 = 절대 시계)
+func (vcm *VectorClockManager) EpochBase(epoch int) []int {
+	vcm.rlockManager()
+	defer vcm.Mu.RUnlock()
+	return append([]int(nil), vcm.epochBaseLocked(epoch)...)
+}
+
+// Absolute 에포크 시계를 마지막 재설정 이후 첫 에포크 기준 절대 시계로 변환
+func (vcm *VectorClockManager) Absolute(epoch int, clock []int) []int {
+	vcm.rlockManager()
+	defer vcm.Mu.RUnlock()
+
+	base := vcm.epochBaseLocked(epoch)
+	abs := make([]int, len(clock))
+	for i, v := range clock {
+		abs[i] = v
+		if i < len(base) {
+			abs[i] += base[i]
+		}
+	}
+	return abs
+}
+
+// Translate from 에포크의 시계를 to 에포크 기준으로 변환
+//
+// 기준 시계보다 작은 원소(체크포인트 이전 이벤트)는 0 으로 잘라낸다.
+// 두 에포크 사이에 재설정이 있었다면 EpochError 를 반환한다.
+func (vcm *VectorClockManager) Translate(clock []int, from, to int) ([]int, error) {
+	vcm.rlockManager()
+	defer vcm.Mu.RUnlock()
+	return vcm.translateLocked(clock, from, to)
+}
+
+// CompareEpochs 서로 다른 에포크의 두 시계 비교 (재설정으로 나뉜 에포크는 EpochError)
+func (vcm *VectorClockManager) CompareEpochs(epochA int, a []int, epochB int, b []int) (Ordering, error) {
+	if !vcm.comparable(epochA, epochB) {
+		return Concurrent, &EpochError{From: epochA, To: epochB}
+	}
+	return Compare(vcm.Absolute(epochA, a), vcm.Absolute(epochB, b)), nil
+}
+
+// comparable 두 에포크 사이에 재설정이 없는지 여부
+func (vcm *VectorClockManager) comparable(a, b int) bool {
+	vcm.rlockManager()
+	defer vcm.Mu.RUnlock()
+	return vcm.comparableLocked(a, b)
+}
+
+// comparableLocked comparable 구현 (vcm.Mu 읽기 잠금 이상 보유 상태에서 호출)
+func (vcm *VectorClockManager) comparableLocked(a, b int) bool {
+	if a > b {
+		a, b = b, a
+	}
+	for e := a + 1; e <= b; e++ {
+		if e >= len(vcm.epochs) || vcm.epochs[e].reset {
+			return false
+		}
+	}
+	return true
+}
+
+// translateLocked Translate 구현 (vcm.Mu 읽기 잠금 이상 보유 상태에서 호출)
+func (vcm *VectorClockManager) translateLocked(clock []int, from, to int) ([]int, error) {
+	if from == to {
+		return clock, nil
+	}
+	if from > to || !vcm.comparableLocked(from, to) {
+		return nil, &EpochError{From: from, To: to}
+	}
+	if clock == nil {
+		return nil, nil
+	}
+	fromBase := vcm.epochBaseLocked(from)
+	toBase := vcm.epochBaseLocked(to)
+	out := make([]int, len(clock))
+	for i, v := range clock {
+		if i < len(fromBase) && i < len(toBase) {
+			v += fromBase[i] - toBase[i]
+		}
+		if v < 0 {
+			v = 0
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+// epochBaseLocked 에포크 누적 기준 시계 (vcm.Mu 읽기 잠금 이상 보유 상태에서 호출)
+func (vcm *VectorClockManager) epochBaseLocked(epoch int) []int {
+	if epoch < 0 {
+		epoch = 0
+	}
+	if epoch >= len(vcm.epochs) {
+		epoch = len(vcm.epochs) - 1
+	}
+	return vcm.epochs[epoch].base
+}
+
+// advance 로컬 이벤트로 시계를 증가시키고 게시된 스냅샷(읽기 전용)과 에포크를 함께 반환
+func (vcm *VectorClockManager) advance(processID int) ([]int, int) {
+	vcm.lockClock(processID)
+	defer vcm.unlockClock(processID)
+
+	old := vcm.auditBefore(vcm.Clock[processID])
+	vcm.Clock[processID][processID]++
+	vcm.auditClockLocked(processID, AuditLocal, -1, "", old)
+	return vcm.publishLocked(processID), vcm.epoch
+}
+
+// advanceChannel 채널 시계를 증가시키고 복사본과 에포크를 함께 반환
+func (vcm *VectorClockManager) advanceChannel(owner, peer int) ([]int, int) {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	clock := vcm.channelClockLocked(ChannelKey{Owner: owner, Peer: peer})
+	old := vcm.auditBefore(clock)
+	clock[owner]++
+	vcm.auditLocked(AuditRecord{Process: owner, Peer: peer, Cause: AuditLocal, From: -1, Old: old}, clock)
+	return append([]int(nil), clock...), vcm.epoch
+}
+
+// subtractBase 시계에서 기준 시계를 뺌 (0 미만은 0)
+func subtractBase(clock, base []int) {
+	for i := 0; i < len(clock) && i < len(base); i++ {
+		clock[i] -= base[i]
+		if clock[i] < 0 {
+			clock[i] = 0
+		}
+	}
+}
+
+// currentVector 메시지 시계를 현재 에포크 기준으로 변환 (변환한 에포크 함께 반환)
+func (p *Process) currentVector(msg Message) (vector []int, epoch int, err error) {
+	vcm := p.ClockMgr
+	vcm.rlockManager()
+	defer vcm.Mu.RUnlock()
+	if msg.Epoch == vcm.epoch {
+		return msg.Vector, vcm.epoch, nil
+	}
+	vector, err = vcm.translateLocked(msg.Vector, msg.Epoch, vcm.epoch)
+	return vector, vcm.epoch, err
+}
+
+// syncCausalEpoch 보관 중인 SES 목적지 벡터를 현재 에포크 기준으로 변환 (p.Mu 보유 상태에서 호출)
+func (p *Process) syncCausalEpoch() {
+	vcm := p.ClockMgr
+	vcm.rlockManager()
+	defer vcm.Mu.RUnlock()
+
+	if p.causal.epoch == vcm.epoch {
+		return
+	}
+	for dest, v := range p.causal.destClocks {
+		translated, err := vcm.translateLocked(v, p.causal.epoch, vcm.epoch)
+		if err != nil {
+			// 재설정 이전에 보낸 메시지는 더 이상 전달 순서를 제약하지 않음
+			delete(p.causal.destClocks, dest)
+			continue
+		}
+		p.causal.destClocks[dest] = translated
+	}
+	if !vcm.comparableLocked(p.causal.epoch, vcm.epoch) {
+		for i := range p.causal.delivered {
+			p.causal.delivered[i] = 0
+		}
+		p.causal.topics = nil
+		p.causal.buffer = nil
+	}
+	p.causal.epoch = vcm.epoch
+}
@@ -0,0 +1,238 @@
+package process
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReceiveRejectsMessageFromBeforeReset(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	sender := NewProcess(0, vcm)
+	receiver := NewProcess(1, vcm)
+	sender.SendMessage(1, "old", receiver.MessageCh, false)
+	vcm.ResetEpoch()
+
+	if err := receiver.ReceiveMessages(receiver.MessageCh); !errors.Is(err, ErrEpochMismatch) {
+		t.Fatalf("receive across reset: got %v, want ErrEpochMismatch", err)
+	}
+	if got := vcm.GetClock(1); !reflect.DeepEqual(got, []int{0, 0}) {
+		t.Fatalf("receiver clock = %v, want the old vector discarded", got)
+	}
+}
+
+// testCausalAcrossCheckpoint 체크포인트 이전에 보낸 메시지가 늦게 도착해도 인과 순서대로 전달되는지 확인
+func testCausalAcrossCheckpoint(t *testing.T, delivery DeliveryAlgorithm) {
+	vcm, procs, targets := newCausalGroup(3, delivery)
+	p0, p1, p2 := procs[0], procs[1], procs[2]
+
+	// 모두가 p0 의 첫 브로드캐스트를 알게 해서 체크포인트 기준이 0 이 아니게 함
+	p0.Broadcast("warmup", targets(0))
+	p1.DeliverCausal(p1.MessageCh)
+	p2.DeliverCausal(p2.MessageCh)
+
+	hold := make(chan Message, 1)
+	t0 := targets(0)
+	t0[2] = hold
+	p0.Broadcast("m1", t0)
+	if got := p1.DeliverCausal(p1.MessageCh); len(got) != 1 || got[0].Event != "m1" {
+		t.Fatalf("p1 delivered %v, want m1", got)
+	}
+	if _, base := vcm.Checkpoint(); base[0] == 0 {
+		t.Fatalf("checkpoint base = %v, want p0 entry removed", base)
+	}
+	p1.Broadcast("m2", targets(1))
+	if got := p2.DeliverCausal(p2.MessageCh); len(got) != 0 {
+		t.Fatalf("p2 delivered %v before its causal predecessor", got)
+	}
+	p2.MessageCh <- <-hold
+	got := p2.DeliverCausal(p2.MessageCh)
+	if len(got) != 2 || got[0].Event != "m1" || got[1].Event != "m2" {
+		t.Fatalf("p2 delivered %v, want [m1 m2]", got)
+	}
+	if m1, m2 := got[0], got[1]; m1.Epoch != 0 || m2.Epoch != 1 {
+		t.Fatalf("epochs = %d, %d, want 0, 1", m1.Epoch, m2.Epoch)
+	}
+}
+
+func TestBSSDeliversAcrossCheckpoint(t *testing.T) {
+	testCausalAcrossCheckpoint(t, DeliveryBSS)
+}
+
+func TestSESDeliversAcrossCheckpoint(t *testing.T) {
+	testCausalAcrossCheckpoint(t, DeliverySES)
+}
+
+func TestResetEpochRejectsOldEpochMerges(t *testing.T) {
+	for trial := 0; trial < 100; trial++ {
+		vcm := NewVectorClockManager(2, WithLogger(nil))
+		receiver := NewProcess(0, vcm, WithMailboxSize(64))
+		sender := NewProcess(1, vcm)
+		for i := 0; i < 1000; i++ {
+			sender.LocalEvent("warmup")
+		}
+		const messages = 40
+		for i := 0; i < messages; i++ {
+			if err := sender.SendMessage(0, "m", receiver.MessageCh, false); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < messages; i++ {
+				err := receiver.ReceiveMessages(receiver.MessageCh)
+				if err != nil && !errors.Is(err, ErrEpochMismatch) {
+					t.Error(err)
+				}
+			}
+		}()
+		vcm.ResetEpoch()
+		wg.Wait()
+
+		// 재설정 뒤 송신자는 아무것도 보내지 않았으므로 이전 에포크 시계가 병합되었다면 송신자 항목이 남음
+		if got := vcm.GetClockCopy(0)[1]; got != 0 {
+			t.Fatalf("trial %d: receiver knows %d sender events after reset, want 0", trial, got)
+		}
+	}
+}
+
+func TestMergeClockTranslatesCheckpointEpochs(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	vcm.UpdateClock(0, nil)
+	vcm.UpdateClock(1, nil)
+	vcm.UpdateClock(1, nil)
+	old := vcm.GetClockCopy(1)
+	vcm.Checkpoint() // 기준 [1 0]... 원소별 최소값
+
+	if err := vcm.MergeClock(0, 0, old); err != nil {
+		t.Fatal(err)
+	}
+	want, err := vcm.Translate(old, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := vcm.GetClockCopy(0)[1]; got != want[1] {
+		t.Fatalf("merged sender entry = %d, want translated %d", got, want[1])
+	}
+
+	vcm.ResetEpoch()
+	if err := vcm.MergeClock(0, 0, old); !errors.Is(err, ErrEpochMismatch) {
+		t.Fatalf("merge across reset: got %v, want ErrEpochMismatch", err)
+	}
+}
+
+func TestCheckpointKeepsAbsoluteClocks(t *testing.T) {
+	vcm := NewVectorClockManager(3, WithLogger(nil))
+	for id := 0; id < 3; id++ {
+		for i := 0; i <= id; i++ {
+			vcm.UpdateClock(id, nil)
+		}
+	}
+	vcm.UpdateClock(2, vcm.GetClockCopy(0)) // 2 는 0 의 이벤트를 앎
+	before := make([][]int, 3)
+	for id := range before {
+		before[id] = vcm.GetClockCopy(id)
+	}
+
+	epoch, base := vcm.Checkpoint()
+	if epoch != 1 || vcm.CurrentEpoch() != 1 {
+		t.Fatalf("epoch = %d (current %d), want 1", epoch, vcm.CurrentEpoch())
+	}
+	// 원소별 최소값: 모든 프로세스가 아는 이벤트가 아직 없음
+	if want := []int{0, 0, 0}; !reflect.DeepEqual(base, want) {
+		t.Fatalf("base = %v, want %v", base, want)
+	}
+	for id := range before {
+		if got := vcm.Absolute(epoch, vcm.GetClockCopy(id)); !reflect.DeepEqual(got, before[id]) {
+			t.Fatalf("process %d absolute clock = %v, want %v", id, got, before[id])
+		}
+	}
+
+	// 모두가 아는 이벤트가 생긴 뒤의 체크포인트는 그만큼 카운터를 줄임
+	for id := 0; id < 3; id++ {
+		vcm.UpdateClock(id, vcm.GetClockCopy(2))
+	}
+	old := vcm.GetClockCopy(1)
+	epoch, base = vcm.Checkpoint()
+	if base[0] == 0 || base[2] == 0 {
+		t.Fatalf("second base = %v, want shared entries removed", base)
+	}
+	if got := vcm.EpochBase(epoch); !reflect.DeepEqual(got, base) {
+		t.Fatalf("EpochBase = %v, want cumulative %v", got, base)
+	}
+	vcm.UpdateClock(1, nil)
+	order, err := vcm.CompareEpochs(epoch-1, old, epoch, vcm.GetClockCopy(1))
+	if err != nil || order != Before {
+		t.Fatalf("CompareEpochs = %v, %v, want Before", order, err)
+	}
+	if _, err := vcm.Translate(vcm.GetClockCopy(1), epoch, epoch-1); !errors.Is(err, ErrEpochMismatch) {
+		t.Fatalf("translate to an earlier epoch: got %v, want ErrEpochMismatch", err)
+	}
+}
+
+func TestResetEpochSnapshot(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	vcm.UpdateClock(0, nil)
+	vcm.UpdateClock(1, vcm.GetClockCopy(0))
+	want := map[int][]int{0: vcm.GetClockCopy(0), 1: vcm.GetClockCopy(1)}
+
+	epoch, snapshot := vcm.ResetEpoch()
+	if !reflect.DeepEqual(snapshot, want) {
+		t.Fatalf("snapshot = %v, want %v", snapshot, want)
+	}
+	if got := vcm.EpochSnapshot(epoch); !reflect.DeepEqual(got, want) {
+		t.Fatalf("EpochSnapshot = %v, want %v", got, want)
+	}
+	if vcm.EpochSnapshot(0) != nil {
+		t.Fatal("epoch 0 has a snapshot")
+	}
+	for id := 0; id < 2; id++ {
+		if got := vcm.GetClockCopy(id); !reflect.DeepEqual(got, []int{0, 0}) {
+			t.Fatalf("process %d clock after reset = %v, want zeros", id, got)
+		}
+	}
+	if _, err := vcm.CompareEpochs(0, want[0], epoch, vcm.GetClockCopy(0)); !errors.Is(err, ErrEpochMismatch) {
+		t.Fatalf("compare across reset: got %v, want ErrEpochMismatch", err)
+	}
+}
+
+func TestCheckpointWhileRecordingEvents(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	vcm := NewVectorClockManager(4, WithLogger(nil))
+	procs := []*Process{NewProcess(0, vcm), NewProcess(1, vcm), NewProcess(2, vcm)}
+
+	// 이벤트 기록이 시각을 읽는 동안 Checkpoint 가 에포크 경계를 기록
+	var wg sync.WaitGroup
+	for _, p := range procs {
+		wg.Add(1)
+		go func(p *Process) {
+			defer wg.Done()
+			for i := 0; i < 3000; i++ {
+				p.LocalEvent("e")
+			}
+		}(p)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 3000; i++ {
+			vcm.Checkpoint()
+		}
+	}()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("event recording deadlocked with Checkpoint")
+	}
+}
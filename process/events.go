@@ -0,0 +1,198 @@
+package process
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EventKind 이벤트 종류
+type EventKind int
+
+const (
+	// EventLocal 내부 계산 이벤트
+	EventLocal EventKind = iota
+	// EventSend 송신 이벤트
+	EventSend
+	// EventReceive 수신 이벤트
+	EventReceive
+	// EventDrop 송신했지만 전달하지 못한 메시지 (dead-letter, 시계는 송신처럼 증가)
+	EventDrop
+)
+
+// String 종류 이름 반환
+func (k EventKind) String() string {
+	switch k {
+	case EventLocal:
+		return "local"
+	case EventSend:
+		return "send"
+	case EventReceive:
+		return "receive"
+	case EventDrop:
+		return "drop"
+	default:
+		return fmt.Sprintf("EventKind(%d)", int(k))
+	}
+}
+
+// ParseEventKind 종류 이름(String 의 결과)을 EventKind 로 (알 수 없으면 false)
+func ParseEventKind(name string) (EventKind, bool) {
+	for k := EventLocal; k <= EventDrop; k++ {
+		if k.String() == name {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// Event 프로세스에서 일어난 이벤트 기록
+type Event struct {
+	Seq       int64     // 매니저 전체에서의 기록 순서 (1 부터)
+	Kind      EventKind // 이벤트 종류
+	Process   int       // 이벤트가 일어난 프로세스 ID
+	Name      string    // 로컬 이벤트 이름 또는 메시지 내용
+	MessageID string    // 송신/수신 메시지 ID
+	From      int       // 송신/수신 메시지를 보낸 프로세스 ID
+	To        int       // 송신/수신 메시지를 받는 프로세스 ID
+	Domain    string    // 시계 도메인 ("" 이면 기본 시계)
+	Clock     []int     // 이벤트 직후의 Vector Clock (읽기 전용)
+	Timestamp int64     // 송신/수신 메시지의 Timestamp (송신자의 벽시계)
+	Time      time.Time // 기록 시각
+}
+
+// eventLog 매니저 이벤트 기록
+type eventLog struct {
+	mu         sync.Mutex
+	seq        int64
+	events     []Event
+	boundaries []int64 // 에포크 e 가 시작될 때의 seq (boundaries[e-1], Compact)
+}
+
+// Events 기록된 모든 이벤트 (기록 순서)
+func (vcm *VectorClockManager) Events() []Event {
+	vcm.log.mu.Lock()
+	defer vcm.log.mu.Unlock()
+	return append([]Event(nil), vcm.log.events...)
+}
+
+// EventsOf 특정 프로세스에서 일어난 이벤트 (기록 순서)
+func (vcm *VectorClockManager) EventsOf(processID int) []Event {
+	vcm.log.mu.Lock()
+	defer vcm.log.mu.Unlock()
+
+	var events []Event
+	for _, e := range vcm.log.events {
+		if e.Process == processID {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// record 이벤트 기록
+func (vcm *VectorClockManager) record(e Event) Event {
+	// 가상 시각은 vcm.Mu 를 잡으므로 기록 잠금 전에 읽음 (Checkpoint 는 vcm.Mu 다음에 기록 잠금을 잡음)
+	if e.Time.IsZero() {
+		e.Time = vcm.now()
+	}
+	vcm.log.mu.Lock()
+	defer vcm.log.mu.Unlock()
+
+	vcm.log.seq++
+	e.Seq = vcm.log.seq
+	vcm.log.events = append(vcm.log.events, e)
+	vcm.countEvent(e)
+	return e
+}
+
+// recordSend 송신 이벤트 기록
+func (p *Process) recordSend(msg Message) {
+	p.touch()
+	p.ClockMgr.record(Event{
+		Kind:      EventSend,
+		Process:   p.ID,
+		Name:      msg.Event,
+		MessageID: msg.MessageID,
+		From:      msg.From,
+		To:        msg.To,
+		Domain:    msg.Domain,
+		Clock:     append([]int(nil), msg.Vector...),
+		Timestamp: msg.Timestamp,
+	})
+}
+
+// recordDrop 전달하지 못한 송신 이벤트 기록
+func (p *Process) recordDrop(msg Message) {
+	p.touch()
+	p.ClockMgr.record(Event{
+		Kind:      EventDrop,
+		Process:   p.ID,
+		Name:      msg.Event,
+		MessageID: msg.MessageID,
+		From:      msg.From,
+		To:        msg.To,
+		Domain:    msg.Domain,
+		Clock:     append([]int(nil), msg.Vector...),
+		Timestamp: msg.Timestamp,
+	})
+}
+
+// recordReceive 수신 이벤트 기록 (병합 이후의 시계)
+func (p *Process) recordReceive(msg Message) {
+	p.touch()
+	var clock []int
+	if msg.Domain != "" {
+		clock, _ = p.ClockMgr.GetDomainClock(msg.Domain, p.ID)
+	} else {
+		clock = p.clockFor(msg.From)
+	}
+	p.ClockMgr.record(Event{
+		Kind:      EventReceive,
+		Process:   p.ID,
+		Name:      msg.Event,
+		MessageID: msg.MessageID,
+		From:      msg.From,
+		To:        msg.To,
+		Domain:    msg.Domain,
+		Clock:     clock,
+		Timestamp: msg.Timestamp,
+	})
+}
+
+// LocalEvent 이름 있는 내부 이벤트 (로컬 시계 1 증가 후 기록)
+func (p *Process) LocalEvent(name string) Event {
+	clock, _ := p.ClockMgr.advance(p.ID)
+	p.touch()
+	e := p.ClockMgr.record(Event{Kind: EventLocal, Process: p.ID, Name: name, From: p.ID, To: p.ID, Clock: clock})
+	p.logf("Process %d: Local event %q, Vector: %v\n", p.ID, name, clock)
+	return e
+}
+
+// ScheduleLocalEvent interval 마다 이름 있는 내부 이벤트 발생 (반환된 함수로 중지)
+//
+// 매니저의 스케줄러로 구동되므로 가상 시간 스케줄러에서는 Advance 로 시간이 흐를 때만 발생한다.
+func (p *Process) ScheduleLocalEvent(interval time.Duration, name string) (stop func()) {
+	var mu sync.Mutex
+	stopped := false
+	sched := p.ClockMgr.Scheduler()
+
+	var tick func()
+	tick = func() {
+		mu.Lock()
+		done := stopped
+		mu.Unlock()
+		if done {
+			return
+		}
+		p.LocalEvent(name)
+		sched.After(interval, tick)
+	}
+	sched.After(interval, tick)
+
+	return func() {
+		mu.Lock()
+		stopped = true
+		mu.Unlock()
+	}
+}
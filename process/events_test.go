@@ -0,0 +1,68 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEventLogRecordsSendReceiveAndLocal(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+
+	a.LocalEvent("start")
+	if err := a.Send(1, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+
+	events := vcm.Events()
+	var kinds []EventKind
+	for i, e := range events {
+		if e.Seq != int64(i+1) {
+			t.Fatalf("event %d has Seq %d", i, e.Seq)
+		}
+		kinds = append(kinds, e.Kind)
+	}
+	if want := []EventKind{EventLocal, EventSend, EventReceive}; !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("kinds = %v, want %v", kinds, want)
+	}
+	if send, recv := events[1], events[2]; send.MessageID != recv.MessageID || !reflect.DeepEqual(recv.Clock, []int{2, 1}) {
+		t.Fatalf("send %+v / receive %+v", send, recv)
+	}
+	if got := vcm.EventsOf(1); len(got) != 1 || got[0].Kind != EventReceive {
+		t.Fatalf("EventsOf(1) = %+v", got)
+	}
+}
+
+func TestScheduledLocalEventsStop(t *testing.T) {
+	s := NewVirtualScheduler()
+	vcm := NewVectorClockManager(1, WithScheduler(s))
+	p := NewProcess(0, vcm)
+
+	stop := p.ScheduleLocalEvent(10*time.Millisecond, "tick")
+	s.Advance(35 * time.Millisecond)
+	stop()
+	s.Advance(time.Second)
+
+	if got := len(vcm.EventsOf(0)); got != 3 {
+		t.Fatalf("recorded %d ticks, want 3", got)
+	}
+	if got := vcm.GetClock(0); got[0] != 3 {
+		t.Fatalf("clock = %v, want [3]", got)
+	}
+}
+
+func TestParseEventKind(t *testing.T) {
+	for k := EventLocal; k <= EventDrop; k++ {
+		if got, ok := ParseEventKind(k.String()); !ok || got != k {
+			t.Fatalf("ParseEventKind(%q) = %v, %v", k.String(), got, ok)
+		}
+	}
+	if _, ok := ParseEventKind("teleport"); ok {
+		t.Fatal("ParseEventKind accepted an unknown kind")
+	}
+}
@@ -0,0 +1,244 @@
+package process
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultPhiThreshold 실패 의심 기본 임계값 (phi 8 은 약 1e-8 확률로 오탐)
+const DefaultPhiThreshold = 8.0
+
+// maxHeartbeatSamples 상대별로 유지하는 하트비트 도착 간격 표본 수
+const maxHeartbeatSamples = 100
+
+// FailureEvent 실패 의심 상태 변화
+type FailureEvent struct {
+	Process   int           // 상태가 바뀐 프로세스 ID
+	Phi       float64       // 상태가 바뀐 시점의 의심 수준
+	Suspected bool          // true 면 실패 의심, false 면 회복 (다시 하트비트를 받음)
+	At        time.Duration // 상태가 바뀐 시각 (스케줄러 기준)
+}
+
+// String 이벤트 요약
+func (e FailureEvent) String() string {
+	state := "recovered"
+	if e.Suspected {
+		state = "suspected"
+	}
+	return fmt.Sprintf("process %d %s (phi=%.2f) at %v", e.Process, state, e.Phi, e.At)
+}
+
+// Suspicion 프로세스별 의심 수준
+type Suspicion struct {
+	Process int     // 프로세스 ID
+	Phi     float64 // 의심 수준
+}
+
+// FailureDetector 하트비트 도착 간격에 기반한 phi-accrual 실패 감지기
+//
+// 상대별로 최근 하트비트 도착 간격의 평균/표준편차를 유지하고, 마지막 하트비트 이후 경과 시간이
+// 그 분포에서 나올 확률 P 로부터 phi = -log10(P) 를 계산한다. phi 가 임계값을 넘으면 실패를 의심하고,
+// 다시 하트비트를 받으면 회복으로 본다. 상태 변화는 Watch 로 등록한 함수에 전달된다.
+type FailureDetector struct {
+	p         *Process
+	threshold float64
+
+	mu        sync.Mutex
+	peers     map[int]*arrivalWindow
+	suspected map[int]bool
+	watchers  []func(FailureEvent)
+	stopped   bool
+}
+
+// arrivalWindow 하트비트 도착 기록
+type arrivalWindow struct {
+	last      time.Duration   // 마지막 도착 시각
+	intervals []time.Duration // 최근 도착 간격
+}
+
+// NewFailureDetector p 가 받는 하트비트로 상대의 실패를 감지하는 감지기 생성
+//
+// threshold 가 0 이하면 DefaultPhiThreshold 를 쓰며, checkInterval 마다 (스케줄러 기준) 의심 수준을 검사한다.
+func NewFailureDetector(p *Process, threshold float64, checkInterval time.Duration) *FailureDetector {
+	if threshold <= 0 {
+		threshold = DefaultPhiThreshold
+	}
+	fd := &FailureDetector{
+		p:         p,
+		threshold: threshold,
+		peers:     make(map[int]*arrivalWindow),
+		suspected: make(map[int]bool),
+	}
+	p.onHeartbeat(fd.heartbeat)
+
+	sched := p.ClockMgr.Scheduler()
+	var check func()
+	check = func() {
+		if fd.isStopped() {
+			return
+		}
+		fd.check()
+		sched.After(checkInterval, check)
+	}
+	sched.After(checkInterval, check)
+	return fd
+}
+
+// Watch 실패 의심/회복 알림 함수 등록
+func (fd *FailureDetector) Watch(fn func(FailureEvent)) {
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+	fd.watchers = append(fd.watchers, fn)
+}
+
+// Stop 주기 검사 중지
+func (fd *FailureDetector) Stop() {
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+	fd.stopped = true
+}
+
+// isStopped 중지 여부
+func (fd *FailureDetector) isStopped() bool {
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+	return fd.stopped
+}
+
+// Phi 현재 시각 기준 상대의 의심 수준 (도착 간격 표본이 없으면 0)
+func (fd *FailureDetector) Phi(processID int) float64 {
+	now := fd.p.ClockMgr.Scheduler().Now()
+
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+	return fd.phiLocked(processID, now)
+}
+
+// Suspected 현재 실패가 의심되는 프로세스 (ID 정렬)
+func (fd *FailureDetector) Suspected() []Suspicion {
+	now := fd.p.ClockMgr.Scheduler().Now()
+
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+
+	var result []Suspicion
+	for id := range fd.suspected {
+		result = append(result, Suspicion{Process: id, Phi: fd.phiLocked(id, now)})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Process < result[j].Process })
+	return result
+}
+
+// IsSuspected 상대의 실패 의심 여부
+func (fd *FailureDetector) IsSuspected(processID int) bool {
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+	return fd.suspected[processID]
+}
+
+// heartbeat 하트비트 도착 기록 (의심 중이던 상대면 회복 알림)
+func (fd *FailureDetector) heartbeat(hb Heartbeat) {
+	fd.mu.Lock()
+	w, ok := fd.peers[hb.From]
+	if !ok {
+		w = &arrivalWindow{}
+		fd.peers[hb.From] = w
+	} else {
+		w.intervals = append(w.intervals, hb.At-w.last)
+		if len(w.intervals) > maxHeartbeatSamples {
+			w.intervals = w.intervals[1:]
+		}
+	}
+	w.last = hb.At
+
+	var events []FailureEvent
+	if fd.suspected[hb.From] {
+		delete(fd.suspected, hb.From)
+		events = append(events, FailureEvent{Process: hb.From, Phi: fd.phiLocked(hb.From, hb.At), At: hb.At})
+	}
+	watchers := fd.watchersLocked()
+	fd.mu.Unlock()
+
+	fd.notify(watchers, events)
+}
+
+// check 모든 상대의 의심 수준 검사
+func (fd *FailureDetector) check() {
+	now := fd.p.ClockMgr.Scheduler().Now()
+
+	fd.mu.Lock()
+	ids := make([]int, 0, len(fd.peers))
+	for id := range fd.peers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var events []FailureEvent
+	for _, id := range ids {
+		phi := fd.phiLocked(id, now)
+		if phi >= fd.threshold && !fd.suspected[id] {
+			fd.suspected[id] = true
+			events = append(events, FailureEvent{Process: id, Phi: phi, Suspected: true, At: now})
+		}
+	}
+	watchers := fd.watchersLocked()
+	fd.mu.Unlock()
+
+	fd.notify(watchers, events)
+}
+
+// watchersLocked 알림 함수 복사본 (fd.mu 보유 상태에서 호출)
+func (fd *FailureDetector) watchersLocked() []func(FailureEvent) {
+	watchers := make([]func(FailureEvent), len(fd.watchers))
+	copy(watchers, fd.watchers)
+	return watchers
+}
+
+// notify 상태 변화 출력 및 알림
+func (fd *FailureDetector) notify(watchers []func(FailureEvent), events []FailureEvent) {
+	for _, e := range events {
+		fd.p.logf("Process %d: Failure detector: %v\n", fd.p.ID, e)
+		for _, fn := range watchers {
+			fn(e)
+		}
+	}
+}
+
+// phiLocked 의심 수준 계산 (fd.mu 보유 상태에서 호출)
+func (fd *FailureDetector) phiLocked(processID int, now time.Duration) float64 {
+	w, ok := fd.peers[processID]
+	if !ok || len(w.intervals) == 0 {
+		return 0
+	}
+
+	var mean float64
+	for _, d := range w.intervals {
+		mean += float64(d)
+	}
+	mean /= float64(len(w.intervals))
+
+	var variance float64
+	for _, d := range w.intervals {
+		variance += (float64(d) - mean) * (float64(d) - mean)
+	}
+	variance /= float64(len(w.intervals))
+
+	// 간격이 일정하면 표준편차가 0 이 되므로 평균의 10% 를 하한으로 둠
+	stddev := math.Max(math.Sqrt(variance), mean/10)
+	if stddev == 0 {
+		return 0
+	}
+
+	elapsed := float64(now - w.last)
+	later := 0.5 * math.Erfc((elapsed-mean)/(stddev*math.Sqrt2))
+	switch {
+	case later <= 0:
+		return math.Inf(1)
+	case later >= 1:
+		return 0
+	}
+	return -math.Log10(later)
+}
@@ -0,0 +1,48 @@
+package process
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFailureDetectorSuspectsSilentPeerAndRecovers(t *testing.T) {
+	s := NewVirtualScheduler()
+	vcm := NewVectorClockManager(2, WithScheduler(s))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+
+	fd := NewFailureDetector(b, 0, 10*time.Millisecond)
+	defer fd.Stop()
+	var events []FailureEvent
+	fd.Watch(func(e FailureEvent) { events = append(events, e) })
+
+	stop := a.StartHeartbeat(10*time.Millisecond, 1)
+	s.Advance(200 * time.Millisecond)
+	if fd.IsSuspected(0) || len(events) != 0 {
+		t.Fatalf("suspected a peer that keeps sending heartbeats: %v", events)
+	}
+	stop()
+	s.Advance(200 * time.Millisecond)
+	if !fd.IsSuspected(0) || len(events) != 1 || !events[0].Suspected {
+		t.Fatalf("silent peer not suspected: %v", events)
+	}
+	if got := fd.Suspected(); len(got) != 1 || got[0].Process != 0 || got[0].Phi < DefaultPhiThreshold {
+		t.Fatalf("Suspected() = %+v", got)
+	}
+
+	a.StartHeartbeat(10*time.Millisecond, 1)
+	s.Advance(10 * time.Millisecond)
+	if fd.IsSuspected(0) || len(events) != 2 || events[1].Suspected {
+		t.Fatalf("peer not recovered after a heartbeat: %v", events)
+	}
+}
+
+func TestPhiIsZeroWithoutSamples(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithScheduler(NewVirtualScheduler()))
+	b := NewProcess(1, vcm)
+	fd := NewFailureDetector(b, 0, time.Second)
+	defer fd.Stop()
+	if phi := fd.Phi(0); phi != 0 {
+		t.Fatalf("Phi of unknown peer = %v, want 0", phi)
+	}
+}
@@ -0,0 +1,129 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrClockSyntax 정규 시계 표기로 읽을 수 없는 문자열
+var ErrClockSyntax = errors.New("process: invalid clock syntax")
+
+// maxParsedClock ParseClock 이 받아들이는 최대 프로세스 수 (큰 ID 하나로 거대한 시계를 만들지 않도록)
+const maxParsedClock = 1 << 20
+
+// Clock 프로세스 ID 순서의 Vector Clock ([]int 와 서로 대입할 수 있음)
+type Clock []int
+
+// String 정규 표기 반환 (FormatClock)
+func (c Clock) String() string {
+	return FormatClock(c)
+}
+
+// FormatClock Vector Clock 을 정규 표기로 변환 (예: {P0:3,P1:1,P2:0})
+//
+// 모든 항목을 프로세스 ID 순서로 공백 없이 쓰므로, 같은 시계는 항상 같은 문자열이 되어
+// 로그에서 grep 하거나 다른 도구에 그대로 붙여 넣을 수 있다.
+func FormatClock(clock []int) string {
+	var b strings.Builder
+	b.Grow(2 + len(clock)*6)
+	b.WriteByte('{')
+	for i, c := range clock {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('P')
+		b.WriteString(strconv.Itoa(i))
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(c))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// ParseClock 정규 표기 문자열을 Vector Clock 으로 읽음 (FormatClock 의 역)
+//
+// 항목 사이와 괄호 안쪽의 공백은 허용한다. 항목 순서는 자유롭지만 같은 프로세스가 두 번 나오거나
+// 값이 음수이면 ErrClockSyntax 를 반환하며, 빠진 프로세스 ID 의 항목은 0 이다.
+func ParseClock(s string) ([]int, error) {
+	body := strings.TrimSpace(s)
+	if len(body) < 2 || body[0] != '{' || body[len(body)-1] != '}' {
+		return nil, fmt.Errorf("%w: %q is not enclosed in braces", ErrClockSyntax, s)
+	}
+	body = strings.TrimSpace(body[1 : len(body)-1])
+	if body == "" {
+		return []int{}, nil
+	}
+
+	entries := strings.Split(body, ",")
+	values := make(map[int]int, len(entries))
+	size := 0
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || !strings.HasPrefix(name, "P") {
+			return nil, fmt.Errorf("%w: entry %q in %q is not P<id>:<count>", ErrClockSyntax, entry, s)
+		}
+		id, err := strconv.Atoi(strings.TrimSpace(name[1:]))
+		if err != nil || id < 0 || id >= maxParsedClock {
+			return nil, fmt.Errorf("%w: bad process id in entry %q of %q", ErrClockSyntax, entry, s)
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("%w: bad count in entry %q of %q", ErrClockSyntax, entry, s)
+		}
+		if _, dup := values[id]; dup {
+			return nil, fmt.Errorf("%w: process %d appears twice in %q", ErrClockSyntax, id, s)
+		}
+		values[id] = count
+		if id+1 > size {
+			size = id + 1
+		}
+	}
+
+	clock := make([]int, size)
+	for id, count := range values {
+		clock[id] = count
+	}
+	return clock, nil
+}
+
+// String 메시지 요약 (ID, 종류, 송수신자, 시계, 내용과 비어 있지 않은 선택 필드)
+//
+// 예: 1-3 event P1->P2 {P0:0,P1:3,P2:0} "hello" epoch=1 trace=t-7
+// 응답 채널, 서명, 인과 전달 벡터처럼 내부 상태에 가까운 필드는 쓰지 않는다.
+func (msg Message) String() string {
+	var b strings.Builder
+	id := msg.MessageID
+	if id == "" {
+		id = "-"
+	}
+	fmt.Fprintf(&b, "%s %v P%d->P%d %s %q", id, msg.Kind, msg.From, msg.To, FormatClock(msg.Vector), msg.Event)
+	if msg.Epoch != 0 {
+		fmt.Fprintf(&b, " epoch=%d", msg.Epoch)
+	}
+	if msg.Domain != "" {
+		fmt.Fprintf(&b, " domain=%s", msg.Domain)
+	}
+	if msg.Topic != "" {
+		fmt.Fprintf(&b, " topic=%s", msg.Topic)
+	}
+	if msg.ReplyTo != "" {
+		fmt.Fprintf(&b, " reply-to=%s", msg.ReplyTo)
+	}
+	if msg.CausalParent != "" {
+		fmt.Fprintf(&b, " parent=%s", msg.CausalParent)
+	}
+	if msg.TraceID != "" {
+		fmt.Fprintf(&b, " trace=%s", msg.TraceID)
+	}
+	return b.String()
+}
+
+// String 프로세스 이름 (예: Process 2)
+func (p *Process) String() string {
+	if p == nil {
+		return "Process <nil>"
+	}
+	return "Process " + strconv.Itoa(p.ID)
+}
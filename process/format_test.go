@@ -0,0 +1,83 @@
+package process
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFormatClock(t *testing.T) {
+	for _, tc := range []struct {
+		clock []int
+		want  string
+	}{
+		{nil, "{}"},
+		{[]int{3, 1, 0}, "{P0:3,P1:1,P2:0}"},
+		{[]int{12}, "{P0:12}"},
+	} {
+		if got := FormatClock(tc.clock); got != tc.want {
+			t.Errorf("FormatClock(%v) = %q, want %q", tc.clock, got, tc.want)
+		}
+	}
+}
+
+func TestParseClockRoundTrip(t *testing.T) {
+	for _, clock := range [][]int{{}, {0}, {3, 1, 0}, {0, 0, 7, 100000}} {
+		got, err := ParseClock(FormatClock(clock))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, clock) {
+			t.Fatalf("ParseClock(FormatClock(%v)) = %v", clock, got)
+		}
+	}
+}
+
+func TestParseClockIsLenient(t *testing.T) {
+	got, err := ParseClock(" { P2 : 5 , P0:1 } ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 0, 5}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseClock = %v, want %v", got, want)
+	}
+}
+
+func TestParseClockRejectsBadSyntax(t *testing.T) {
+	for _, s := range []string{
+		"", "P0:1", "{P0:1", "[1 2]", "{0:1}", "{P0}", "{Px:1}", "{P0:-1}", "{P-1:1}",
+		"{P0:1,P0:2}", "{P0:1,}", "{P99999999:1}",
+	} {
+		if _, err := ParseClock(s); !errors.Is(err, ErrClockSyntax) {
+			t.Errorf("ParseClock(%q) = %v, want ErrClockSyntax", s, err)
+		}
+	}
+}
+
+func TestClockString(t *testing.T) {
+	var c Clock = []int{2, 0}
+	if got := c.String(); got != "{P0:2,P1:0}" {
+		t.Fatalf("String() = %q", got)
+	}
+}
+
+func TestMessageString(t *testing.T) {
+	msg := Message{From: 1, To: 2, Vector: []int{0, 3, 0}, Event: "hello", MessageID: "1-3", Epoch: 1, TraceID: "t-7"}
+	if got, want := msg.String(), `1-3 event P1->P2 {P0:0,P1:3,P2:0} "hello" epoch=1 trace=t-7`; got != want {
+		t.Fatalf("String() = %s, want %s", got, want)
+	}
+	if got, want := (Message{Kind: KindReply, ReplyTo: "0-1"}).String(), `- reply P0->P0 {} "" reply-to=0-1`; got != want {
+		t.Fatalf("String() = %s, want %s", got, want)
+	}
+}
+
+func TestProcessString(t *testing.T) {
+	var nilProc *Process
+	if got := nilProc.String(); got != "Process <nil>" {
+		t.Fatalf("nil String() = %q", got)
+	}
+	p := NewProcess(2, NewVectorClockManager(3, WithLogger(nil)))
+	if got := p.String(); got != "Process 2" {
+		t.Fatalf("String() = %q", got)
+	}
+}
@@ -0,0 +1,100 @@
+package process
+
+import (
+	"sort"
+	"time"
+)
+
+// GlobalView 한 시점의 모든 프로세스 Vector Clock 과 원소별 최대값(전역 가상 시간)
+//
+// 한 번의 잠금으로 만든 읽기 전용 값이다. 접근자는 복사본을 반환하므로 여러 고루틴에서 공유해도 된다.
+type GlobalView struct {
+	time     time.Time
+	epoch    int
+	ids      []int
+	clocks   map[int][]int
+	supremum []int
+	stable   []int
+}
+
+// GlobalView 모든 프로세스의 시계와 그 원소별 최대값을 한 번에 읽은 뷰
+//
+// 프로세스마다 GetClock 을 부르면 그 사이에 시계가 바뀔 수 있지만, 뷰의 시계는 모두 같은 시점의 값이다.
+func (vcm *VectorClockManager) GlobalView() GlobalView {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	v := GlobalView{
+		time:   time.Now(),
+		epoch:  vcm.epoch,
+		ids:    make([]int, 0, len(vcm.Clock)),
+		clocks: make(map[int][]int, len(vcm.Clock)),
+	}
+	for id, clock := range vcm.Clock {
+		v.ids = append(v.ids, id)
+		v.clocks[id] = append([]int(nil), clock...)
+		if v.supremum == nil {
+			v.supremum = append([]int(nil), clock...)
+			v.stable = append([]int(nil), clock...)
+			continue
+		}
+		for i, c := range clock {
+			if i >= len(v.supremum) {
+				v.supremum = append(v.supremum, c)
+				v.stable = append(v.stable, 0)
+				continue
+			}
+			if c > v.supremum[i] {
+				v.supremum[i] = c
+			}
+			if c < v.stable[i] {
+				v.stable[i] = c
+			}
+		}
+	}
+	sort.Ints(v.ids)
+	return v
+}
+
+// Time 뷰를 만든 시각
+func (v GlobalView) Time() time.Time {
+	return v.time
+}
+
+// Epoch 뷰를 만든 시점의 에포크
+func (v GlobalView) Epoch() int {
+	return v.epoch
+}
+
+// Processes 뷰에 있는 프로세스 ID 목록 (오름차순)
+func (v GlobalView) Processes() []int {
+	return append([]int(nil), v.ids...)
+}
+
+// Clock 프로세스 id 의 시계 복사본 (없으면 nil)
+func (v GlobalView) Clock(id int) []int {
+	clock, ok := v.clocks[id]
+	if !ok {
+		return nil
+	}
+	return append([]int(nil), clock...)
+}
+
+// Clocks 모든 프로세스의 시계 복사본 (프로세스 ID -> 시계)
+func (v GlobalView) Clocks() map[int][]int {
+	clocks := make(map[int][]int, len(v.clocks))
+	for id, clock := range v.clocks {
+		clocks[id] = append([]int(nil), clock...)
+	}
+	return clocks
+}
+
+// Supremum 모든 시계의 원소별 최대값 (전역 가상 시간: 시스템 어딘가에서 일어난 것으로 알려진 모든 이벤트)
+func (v GlobalView) Supremum() []int {
+	return append([]int(nil), v.supremum...)
+}
+
+// Stable 모든 시계의 원소별 최소값 (StableClock 과 같은 값을 같은 시점에서)
+func (v GlobalView) Stable() []int {
+	return append([]int(nil), v.stable...)
+}
@@ -0,0 +1,48 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGlobalViewSupremumAndStable(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	vcm.UpdateClock(0, nil)
+	vcm.UpdateClock(0, nil)
+	vcm.UpdateClock(1, vcm.GetClock(0))
+	vcm.UpdateClock(2, nil)
+
+	v := vcm.GlobalView()
+	if got := v.Processes(); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Fatalf("Processes() = %v, want [0 1 2]", got)
+	}
+	if got := v.Clock(1); !reflect.DeepEqual(got, []int{2, 1, 0}) {
+		t.Fatalf("Clock(1) = %v, want [2 1 0]", got)
+	}
+	if got := v.Supremum(); !reflect.DeepEqual(got, []int{2, 1, 1}) {
+		t.Fatalf("Supremum() = %v, want [2 1 1]", got)
+	}
+	if got, want := v.Stable(), vcm.StableClock(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Stable() = %v, want StableClock %v", got, want)
+	}
+	if v.Clock(7) != nil {
+		t.Fatal("Clock of an unknown process is not nil")
+	}
+}
+
+func TestGlobalViewIsReadOnly(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	vcm.UpdateClock(0, nil)
+	v := vcm.GlobalView()
+	vcm.UpdateClock(0, nil)
+
+	v.Clock(0)[0] = 9
+	v.Clocks()[0][0] = 9
+	v.Supremum()[0] = 9
+	if got := v.Clock(0); !reflect.DeepEqual(got, []int{1, 0}) {
+		t.Fatalf("view clock = %v, want [1 0]", got)
+	}
+	if got := v.Supremum(); !reflect.DeepEqual(got, []int{1, 0}) {
+		t.Fatalf("view supremum = %v, want [1 0]", got)
+	}
+}
@@ -0,0 +1,168 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownGroup 존재하지 않는 그룹을 참조하는 경우
+var ErrUnknownGroup = errors.New("process: unknown group")
+
+// JoinGroup 프로세스들을 그룹에 추가 (그룹이 없으면 생성)
+//
+// 그룹 안에서 각 프로세스는 가입 순서대로 0 부터 시작하는 지역 인덱스를 가지며,
+// "그룹/인덱스" 형태의 이름(예: "shard-A/1")으로 참조할 수 있다.
+func (vcm *VectorClockManager) JoinGroup(group string, ids ...int) {
+	vcm.procMu.Lock()
+	defer vcm.procMu.Unlock()
+
+	if vcm.groups == nil {
+		vcm.groups = make(map[string][]int)
+	}
+	members := vcm.groups[group]
+	for _, id := range ids {
+		if indexOf(members, id) < 0 {
+			members = append(members, id)
+		}
+	}
+	vcm.groups[group] = members
+}
+
+// LeaveGroup 그룹에서 프로세스 제거 (마지막 멤버가 나가면 그룹 삭제)
+func (vcm *VectorClockManager) LeaveGroup(group string, id int) {
+	vcm.procMu.Lock()
+	defer vcm.procMu.Unlock()
+
+	members := vcm.groups[group]
+	if i := indexOf(members, id); i >= 0 {
+		members = append(members[:i:i], members[i+1:]...)
+	}
+	if len(members) == 0 {
+		delete(vcm.groups, group)
+		return
+	}
+	vcm.groups[group] = members
+}
+
+// Group 그룹 멤버 ID 목록 (지역 인덱스 순서)
+func (vcm *VectorClockManager) Group(group string) []int {
+	vcm.procMu.RLock()
+	defer vcm.procMu.RUnlock()
+	return append([]int(nil), vcm.groups[group]...)
+}
+
+// Groups 모든 그룹 이름 (정렬)
+func (vcm *VectorClockManager) Groups() []string {
+	vcm.procMu.RLock()
+	defer vcm.procMu.RUnlock()
+
+	names := make([]string, 0, len(vcm.groups))
+	for name := range vcm.groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GroupsOf 프로세스가 속한 그룹 이름 (정렬)
+func (vcm *VectorClockManager) GroupsOf(id int) []string {
+	vcm.procMu.RLock()
+	defer vcm.procMu.RUnlock()
+
+	var names []string
+	for name, members := range vcm.groups {
+		if indexOf(members, id) >= 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve "그룹/인덱스" 형태의 이름을 프로세스 ID 로 변환 (숫자만 있으면 전역 ID)
+func (vcm *VectorClockManager) Resolve(name string) (int, error) {
+	group, local, ok := strings.Cut(name, "/")
+	if !ok {
+		id, err := strconv.Atoi(name)
+		if err != nil {
+			return 0, fmt.Errorf("process: invalid process name %q", name)
+		}
+		return id, nil
+	}
+	idx, err := strconv.Atoi(local)
+	if err != nil {
+		return 0, fmt.Errorf("process: invalid process name %q", name)
+	}
+
+	members := vcm.Group(group)
+	if members == nil {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownGroup, group)
+	}
+	if idx < 0 || idx >= len(members) {
+		return 0, fmt.Errorf("process: group %s has no member %d", group, idx)
+	}
+	return members[idx], nil
+}
+
+// GroupClock 프로세스의 Vector Clock 을 그룹 멤버 항목만으로 투영 (지역 인덱스 순서)
+func (vcm *VectorClockManager) GroupClock(group string, processID int) ([]int, error) {
+	members := vcm.Group(group)
+	if members == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownGroup, group)
+	}
+	return project(vcm.GetClock(processID), members), nil
+}
+
+// GroupView 그룹 멤버 전체의 그룹 범위 Vector Clock (프로세스 ID -> 투영된 Vector Clock)
+func (vcm *VectorClockManager) GroupView(group string) (map[int][]int, error) {
+	members := vcm.Group(group)
+	if members == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownGroup, group)
+	}
+	view := make(map[int][]int, len(members))
+	for _, id := range members {
+		view[id] = project(vcm.GetClock(id), members)
+	}
+	return view, nil
+}
+
+// BroadcastGroup 그룹의 다른 모든 멤버에게 메시지 전송
+func (p *Process) BroadcastGroup(group, event string) error {
+	members := p.ClockMgr.Group(group)
+	if members == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownGroup, group)
+	}
+	for _, id := range members {
+		if id == p.ID {
+			continue
+		}
+		if err := p.Send(id, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// project 시계에서 지정한 항목만 순서대로 추출
+func project(clock []int, ids []int) []int {
+	out := make([]int, len(ids))
+	for i, id := range ids {
+		if id >= 0 && id < len(clock) {
+			out[i] = clock[id]
+		}
+	}
+	return out
+}
+
+// indexOf 목록에서 값의 위치 (없으면 -1)
+func indexOf(ids []int, id int) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,69 @@
+package process
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGroupResolveAndLeave(t *testing.T) {
+	vcm := NewVectorClockManager(4)
+	vcm.JoinGroup("shard-A", 2, 0)
+	vcm.JoinGroup("shard-A", 2) // 이미 멤버
+	vcm.JoinGroup("shard-B", 1, 3)
+
+	if got := vcm.Group("shard-A"); !reflect.DeepEqual(got, []int{2, 0}) {
+		t.Fatalf("Group(shard-A) = %v, want join order [2 0]", got)
+	}
+	if id, err := vcm.Resolve("shard-A/1"); err != nil || id != 0 {
+		t.Fatalf("Resolve(shard-A/1) = %d, %v, want 0", id, err)
+	}
+	if id, err := vcm.Resolve("3"); err != nil || id != 3 {
+		t.Fatalf("Resolve(3) = %d, %v, want global id 3", id, err)
+	}
+	if _, err := vcm.Resolve("nope/0"); !errors.Is(err, ErrUnknownGroup) {
+		t.Fatalf("Resolve(nope/0) = %v, want ErrUnknownGroup", err)
+	}
+	if _, err := vcm.Resolve("shard-A/5"); err == nil {
+		t.Fatal("Resolve accepted an index past the last member")
+	}
+
+	vcm.JoinGroup("shard-C", 3)
+	if got := vcm.GroupsOf(3); !reflect.DeepEqual(got, []string{"shard-B", "shard-C"}) {
+		t.Fatalf("GroupsOf(3) = %v", got)
+	}
+	vcm.LeaveGroup("shard-C", 3)
+	if got := vcm.Groups(); !reflect.DeepEqual(got, []string{"shard-A", "shard-B"}) {
+		t.Fatalf("Groups() after the last member left = %v", got)
+	}
+}
+
+func TestBroadcastGroupProjectsClock(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	procs := []*Process{NewProcess(0, vcm), NewProcess(1, vcm), NewProcess(2, vcm)}
+	vcm.JoinGroup("pair", 2, 0)
+
+	if err := procs[2].BroadcastGroup("pair", "hello"); err != nil {
+		t.Fatal(err)
+	}
+	procs[0].ReceiveMessages(procs[0].MessageCh)
+	if len(procs[1].MessageCh) != 0 {
+		t.Fatal("process outside the group received the group broadcast")
+	}
+
+	clock, err := vcm.GroupClock("pair", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 지역 인덱스 순서: [2 의 항목, 0 의 항목]
+	if want := []int{1, 1}; !reflect.DeepEqual(clock, want) {
+		t.Fatalf("GroupClock(pair, 0) = %v, want %v", clock, want)
+	}
+	view, err := vcm.GroupView("pair")
+	if err != nil || len(view) != 2 || !reflect.DeepEqual(view[2], []int{1, 0}) {
+		t.Fatalf("GroupView(pair) = %v, %v", view, err)
+	}
+	if err := procs[0].BroadcastGroup("missing", "x"); !errors.Is(err, ErrUnknownGroup) {
+		t.Fatalf("BroadcastGroup(missing) = %v, want ErrUnknownGroup", err)
+	}
+}
@@ -0,0 +1,41 @@
+package process
+
+// Grow Vector Clock 항목 수를 n 으로 늘림 (이미 n 이상이면 아무것도 하지 않음)
+//
+// 실행 중에 그룹에 들어온 프로세스(ID 가 기존 크기 이상)를 위해 모든 프로세스 시계, 채널 시계,
+// 에포크 기준 시계를 0 으로 채워 늘리고, 새 ID 의 시계를 만든 뒤 모든 시계를 다시 게시한다.
+// 늘어난 항목은 0 이므로 기존 시계 사이의 순서는 바뀌지 않으며, 다른 노드가 늘리기 전에 보낸
+// 짧은 시계도 없는 항목을 0 으로 보고 그대로 받는다.
+func (vcm *VectorClockManager) Grow(n int) {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	size := len(vcm.Clock)
+	if n <= size {
+		return
+	}
+	for id, clock := range vcm.Clock {
+		vcm.Clock[id] = padClock(clock, n)
+	}
+	for id := size; id < n; id++ {
+		vcm.Clock[id] = make([]int, n)
+	}
+	for key, clock := range vcm.channels {
+		vcm.channels[key] = padClock(clock, n)
+	}
+	for i := range vcm.epochs {
+		vcm.epochs[i].base = padClock(vcm.epochs[i].base, n)
+	}
+	vcm.publishAllLocked()
+	vcm.logf("Manager: grew vector clocks from %d to %d entries\n", size, n)
+}
+
+// padClock 시계를 n 개 항목으로 늘린 새 슬라이스 (늘어난 항목은 0, 이미 n 이상이면 그대로)
+func padClock(clock []int, n int) []int {
+	if len(clock) >= n {
+		return clock
+	}
+	out := make([]int, n)
+	copy(out, clock)
+	return out
+}
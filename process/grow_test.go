@@ -0,0 +1,82 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGrowAdmitsProcessAboveInitialSize(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	NewProcess(1, vcm, WithMailboxSize(4))
+	if err := a.Send(1, "before"); err != nil {
+		t.Fatal(err)
+	}
+
+	vcm.Grow(3)
+	if n := vcm.Size(); n != 3 {
+		t.Fatalf("Size() = %d after Grow(3), want 3", n)
+	}
+	if got, want := vcm.GetClockCopy(0), []int{1, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("process 0 clock = %v, want %v", got, want)
+	}
+
+	late := NewProcess(2, vcm, WithMailboxSize(4))
+	if err := late.Send(0, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ReceiveMessages(a.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := vcm.GetClockCopy(0), []int{2, 0, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("process 0 clock after receive = %v, want %v", got, want)
+	}
+
+	vcm.Grow(2) // 줄이지 않음
+	if n := vcm.Size(); n != 3 {
+		t.Fatalf("Size() = %d after Grow(2), want 3", n)
+	}
+}
+
+func TestGrowAcceptsShortVectors(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	p := NewProcess(0, vcm, WithMailboxSize(4))
+	vcm.Grow(4)
+
+	// 아직 늘리지 않은 다른 노드가 보낸 메시지
+	if err := vcm.Deliver(Message{From: 1, To: 0, Event: "old", Vector: []int{0, 3}, MessageID: "short"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.ReceiveMessages(p.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := vcm.GetClockCopy(0), []int{1, 3, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("clock = %v, want %v", got, want)
+	}
+
+	if err := vcm.Deliver(Message{From: 1, To: 0, Event: "long", Vector: make([]int, 5), MessageID: "long"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.ReceiveMessages(p.MessageCh); err == nil {
+		t.Fatal("vector longer than the group was accepted")
+	}
+}
+
+func TestGrowExtendsChannelClocksAndEpochBases(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithClockMode(ClockPerChannel))
+	vcm.UpdateChannelClock(0, 1, nil)
+	epoch, _ := vcm.Checkpoint()
+
+	vcm.Grow(3)
+	if got := vcm.GetChannelClock(0, 1); len(got) != 3 {
+		t.Fatalf("channel clock = %v, want 3 entries", got)
+	}
+	for e := 0; e <= epoch; e++ {
+		if got := vcm.EpochBase(e); len(got) != 3 {
+			t.Fatalf("epoch %d base = %v, want 3 entries", e, got)
+		}
+	}
+	if got := vcm.GetClock(2); !reflect.DeepEqual(got, []int{0, 0, 0}) {
+		t.Fatalf("new process clock = %v, want zeros", got)
+	}
+}
@@ -0,0 +1,65 @@
+package process
+
+import "time"
+
+// Health 프로세스 상태 점검 결과 (서비스 헬스 엔드포인트용)
+type Health struct {
+	Process      int           // 프로세스 ID
+	Running      bool          // 수신 루프(Start) 실행 여부
+	LastActivity time.Time     // 마지막 송신/수신/로컬 이벤트 시각 (없으면 zero)
+	Idle         time.Duration // 마지막 활동 이후 경과 시간 (활동이 없으면 0)
+	MailboxDepth int           // 메일박스에 쌓인 메시지 수
+	MailboxCap   int           // 메일박스 크기
+	Saturation   float64       // 메일박스 포화도 (0~1)
+	Lag          map[int]int   // 상대별 시계 뒤처짐 (상대의 실제 항목 - 내가 아는 항목)
+	MaxLag       int           // Lag 의 최대값
+	Healthy      bool          // 메일박스가 가득 차지 않았고, 시작된 수신 루프가 멈추지 않음
+}
+
+// Health 수신 루프 실행 여부, 마지막 활동 시각, 메일박스 포화도, 상대 대비 시계 뒤처짐 점검
+func (p *Process) Health() Health {
+	h := Health{
+		Process:      p.ID,
+		Running:      p.Running(),
+		MailboxDepth: p.mailboxLen(),
+		MailboxCap:   p.mailboxCap(),
+		Lag:          make(map[int]int),
+	}
+	if h.MailboxCap > 0 {
+		h.Saturation = float64(h.MailboxDepth) / float64(h.MailboxCap)
+	}
+	if ns := p.lastActive.Load(); ns != 0 {
+		h.LastActivity = time.Unix(0, ns)
+		h.Idle = p.clockNow().Sub(h.LastActivity)
+	}
+
+	own := p.ClockMgr.GetClock(p.ID)
+	for _, peer := range p.ClockMgr.Processes() {
+		if peer.ID == p.ID || peer.ID < 0 || peer.ID >= len(own) {
+			continue
+		}
+		actual := p.ClockMgr.GetClock(peer.ID)
+		if peer.ID >= len(actual) {
+			continue
+		}
+		lag := actual[peer.ID] - own[peer.ID]
+		if lag < 0 {
+			lag = 0
+		}
+		h.Lag[peer.ID] = lag
+		if lag > h.MaxLag {
+			h.MaxLag = lag
+		}
+	}
+
+	p.actor.mu.Lock()
+	stopped := p.actor.started && !p.actor.running
+	p.actor.mu.Unlock()
+	h.Healthy = h.Saturation < 1 && !stopped
+	return h
+}
+
+// touch 마지막 활동 시각 갱신
+func (p *Process) touch() {
+	p.lastActive.Store(p.clockNow().UnixNano())
+}
@@ -0,0 +1,31 @@
+package process
+
+import "testing"
+
+func TestHealthReportsMailboxAndLag(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+
+	if h := b.Health(); !h.Healthy || !h.LastActivity.IsZero() || h.MaxLag != 0 {
+		t.Fatalf("fresh process health = %+v", h)
+	}
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	h := b.Health()
+	if h.MailboxDepth != 1 || h.Saturation != 1 || h.Healthy {
+		t.Fatalf("health with a full mailbox = %+v", h)
+	}
+	if h.Lag[0] != 1 || h.MaxLag != 1 {
+		t.Fatalf("lag before receive = %v (max %d), want 1 behind process 0", h.Lag, h.MaxLag)
+	}
+
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	h = b.Health()
+	if !h.Healthy || h.MaxLag != 0 || h.LastActivity.IsZero() {
+		t.Fatalf("health after receive = %+v", h)
+	}
+}
@@ -0,0 +1,165 @@
+package process
+
+import (
+	"sync"
+	"time"
+)
+
+// Heartbeat 하트비트로 받은 상대의 시계 요약
+type Heartbeat struct {
+	From  int           // 하트비트를 보낸 프로세스 ID
+	Seq   int           // 보낸 프로세스 기준 하트비트 순번 (1 부터)
+	Clock []int         // 보낸 시점의 Vector Clock
+	Epoch int           // 보낸 시점의 에포크
+	At    time.Duration // 받은 시각 (스케줄러 기준)
+}
+
+// heartbeatState 하트비트 송수신 상태
+type heartbeatState struct {
+	mu        sync.Mutex
+	seq       int               // 보낸 하트비트 수
+	last      map[int]Heartbeat // 상대별 마지막 하트비트
+	listeners []func(Heartbeat) // 하트비트 수신 알림 (실패 감지기 등)
+}
+
+// StartHeartbeat interval 마다 peers 에게 현재 Vector Clock 을 하트비트로 보냄 (반환된 함수로 중지)
+//
+// 하트비트는 메일박스를 거치지 않고 레지스트리를 통해 바로 전달되며, 애플리케이션 메시지가 없어도
+// 받는 쪽 시계가 상대의 시계를 따라잡게 한다(원소별 최대값 병합, 자신의 항목은 증가하지 않음).
+// peers 를 지정하지 않으면 자신을 제외한 등록된 모든 프로세스에게 보낸다.
+func (p *Process) StartHeartbeat(interval time.Duration, peers ...int) (stop func()) {
+	var mu sync.Mutex
+	stopped := false
+	sched := p.ClockMgr.Scheduler()
+
+	var beat func()
+	beat = func() {
+		mu.Lock()
+		done := stopped
+		mu.Unlock()
+		if done {
+			return
+		}
+		p.sendHeartbeat(peers)
+		sched.After(interval, beat)
+	}
+	sched.After(interval, beat)
+
+	return func() {
+		mu.Lock()
+		stopped = true
+		mu.Unlock()
+	}
+}
+
+// sendHeartbeat 하트비트 한 번 전송
+func (p *Process) sendHeartbeat(peers []int) {
+	if len(peers) == 0 {
+		for _, peer := range p.ClockMgr.Processes() {
+			if peer.ID != p.ID {
+				peers = append(peers, peer.ID)
+			}
+		}
+	}
+
+	p.heartbeat.mu.Lock()
+	p.heartbeat.seq++
+	seq := p.heartbeat.seq
+	p.heartbeat.mu.Unlock()
+
+	clock := p.ClockMgr.GetClock(p.ID)
+	epoch := p.ClockMgr.CurrentEpoch()
+	for _, to := range peers {
+		target, ok := p.ClockMgr.Lookup(to)
+		if !ok {
+			continue
+		}
+		target.receiveHeartbeat(Heartbeat{From: p.ID, Seq: seq, Clock: append([]int(nil), clock...), Epoch: epoch})
+	}
+}
+
+// receiveHeartbeat 하트비트 수신 처리
+func (p *Process) receiveHeartbeat(hb Heartbeat) {
+	hb.At = p.ClockMgr.Scheduler().Now()
+
+	// 기본 시계만 병합 (채널별 시계는 상대와의 통신에서만 갱신)
+	if p.ClockMgr.Mode == ClockPerProcess {
+		if vector, err := p.ClockMgr.Translate(hb.Clock, hb.Epoch, p.ClockMgr.CurrentEpoch()); err == nil {
+			p.ClockMgr.absorb(p.ID, vector)
+		}
+	}
+
+	p.heartbeat.mu.Lock()
+	if p.heartbeat.last == nil {
+		p.heartbeat.last = make(map[int]Heartbeat)
+	}
+	if prev, ok := p.heartbeat.last[hb.From]; ok && prev.Seq >= hb.Seq {
+		p.heartbeat.mu.Unlock()
+		return
+	}
+	p.heartbeat.last[hb.From] = hb
+	listeners := make([]func(Heartbeat), len(p.heartbeat.listeners))
+	copy(listeners, p.heartbeat.listeners)
+	p.heartbeat.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(hb)
+	}
+	p.logf("Process %d: Heartbeat from %d, Vector: %v\n", p.ID, hb.From, hb.Clock)
+}
+
+// onHeartbeat 하트비트 수신 알림 등록
+func (p *Process) onHeartbeat(fn func(Heartbeat)) {
+	p.heartbeat.mu.Lock()
+	defer p.heartbeat.mu.Unlock()
+	p.heartbeat.listeners = append(p.heartbeat.listeners, fn)
+}
+
+// LastHeartbeat 상대에게서 받은 마지막 하트비트
+func (p *Process) LastHeartbeat(from int) (Heartbeat, bool) {
+	p.heartbeat.mu.Lock()
+	defer p.heartbeat.mu.Unlock()
+
+	hb, ok := p.heartbeat.last[from]
+	if ok {
+		hb.Clock = append([]int(nil), hb.Clock...)
+	}
+	return hb, ok
+}
+
+// Heartbeats 상대별 마지막 하트비트
+func (p *Process) Heartbeats() map[int]Heartbeat {
+	p.heartbeat.mu.Lock()
+	defer p.heartbeat.mu.Unlock()
+
+	result := make(map[int]Heartbeat, len(p.heartbeat.last))
+	for id, hb := range p.heartbeat.last {
+		hb.Clock = append([]int(nil), hb.Clock...)
+		result[id] = hb
+	}
+	return result
+}
+
+// SinceHeartbeat 상대의 마지막 하트비트 이후 경과 시간 (스케줄러 기준, 받은 적 없으면 false)
+func (p *Process) SinceHeartbeat(from int) (time.Duration, bool) {
+	hb, ok := p.LastHeartbeat(from)
+	if !ok {
+		return 0, false
+	}
+	return p.ClockMgr.Scheduler().Now() - hb.At, true
+}
+
+// absorb 자신의 항목을 증가시키지 않고 원소별 최대값으로 병합 (같은 매니저의 시계끼리이므로 길이가 같음)
+func (vcm *VectorClockManager) absorb(processID int, clock []int) {
+	vcm.lockClock(processID)
+	defer vcm.unlockClock(processID)
+	local := vcm.Clock[processID]
+	old := vcm.auditBefore(local)
+	for i := 0; i < len(clock) && i < len(local); i++ {
+		if clock[i] > local[i] {
+			local[i] = clock[i]
+		}
+	}
+	vcm.auditClockLocked(processID, AuditAbsorb, -1, "", old)
+	vcm.publishLocked(processID)
+}
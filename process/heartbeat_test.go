@@ -0,0 +1,52 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHeartbeatCarriesClockSummary(t *testing.T) {
+	s := NewVirtualScheduler()
+	vcm := NewVectorClockManager(3, WithScheduler(s))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+	c := NewProcess(2, vcm)
+
+	a.LocalEvent("work")
+	stop := a.StartHeartbeat(10 * time.Millisecond) // 대상이 없으면 다른 모든 프로세스
+	s.Advance(25 * time.Millisecond)
+	stop()
+	s.Advance(time.Second)
+
+	for _, p := range []*Process{b, c} {
+		hb, ok := p.LastHeartbeat(0)
+		if !ok || hb.Seq != 2 || hb.At != 20*time.Millisecond || !reflect.DeepEqual(hb.Clock, []int{1, 0, 0}) {
+			t.Fatalf("process %d last heartbeat = %+v, %v", p.ID, hb, ok)
+		}
+	}
+	if got := vcm.GetClock(1); !reflect.DeepEqual(got, []int{1, 0, 0}) {
+		t.Fatalf("heartbeat did not merge the sender's clock: %v", got)
+	}
+	if since, ok := b.SinceHeartbeat(0); !ok || since != time.Second+5*time.Millisecond {
+		t.Fatalf("SinceHeartbeat(0) = %v, %v", since, ok)
+	}
+	if _, ok := b.SinceHeartbeat(2); ok {
+		t.Fatal("SinceHeartbeat reported a process that never sent one")
+	}
+	if got := len(a.Heartbeats()); got != 0 {
+		t.Fatalf("sender recorded %d heartbeats from itself", got)
+	}
+}
+
+func TestStaleHeartbeatIsIgnored(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithScheduler(NewVirtualScheduler()))
+	NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+
+	b.receiveHeartbeat(Heartbeat{From: 0, Seq: 2, Clock: []int{2, 0}})
+	b.receiveHeartbeat(Heartbeat{From: 0, Seq: 1, Clock: []int{1, 0}})
+	if hb, _ := b.LastHeartbeat(0); hb.Seq != 2 {
+		t.Fatalf("last heartbeat Seq = %d, want 2", hb.Seq)
+	}
+}
@@ -0,0 +1,163 @@
+package process
+
+import (
+	"math"
+	"sync"
+)
+
+// HierarchicalClock 계층 시계 값 (이벤트 직후 프로세스의 시계, 또는 메시지에 실어 보내는 시계)
+type HierarchicalClock struct {
+	Process int   // 이벤트가 일어난 프로세스 ID
+	Group   int   // 프로세스가 속한 그룹
+	Groups  []int // 그룹별 Lamport 시계 (그룹 수 항목)
+	Local   []int // 그룹 안의 Vector Clock (그룹 크기 항목, 다른 그룹으로 보내는 메시지에서는 nil)
+}
+
+// clone 깊은 복사
+func (hc HierarchicalClock) clone() HierarchicalClock {
+	hc.Groups = append([]int(nil), hc.Groups...)
+	if hc.Local != nil {
+		hc.Local = append([]int(nil), hc.Local...)
+	}
+	return hc
+}
+
+// HierarchicalClockManager 프로세스를 그룹으로 나눈 계층 시계 관리
+//
+// 그룹 안에서는 그룹 크기의 Vector Clock(Local)을, 그룹 사이에서는 그룹마다 하나의 Lamport 시계(Groups)를 유지한다.
+// 같은 그룹으로 보내는 메시지는 O(그룹 수 + 그룹 크기), 다른 그룹으로 보내는 메시지는 O(그룹 수) 만 실으므로
+// 그룹 크기를 √N 으로 두면 N = 4096 에서도 메시지당 128 항목 이하를 쓴다 (전체 Vector Clock 은 4096).
+//
+// 정밀도를 잃는 대신 Compare 가 보장하는 것은 다음과 같다.
+//   - 같은 그룹: 그룹 안의 메시지로 이어진 인과 관계는 정확히 Before/After 로 보고한다.
+//     다른 그룹을 거쳐 이어진 인과 관계는 Local 에 남지 않으므로 "아마 동시"(Concurrent, exact=false)가 될 수 있다.
+//   - 다른 그룹: 그룹 Lamport 시계만 비교하므로 Before/After 는 항상 근사값(exact=false)이다.
+//     실제로 인과 관계가 있으면 반대 방향으로 보고하지는 않는다.
+//   - exact=true 인 Concurrent 는 항상 맞다 (Lamport 시계로 양쪽 방향을 모두 배제한 경우).
+type HierarchicalClockManager struct {
+	Clock     map[int]*HierarchicalClock // 프로세스별 계층 시계 (프로세스 ID -> 시계)
+	N         int                        // 프로세스 수
+	GroupSize int                        // 그룹 크기 (프로세스 i 는 그룹 i / GroupSize)
+	Mu        sync.Mutex                 // 동시성 제어
+}
+
+// NewHierarchicalClockManager n 개 프로세스를 groupSize 개씩 묶은 HierarchicalClockManager 초기화 (groupSize 가 0 이하면 ⌈√n⌉)
+func NewHierarchicalClockManager(n, groupSize int) *HierarchicalClockManager {
+	if n < 1 {
+		n = 1
+	}
+	if groupSize <= 0 {
+		groupSize = int(math.Ceil(math.Sqrt(float64(n))))
+	}
+	hcm := &HierarchicalClockManager{
+		Clock:     make(map[int]*HierarchicalClock, n),
+		N:         n,
+		GroupSize: groupSize,
+	}
+	groups := hcm.Groups()
+	for i := 0; i < n; i++ {
+		hcm.Clock[i] = &HierarchicalClock{
+			Process: i,
+			Group:   hcm.GroupOf(i),
+			Groups:  make([]int, groups),
+			Local:   make([]int, hcm.sizeOf(hcm.GroupOf(i))),
+		}
+	}
+	return hcm
+}
+
+// Groups 그룹 수
+func (hcm *HierarchicalClockManager) Groups() int {
+	return (hcm.N + hcm.GroupSize - 1) / hcm.GroupSize
+}
+
+// GroupOf 프로세스가 속한 그룹
+func (hcm *HierarchicalClockManager) GroupOf(processID int) int {
+	return processID / hcm.GroupSize
+}
+
+// sizeOf 그룹의 프로세스 수 (마지막 그룹은 GroupSize 보다 작을 수 있음)
+func (hcm *HierarchicalClockManager) sizeOf(group int) int {
+	if rest := hcm.N - group*hcm.GroupSize; rest < hcm.GroupSize {
+		return rest
+	}
+	return hcm.GroupSize
+}
+
+// tickLocked 프로세스 시계에서 이벤트 하나 진행 (hcm.Mu 보유 상태에서 호출)
+func (hcm *HierarchicalClockManager) tickLocked(clock *HierarchicalClock) {
+	clock.Local[clock.Process%hcm.GroupSize]++
+	clock.Groups[clock.Group]++
+}
+
+// LocalEvent 로컬 이벤트 (이벤트 직후 시계 반환)
+func (hcm *HierarchicalClockManager) LocalEvent(processID int) HierarchicalClock {
+	hcm.Mu.Lock()
+	defer hcm.Mu.Unlock()
+
+	clock := hcm.Clock[processID]
+	hcm.tickLocked(clock)
+	return clock.clone()
+}
+
+// Send processID 가 to 에게 보내는 송신 이벤트 (메시지에 실을 시계 반환, 다른 그룹으로 보내면 Local 은 nil)
+func (hcm *HierarchicalClockManager) Send(processID, to int) HierarchicalClock {
+	hcm.Mu.Lock()
+	defer hcm.Mu.Unlock()
+
+	clock := hcm.Clock[processID]
+	hcm.tickLocked(clock)
+	stamp := clock.clone()
+	if hcm.GroupOf(to) != clock.Group {
+		stamp.Local = nil
+	}
+	return stamp
+}
+
+// UpdateClock 특정 프로세스의 계층 시계 업데이트 (수신 이벤트, 수신 직후 시계 반환)
+//
+// 그룹 Lamport 시계는 항상 병합하고, 같은 그룹에서 온 시계만 Local 을 병합한다.
+func (hcm *HierarchicalClockManager) UpdateClock(processID int, received HierarchicalClock) HierarchicalClock {
+	hcm.Mu.Lock()
+	defer hcm.Mu.Unlock()
+
+	clock := hcm.Clock[processID]
+	clock.Groups = mergeMax(clock.Groups, received.Groups)
+	if received.Group == clock.Group {
+		clock.Local = mergeMax(clock.Local, received.Local)
+	}
+	hcm.tickLocked(clock)
+	return clock.clone()
+}
+
+// GetClock 특정 프로세스의 계층 시계 반환
+func (hcm *HierarchicalClockManager) GetClock(processID int) HierarchicalClock {
+	hcm.Mu.Lock()
+	defer hcm.Mu.Unlock()
+	return hcm.Clock[processID].clone()
+}
+
+// Compare 두 이벤트의 계층 시계 비교 (exact 가 false 면 근사값, 보장 범위는 HierarchicalClockManager 참고)
+func (hcm *HierarchicalClockManager) Compare(a, b HierarchicalClock) (order Ordering, exact bool) {
+	if a.Group == b.Group && a.Local != nil && b.Local != nil {
+		if order := Compare(a.Local, b.Local); order != Concurrent {
+			return order, true
+		}
+		// 그룹 안의 경로로는 이어지지 않음: 다른 그룹을 거친 경로는 Lamport 시계가 같으면 불가능
+		return Concurrent, a.Groups[a.Group] == b.Groups[b.Group]
+	}
+
+	// a -> b 이면 b 는 a 그룹의 Lamport 시계를 a 이상으로 알고 있다 (반대도 같음)
+	mayBefore := a.Groups[a.Group] <= b.Groups[a.Group]
+	mayAfter := b.Groups[b.Group] <= a.Groups[b.Group]
+	switch {
+	case mayBefore && !mayAfter:
+		return Before, false
+	case mayAfter && !mayBefore:
+		return After, false
+	case !mayBefore && !mayAfter:
+		return Concurrent, true
+	default:
+		return Concurrent, false
+	}
+}
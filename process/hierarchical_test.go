@@ -0,0 +1,70 @@
+package process
+
+import "testing"
+
+func TestHierarchicalGroupsDefaultToSquareRoot(t *testing.T) {
+	hcm := NewHierarchicalClockManager(10, 0)
+	if hcm.GroupSize != 4 || hcm.Groups() != 3 {
+		t.Fatalf("group size %d, %d groups, want 4 and 3", hcm.GroupSize, hcm.Groups())
+	}
+	last := hcm.GetClock(9)
+	if last.Group != 2 || len(last.Local) != 2 || len(last.Groups) != 3 {
+		t.Fatalf("clock of process 9 = %+v, want group 2 with 2 local entries", last)
+	}
+	if stamp := hcm.Send(0, 1); stamp.Local == nil {
+		t.Fatal("message inside a group lost its local vector")
+	}
+	if stamp := hcm.Send(0, 9); stamp.Local != nil || len(stamp.Groups) != 3 {
+		t.Fatalf("message to another group = %+v, want only group clocks", stamp)
+	}
+}
+
+func TestHierarchicalCompareInsideGroupIsExact(t *testing.T) {
+	hcm := NewHierarchicalClockManager(4, 2)
+	send := hcm.Send(0, 1)
+	recv := hcm.UpdateClock(1, send)
+	if order, exact := hcm.Compare(send, recv); order != Before || !exact {
+		t.Fatalf("Compare(send, receive) = %s, %v, want exact before", order, exact)
+	}
+	if order, exact := hcm.Compare(recv, send); order != After || !exact {
+		t.Fatalf("Compare(receive, send) = %s, %v, want exact after", order, exact)
+	}
+
+	// 서로 모르는 첫 이벤트는 그룹 Lamport 시계도 같으므로 확실히 동시
+	other := NewHierarchicalClockManager(4, 2)
+	a, b := other.LocalEvent(0), other.LocalEvent(1)
+	if order, exact := other.Compare(a, b); order != Concurrent || !exact {
+		t.Fatalf("Compare of independent events = %s, %v, want exact concurrent", order, exact)
+	}
+}
+
+func TestHierarchicalCompareAcrossGroupsIsApproximate(t *testing.T) {
+	hcm := NewHierarchicalClockManager(4, 2)
+	send := hcm.Send(0, 2)
+	recv := hcm.UpdateClock(2, send)
+	if order, exact := hcm.Compare(send, recv); order != Before || exact {
+		t.Fatalf("Compare(send, receive) = %s, %v, want approximate before", order, exact)
+	}
+	if order, _ := hcm.Compare(recv, send); order != After {
+		t.Fatalf("Compare(receive, send) = %s, want after", order)
+	}
+
+	other := NewHierarchicalClockManager(4, 2)
+	a, b := other.LocalEvent(0), other.LocalEvent(2)
+	if order, exact := other.Compare(a, b); order != Concurrent || !exact {
+		t.Fatalf("Compare of independent events = %s, %v, want exact concurrent", order, exact)
+	}
+}
+
+func TestHierarchicalPathThroughOtherGroupIsProbablyConcurrent(t *testing.T) {
+	hcm := NewHierarchicalClockManager(4, 2)
+	first := hcm.LocalEvent(0)
+	hcm.UpdateClock(2, hcm.Send(0, 2))
+	relay := hcm.Send(2, 1)
+	last := hcm.UpdateClock(1, relay)
+
+	// 0 -> 2 -> 1 로 이어졌지만 그룹 0 의 Local 에는 남지 않음
+	if order, exact := hcm.Compare(first, last); order != Concurrent || exact {
+		t.Fatalf("Compare(first, last) = %s, %v, want probably concurrent", order, exact)
+	}
+}
@@ -0,0 +1,50 @@
+package process
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestDefaultMessageIDsCountPerSender(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	want := []string{"0-1", "1-1", "0-2", "0-3", "1-2"}
+	for i, from := range []int{0, 1, 0, 0, 1} {
+		if got := vcm.messageID(from); got != want[i] {
+			t.Fatalf("messageID(%d) #%d = %q, want %q", from, i, got, want[i])
+		}
+	}
+}
+
+func TestSequenceIDsPrefix(t *testing.T) {
+	next := SequenceIDs("node-a.3")
+	if got := next(2); got != "node-a.3-2-1" {
+		t.Fatalf("first ID = %q, want node-a.3-2-1", got)
+	}
+	if got := SequenceIDs("")(2); got != "2-1" {
+		t.Fatalf("ID without prefix = %q, want 2-1", got)
+	}
+}
+
+func TestSequenceIDsAreUniqueUnderConcurrency(t *testing.T) {
+	next := SequenceIDs("")
+	const workers, each = 8, 500
+	var mu sync.Mutex
+	seen := make(map[string]bool, workers*each)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < each; i++ {
+				id := next(0)
+				mu.Lock()
+				seen[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != workers*each {
+		t.Fatalf("%d unique IDs from %d sends", len(seen), workers*each)
+	}
+}
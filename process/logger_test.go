@@ -0,0 +1,32 @@
+package process
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// captureLogger 테스트에서 로그를 모아 두는 Logger
+type captureLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *captureLogger) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+// count substr 를 포함한 로그 줄 수
+func (l *captureLogger) count(substr string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for _, line := range l.lines {
+		if strings.Contains(line, substr) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,111 @@
+package process
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+)
+
+// clockBinaryVersion Clock 이진 형식 버전 (첫 바이트)
+const clockBinaryVersion = 1
+
+// maxClockValue int 로 표현할 수 있는 최대 항목 값
+const maxClockValue = int(^uint(0) >> 1)
+
+var (
+	_ encoding.TextMarshaler     = Clock(nil)
+	_ encoding.TextUnmarshaler   = (*Clock)(nil)
+	_ encoding.BinaryMarshaler   = Clock(nil)
+	_ encoding.BinaryUnmarshaler = (*Clock)(nil)
+	_ driver.Valuer              = Clock(nil)
+	_ sql.Scanner                = (*Clock)(nil)
+)
+
+// MarshalText 정규 표기로 직렬화 (JSON, YAML, XML 에서는 "{P0:3,P1:1}" 같은 문자열이 됨)
+func (c Clock) MarshalText() ([]byte, error) {
+	return []byte(FormatClock(c)), nil
+}
+
+// UnmarshalText 정규 표기를 읽음 (ParseClock)
+func (c *Clock) UnmarshalText(text []byte) error {
+	clock, err := ParseClock(string(text))
+	if err != nil {
+		return err
+	}
+	*c = clock
+	return nil
+}
+
+// MarshalBinary 이진 형식으로 직렬화 (버전 바이트, 항목 수, 항목을 차례로 uvarint 로)
+func (c Clock) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, 1+binary.MaxVarintLen64*(len(c)+1))
+	b = append(b, clockBinaryVersion)
+	b = binary.AppendUvarint(b, uint64(len(c)))
+	for i, v := range c {
+		if v < 0 {
+			return nil, fmt.Errorf("process: marshal clock: negative entry %d at P%d", v, i)
+		}
+		b = binary.AppendUvarint(b, uint64(v))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary MarshalBinary 형식을 읽음
+func (c *Clock) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != clockBinaryVersion {
+		return fmt.Errorf("%w: unknown binary clock version", ErrClockSyntax)
+	}
+	data = data[1:]
+	n, k := binary.Uvarint(data)
+	// 항목마다 최소 1 바이트이므로 남은 길이보다 많은 항목은 잘린 데이터
+	if k <= 0 || n > uint64(len(data)-k) {
+		return fmt.Errorf("%w: truncated binary clock", ErrClockSyntax)
+	}
+	data = data[k:]
+	clock := make(Clock, n)
+	for i := range clock {
+		v, k := binary.Uvarint(data)
+		if k <= 0 || v > uint64(maxClockValue) {
+			return fmt.Errorf("%w: bad binary entry at P%d", ErrClockSyntax, i)
+		}
+		clock[i] = int(v)
+		data = data[k:]
+	}
+	if len(data) != 0 {
+		return fmt.Errorf("%w: %d trailing bytes after binary clock", ErrClockSyntax, len(data))
+	}
+	*c = clock
+	return nil
+}
+
+// Value 데이터베이스 열 값 (정규 표기 문자열, nil 시계는 NULL)
+//
+// 텍스트 열(TEXT, VARCHAR)에 그대로 저장되어 SQL 로 조회해도 읽을 수 있다.
+func (c Clock) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return FormatClock(c), nil
+}
+
+// Scan 데이터베이스 열 값을 읽음 (정규 표기 문자열, 또는 BLOB 열의 MarshalBinary 형식, NULL 은 nil)
+func (c *Clock) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case string:
+		return c.UnmarshalText([]byte(v))
+	case []byte:
+		if len(v) > 0 && v[0] == clockBinaryVersion {
+			return c.UnmarshalBinary(v)
+		}
+		// 드라이버가 텍스트 열을 []byte 로 넘기는 경우 (버퍼를 재사용할 수 있으므로 ParseClock 이 새 시계를 만든다)
+		return c.UnmarshalText(bytes.TrimSpace(v))
+	default:
+		return fmt.Errorf("process: cannot scan %T into Clock", src)
+	}
+}
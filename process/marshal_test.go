@@ -0,0 +1,105 @@
+package process
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestClockJSONUsesCanonicalText(t *testing.T) {
+	type doc struct {
+		Clock Clock `json:"clock"`
+	}
+	data, err := json.Marshal(doc{Clock: Clock{3, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"clock":"{P0:3,P1:1}"}`; got != want {
+		t.Fatalf("json = %s, want %s", got, want)
+	}
+	var back doc
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back.Clock, Clock{3, 1}) {
+		t.Fatalf("decoded %v", back.Clock)
+	}
+	if err := json.Unmarshal([]byte(`{"clock":"[3 1]"}`), &back); !errors.Is(err, ErrClockSyntax) {
+		t.Fatalf("decoding a bad clock = %v, want ErrClockSyntax", err)
+	}
+}
+
+func TestClockBinaryRoundTrip(t *testing.T) {
+	for _, c := range []Clock{{}, {0}, {1, 300, 0, 1 << 40}} {
+		data, err := c.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Clock
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, c) {
+			t.Fatalf("binary round trip of %v = %v", c, got)
+		}
+	}
+	if _, err := (Clock{1, -1}).MarshalBinary(); err == nil {
+		t.Fatal("negative entry marshalled")
+	}
+}
+
+func TestClockUnmarshalBinaryRejectsBadData(t *testing.T) {
+	good, err := Clock{5, 7, 9}.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string][]byte{
+		"empty":     nil,
+		"version":   {9, 0},
+		"truncated": good[:len(good)-1],
+		"trailing":  append(append([]byte(nil), good...), 0),
+		"huge":      {clockBinaryVersion, 0xFF, 0xFF, 0xFF, 0xFF, 0x0F},
+	} {
+		var c Clock
+		if err := c.UnmarshalBinary(data); !errors.Is(err, ErrClockSyntax) {
+			t.Errorf("%s: UnmarshalBinary = %v, want ErrClockSyntax", name, err)
+		}
+	}
+}
+
+func TestClockValueAndScan(t *testing.T) {
+	v, err := Clock{2, 0, 1}.Value()
+	if err != nil || v != "{P0:2,P1:0,P2:1}" {
+		t.Fatalf("Value() = %v, %v", v, err)
+	}
+	if v, err := Clock(nil).Value(); err != nil || v != nil {
+		t.Fatalf("nil Value() = %v, %v, want NULL", v, err)
+	}
+
+	bin, err := Clock{4, 4}.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		src  any
+		want Clock
+	}{
+		{"{P0:2,P1:0,P2:1}", Clock{2, 0, 1}},
+		{[]byte(" {P1:3} "), Clock{0, 3}},
+		{bin, Clock{4, 4}},
+		{nil, nil},
+	} {
+		c := Clock{9}
+		if err := c.Scan(tc.src); err != nil {
+			t.Fatalf("Scan(%v): %v", tc.src, err)
+		}
+		if !reflect.DeepEqual(c, tc.want) {
+			t.Fatalf("Scan(%v) = %v, want %v", tc.src, c, tc.want)
+		}
+	}
+	var c Clock
+	if err := c.Scan(int64(3)); err == nil {
+		t.Fatal("scanning an integer succeeded")
+	}
+}
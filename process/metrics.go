@@ -0,0 +1,205 @@
+package process
+
+import (
+	"expvar"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ProcessMetrics 프로세스별 누적 카운터
+type ProcessMetrics struct {
+	Events     int64 `json:"events"`     // 기록된 모든 이벤트 (로컬, 송신, 수신, 전달 실패)
+	Sent       int64 `json:"sent"`       // 송신한 메시지
+	Received   int64 `json:"received"`   // 수신한 메시지
+	Merges     int64 `json:"merges"`     // 수신 시계를 병합한 횟수
+	Dropped    int64 `json:"dropped"`    // 전달하지 못한 송신 메시지 (메일박스 가득 참, 닫힌 채널)
+	Rejected   int64 `json:"rejected"`   // 검증에 실패해 거부한 수신 메시지
+	Duplicates int64 `json:"duplicates"` // 중복 제거 창(WithDedupe)에서 걸러낸 수신 메시지
+
+	MailboxDepth     int64         `json:"mailbox_depth"`      // 메일박스에서 아직 꺼내지 않은 메시지 수
+	MailboxHighWater int64         `json:"mailbox_high_water"` // 메일박스 깊이의 최대값
+	MailboxFull      time.Duration `json:"mailbox_full_ns"`    // 메일박스가 가득 차 있던 누적 시간 (송신자가 막힌 시간)
+}
+
+// add 다른 카운터 o 를 더함 (MailboxHighWater 는 최대값)
+func (m *ProcessMetrics) add(o ProcessMetrics) {
+	m.Events += o.Events
+	m.Sent += o.Sent
+	m.Received += o.Received
+	m.Merges += o.Merges
+	m.Dropped += o.Dropped
+	m.Rejected += o.Rejected
+	m.Duplicates += o.Duplicates
+	m.MailboxDepth += o.MailboxDepth
+	if o.MailboxHighWater > m.MailboxHighWater {
+		m.MailboxHighWater = o.MailboxHighWater
+	}
+	m.MailboxFull += o.MailboxFull
+}
+
+// counters 프로세스별 카운터 (잠금 없이 증가)
+type counters struct {
+	events, sent, received, merges, dropped, rejected, duplicates atomic.Int64
+
+	// 메일박스 (변경은 vcm.term.mu 보유 상태에서만)
+	depth, highWater atomic.Int64
+	fullNanos        atomic.Int64 // 끝난 가득 참 구간의 누적 시간
+	fullSince        atomic.Int64 // 지금 가득 차 있으면 시작 시각 (유닉스 나노초, 아니면 0)
+
+	// 잠금 대기 (WithLockProfiling)
+	clockLock, procLock lockCounter
+}
+
+// snapshot now 시점의 카운터 값 (가득 찬 상태가 이어지는 중이면 now 까지의 시간 포함)
+func (c *counters) snapshot(now time.Time) ProcessMetrics {
+	full := time.Duration(c.fullNanos.Load())
+	if since := c.fullSince.Load(); since != 0 {
+		full += now.Sub(time.Unix(0, since))
+	}
+	return ProcessMetrics{
+		Events:           c.events.Load(),
+		Sent:             c.sent.Load(),
+		Received:         c.received.Load(),
+		Merges:           c.merges.Load(),
+		Dropped:          c.dropped.Load(),
+		Rejected:         c.rejected.Load(),
+		Duplicates:       c.duplicates.Load(),
+		MailboxDepth:     c.depth.Load(),
+		MailboxHighWater: c.highWater.Load(),
+		MailboxFull:      full,
+	}
+}
+
+// mailbox 메일박스 깊이 변경 후 새 깊이 반환 (vcm.term.mu 보유 상태에서 호출)
+//
+// 버퍼가 capacity 인 메일박스는 깊이가 capacity 이상이면, 버퍼 없는 메일박스는 깊이가 1 이상이면
+// (송신자가 받는 쪽을 기다리는 중) 가득 찬 것으로 본다.
+func (c *counters) mailbox(delta int64, capacity int, now time.Time) int64 {
+	depth := c.depth.Add(delta)
+	if depth > c.highWater.Load() {
+		c.highWater.Store(depth)
+	}
+	full := depth > 0 && depth >= int64(capacity)
+	since := c.fullSince.Load()
+	switch {
+	case full && since == 0:
+		c.fullSince.Store(now.UnixNano())
+	case !full && since != 0:
+		if d := now.UnixNano() - since; d > 0 {
+			c.fullNanos.Add(d)
+		}
+		c.fullSince.Store(0)
+	}
+	return depth
+}
+
+// metricsState 매니저의 프로세스별 카운터
+type metricsState struct {
+	mu    sync.RWMutex
+	procs map[int]*counters
+}
+
+// counters 프로세스 id 의 카운터 (없으면 생성)
+func (vcm *VectorClockManager) counters(id int) *counters {
+	m := &vcm.metrics
+	m.mu.RLock()
+	c, ok := m.procs[id]
+	m.mu.RUnlock()
+	if ok {
+		return c
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if c, ok := m.procs[id]; ok {
+		return c
+	}
+	if m.procs == nil {
+		m.procs = make(map[int]*counters)
+	}
+	c = &counters{}
+	m.procs[id] = c
+	return c
+}
+
+// countEvent 기록된 이벤트의 카운터 증가
+func (vcm *VectorClockManager) countEvent(e Event) {
+	c := vcm.counters(e.Process)
+	c.events.Add(1)
+	switch e.Kind {
+	case EventSend:
+		c.sent.Add(1)
+	case EventReceive:
+		c.received.Add(1)
+	case EventDrop:
+		c.dropped.Add(1)
+	}
+}
+
+// Metrics 프로세스별 누적 카운터 (프로세스 ID -> 카운터)
+func (vcm *VectorClockManager) Metrics() map[int]ProcessMetrics {
+	now := vcm.now()
+	m := &vcm.metrics
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	metrics := make(map[int]ProcessMetrics, len(m.procs))
+	for id, c := range m.procs {
+		metrics[id] = c.snapshot(now)
+	}
+	return metrics
+}
+
+// TotalMetrics 모든 프로세스의 카운터 합계
+func (vcm *VectorClockManager) TotalMetrics() ProcessMetrics {
+	var total ProcessMetrics
+	for _, m := range vcm.Metrics() {
+		total.add(m)
+	}
+	return total
+}
+
+// Metrics 프로세스의 누적 카운터
+func (p *Process) Metrics() ProcessMetrics {
+	return p.ClockMgr.counters(p.ID).snapshot(p.ClockMgr.now())
+}
+
+// expvarMetrics expvar 로 공개하는 문서
+type expvarMetrics struct {
+	Processes map[string]ProcessMetrics `json:"processes"`       // 프로세스 ID(문자열) -> 카운터
+	Total     ProcessMetrics            `json:"total"`           // 합계
+	Locks     *LockMetrics              `json:"locks,omitempty"` // 잠금 경합 (WithLockProfiling 일 때만)
+}
+
+// expvarMu PublishExpvar 의 이름 확인과 등록을 묶음 (동시에 같은 이름을 공개하면 expvar.Publish 가 panic)
+var expvarMu sync.Mutex
+
+// PublishExpvar 카운터를 expvar 변수 name 으로 공개 (/debug/vars 에 {"processes": {...}, "total": {...}} 로 나타남)
+//
+// WithLockProfiling 을 켠 매니저는 잠금 경합 통계(LockMetrics)를 "locks" 로 함께 공개한다.
+//
+// 외부 의존성 없이 표준 라이브러리만으로 기본 관측을 제공한다. 값은 조회할 때마다 새로 계산된다.
+// expvar 변수는 프로세스 전체에서 하나의 이름만 쓸 수 있으므로, 같은 이름이 이미 있으면 에러를 반환한다.
+func (vcm *VectorClockManager) PublishExpvar(name string) error {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("process: expvar %q already published", name)
+	}
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		doc := expvarMetrics{Processes: make(map[string]ProcessMetrics)}
+		for id, m := range vcm.Metrics() {
+			doc.Processes[strconv.Itoa(id)] = m
+			doc.Total.add(m)
+		}
+		if vcm.locks.enabled {
+			locks := vcm.LockMetrics()
+			doc.Locks = &locks
+		}
+		return doc
+	}))
+	return nil
+}
@@ -0,0 +1,155 @@
+package process
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMetricsCountMessagesAndMerges(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm, WithMailboxSize(4))
+	a.LocalEvent("start")
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := a.Metrics(), (ProcessMetrics{Events: 2, Sent: 1}); got != want {
+		t.Fatalf("sender metrics = %+v, want %+v", got, want)
+	}
+	if got, want := b.Metrics(), (ProcessMetrics{Events: 1, Received: 1, Merges: 1, MailboxHighWater: 1}); got != want {
+		t.Fatalf("receiver metrics = %+v, want %+v", got, want)
+	}
+	if total := vcm.TotalMetrics(); total.Events != 3 || total.Sent != 1 || total.Received != 1 {
+		t.Fatalf("TotalMetrics() = %+v", total)
+	}
+}
+
+func TestMetricsCountDropsAndRejections(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm, WithSendTimeout(time.Millisecond))
+	b := NewProcess(1, vcm)
+	full := make(chan Message)
+	if err := a.SendMessage(1, "m", full, false); err == nil {
+		t.Fatal("send to a full channel succeeded")
+	}
+	if err := b.receive(Message{From: 7, To: 1, Vector: []int{1, 0}, MessageID: "7-x"}); err == nil {
+		t.Fatal("invalid message was accepted")
+	}
+	if got := a.Metrics().Dropped; got != 1 {
+		t.Fatalf("Dropped = %d, want 1", got)
+	}
+	if got := b.Metrics(); got.Rejected != 1 || got.Merges != 0 {
+		t.Fatalf("receiver metrics = %+v, want one rejection and no merge", got)
+	}
+}
+
+func TestPublishExpvar(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+	name := fmt.Sprintf("vectorclock_test_publish_%d", expvarRuns.Add(1))
+	if err := vcm.PublishExpvar(name); err != nil {
+		t.Fatal(err)
+	}
+	if err := vcm.PublishExpvar(name); err == nil {
+		t.Fatal("publishing the same name twice succeeded")
+	}
+
+	// 공개한 뒤의 변화도 조회할 때 반영됨
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	var doc expvarMetrics
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Processes["0"].Sent != 1 || doc.Processes["1"].Merges != 1 || doc.Total.Events != 2 {
+		t.Fatalf("published %+v", doc)
+	}
+}
+
+func TestMetricsTrackMailboxDepth(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm, WithMailboxSize(2))
+	for _, event := range []string{"m1", "m2"} {
+		if err := a.Send(1, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	if got := b.Metrics(); got.MailboxDepth != 2 || got.MailboxHighWater != 2 || got.MailboxFull <= 0 {
+		t.Fatalf("full mailbox metrics = %+v, want depth 2 and time spent full", got)
+	}
+
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	full := b.Metrics().MailboxFull
+	time.Sleep(5 * time.Millisecond)
+	got := b.Metrics()
+	if got.MailboxDepth != 1 || got.MailboxHighWater != 2 {
+		t.Fatalf("metrics after a receive = %+v, want depth 1 high water 2", got)
+	}
+	if got.MailboxFull != full {
+		t.Fatalf("MailboxFull grew from %v to %v after the mailbox drained", full, got.MailboxFull)
+	}
+	if total := vcm.TotalMetrics(); total.MailboxDepth != 1 || total.MailboxHighWater != 2 {
+		t.Fatalf("TotalMetrics() = %+v", total)
+	}
+}
+
+// expvarRuns 반복 실행(-count)마다 새 expvar 이름을 쓰기 위한 카운터
+var expvarRuns atomic.Int32
+
+func TestPublishExpvarConcurrentSameName(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	NewProcess(1, vcm, WithMailboxSize(4))
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+
+	name := fmt.Sprintf("vectorclock_test_concurrent_%d", expvarRuns.Add(1))
+	const callers = 8
+	var ok atomic.Int32
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if vcm.PublishExpvar(name) == nil {
+				ok.Add(1)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	if n := ok.Load(); n != 1 {
+		t.Fatalf("%d of %d calls published the name, want exactly 1", n, callers)
+	}
+
+	var doc expvarMetrics
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Total.Sent != 1 || doc.Processes["0"].Sent != 1 {
+		t.Fatalf("published %+v, want one send by process 0", doc)
+	}
+}
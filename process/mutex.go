@@ -0,0 +1,174 @@
+package process
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// mutexState Ricart–Agrawala 분산 상호 배제 상태
+type mutexState struct {
+	lock sync.Mutex // 같은 프로세스 안의 Lock 호출 직렬화 (Unlock 까지 보유)
+
+	mu         sync.Mutex
+	requesting bool         // 진입 요청 중 (허락 대기)
+	held       bool         // 임계 구역 안
+	stamp      int          // 요청 시점의 논리 시각 (Vector Clock 원소 합)
+	waiting    map[int]bool // 아직 허락하지 않은 상대
+	deferred   []int        // 임계 구역을 나올 때까지 허락을 미룬 상대
+	blocked    bool         // 허락 대기로 등록됨 (교착 상태 감지)
+	granted    chan struct{}
+}
+
+// Lock 분산 임계 구역 진입 (Ricart–Agrawala)
+//
+// 자신을 제외한 등록된 모든 프로세스에게 진입 요청을 보내고 모두에게 허락을 받을 때까지 기다린다.
+// 요청의 우선순위는 요청 시점 Vector Clock 의 원소 합(인과 순서와 일치하는 논리 시각)과
+// 프로세스 ID 로 정해지며, 우선순위가 낮은 요청을 받은 프로세스는 자신이 나올 때까지 허락을 미룬다.
+// 요청과 허락은 메일박스를 거치지 않고 레지스트리를 통해 바로 전달되므로 상대가 수신 루프를
+// 돌지 않아도 된다. 모든 프로세스가 같은 매니저에 등록된 뒤 호출해야 한다.
+// 허락을 기다리는 중에 교착 상태가 감지되면 *DeadlockError 로 panic 한다.
+func (p *Process) Lock() {
+	p.mutex.lock.Lock()
+
+	var peers []*Process
+	for _, peer := range p.ClockMgr.Processes() {
+		if peer.ID != p.ID {
+			peers = append(peers, peer)
+		}
+	}
+
+	granted := make(chan struct{})
+
+	// 요청 시각은 잠금 안에서 정함: 요청 중으로 표시하기 전에 받아 허락한 요청보다 늦은 시각이 되어야 함
+	p.mutex.mu.Lock()
+	clock, epoch := p.ClockMgr.advance(p.ID)
+	p.mutex.requesting = true
+	p.mutex.stamp = sum(clock)
+	p.mutex.waiting = make(map[int]bool, len(peers))
+	for _, peer := range peers {
+		p.mutex.waiting[peer.ID] = true
+	}
+	p.mutex.granted = granted
+	if len(peers) == 0 {
+		p.grantLocked()
+	}
+	p.mutex.mu.Unlock()
+
+	for _, peer := range peers {
+		req := p.newMessage(peer.ID, "lock", clock, epoch)
+		req.Kind = KindLockRequest
+		p.sign(&req)
+		p.recordSend(req)
+		peer.receiveMutex(req)
+	}
+
+	// 아직 허락하지 않은 상대가 있으면 대기 (교착 상태 감지 대상)
+	// 잠금을 쥔 채 등록해야 이미 허락을 받은 뒤 대기로 등록되지 않고, 허락하는 쪽(grantLocked)이 대기를 푼다
+	var w *waiter
+	p.mutex.mu.Lock()
+	if len(p.mutex.waiting) > 0 {
+		on := make([]int, 0, len(p.mutex.waiting))
+		for id := range p.mutex.waiting {
+			on = append(on, id)
+		}
+		sort.Ints(on)
+		w = p.ClockMgr.block(p.ID, WaitLock, on)
+		p.mutex.blocked = true
+	}
+	p.mutex.mu.Unlock()
+	if w != nil {
+		select {
+		case <-granted:
+		case <-w.abort:
+			// sync.Mutex 처럼 되돌릴 수 없는 대기이므로 교착 상태는 panic 으로 보고
+			panic(w.err)
+		}
+	}
+	p.logf("Process %d: Entered critical section, Vector: %v\n", p.ID, p.ClockMgr.GetClock(p.ID))
+}
+
+// Unlock 분산 임계 구역에서 나옴 (미뤄 둔 요청에 허락을 보냄)
+func (p *Process) Unlock() {
+	p.mutex.mu.Lock()
+	if !p.mutex.held {
+		p.mutex.mu.Unlock()
+		panic(fmt.Sprintf("process: Unlock of unlocked process %d", p.ID))
+	}
+	p.mutex.held = false
+	deferred := p.mutex.deferred
+	p.mutex.deferred = nil
+	p.mutex.mu.Unlock()
+
+	p.logf("Process %d: Left critical section, Vector: %v\n", p.ID, p.ClockMgr.GetClock(p.ID))
+	for _, to := range deferred {
+		p.grant(to)
+	}
+	p.mutex.lock.Unlock()
+}
+
+// InCriticalSection 분산 임계 구역 안에 있는지 여부
+func (p *Process) InCriticalSection() bool {
+	p.mutex.mu.Lock()
+	defer p.mutex.mu.Unlock()
+	return p.mutex.held
+}
+
+// receiveMutex 진입 요청/허락 메시지 처리
+func (p *Process) receiveMutex(msg Message) {
+	if err := p.ClockMgr.mergeClock(p.ID, msg.Epoch, msg.Vector, msg.From, msg.MessageID); err != nil {
+		_ = p.reject(msg, err)
+		return
+	}
+	p.recordReceive(msg)
+
+	p.mutex.mu.Lock()
+	if msg.Kind == KindLockReply {
+		if p.mutex.requesting && p.mutex.waiting[msg.From] {
+			delete(p.mutex.waiting, msg.From)
+			if len(p.mutex.waiting) == 0 {
+				p.grantLocked()
+			}
+		}
+		p.mutex.mu.Unlock()
+		return
+	}
+
+	// 임계 구역 안이거나, 자신의 요청이 더 먼저면 허락을 미룸
+	stamp := sum(msg.Vector)
+	mine := p.mutex.requesting && (p.mutex.stamp < stamp || (p.mutex.stamp == stamp && p.ID < msg.From))
+	if p.mutex.held || mine {
+		p.mutex.deferred = append(p.mutex.deferred, msg.From)
+		p.mutex.mu.Unlock()
+		return
+	}
+	p.mutex.mu.Unlock()
+	p.grant(msg.From)
+}
+
+// grantLocked 모든 허락을 받아 임계 구역에 들어감 (p.mutex.mu 보유 상태에서 호출)
+//
+// 기다리는 쪽이 깨어나기 전에 대기를 풀어, 그 사이 다른 프로세스가 대기하며 교착 상태로 잘못 감지하지 않게 한다.
+func (p *Process) grantLocked() {
+	p.mutex.requesting = false
+	p.mutex.held = true
+	if p.mutex.blocked {
+		p.mutex.blocked = false
+		p.ClockMgr.unblock(p.ID)
+	}
+	close(p.mutex.granted)
+}
+
+// grant 상대에게 진입 허락 전송
+func (p *Process) grant(to int) {
+	target, ok := p.ClockMgr.Lookup(to)
+	if !ok {
+		return
+	}
+	clock, epoch := p.ClockMgr.advance(p.ID)
+	reply := p.newMessage(to, "lock-ok", clock, epoch)
+	reply.Kind = KindLockReply
+	p.sign(&reply)
+	p.recordSend(reply)
+	target.receiveMutex(reply)
+}
@@ -0,0 +1,106 @@
+package process
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLockMutualExclusion(t *testing.T) {
+	const (
+		procs  = 4
+		rounds = 25
+	)
+	vcm := NewVectorClockManager(procs, WithLogger(nil))
+	ps := make([]*Process, procs)
+	for i := range ps {
+		ps[i] = NewProcess(i, vcm)
+	}
+
+	var inside, entries atomic.Int32
+	var wg sync.WaitGroup
+	for _, p := range ps {
+		wg.Add(1)
+		go func(p *Process) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				p.Lock()
+				if n := inside.Add(1); n != 1 {
+					t.Errorf("process %d entered with %d processes inside", p.ID, n)
+				}
+				if !p.InCriticalSection() {
+					t.Errorf("process %d: InCriticalSection false after Lock", p.ID)
+				}
+				entries.Add(1)
+				time.Sleep(50 * time.Microsecond) // 다른 프로세스가 끼어들 틈
+				inside.Add(-1)
+				p.Unlock()
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	if got := entries.Load(); got != procs*rounds {
+		t.Fatalf("entries = %d, want %d", got, procs*rounds)
+	}
+	for _, p := range ps {
+		if p.InCriticalSection() {
+			t.Fatalf("process %d still in critical section", p.ID)
+		}
+	}
+}
+
+func TestLockOrdersByRequestClock(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+
+	a.Lock()
+	entered := make(chan struct{})
+	go func() {
+		b.Lock()
+		close(entered)
+	}()
+	// a 가 나오기 전에는 b 가 들어가지 못함
+	for vcm.GetClockCopy(0)[1] == 0 { // b 의 요청이 a 의 시계에 병합될 때까지 대기
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-entered:
+		t.Fatal("process 1 entered while process 0 holds the lock")
+	default:
+	}
+	a.Unlock()
+	<-entered
+	if !b.InCriticalSection() {
+		t.Fatal("process 1 not in critical section after grant")
+	}
+	b.Unlock()
+
+	// 허락 메시지로 b 의 시계가 a 의 Unlock 이후를 알게 됨
+	if got := vcm.GetClockCopy(1)[0]; got < 2 {
+		t.Fatalf("process 1 clock entry for 0 = %d, want >= 2", got)
+	}
+}
+
+func TestLockWithoutPeers(t *testing.T) {
+	vcm := NewVectorClockManager(1, WithLogger(nil))
+	p := NewProcess(0, vcm)
+	p.Lock()
+	if !p.InCriticalSection() {
+		t.Fatal("single process did not enter critical section")
+	}
+	p.Unlock()
+}
+
+func TestUnlockWithoutLockPanics(t *testing.T) {
+	vcm := NewVectorClockManager(1, WithLogger(nil))
+	p := NewProcess(0, vcm)
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Unlock of unlocked process did not panic")
+		}
+	}()
+	p.Unlock()
+}
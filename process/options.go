@@ -0,0 +1,239 @@
+package process
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ManagerOption VectorClockManager 설정 옵션
+//
+// 기본 설정: 프로세스별 시계(ClockPerProcess), BSS 인과 전달, 표준 출력 로그,
+// 프로세스 내부 전송(메일박스 채널), time.Now 시각.
+type ManagerOption func(*VectorClockManager)
+
+// ProcessOption Process 설정 옵션
+//
+// 기본 설정: 메일박스 크기 1, 송신 속도/흐름 제한 없음, 메일박스가 찰 때 무한정 대기.
+type ProcessOption func(*Process)
+
+// Logger 프로세스 동작 로그 출력 (*log.Logger 호환)
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// stdoutLogger 표준 출력 로그 (기본값)
+type stdoutLogger struct{}
+
+func (stdoutLogger) Printf(format string, v ...interface{}) {
+	fmt.Printf(format, v...)
+}
+
+// nopLogger 로그를 버림
+type nopLogger struct{}
+
+func (nopLogger) Printf(string, ...interface{}) {}
+
+// NopLogger 아무것도 출력하지 않는 Logger
+var NopLogger Logger = nopLogger{}
+
+// Transport 레지스트리에 없는(다른 노드의) 프로세스로 보내는 메시지의 전달 경로
+//
+// Send, SendDomain, Publish 등 레지스트리를 통한 송신은 대상이 로컬에 등록되어 있으면
+// 그 메일박스로, 아니면 Transport 가 돌려준 채널로 메시지를 넣는다.
+type Transport interface {
+	// Mailbox 프로세스 to 에게 메시지를 전달하는 채널 (알 수 없는 프로세스면 에러)
+	//
+	// 채널에서 꺼낸 메시지는 매니저의 Forwarded 로, 다른 노드에서 받은 메시지는 Deliver 로 넘긴다.
+	Mailbox(to int) (chan<- Message, error)
+}
+
+// TimeSource 메시지 Timestamp 에 쓰는 현재 시각 (FrozenTime, MonotonicTime, NewDisciplinedTime 참고)
+type TimeSource interface {
+	Now() time.Time
+}
+
+// TimeFunc 함수를 TimeSource 로 사용 (예: TimeFunc(time.Now))
+type TimeFunc func() time.Time
+
+// Now 현재 시각
+func (f TimeFunc) Now() time.Time {
+	return f()
+}
+
+// IDGenerator 송신자 from 이 보낼 메시지의 고유 ID 생성
+type IDGenerator func(from int) string
+
+// WithLogger 로그 출력 대상 지정 (nil 이면 NopLogger)
+func WithLogger(l Logger) ManagerOption {
+	return func(vcm *VectorClockManager) {
+		if l == nil {
+			l = NopLogger
+		}
+		vcm.logger = l
+	}
+}
+
+// WithTransport 레지스트리에 없는 프로세스로의 전달 경로 지정
+func WithTransport(t Transport) ManagerOption {
+	return func(vcm *VectorClockManager) {
+		vcm.transport = t
+	}
+}
+
+// WithTimeSource 메시지 시각에 쓸 시계 지정 (nil 이면 time.Now, 가상 시간이면 가상 시각)
+func WithTimeSource(ts TimeSource) ManagerOption {
+	return func(vcm *VectorClockManager) {
+		vcm.timeSource = ts
+	}
+}
+
+// WithIDGenerator 메시지 ID 생성 방식 지정 (nil 이면 "송신자-순번", 예: SequenceIDs, UUIDv7IDs)
+//
+// 기본 ID 는 송신자별 순번이므로 시각과 무관하게 같은 실행이 같은 메시지 ID 를 만들고, 빠르게 보내도 겹치지 않는다.
+func WithIDGenerator(g IDGenerator) ManagerOption {
+	return func(vcm *VectorClockManager) {
+		vcm.ids = g
+	}
+}
+
+// WithProcessTimeSource 이 프로세스의 메시지 Timestamp 에 쓸 시계 지정 (nil 이면 매니저 시각)
+//
+// WithClockSkew 보다 먼저 지정해야 어긋남의 기준 시각도 이 시계를 따른다.
+func WithProcessTimeSource(ts TimeSource) ProcessOption {
+	return func(p *Process) {
+		p.timeSource = ts
+	}
+}
+
+// WithMailboxSize 메일박스(MessageCh) 버퍼 크기 지정 (0 이면 버퍼 없음)
+func WithMailboxSize(n int) ProcessOption {
+	return func(p *Process) {
+		if n < 0 {
+			n = 0
+		}
+		p.MessageCh = make(chan Message, n)
+	}
+}
+
+// Logger 매니저가 쓰는 로그 출력 대상
+func (vcm *VectorClockManager) Logger() Logger {
+	if vcm.logger == nil {
+		return stdoutLogger{}
+	}
+	return vcm.logger
+}
+
+// logf 매니저 로그 출력
+func (vcm *VectorClockManager) logf(format string, v ...interface{}) {
+	if vcm.logger == nil {
+		stdoutLogger{}.Printf(format, v...)
+		return
+	}
+	vcm.logger.Printf(format, v...)
+}
+
+// logf 프로세스 로그 출력 (매니저의 Logger 사용)
+func (p *Process) logf(format string, v ...interface{}) {
+	p.ClockMgr.logf(format, v...)
+}
+
+// now 메시지 시각 (시계를 지정하지 않았으면 가상 시간 스케줄러의 시각, 그것도 없으면 time.Now)
+func (vcm *VectorClockManager) now() time.Time {
+	if vcm.timeSource != nil {
+		return vcm.timeSource.Now()
+	}
+	if s := vcm.virtualScheduler(); s != nil {
+		return s.Wall().Now()
+	}
+	return time.Now()
+}
+
+// nowLocked now 와 같지만 vcm.Mu (읽기 또는 쓰기) 또는 lockClock 보유 상태에서 호출
+func (vcm *VectorClockManager) nowLocked() time.Time {
+	if vcm.timeSource != nil {
+		return vcm.timeSource.Now()
+	}
+	if s := vcm.scheduler; s != nil && s.Virtual() {
+		return s.Wall().Now()
+	}
+	return time.Now()
+}
+
+// messageID 송신자 from 이 보낼 메시지의 ID
+func (vcm *VectorClockManager) messageID(from int) string {
+	if vcm.ids == nil {
+		return vcm.seqIDs.next("", from)
+	}
+	return vcm.ids(from)
+}
+
+// sequence 송신자별 메시지 순번
+type sequence struct {
+	mu     sync.Mutex
+	counts map[int]uint64
+}
+
+// next 송신자 from 의 다음 순번으로 만든 ID ("접두사-송신자-순번", 접두사가 없으면 "송신자-순번")
+func (s *sequence) next(prefix string, from int) string {
+	s.mu.Lock()
+	if s.counts == nil {
+		s.counts = make(map[int]uint64)
+	}
+	s.counts[from]++
+	n := s.counts[from]
+	s.mu.Unlock()
+
+	id := strconv.Itoa(from) + "-" + strconv.FormatUint(n, 10)
+	if prefix != "" {
+		id = prefix + "-" + id
+	}
+	return id
+}
+
+// SequenceIDs 송신자별 순번으로 만든 메시지 ID ("prefix-송신자-순번", prefix 가 "" 이면 "송신자-순번")
+//
+// 순번은 이 생성기를 만든 뒤 1 부터 시작하므로, 같은 프로세스가 다시 시작한 뒤에도 ID 가 겹치지 않아야
+// 하면 실행마다 다른 prefix(노드 이름과 부팅 번호 등)를 준다.
+func SequenceIDs(prefix string) IDGenerator {
+	s := &sequence{}
+	return func(from int) string {
+		return s.next(prefix, from)
+	}
+}
+
+// Deliver 다른 노드에서 받은 메시지를 로컬 프로세스 msg.To 의 메일박스에 넣음 (Transport 구현용)
+//
+// 메시지는 받는 쪽이 꺼낼 때까지 이 매니저에서 전송 중으로 센다. 메일박스가 가득 차면 전송 계층의 수신
+// 루프를 막지 않도록 받는 프로세스의 WithSendTimeout 만큼만 (지정하지 않았으면 전혀) 기다리고, 링 버퍼 메일박스면
+// 그 넘침 정책을 따른다. 넣지 못한 메시지는 받는 프로세스의 dead-letter 큐로 옮기고 ErrMailboxFull 을 반환한다.
+func (vcm *VectorClockManager) Deliver(msg Message) error {
+	target, ok := vcm.Lookup(msg.To)
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownProcess, msg.To)
+	}
+	timeout := target.sendTimeout
+	if timeout <= 0 {
+		timeout = -1
+	}
+	return target.offer(target.MessageCh, msg, timeout)
+}
+
+// Forwarded Transport 가 돌려준 채널에서 꺼내 다른 노드로 넘긴 메시지 정리 (Transport 구현용)
+//
+// 송신할 때 전송 중으로 센 메시지를 이 매니저의 계산에서 뺀다 (받는 노드가 다시 센다).
+func (vcm *VectorClockManager) Forwarded(msg Message) {
+	vcm.leave(msg)
+}
+
+// mailbox 프로세스 to 의 메일박스 (로컬 레지스트리 우선, 없으면 Transport)
+func (vcm *VectorClockManager) mailbox(to int) (chan<- Message, error) {
+	if target, ok := vcm.Lookup(to); ok {
+		return target.MessageCh, nil
+	}
+	if vcm.transport != nil {
+		return vcm.transport.Mailbox(to)
+	}
+	return nil, fmt.Errorf("%w: %d", ErrUnknownProcess, to)
+}
@@ -0,0 +1,78 @@
+package process
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithLoggerCapturesProcessLogs(t *testing.T) {
+	logs := &captureLogger{}
+	vcm := NewVectorClockManager(2, WithLogger(logs))
+	a := NewProcess(0, vcm)
+	NewProcess(1, vcm)
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if n := logs.count("Process 0: Sent message to Process 1"); n != 1 {
+		t.Fatalf("captured %d send lines, want 1: %v", n, logs.lines)
+	}
+	// nil 이면 로그를 버림
+	quiet := NewVectorClockManager(1, WithLogger(nil))
+	NewProcess(0, quiet).LocalEvent("x")
+}
+
+func TestWithMailboxSize(t *testing.T) {
+	vcm := NewVectorClockManager(3, WithLogger(nil))
+	if got := cap(NewProcess(0, vcm).MessageCh); got != 1 {
+		t.Fatalf("default mailbox size = %d, want 1", got)
+	}
+	if got := cap(NewProcess(1, vcm, WithMailboxSize(8)).MessageCh); got != 8 {
+		t.Fatalf("mailbox size = %d, want 8", got)
+	}
+	if got := cap(NewProcess(2, vcm, WithMailboxSize(-1)).MessageCh); got != 0 {
+		t.Fatalf("negative mailbox size gave %d, want 0", got)
+	}
+}
+
+// remoteTransport 레지스트리에 없는 프로세스 하나로 가는 Transport
+type remoteTransport struct {
+	id int
+	ch chan Message
+}
+
+func (t remoteTransport) Mailbox(to int) (chan<- Message, error) {
+	if to != t.id {
+		return nil, ErrUnknownProcess
+	}
+	return t.ch, nil
+}
+
+func TestWithTransportCarriesRemoteSends(t *testing.T) {
+	remote := remoteTransport{id: 1, ch: make(chan Message, 1)}
+	vcm := NewVectorClockManager(3, WithLogger(nil), WithTransport(remote))
+	a := NewProcess(0, vcm)
+
+	if err := a.Send(1, "remote"); err != nil {
+		t.Fatal(err)
+	}
+	if msg := <-remote.ch; msg.Event != "remote" || msg.To != 1 {
+		t.Fatalf("transport got %+v", msg)
+	}
+	if err := a.Send(2, "nowhere"); !errors.Is(err, ErrUnknownProcess) {
+		t.Fatalf("Send to unknown remote = %v, want ErrUnknownProcess", err)
+	}
+}
+
+func TestWithTimeSourceStampsMessages(t *testing.T) {
+	at := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithTimeSource(TimeFunc(func() time.Time { return at })))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if msg := <-b.MessageCh; msg.Timestamp != at.Unix() {
+		t.Fatalf("Timestamp = %d, want %d", msg.Timestamp, at.Unix())
+	}
+}
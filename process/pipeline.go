@@ -0,0 +1,118 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidPipeline 파이프라인 구성이 잘못된 경우 (단계 없음, 중복 프로세스, 다른 매니저)
+var ErrInvalidPipeline = errors.New("process: invalid pipeline")
+
+// StageFunc 파이프라인 단계의 변환 함수 (다음 단계로 보낼 메시지 내용, forward 가 false 면 보내지 않음)
+type StageFunc func(msg Message) (event string, forward bool)
+
+// PipelineBuilder 프로세스를 차례로 이어 파이프라인을 구성
+//
+// 각 단계는 메시지를 받아 시계를 병합하고 StageFunc 로 변환한 뒤 다음 단계로 보낸다 (송신 이벤트로 시계 전파).
+// 단계 사이의 채널은 레지스트리로 찾으므로 단계마다 채널을 따로 연결할 필요가 없다.
+type PipelineBuilder struct {
+	stages []*Process
+	funcs  []StageFunc
+}
+
+// NewPipeline 빈 PipelineBuilder 생성
+func NewPipeline() *PipelineBuilder {
+	return &PipelineBuilder{}
+}
+
+// Stage 프로세스 p 를 fn 으로 변환하는 다음 단계로 추가 (fn 이 nil 이면 받은 내용을 그대로 보냄)
+func (b *PipelineBuilder) Stage(p *Process, fn StageFunc) *PipelineBuilder {
+	b.stages = append(b.stages, p)
+	b.funcs = append(b.funcs, fn)
+	return b
+}
+
+// Build 단계를 검증하고 Pipeline 생성 (단계는 아직 시작하지 않음, Start 참고)
+func (b *PipelineBuilder) Build() (*Pipeline, error) {
+	if len(b.stages) == 0 {
+		return nil, fmt.Errorf("%w: no stages", ErrInvalidPipeline)
+	}
+	seen := make(map[int]bool, len(b.stages))
+	for i, p := range b.stages {
+		switch {
+		case p == nil:
+			return nil, fmt.Errorf("%w: stage %d has no process", ErrInvalidPipeline, i)
+		case p.ClockMgr != b.stages[0].ClockMgr:
+			return nil, fmt.Errorf("%w: stage %d (process %d) uses a different manager", ErrInvalidPipeline, i, p.ID)
+		case seen[p.ID]:
+			return nil, fmt.Errorf("%w: process %d appears in more than one stage", ErrInvalidPipeline, p.ID)
+		}
+		seen[p.ID] = true
+	}
+	return &Pipeline{
+		stages: append([]*Process(nil), b.stages...),
+		funcs:  append([]StageFunc(nil), b.funcs...),
+	}, nil
+}
+
+// Pipeline 구성된 파이프라인 (첫 단계가 입력을 받고 마지막 단계의 출력은 버림)
+type Pipeline struct {
+	stages []*Process
+	funcs  []StageFunc
+}
+
+// Stages 단계 프로세스 (입력 쪽부터)
+func (pl *Pipeline) Stages() []*Process {
+	return append([]*Process(nil), pl.stages...)
+}
+
+// Head 첫 단계 프로세스
+func (pl *Pipeline) Head() *Process {
+	return pl.stages[0]
+}
+
+// Tail 마지막 단계 프로세스
+func (pl *Pipeline) Tail() *Process {
+	return pl.stages[len(pl.stages)-1]
+}
+
+// Start 모든 단계의 수신 루프 시작 (하나라도 실패하면 이미 시작한 단계를 멈추고 에러 반환)
+func (pl *Pipeline) Start() error {
+	for i, p := range pl.stages {
+		if err := p.Start(pl.behavior(i)); err != nil {
+			for _, started := range pl.stages[:i] {
+				started.Stop()
+			}
+			return fmt.Errorf("%w: pipeline stage %d (process %d)", err, i, p.ID)
+		}
+	}
+	return nil
+}
+
+// Stop 모든 단계의 수신 루프를 입력 쪽부터 멈춤
+func (pl *Pipeline) Stop() {
+	for _, p := range pl.stages {
+		p.Stop()
+	}
+}
+
+// Feed 프로세스 from 이 첫 단계로 event 를 보냄
+func (pl *Pipeline) Feed(from *Process, event string) error {
+	return from.Send(pl.Head().ID, event)
+}
+
+// behavior i 번째 단계의 메시지 처리 함수
+func (pl *Pipeline) behavior(i int) Behavior {
+	fn := pl.funcs[i]
+	last := i == len(pl.stages)-1
+	return func(msg Message) []Outgoing {
+		event, forward := msg.Event, true
+		if fn != nil {
+			event, forward = fn(msg)
+		}
+		if !forward || last {
+			return nil
+		}
+		return []Outgoing{{To: pl.stages[i+1].ID, Event: event}}
+	}
+}
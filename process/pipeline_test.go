@@ -0,0 +1,89 @@
+package process
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPipelineForwardsThroughStages(t *testing.T) {
+	vcm := NewVectorClockManager(4, WithLogger(nil))
+	source := NewProcess(0, vcm, WithMailboxSize(8))
+	out := make(chan Message, 4)
+	pl, err := NewPipeline().
+		Stage(NewProcess(1, vcm, WithMailboxSize(8)), func(msg Message) (string, bool) {
+			return strings.ToUpper(msg.Event), true
+		}).
+		Stage(NewProcess(2, vcm, WithMailboxSize(8)), func(msg Message) (string, bool) {
+			return msg.Event, msg.Event != "SKIP"
+		}).
+		Stage(NewProcess(3, vcm, WithMailboxSize(8)), func(msg Message) (string, bool) {
+			out <- msg
+			return "", false
+		}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pl.Head().ID != 1 || pl.Tail().ID != 3 || len(pl.Stages()) != 3 {
+		t.Fatalf("stages %v, head %d, tail %d", pl.Stages(), pl.Head().ID, pl.Tail().ID)
+	}
+	if err := pl.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer pl.Stop()
+
+	for _, event := range []string{"a", "skip", "b"} {
+		if err := pl.Feed(source, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, want := range []string{"A", "B"} {
+		msg := waitMessage(t, out)
+		if msg.Event != want || msg.From != 2 {
+			t.Fatalf("tail received %+v, want %s from stage 2", msg, want)
+		}
+		// 입력부터 모든 단계를 거친 인과 사슬: 원본 송신이 i+1 번째 입력 이상을 포함
+		if msg.Vector[0] < 1+2*i || msg.Vector[1] == 0 {
+			t.Fatalf("tail message vector %v does not carry the source and first stage", msg.Vector)
+		}
+	}
+}
+
+func TestPipelineBuildRejectsInvalidStages(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	other := NewVectorClockManager(2, WithLogger(nil))
+	p0, p1 := NewProcess(0, vcm), NewProcess(1, vcm)
+	for name, b := range map[string]*PipelineBuilder{
+		"empty":             NewPipeline(),
+		"nil process":       NewPipeline().Stage(p0, nil).Stage(nil, nil),
+		"different manager": NewPipeline().Stage(p0, nil).Stage(NewProcess(1, other), nil),
+		"duplicate":         NewPipeline().Stage(p0, nil).Stage(p1, nil).Stage(p0, nil),
+	} {
+		if _, err := b.Build(); !errors.Is(err, ErrInvalidPipeline) {
+			t.Fatalf("%s: Build() = %v, want ErrInvalidPipeline", name, err)
+		}
+	}
+}
+
+func TestPipelineStartStopsStartedStagesOnFailure(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	first, busy := NewProcess(0, vcm), NewProcess(1, vcm)
+	if err := busy.Start(func(Message) []Outgoing { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	defer busy.Stop()
+
+	pl, err := NewPipeline().Stage(first, nil).Stage(busy, nil).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pl.Start(); !errors.Is(err, ErrAlreadyRunning) {
+		t.Fatalf("Start() = %v, want ErrAlreadyRunning", err)
+	}
+	// 첫 단계는 멈췄으므로 다시 시작할 수 있음
+	if err := first.Start(func(Message) []Outgoing { return nil }); err != nil {
+		t.Fatalf("first stage was left running: %v", err)
+	}
+	first.Stop()
+}
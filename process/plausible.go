@@ -0,0 +1,156 @@
+package process
+
+import "sync"
+
+// Comb 프로세스 ID 를 R 개의 시계 항목 중 하나로 사상하는 전략
+type Comb func(processID, r int) int
+
+// ModComb 프로세스 ID 를 R 로 나눈 나머지 항목에 사상 (i mod R)
+func ModComb(processID, r int) int {
+	return processID % r
+}
+
+// BlockComb n 개의 프로세스를 연속된 블록 단위로 R 개 항목에 사상 (i * R / n)
+func BlockComb(n int) Comb {
+	return func(processID, r int) int {
+		if n <= r {
+			return processID % r
+		}
+		return processID * r / n
+	}
+}
+
+// PlausibleClockManager 고정 크기(R 항목) Plausible Clock 관리
+//
+// 프로세스 수 N 과 무관하게 시계 크기가 R 로 고정되므로, 수만 개의 논리 프로세스에서도
+// 메시지당 O(R) 비용만 든다. 대신 동시(concurrent) 이벤트를 선후 관계로 잘못 보고할 수 있다.
+// 인과 관계(a -> b)는 항상 올바르게 보고되며, "동시" 라는 결과는 항상 정확하다.
+// Compare 는 결과가 틀릴 수 있는 항목과 프로세스 수를 오차 한계로 함께 반환한다.
+type PlausibleClockManager struct {
+	Clock map[int][]int // 프로세스별 Plausible Clock (프로세스 ID -> R 항목 시계)
+	R     int           // 시계 항목 수
+	Comb  Comb          // 항목 사상 전략
+	Mu    sync.Mutex    // 동시성 제어
+
+	exact bool // 모든 프로세스가 서로 다른 항목에 사상되는지 여부
+}
+
+// NewPlausibleClockManager PlausibleClockManager 초기화 (comb 가 nil 이면 ModComb 사용)
+func NewPlausibleClockManager(n, r int, comb Comb) *PlausibleClockManager {
+	if r <= 0 {
+		r = 1
+	}
+	if comb == nil {
+		comb = ModComb
+	}
+
+	clock := make(map[int][]int, n)
+	used := make(map[int]bool, r)
+	exact := true
+	for i := 0; i < n; i++ {
+		clock[i] = make([]int, r)
+		entry := comb(i, r)
+		if used[entry] {
+			exact = false
+		}
+		used[entry] = true
+	}
+	return &PlausibleClockManager{Clock: clock, R: r, Comb: comb, exact: exact}
+}
+
+// Entry 프로세스가 증가시키는 시계 항목 인덱스
+func (pcm *PlausibleClockManager) Entry(processID int) int {
+	return pcm.Comb(processID, pcm.R)
+}
+
+// UpdateClock 특정 프로세스의 Plausible Clock 업데이트
+func (pcm *PlausibleClockManager) UpdateClock(processID int, receivedClock []int) {
+	pcm.Mu.Lock()
+	defer pcm.Mu.Unlock()
+
+	clock, ok := pcm.Clock[processID]
+	if !ok {
+		clock = make([]int, pcm.R)
+		pcm.Clock[processID] = clock
+		pcm.exact = false
+	}
+	for i := 0; i < len(receivedClock) && i < len(clock); i++ {
+		if receivedClock[i] > clock[i] {
+			clock[i] = receivedClock[i]
+		}
+	}
+
+	// 자신에게 사상된 항목 증가 (로컬 이벤트 1 증가)
+	clock[pcm.Entry(processID)]++
+}
+
+// GetClock 특정 프로세스의 Plausible Clock 반환
+func (pcm *PlausibleClockManager) GetClock(processID int) []int {
+	pcm.Mu.Lock()
+	defer pcm.Mu.Unlock()
+
+	clockCopy := make([]int, len(pcm.Clock[processID]))
+	copy(clockCopy, pcm.Clock[processID])
+	return clockCopy
+}
+
+// Exact 모든 프로세스가 서로 다른 항목을 사용하여 비교 결과가 항상 정확한지 여부
+func (pcm *PlausibleClockManager) Exact() bool {
+	pcm.Mu.Lock()
+	defer pcm.Mu.Unlock()
+	return pcm.exact
+}
+
+// PlausibleComparison Plausible Clock 비교 결과와 그 오차 한계
+//
+// 항목 하나를 여러 프로세스가 공유하면 그 항목 값은 그 프로세스들의 이벤트를 합친 것이므로,
+// 한 프로세스의 이벤트를 앞선 쪽만 알고 있어도 다른 프로세스의 이벤트에 가려질 수 있다.
+// 결과가 틀렸다면(실제로는 동시) 그 원인은 Ambiguous 항목에 사상된 Suspect 개 프로세스 중에 있다.
+type PlausibleComparison struct {
+	Order     Ordering // 시계 비교 결과
+	Exact     bool     // 결과가 정확함이 보장되는지 (Suspect 가 0)
+	Ambiguous []int    // 결과를 뒤집을 수 있는 공유 항목 (오름차순)
+	Suspect   int      // Ambiguous 항목에 사상된 프로세스 수 (동시 관계가 가려졌을 수 있는 최대 프로세스 수)
+	Processes int      // 시계를 가진 프로세스 수 (Suspect / Processes 가 잘못 보고될 수 있는 비율의 상한)
+}
+
+// Compare 두 Plausible Clock 비교 (결과와 오차 한계 반환)
+//
+// Concurrent 는 항상 정확하다. Before/After/Equal 은 앞선(Equal 이면 어느) 쪽이 이벤트를 알고 있는
+// 공유 항목이 있을 때만 실제로는 동시일 수 있으며, 그런 항목과 거기에 사상된 프로세스 수를 함께 반환한다.
+func (pcm *PlausibleClockManager) Compare(a, b []int) PlausibleComparison {
+	c := PlausibleComparison{Order: Compare(a, b)}
+	collisions := pcm.Collisions()
+	for _, n := range collisions {
+		c.Processes += n
+	}
+
+	// 앞선 쪽 시계 (그 쪽이 아는 이벤트가 다른 쪽에 가려졌을 수 있음)
+	var earlier []int
+	switch c.Order {
+	case Before, Equal:
+		earlier = a
+	case After:
+		earlier = b
+	}
+	for e := 0; e < len(earlier) && e < len(collisions); e++ {
+		if earlier[e] > 0 && collisions[e] > 1 {
+			c.Ambiguous = append(c.Ambiguous, e)
+			c.Suspect += collisions[e]
+		}
+	}
+	c.Exact = c.Suspect == 0
+	return c
+}
+
+// Collisions 항목별로 사상된 프로세스 수 (1 보다 크면 해당 항목에서 정밀도 손실 가능)
+func (pcm *PlausibleClockManager) Collisions() []int {
+	pcm.Mu.Lock()
+	defer pcm.Mu.Unlock()
+
+	counts := make([]int, pcm.R)
+	for id := range pcm.Clock {
+		counts[pcm.Comb(id, pcm.R)]++
+	}
+	return counts
+}
@@ -0,0 +1,104 @@
+package process
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestBlockCombKeepsNeighboursTogether(t *testing.T) {
+	comb := BlockComb(6)
+	for id, want := range []int{0, 0, 1, 1, 2, 2} {
+		if got := comb(id, 3); got != want {
+			t.Errorf("BlockComb(6)(%d, 3) = %d, want %d", id, got, want)
+		}
+	}
+	pcm := NewPlausibleClockManager(6, 3, comb)
+	if got, want := pcm.GetClock(5), []int{0, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("clock size = %v, want R entries", got)
+	}
+}
+
+func TestPlausibleCompareReportsSharedEntries(t *testing.T) {
+	pcm := NewPlausibleClockManager(4, 2, ModComb) // 0, 2 -> 항목 0 / 1, 3 -> 항목 1
+	pcm.UpdateClock(0, nil)
+	pcm.UpdateClock(2, nil)
+	pcm.UpdateClock(2, nil)
+	pcm.UpdateClock(1, nil)
+
+	// 0 과 2 의 이벤트는 실제로는 동시이지만 같은 항목을 써서 선후로 보임
+	c := pcm.Compare(pcm.GetClock(0), pcm.GetClock(2))
+	if c.Order != Before || c.Exact || !reflect.DeepEqual(c.Ambiguous, []int{0}) || c.Suspect != 2 || c.Processes != 4 {
+		t.Fatalf("shared entry: got %+v", c)
+	}
+	// 서로 다른 항목의 증가는 동시로 정확히 보고됨
+	if c := pcm.Compare(pcm.GetClock(0), pcm.GetClock(1)); c.Order != Concurrent || !c.Exact || c.Suspect != 0 {
+		t.Fatalf("concurrent: got %+v", c)
+	}
+}
+
+func TestPlausibleCompareExactWithoutCollisions(t *testing.T) {
+	pcm := NewPlausibleClockManager(3, 3, nil)
+	pcm.UpdateClock(0, nil)
+	pcm.UpdateClock(1, pcm.GetClock(0))
+	if c := pcm.Compare(pcm.GetClock(0), pcm.GetClock(1)); c.Order != Before || !c.Exact || len(c.Ambiguous) != 0 {
+		t.Fatalf("got %+v, want exact Before", c)
+	}
+}
+
+// TestPlausibleCompareBoundHolds 실제 Vector Clock 과 함께 실행해 틀린 결과가 항상 오차 한계 안에 있는지 확인
+func TestPlausibleCompareBoundHolds(t *testing.T) {
+	const n, r = 6, 3
+	rng := rand.New(rand.NewSource(1))
+	pcm := NewPlausibleClockManager(n, r, ModComb)
+	truth := NewVectorClockManager(n, WithLogger(nil))
+
+	type snapshot struct{ exact, plausible []int }
+	var events []snapshot
+	for step := 0; step < 300; step++ {
+		from, to := rng.Intn(n), rng.Intn(n)
+		if rng.Intn(2) == 0 {
+			truth.UpdateClock(to, truth.GetClockCopy(from))
+			pcm.UpdateClock(to, pcm.GetClock(from))
+		} else {
+			truth.UpdateClock(to, nil)
+			pcm.UpdateClock(to, nil)
+		}
+		events = append(events, snapshot{truth.GetClockCopy(to), pcm.GetClock(to)})
+	}
+
+	wrong := 0
+	for i := range events {
+		for j := range events {
+			want := Compare(events[i].exact, events[j].exact)
+			c := pcm.Compare(events[i].plausible, events[j].plausible)
+			if c.Order == want {
+				continue
+			}
+			wrong++
+			if c.Exact || c.Order == Concurrent || want != Concurrent {
+				t.Fatalf("events %d, %d: plausible %+v, exact %v outside the bound", i, j, c, want)
+			}
+			// 실제 동시 관계를 만든 프로세스 중 하나는 반드시 Ambiguous 항목에 사상됨
+			covered := false
+			for p := 0; p < n; p++ {
+				later := events[j].exact
+				earlier := events[i].exact
+				if c.Order == After {
+					earlier, later = later, earlier
+				}
+				if earlier[p] > later[p] || (c.Order == Equal && earlier[p] != later[p]) {
+					for _, e := range c.Ambiguous {
+						covered = covered || pcm.Entry(p) == e
+					}
+				}
+			}
+			if !covered {
+				t.Fatalf("events %d, %d: no ambiguous entry explains %+v", i, j, c)
+			}
+		}
+	}
+	if wrong == 0 {
+		t.Fatal("simulation produced no misreported pair, test does not exercise the bound")
+	}
+}
@@ -0,0 +1,49 @@
+package process
+
+import "sync"
+
+// vectorBuf 풀에 보관하는 Vector Clock 슬라이스 (포인터로 보관해 Put 할 때 할당하지 않음)
+type vectorBuf struct {
+	v []int
+}
+
+var (
+	// vectorPool 수신 처리가 끝난 메시지에서 돌려받은 Vector Clock 슬라이스
+	vectorPool = sync.Pool{New: func() any { return new(vectorBuf) }}
+	// wirePool 디코딩에 재사용하는 WireMessage
+	wirePool = sync.Pool{New: func() any { return new(WireMessage) }}
+)
+
+// AcquireWire 디코딩에 쓸 빈 WireMessage (Vector 는 풀에서 빌린 용량을 가진 빈 슬라이스)
+//
+// 전송 계층의 수신 루프에서 gob / JSON 디코더가 Vector 를 새로 할당하지 않고 기존 용량에 채우도록 쓴다.
+// Decode 로 만든 Message 가 Vector 를 넘겨받고, ReceiveMessages 가 병합을 마친 뒤 풀로 돌려준다.
+// 메시지를 보관하는 경로(dead-letter, 액터 Behavior, 응답 대기)로 간 Vector 는 돌려받지 않고 GC 가 회수한다.
+// 다 쓴 WireMessage 는 Decode 뒤에 ReleaseWire 로 돌려준다.
+//
+// 메모리 내 전송(SendMessage, Deliver)과 sim 의 시뮬레이션 네트워크는 송신자의 게시된 불변 시계 스냅샷을
+// 그대로 Vector 로 실어 보내므로 메시지마다 복사하는 Vector 가 없고, 풀은 디코딩하는 전송 계층에만 쓴다.
+func AcquireWire() *WireMessage {
+	w := wirePool.Get().(*WireMessage)
+	buf := vectorPool.Get().(*vectorBuf)
+	w.Vector = buf.v[:0]
+	w.buf = buf
+	return w
+}
+
+// ReleaseWire AcquireWire 로 받은 WireMessage 를 비워서 반환 (Vector 는 Decode 로 Message 에 넘어갔으므로 건드리지 않음)
+func ReleaseWire(w *WireMessage) {
+	*w = WireMessage{}
+	wirePool.Put(w)
+}
+
+// releaseVector 풀에서 빌린 메시지 Vector 를 돌려줌 (풀에서 온 메시지가 아니면 무시)
+//
+// 메시지와 그 복사본이 더 이상 Vector 를 읽지 않을 때만 호출한다.
+func releaseVector(msg Message) {
+	if msg.buf == nil {
+		return
+	}
+	msg.buf.v = msg.Vector[:0]
+	vectorPool.Put(msg.buf)
+}
@@ -0,0 +1,118 @@
+package process
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestCaptureStateCopiesPooledVectors(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	p := NewProcess(1, vcm, WithMailboxSize(4))
+	dec := gob.NewDecoder(decodeStream(t, 1, 2))
+
+	w := AcquireWire()
+	if err := dec.Decode(w); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := w.Decode()
+	ReleaseWire(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vcm.Deliver(msg); err != nil {
+		t.Fatal(err)
+	}
+	s := vcm.CaptureState()
+	if len(s.Transit) != 1 {
+		t.Fatalf("transit = %v, want the delivered message", s.Transit)
+	}
+	captured := s.Transit[0]
+	if captured.buf != nil || &captured.Vector[0] == &msg.Vector[0] {
+		t.Fatal("captured message shares the pooled vector that returns after receive")
+	}
+	if err := p.ReceiveMessages(p.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if captured.Vector[0] != 1 {
+		t.Fatalf("captured vector = %v, want [1 0]", captured.Vector)
+	}
+}
+
+// decodeStream n 개 메시지를 gob 으로 이어 쓴 스트림
+func decodeStream(t testing.TB, n, size int) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	for i := 0; i < n; i++ {
+		vector := make([]int, size)
+		vector[0] = i + 1
+		if err := enc.Encode(Message{From: 0, To: 1, Vector: vector, Event: "m", MessageID: "id"}.Wire()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &buf
+}
+
+func TestPooledVectorReturnsAfterReceive(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	p := NewProcess(1, vcm, WithMailboxSize(4))
+	dec := gob.NewDecoder(decodeStream(t, 1, 2))
+
+	w := AcquireWire()
+	if err := dec.Decode(w); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := w.Decode()
+	ReleaseWire(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.buf == nil {
+		t.Fatal("decoded message does not carry its pooled buffer")
+	}
+	buf := msg.buf
+	if err := vcm.Deliver(msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.ReceiveMessages(p.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if got := vcm.GetClock(1); got[0] != 1 {
+		t.Fatalf("clock = %v, want the sender entry merged", got)
+	}
+	if cap(buf.v) < 2 || len(buf.v) != 0 {
+		t.Fatalf("buffer after receive has len %d cap %d, want an empty slice keeping its capacity", len(buf.v), cap(buf.v))
+	}
+}
+
+// BenchmarkDecodeWire 풀을 쓴 디코딩과 매번 새로 할당하는 디코딩의 메시지당 할당 비교
+func BenchmarkDecodeWire(b *testing.B) {
+	for _, bc := range []struct {
+		name   string
+		pooled bool
+	}{{"fresh", false}, {"pooled", true}} {
+		b.Run(bc.name, func(b *testing.B) {
+			dec := gob.NewDecoder(decodeStream(b, b.N, 64))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				w := new(WireMessage)
+				if bc.pooled {
+					w = AcquireWire()
+				}
+				if err := dec.Decode(w); err != nil {
+					b.Fatal(err)
+				}
+				msg, err := w.Decode()
+				if err != nil {
+					b.Fatal(err)
+				}
+				if bc.pooled {
+					ReleaseWire(w)
+					releaseVector(msg) // ReceiveMessages 가 병합 뒤에 하는 반환
+				}
+			}
+		})
+	}
+}
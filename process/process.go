@@ -1,8 +1,8 @@
 package process
 
 import (
-	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -10,16 +10,70 @@ import (
 type Message struct {
 	From      int    // 메시지를 보낸 프로세스 ID
 	To        int    // 메시지를 받는 프로세스 ID
-	Vector    []int  // 메시지를 보낸 프로세스의 Vector Clock
+	Vector    []int  // 메시지를 보낸 프로세스의 Vector Clock (읽기 전용, 송신자의 시계 스냅샷과 공유)
 	Event     string // 메시지 내용
 	MessageID string // 메시지 고유 ID
 	Timestamp int64  // 메시지 전송 시점
+	Epoch     int    // 메시지를 보낸 시점의 에포크
+	Domain    string // 메시지가 갱신하는 시계 도메인 ("" 이면 기본 시계)
+	Topic     string // 발행된 토픽 ("" 이면 토픽 없음)
+
+	Kind    MessageKind    // 메시지 종류 (일반/요청/응답)
+	ReplyTo string         // 응답 메시지가 가리키는 요청 메시지 ID
+	ReplyCh chan<- Message // 동기 요청의 응답 채널 (SendSync)
+	call    *pendingCall   // 동기 요청의 응답 대기 (SendSync, 요청자가 기다리지 않으면 응답을 받지 않음)
+
+	CausalParent string // 이 메시지를 보내게 한 수신 메시지의 ID
+	TraceID      string // 애플리케이션 trace ID (인과 부모로부터 전파)
+
+	windowed bool // 흐름 제어 윈도우에 포함된 메시지 여부 (수신 시 확인 응답)
+
+	Signature []byte // From, To, Vector, Event, MessageID 의 HMAC (WithSigningKey)
+
+	Causal     []int         // BSS 인과 브로드캐스트 벡터
+	DestClocks map[int][]int // SES 목적지별 벡터 집합
+
+	buf *vectorBuf // Vector 를 빌려 온 풀 버퍼 (AcquireWire 로 디코딩한 메시지, 수신 처리 후 반환)
 }
 
 // VectorClockManager 모든 프로세스의 Vector Clock 관리
 type VectorClockManager struct {
-	Clock map[int][]int // 프로세스별 Vector Clock (프로세스 ID -> Vector Clock)
-	Mu    sync.Mutex    // 동시성 제어
+	Clock map[int][]int // 프로세스별 Vector Clock (프로세스 ID -> Vector Clock, 매니저 메서드로만 변경)
+	Mu    sync.RWMutex  // 동시성 제어 (쓰기 잠금은 모든 시계, 읽기 잠금 + 프로세스별 잠금은 시계 하나)
+
+	clockMu   clockLocks // 프로세스별 시계 잠금 (lockClock)
+	published sync.Map   // 프로세스별 게시된 시계 스냅샷 (프로세스 ID -> *publishedClock, GetClock)
+
+	Delivery DeliveryAlgorithm // 인과 전달 알고리즘
+	Mode     ClockMode         // Vector Clock 유지 단위
+	stats    deliveryCounters  // 인과 전달 통계
+
+	channels    map[ChannelKey][]int          // 채널별 Vector Clock (ClockPerChannel)
+	epoch       int                           // 현재 에포크
+	epochs      []epochInfo                   // 에포크별 기준 시계
+	domains     map[string]*clockDomain       // 이름 있는 시계 도메인
+	crossings   []BridgeCrossing              // 도메인 건너감 기록
+	scheduler   *Scheduler                    // 예약 송신 스케줄러
+	autoAdvance bool                          // 정지 상태에서 가상 시간 자동 진행 (WithVirtualTime)
+	log         eventLog                      // 이벤트 기록
+	logger      Logger                        // 로그 출력 (nil 이면 표준 출력)
+	transport   Transport                     // 레지스트리에 없는 프로세스로의 전달 경로
+	timeSource  TimeSource                    // 메시지 시각 (nil 이면 time.Now)
+	ids         IDGenerator                   // 메시지 ID 생성 (nil 이면 seqIDs)
+	seqIDs      sequence                      // 송신자별 메시지 순번 (기본 메시지 ID)
+	signingKey  func(from int) ([]byte, bool) // 메시지 서명 키 (nil 이면 서명하지 않음)
+	audit       auditLog                      // 시계 변경 기록 (WithAudit)
+	metrics     metricsState                  // 프로세스별 카운터 (Metrics, PublishExpvar)
+	quarantine  quarantineState               // 불가능한 시계를 보낸 송신자 격리 (WithClockValidation)
+	pressure    backpressureState             // 메일박스 포화 알림 (WatchBackpressure)
+	term        terminationState              // 종료 감지 상태
+	locks       contentionState               // 잠금 대기 계측 (WithLockProfiling)
+
+	procMu sync.RWMutex           // 프로세스 레지스트리 동시성 제어
+	procs  map[int]*Process       // 등록된 프로세스 (프로세스 ID -> Process)
+	rings  atomic.Int32           // 링 버퍼 메일박스를 쓰는 등록된 프로세스 수 (0 이면 송신할 때 조회 생략)
+	groups map[string][]int       // 프로세스 그룹 (그룹 이름 -> 멤버 ID)
+	topics map[string]*topicState // 발행/구독 토픽
 }
 
 // Process 분산 시스템의 프로세스를 나타냄
@@ -28,23 +82,73 @@ type Process struct {
 	MessageCh chan Message        // 프로세스별 수신 채널
 	ClockMgr  *VectorClockManager // Vector Clock 매니저
 	Mu        sync.Mutex          // 동시성 제어
+
+	causal    causalState    // 인과 전달 상태
+	actor     actorState     // 액터 실행 상태
+	trace     traceState     // 메시지 인과 체인 추적 상태
+	calls     callTable      // 응답을 기다리는 요청
+	heartbeat heartbeatState // 하트비트 송수신 상태
+	mutex     mutexState     // 분산 상호 배제 상태
+
+	limiter     *tokenBucket    // 송신 속도 제한 (nil 이면 제한 없음)
+	sendTimeout time.Duration   // 메일박스가 가득 찼을 때 기다릴 최대 시간 (0 이면 무한정)
+	dlq         deadLetterQueue // 전달하지 못한 메시지
+	flow        *flowControl    // 링크별 흐름 제어 (nil 이면 제한 없음)
+	ring        *RingMailbox    // 링 버퍼 메일박스 (nil 이면 MessageCh 채널, WithRingMailbox)
+	dedupe      *dedupeState    // 수신 중복 제거 창 (nil 이면 중복을 확인하지 않음, WithDedupe)
+	stash       stashState      // 선택 수신이 건너뛰어 보관 중인 메시지 (ReceiveFrom, ReceiveMatching)
+	lastActive  atomic.Int64    // 마지막 활동 시각 (UnixNano, Health)
+	lastSent    atomic.Value    // 마지막으로 만든 송신 메시지 ID (string, LastMessageID)
+	skew        clockSkew       // 벽시계 어긋남 (Timestamp)
+	timeSource  TimeSource      // 프로세스 시계 (nil 이면 매니저 시각)
 }
 
 // NewVectorClockManager VectorClockManager 초기화
-func NewVectorClockManager(n int) *VectorClockManager {
+//
+// 옵션으로 시계 유지 단위(WithClockMode), 인과 전달 알고리즘(WithDelivery), 로그(WithLogger),
+// 전달 경로(WithTransport), 시각(WithTimeSource), 메시지 ID(WithIDGenerator), 스케줄러(WithScheduler),
+// 가상 시간(WithVirtualTime), 메시지 서명(WithSigningKey), 잠금 대기 계측(WithLockProfiling) 을 지정할 수 있다.
+func NewVectorClockManager(n int, opts ...ManagerOption) *VectorClockManager {
 	clock := make(map[int][]int)
 	for i := 0; i < n; i++ {
 		clock[i] = make([]int, n) // 각 프로세스의 Vector Clock 초기화
 	}
-	return &VectorClockManager{Clock: clock}
+	// 에포크 0 정보는 처음부터 있으므로 에포크를 읽는 경로는 읽기 잠금만 잡음
+	vcm := &VectorClockManager{Clock: clock, epochs: []epochInfo{{base: make([]int, n)}}}
+	for _, opt := range opts {
+		opt(vcm)
+	}
+	vcm.publishAllLocked()
+	return vcm
 }
 
 // UpdateClock 특정 프로세스의 Vector Clock 업데이트
 func (vcm *VectorClockManager) UpdateClock(processID int, receivedClock []int) {
-	vcm.Mu.Lock()
-	defer vcm.Mu.Unlock()
+	vcm.updateClock(processID, receivedClock, -1, "")
+}
 
+// updateClock UpdateClock 과 같지만 감사 기록에 병합한 메시지(송신자 from, ID messageID)를 남김
+func (vcm *VectorClockManager) updateClock(processID int, receivedClock []int, from int, messageID string) {
+	vcm.updateClockIn(processID, -1, receivedClock, from, messageID)
+}
+
+// updateClockIn 현재 에포크가 epoch 일 때만 updateClock (epoch 가 음수면 확인하지 않음, 갱신했으면 true)
+//
+// 에포크 확인과 병합을 같은 잠금 구간에서 하므로 다른 에포크 기준으로 변환된 시계가 병합되지 않는다
+// (ResetEpoch / Checkpoint 는 vcm.Mu 쓰기 잠금을 기다림).
+func (vcm *VectorClockManager) updateClockIn(processID, epoch int, receivedClock []int, from int, messageID string) bool {
+	vcm.lockClock(processID)
+	defer vcm.unlockClock(processID)
+
+	if epoch >= 0 && epoch != vcm.epoch {
+		return false
+	}
+
+	old := vcm.auditBefore(vcm.Clock[processID])
+	cause := AuditLocal
 	if receivedClock != nil {
+		cause = AuditMerge
+		vcm.counters(processID).merges.Add(1)
 		// Vector Clocks merge: 최대값으로 병합
 		for i := 0; i < len(receivedClock); i++ {
 			if receivedClock[i] > vcm.Clock[processID][i] {
@@ -55,84 +159,171 @@ func (vcm *VectorClockManager) UpdateClock(processID int, receivedClock []int) {
 
 	// 자신의 인덱스 값 증가 (로컬 이벤트 1 증가)
 	vcm.Clock[processID][processID]++
+	vcm.auditClockLocked(processID, cause, from, messageID, old)
+	vcm.publishLocked(processID)
+	return true
 }
 
-// GetClock 특정 프로세스의 Vector Clock 반환
+// GetClock 특정 프로세스의 Vector Clock 반환 (읽기 전용, 바꾸려면 GetClockCopy)
+//
+// 시계가 바뀔 때마다 게시되는 불변 스냅샷을 그대로 반환하므로 잠금도 할당도 없다.
+// 반환된 슬라이스는 다른 호출자, 송신 메시지, 이벤트 기록과 공유되므로 값을 바꾸면 안 된다.
 func (vcm *VectorClockManager) GetClock(processID int) []int {
-	vcm.Mu.Lock()
-	defer vcm.Mu.Unlock()
+	if clock, ok := vcm.loadClock(processID); ok {
+		return clock
+	}
+	return vcm.GetClockCopy(processID)
+}
+
+// GetClockCopy 특정 프로세스의 Vector Clock 복사본 반환 (호출자가 바꿔도 됨)
+func (vcm *VectorClockManager) GetClockCopy(processID int) []int {
+	if clock, ok := vcm.loadClock(processID); ok {
+		return append(make([]int, 0, len(clock)), clock...)
+	}
 
-	// Vector Clock 복사본 반환
+	// 매니저에 없는 프로세스
+	vcm.lockClock(processID)
+	defer vcm.unlockClock(processID)
 	clockCopy := make([]int, len(vcm.Clock[processID]))
 	copy(clockCopy, vcm.Clock[processID])
 	return clockCopy
 }
 
 // NewProcess Process 초기화
-func NewProcess(id int, clockMgr *VectorClockManager) *Process {
-	return &Process{
+//
+// 옵션으로 메일박스 크기(WithMailboxSize), 링 버퍼 메일박스(WithRingMailbox), 송신 속도 제한(WithRateLimit), 흐름 제어(WithWindow),
+// 송신 제한 시간(WithSendTimeout), 중복 제거(WithDedupe), 시계(WithProcessTimeSource), 벽시계 어긋남(WithClockSkew) 을 지정할 수 있다.
+func NewProcess(id int, clockMgr *VectorClockManager, opts ...ProcessOption) *Process {
+	p := &Process{
 		ID:        id,
 		MessageCh: make(chan Message, 1), // 프로세스별 채널 생성 (버퍼 크기 10)
 		ClockMgr:  clockMgr,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	clockMgr.register(p)
+	return p
 }
 
 // SendMessage 메시지 전송 (상대 프로세스의 채널에 메시지를 보냄)
-func (p *Process) SendMessage(to int, event string, targetCh chan<- Message, showDetails bool) {
-	// (1) 송신 직전 로컬 시계 증가
-	p.ClockMgr.UpdateClock(p.ID, nil)
-
-	// (2) 현재 로컬 클럭 가져옴
-	currentClock := p.ClockMgr.GetClock(p.ID)
+//
+// 보내지 못한 메시지(닫힌 채널, 메일박스 가득 참)는 dead-letter 큐로 옮겨지고 에러가 반환된다.
+func (p *Process) SendMessage(to int, event string, targetCh chan<- Message, showDetails bool) error {
+	// (1) 송신 직전 로컬 시계 증가, (2) 현재 로컬 클럭 가져옴
+	currentClock, epoch := p.tick(to)
 
 	// (3) 메시지 생성
-	msg := Message{
-		From:      p.ID,
-		To:        to,
-		Vector:    currentClock,
-		Event:     event,
-		MessageID: fmt.Sprintf("%d-%d", p.ID, time.Now().UnixNano()),
-		Timestamp: time.Now().Unix(),
-	}
+	msg := p.newMessage(to, event, currentClock, epoch)
 
 	// (4) 대상 프로세스의 채널로 전송
-	targetCh <- msg
+	if err := p.push(targetCh, msg); err != nil {
+		return err
+	}
 
 	// (5) 로그 출력
 	if showDetails {
-		fmt.Printf("Process %d: Sent message to Process %d: %v\n", p.ID, to, msg)
+		p.logf("Process %d: Sent message to Process %d: %v\n", p.ID, to, msg)
 	} else {
-		fmt.Printf("Process %d: Sent message to Process %d, Vector: %v\n", p.ID, to, msg.Vector)
+		p.logf("Process %d: Sent message to Process %d, Vector: %v\n", p.ID, to, msg.Vector)
 	}
+	return nil
+}
+
+// newMessage 송신 메시지 생성 (서명은 모든 필드를 채운 뒤 보내기 직전에 sign 으로)
+func (p *Process) newMessage(to int, event string, vector []int, epoch int) Message {
+	msg := Message{
+		From:      p.ID,
+		To:        to,
+		Vector:    vector,
+		Event:     event,
+		MessageID: p.ClockMgr.messageID(p.ID),
+		Timestamp: p.Now().Unix(),
+		Epoch:     epoch,
+	}
+	p.stampTrace(&msg)
+	p.lastSent.Store(msg.MessageID)
+	return msg
+}
+
+// LastMessageID 이 프로세스가 마지막으로 보낸 메시지의 ID (보낸 적이 없으면 "")
+//
+// 전달에 실패해 dead-letter 로 간 메시지도 포함한다. 이벤트 기록을 훑지 않고 방금 보낸 메시지를 찾을 때 쓴다.
+func (p *Process) LastMessageID() string {
+	id, _ := p.lastSent.Load().(string)
+	return id
 }
 
 // ReceiveMessages 메시지 '한 번만' 수신
 //
 // 실제로는 무한 루프+고루틴 방식이 일반적이지만,
 // "for 루프 구문 없이 단 한 번만" 메시지를 받도록 구성.
-func (p *Process) ReceiveMessages(messageCh <-chan Message) {
-	msg, ok := <-messageCh
+// 재설정(ResetEpoch)으로 나뉜 에포크의 메시지는 병합하지 않고 EpochError 를 반환한다.
+// 모든 프로세스가 멈춰 메시지가 올 수 없으면 대기 그래프를 담은 *DeadlockError 를 반환한다.
+func (p *Process) ReceiveMessages(messageCh <-chan Message) error {
+	msg, ok, err := p.await(messageCh)
+	if err != nil {
+		return err
+	}
 	if !ok {
-		fmt.Printf("Process %d: Channel closed\n", p.ID)
-		return
+		p.logf("Process %d: Channel closed\n", p.ID)
+		return nil
 	}
-	p.Mu.Lock()
+	if err := p.receive(msg); err != nil {
+		return err // 거부된 메시지는 dead-letter 큐가 보관
+	}
+	releaseVector(msg)
+	return nil
+}
 
-	// (1) 수신 메시지의 Clock 과 병합할 수 있으면 병합
-	if p.CanMerge(msg.Vector) {
-		p.ClockMgr.UpdateClock(p.ID, msg.Vector)
-		fmt.Printf("Process %d: Received and merged message from %d, Vector: %v\n",
-			p.ID, msg.From, p.ClockMgr.GetClock(p.ID))
+// receive 수신한 메시지 한 건 처리
+func (p *Process) receive(msg Message) error {
+	p.lock()
+	defer p.Mu.Unlock()
+
+	p.ack(msg)
+	if err := p.validate(msg); err != nil {
+		return p.reject(msg, err)
+	}
+	if err := p.checkDuplicate(msg); err != nil {
+		return err
+	}
+	if msg.Domain != "" {
+		return p.receiveDomain(msg)
+	}
+
+	// (1) 수신 메시지의 Clock 과 병합할 수 있으면 병합 (이전 에포크 시계는 현재 에포크로 변환)
+	vector, epoch, err := p.currentVector(msg)
+	if err != nil {
+		return p.reject(msg, err)
+	}
+	merged, err := p.mergeMessage(msg, vector, epoch)
+	if err != nil {
+		return p.reject(msg, err)
+	}
+	if merged {
+		p.logf("Process %d: Received and merged message from %d, Vector: %v\n",
+			p.ID, msg.From, p.clockFor(msg.From))
 	} else {
-		fmt.Printf("Process %d: Received message from %d, Vector: %v\n",
-			p.ID, msg.From, p.ClockMgr.GetClock(p.ID))
+		p.logf("Process %d: Received message from %d, Vector: %v\n",
+			p.ID, msg.From, p.clockFor(msg.From))
 	}
-	p.Mu.Unlock()
+	p.observe(msg)
+	return nil
 }
 
 // CanMerge 메시지의 Vector Clock 과 현재 프로세스의 Vector Clock 병합 가능 여부
 func (p *Process) CanMerge(receivedClock []int) bool {
-	currentClock := p.ClockMgr.GetClock(p.ID)
+	return canMerge(p.ClockMgr.GetClock(p.ID), receivedClock)
+}
+
+// canMergeFrom 상대 from 과의 통신 시계 기준 병합 가능 여부
+func (p *Process) canMergeFrom(from int, receivedClock []int) bool {
+	return canMerge(p.clockFor(from), receivedClock)
+}
+
+// canMerge receivedClock 에 currentClock 보다 큰 원소가 있는지 여부
+func canMerge(currentClock, receivedClock []int) bool {
 	for i := 0; i < len(receivedClock); i++ {
 		if receivedClock[i] > currentClock[i] {
 			return true
@@ -0,0 +1,98 @@
+package process
+
+import "math/bits"
+
+// Quantizer Vector Clock 항목을 구간으로 묶고 상한을 두는 근사 시계 (원격 측정처럼 정확한 순서보다 규모가 중요한 경우)
+//
+// 항목 v 를 Step 단위 구간 ⌈v / Step⌉ (Log 이면 2 의 거듭제곱 구간 bits.Len(v)) 으로 줄이고 Cap 에서 멈추므로,
+// 항목마다 몇 비트만 써도 (예: Log + Cap 15 는 4 비트) 아주 긴 실행의 시계를 실을 수 있다.
+// 변환은 단조이므로 a -> b 이면 Quantize(a) <= Quantize(b) 가 항상 성립한다. 따라서 Compare 는
+//   - Concurrent(exact=true): 구간이 엇갈리면 실제로도 동시다.
+//   - Before/After: 정확한 항목(0, Step 이 1 인 구간, Log 의 1)에서만 같고 나머지는 엄격히 작으면 exact=true,
+//     다른 구간에서 같은 값이 있으면 실제로는 동시일 수 있어 exact=false 로 보고한다.
+//   - 구간이 모두 같으면 "아마 동시" (Concurrent, exact=false) 이며, 모든 항목이 정확할 때만 Equal 이다.
+type Quantizer struct {
+	Step int  // 선형 구간 크기 (1 이하면 1, Log 이면 무시)
+	Log  bool // 2 의 거듭제곱 구간 (1, 2-3, 4-7, ...) 사용
+	Cap  int  // 구간 값의 상한 (0 이면 상한 없음)
+}
+
+// QuantizeValue 항목 하나를 구간 값으로
+func (q Quantizer) QuantizeValue(v int) int {
+	if v <= 0 {
+		return 0
+	}
+	var x int
+	if q.Log {
+		x = bits.Len(uint(v))
+	} else {
+		step := q.Step
+		if step < 1 {
+			step = 1
+		}
+		x = (v + step - 1) / step
+	}
+	if q.Cap > 0 && x > q.Cap {
+		x = q.Cap
+	}
+	return x
+}
+
+// Quantize Vector Clock 을 구간 값 시계로 (새 슬라이스)
+func (q Quantizer) Quantize(clock []int) []int {
+	out := make([]int, len(clock))
+	for i, v := range clock {
+		out[i] = q.QuantizeValue(v)
+	}
+	return out
+}
+
+// exactValue 구간 값 x 가 원래 값 하나에만 대응하는지 여부
+func (q Quantizer) exactValue(x int) bool {
+	switch {
+	case x == 0:
+		return true
+	case q.Cap > 0 && x >= q.Cap:
+		return false
+	case q.Log:
+		return x == 1
+	default:
+		return q.Step <= 1
+	}
+}
+
+// Compare Quantize 로 만든 두 시계 비교 (exact 가 false 면 근사값, 특히 Concurrent 이면 "아마 동시")
+func (q Quantizer) Compare(a, b []int) (order Ordering, exact bool) {
+	order = Compare(a, b)
+	if order == Concurrent {
+		return Concurrent, true
+	}
+	exact = true
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	for i := 0; i < n && exact; i++ {
+		x, y := entryAt(a, i), entryAt(b, i)
+		if x == y && !q.exactValue(x) {
+			exact = false
+		}
+	}
+	if order == Equal && !exact {
+		return Concurrent, false
+	}
+	return order, exact
+}
+
+// entryAt i 번째 항목 (없으면 0)
+func entryAt(clock []int, i int) int {
+	if i < len(clock) {
+		return clock[i]
+	}
+	return 0
+}
+
+// QuantizedClock 프로세스 시계를 q 로 줄인 근사 시계
+func (vcm *VectorClockManager) QuantizedClock(processID int, q Quantizer) []int {
+	return q.Quantize(vcm.GetClock(processID))
+}
@@ -0,0 +1,84 @@
+package process
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestQuantizeValue(t *testing.T) {
+	for _, tc := range []struct {
+		q    Quantizer
+		v    int
+		want int
+	}{
+		{Quantizer{Step: 10}, 0, 0},
+		{Quantizer{Step: 10}, 1, 1},
+		{Quantizer{Step: 10}, 10, 1},
+		{Quantizer{Step: 10}, 11, 2},
+		{Quantizer{}, 7, 7},
+		{Quantizer{Log: true}, 1, 1},
+		{Quantizer{Log: true}, 3, 2},
+		{Quantizer{Log: true}, 4, 3},
+		{Quantizer{Log: true}, 1000, 10},
+		{Quantizer{Log: true, Cap: 3}, 1000, 3},
+		{Quantizer{Step: 1, Cap: 5}, 9, 5},
+	} {
+		if got := tc.q.QuantizeValue(tc.v); got != tc.want {
+			t.Fatalf("%+v.QuantizeValue(%d) = %d, want %d", tc.q, tc.v, got, tc.want)
+		}
+	}
+}
+
+func TestQuantizerCompareReportsPrecision(t *testing.T) {
+	coarse := Quantizer{Step: 10}
+	for _, tc := range []struct {
+		name  string
+		q     Quantizer
+		a, b  []int
+		order Ordering
+		exact bool
+	}{
+		{"exact step", Quantizer{Step: 1}, []int{5, 20}, []int{5, 40}, Before, true},
+		{"shared bucket", coarse, []int{5, 20}, []int{5, 40}, Before, false},
+		{"zero entries", coarse, []int{0, 20}, []int{0, 40}, Before, true},
+		{"crossing buckets", coarse, []int{10, 30}, []int{30, 10}, Concurrent, true},
+		{"same buckets", coarse, []int{11, 11}, []int{15, 19}, Concurrent, false},
+		{"all zero", coarse, []int{0, 0}, []int{0}, Equal, true},
+		{"capped", Quantizer{Log: true, Cap: 2}, []int{1, 900}, []int{2, 5000}, Before, false},
+	} {
+		order, exact := tc.q.Compare(tc.q.Quantize(tc.a), tc.q.Quantize(tc.b))
+		if order != tc.order || exact != tc.exact {
+			t.Fatalf("%s: Compare = %s, %v, want %s, %v", tc.name, order, exact, tc.order, tc.exact)
+		}
+	}
+}
+
+func TestQuantizerNeverReversesCausality(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, q := range []Quantizer{{Step: 7}, {Log: true}, {Log: true, Cap: 4}} {
+		for k := 0; k < 1000; k++ {
+			a := make([]int, 4)
+			b := make([]int, 4)
+			for i := range a {
+				a[i] = rng.Intn(100)
+				b[i] = a[i] + rng.Intn(3)
+			}
+			order, exact := q.Compare(q.Quantize(a), q.Quantize(b))
+			if order == After || (order == Concurrent && exact) {
+				t.Fatalf("%+v: %v -> %v compared as %s (exact %v)", q, a, b, order, exact)
+			}
+		}
+	}
+}
+
+func TestQuantizedClockOfManager(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	p := NewProcess(0, vcm)
+	for i := 0; i < 5; i++ {
+		p.LocalEvent("tick")
+	}
+	if got := vcm.QuantizedClock(0, Quantizer{Log: true}); !reflect.DeepEqual(got, []int{3, 0}) {
+		t.Fatalf("QuantizedClock(0) = %v, want [3 0]", got)
+	}
+}
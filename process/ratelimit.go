@@ -0,0 +1,102 @@
+package process
+
+import (
+	"sync"
+	"time"
+)
+
+// WithRateLimit 초당 perSecond 개, 최대 burst 개까지 몰아서 보낼 수 있도록 송신 속도 제한 (토큰 버킷)
+//
+// 토큰이 없으면 송신이 토큰이 채워질 때까지 대기하므로, 대역폭이 제한된 송신자를 흉내 낼 수 있다.
+// 토큰은 프로세스 시계(WithProcessTimeSource, 가상 시간이면 가상 시각)로 채워지고 대기도 Sleep 으로 하므로
+// 가상 시간에서는 실제로 기다리지 않는다.
+func WithRateLimit(perSecond float64, burst int) ProcessOption {
+	return func(p *Process) {
+		if perSecond <= 0 {
+			p.limiter = nil
+			return
+		}
+		if burst < 1 {
+			burst = 1
+		}
+		p.limiter = &tokenBucket{
+			rate:   perSecond,
+			burst:  float64(burst),
+			tokens: float64(burst),
+		}
+	}
+}
+
+// tokenBucket 토큰 버킷 송신 속도 제한기
+type tokenBucket struct {
+	mu        sync.Mutex
+	rate      float64       // 초당 채워지는 토큰 수
+	burst     float64       // 최대 토큰 수
+	tokens    float64       // 현재 토큰 수
+	last      time.Time     // 마지막으로 토큰을 채운 시각 (0 이면 아직 채운 적 없음)
+	throttled time.Duration // 토큰을 기다린 누적 시간
+}
+
+// take 시각 now 에 토큰 하나를 차감하고 토큰이 채워질 때까지 기다릴 시간 반환
+func (tb *tokenBucket) take(now time.Time) time.Duration {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if !tb.last.IsZero() {
+		tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+	}
+	if tb.tokens > tb.burst {
+		tb.tokens = tb.burst
+	}
+	tb.last = now
+
+	tb.tokens--
+	var delay time.Duration
+	if tb.tokens < 0 {
+		// 부족한 토큰이 채워질 시간만큼 대기 (토큰은 미리 차감해 두어 순서를 보장)
+		delay = time.Duration(-tb.tokens / tb.rate * float64(time.Second))
+		tb.throttled += delay
+	}
+	return delay
+}
+
+// Throttled 송신 속도 제한으로 대기한 누적 시간
+func (p *Process) Throttled() time.Duration {
+	if p.limiter == nil {
+		return 0
+	}
+	p.limiter.mu.Lock()
+	defer p.limiter.mu.Unlock()
+	return p.limiter.throttled
+}
+
+// throttle 송신 속도 제한이 있으면 토큰을 얻을 때까지 대기
+func (p *Process) throttle() {
+	if p.limiter != nil {
+		p.Sleep(p.limiter.take(p.clockNow()))
+	}
+}
+
+// push 메시지에 서명하고 흐름 제어와 송신 속도 제한을 적용하여 채널로 메시지 전송
+//
+// 보내지 못한 메시지는 dead-letter 큐로 옮겨지고 에러가 반환된다.
+func (p *Process) push(targetCh chan<- Message, msg Message) error {
+	p.sign(&msg)
+	if p.flow != nil {
+		windowed, err := p.acquireWindow(msg.To)
+		if err != nil {
+			return p.deadLetter(msg, DeadLetterWindowFull, err)
+		}
+		msg.windowed = windowed
+	}
+	p.throttle()
+	err := p.offer(targetCh, msg, p.sendTimeout)
+	if err != nil {
+		if msg.windowed {
+			p.flow.release(msg.To)
+		}
+		return err
+	}
+	p.recordSend(msg)
+	return nil
+}
@@ -0,0 +1,36 @@
+package process
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimitThrottlesAfterBurst(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	sender := NewProcess(0, vcm, WithRateLimit(100, 2))
+	NewProcess(1, vcm)
+	inbox := make(chan Message, 4)
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		sender.SendMessage(1, "m", inbox, false)
+	}
+	// 2 개는 바로, 나머지 2 개는 10ms 간격
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Fatalf("4 sends took %v, want at least 15ms", elapsed)
+	}
+	if got := sender.Throttled(); got < 10*time.Millisecond || got > 25*time.Millisecond {
+		t.Fatalf("Throttled() = %v, want about 20ms of waiting", got)
+	}
+	if len(inbox) != 4 {
+		t.Fatalf("inbox has %d messages, want 4", len(inbox))
+	}
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	vcm := NewVectorClockManager(1)
+	p := NewProcess(0, vcm, WithRateLimit(100, 1), WithRateLimit(0, 0))
+	if p.limiter != nil || p.Throttled() != 0 {
+		t.Fatal("WithRateLimit(0, 0) kept the limiter")
+	}
+}
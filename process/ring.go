@@ -0,0 +1,297 @@
+package process
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy 링 버퍼 메일박스가 가득 찼을 때의 처리
+type OverflowPolicy int
+
+const (
+	// OverflowBlock 자리가 날 때까지 송신자가 기다림 (송신 제한 시간이 있으면 초과 시 dead-letter)
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest 가장 오래된 메시지를 밀어내고 새 메시지를 넣음 (밀려난 메시지는 dead-letter)
+	OverflowDropOldest
+	// OverflowDropNewest 새 메시지를 버림 (송신자에게 ErrMailboxFull, 메시지는 dead-letter)
+	OverflowDropNewest
+)
+
+// String 정책 이름 반환
+func (o OverflowPolicy) String() string {
+	switch o {
+	case OverflowBlock:
+		return "block"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowDropNewest:
+		return "drop-newest"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(o))
+	}
+}
+
+// RingMailbox 잠금으로 보호하는 고정 크기 링 버퍼 메일박스 (채널 대신 쓰는 메일박스, WithRingMailbox)
+//
+// 여러 송신자가 넣고 프로세스 하나가 꺼낸다. 버퍼는 한 번만 할당하고 재사용하며,
+// 가득 찼을 때의 처리를 정책으로 고를 수 있다. 채널처럼 닫으면 남은 메시지를 모두 꺼낸 뒤 수신이 끝난다.
+type RingMailbox struct {
+	mu     sync.Mutex
+	buf    []Message
+	head   int  // 가장 오래된 메시지 위치
+	n      int  // 들어 있는 메시지 수
+	closed bool // Close 호출됨
+
+	policy  OverflowPolicy
+	ready   chan struct{} // 메시지가 들어옴 (버퍼 1, 닫으면 close)
+	space   chan struct{} // 자리가 남 (버퍼 1, 닫으면 close)
+	dropped atomic.Int64  // 정책으로 버리거나 밀어낸 메시지 수
+}
+
+// NewRingMailbox 크기 size, 넘침 정책 policy 의 링 버퍼 메일박스 생성 (size 가 1 보다 작으면 1)
+func NewRingMailbox(size int, policy OverflowPolicy) *RingMailbox {
+	if size < 1 {
+		size = 1
+	}
+	return &RingMailbox{
+		buf:    make([]Message, size),
+		policy: policy,
+		ready:  make(chan struct{}, 1),
+		space:  make(chan struct{}, 1),
+	}
+}
+
+// Len 들어 있는 메시지 수
+func (r *RingMailbox) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.n
+}
+
+// Cap 메일박스 크기
+func (r *RingMailbox) Cap() int {
+	return len(r.buf)
+}
+
+// Policy 넘침 정책
+func (r *RingMailbox) Policy() OverflowPolicy {
+	return r.policy
+}
+
+// Dropped 정책으로 버리거나 밀어낸 메시지 수
+func (r *RingMailbox) Dropped() int64 {
+	return r.dropped.Load()
+}
+
+// Close 메일박스 닫기 (이후 넣는 메시지는 ErrChannelClosed, 남은 메시지를 다 꺼내면 수신 종료)
+func (r *RingMailbox) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	r.closed = true
+	close(r.ready)
+	close(r.space)
+}
+
+// wake 기다리는 쪽 깨우기 (r.mu 보유, 닫히지 않은 상태에서 호출)
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// push 메시지를 넣음 (OverflowDropOldest 로 밀려난 메시지가 있으면 evicted 와 true)
+//
+// OverflowBlock 은 자리가 날 때까지 기다리며, timeout 이 0 보다 크면 그 시간이 지나면 ErrMailboxFull (시간은 timer 로 잰다),
+// 음수면 기다리지 않고 ErrMailboxFull.
+// blocked 는 가득 차서 처음 기다리기 시작할 때 한 번 호출된다 (nil 가능).
+func (r *RingMailbox) push(msg Message, timeout time.Duration, timer func(time.Duration) <-chan struct{}, blocked func()) (evicted Message, ok bool, err error) {
+	var deadline <-chan struct{}
+	for {
+		r.mu.Lock()
+		if r.closed {
+			r.mu.Unlock()
+			return Message{}, false, ErrChannelClosed
+		}
+		if r.n < len(r.buf) {
+			r.buf[(r.head+r.n)%len(r.buf)] = msg
+			r.n++
+			wake(r.ready)
+			if r.n < len(r.buf) {
+				wake(r.space) // 다른 송신자도 기다리고 있을 수 있음
+			}
+			r.mu.Unlock()
+			return Message{}, false, nil
+		}
+		switch r.policy {
+		case OverflowDropOldest:
+			evicted = r.buf[r.head]
+			r.buf[r.head] = msg
+			r.head = (r.head + 1) % len(r.buf)
+			r.dropped.Add(1)
+			r.mu.Unlock()
+			return evicted, true, nil
+		case OverflowDropNewest:
+			r.dropped.Add(1)
+			r.mu.Unlock()
+			return Message{}, false, ErrMailboxFull
+		}
+		r.mu.Unlock()
+
+		if timeout < 0 {
+			return Message{}, false, ErrMailboxFull
+		}
+		if deadline == nil {
+			if blocked != nil {
+				blocked()
+			}
+			if timeout > 0 {
+				deadline = timer(timeout)
+			}
+		}
+		select {
+		case <-r.space:
+		case <-deadline:
+			return Message{}, false, ErrMailboxFull
+		}
+	}
+}
+
+// pop 가장 오래된 메시지를 꺼냄 (비어 있으면 메시지가 오거나 닫히거나 abort 가 닫힐 때까지 기다림)
+//
+// 닫힌 메일박스가 비었으면 ok 는 false, abort 로 깨어나면 aborted 가 true.
+func (r *RingMailbox) pop(abort <-chan struct{}) (msg Message, ok, aborted bool) {
+	for {
+		if msg, ok, done := r.tryPop(); done {
+			return msg, ok, false
+		}
+		select {
+		case <-r.ready:
+		case <-abort:
+			return Message{}, false, true
+		}
+	}
+}
+
+// tryPop 기다리지 않고 꺼냄 (꺼냈거나 닫혀서 끝났으면 done, 비어 있기만 하면 done 은 false)
+func (r *RingMailbox) tryPop() (msg Message, ok, done bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.n == 0 {
+		return Message{}, false, r.closed
+	}
+	msg = r.buf[r.head]
+	r.buf[r.head] = Message{} // 꺼낸 메시지의 Vector 를 붙잡지 않음
+	r.head = (r.head + 1) % len(r.buf)
+	r.n--
+	if !r.closed {
+		wake(r.space)
+		if r.n > 0 {
+			wake(r.ready)
+		}
+	}
+	return msg, true, true
+}
+
+// WithRingMailbox 채널 대신 크기 size, 넘침 정책 policy 의 링 버퍼 메일박스 사용
+//
+// MessageCh 는 메일박스를 가리키는 이름으로만 남는다. SendMessage / Send / Broadcast / Deliver 로
+// MessageCh 에 보내면 링 버퍼에 들어가고, ReceiveMessages / ReceiveBatch / DeliverCausal 에
+// MessageCh 를 넘기거나 Start 로 돌리면 링 버퍼에서 꺼낸다. 이름으로만 쓰는 MessageCh 는 닫힌 채널이므로
+// 직접 보내면 panic 이 나고 직접 받으면 바로 닫힘을 본다 (끝낼 때는 Ring().Close 를 쓴다).
+// WithMailboxSize 보다 뒤에 지정해야 한다.
+func WithRingMailbox(size int, policy OverflowPolicy) ProcessOption {
+	return func(p *Process) {
+		p.ring = NewRingMailbox(size, policy)
+		ch := make(chan Message)
+		close(ch)
+		p.MessageCh = ch
+	}
+}
+
+// Ring 링 버퍼 메일박스 (WithRingMailbox 를 쓰지 않았으면 nil)
+func (p *Process) Ring() *RingMailbox {
+	return p.ring
+}
+
+// ringOf messageCh 가 이 프로세스의 메일박스이고 링 버퍼를 쓰면 그 링 버퍼
+func (p *Process) ringOf(messageCh <-chan Message) *RingMailbox {
+	if p.ring == nil || messageCh != p.MessageCh {
+		return nil
+	}
+	return p.ring
+}
+
+// mailboxLen 메일박스에 쌓인 메시지 수 (선택 수신이 보관한 메시지 포함)
+func (p *Process) mailboxLen() int {
+	if p.ring != nil {
+		return p.ring.Len() + p.Stashed()
+	}
+	return len(p.MessageCh) + p.Stashed()
+}
+
+// mailboxCap 메일박스 크기
+func (p *Process) mailboxCap() int {
+	if p.ring != nil {
+		return p.ring.Cap()
+	}
+	return cap(p.MessageCh)
+}
+
+// ringFor 채널 targetCh 가 링 버퍼를 쓰는 로컬 프로세스 to 의 메일박스이면 그 프로세스
+func (vcm *VectorClockManager) ringFor(targetCh chan<- Message, to int) *Process {
+	if vcm.rings.Load() == 0 {
+		return nil
+	}
+	target, ok := vcm.Lookup(to)
+	if !ok || target.ring == nil || targetCh != target.MessageCh {
+		return nil
+	}
+	return target
+}
+
+// offerRing 링 버퍼 메일박스에 메시지를 넣음 (전송 중으로 센 뒤 호출, 실패하면 dead-letter 를 기록한 에러)
+//
+// 밀려난 메시지는 그 메시지를 보낸 로컬 프로세스(다른 노드에서 왔으면 받는 프로세스)의 dead-letter 큐에
+// 기록하고 전송 중에서 뺀다.
+func (p *Process) offerRing(target *Process, msg Message, timeout time.Duration) error {
+	evicted, ok, err := target.ring.push(msg, timeout, p.ClockMgr.timer, func() {
+		p.ClockMgr.notifyBackpressure(BackpressureEvent{
+			Kind:     BackpressureBlocked,
+			Process:  msg.To,
+			Sender:   msg.From,
+			Depth:    target.ring.Len(),
+			Capacity: target.ring.Cap(),
+			Time:     p.ClockMgr.now(),
+		})
+	})
+	switch {
+	case err == ErrChannelClosed:
+		return p.deadLetter(msg, DeadLetterChannelClosed,
+			fmt.Errorf("%w: mailbox of process %d", ErrChannelClosed, msg.To))
+	case err != nil && target.ring.policy == OverflowDropNewest:
+		return p.deadLetter(msg, DeadLetterMailboxFull,
+			fmt.Errorf("%w: mailbox of process %d (%s)", ErrMailboxFull, msg.To, OverflowDropNewest))
+	case err != nil && timeout < 0:
+		return p.deadLetter(msg, DeadLetterMailboxFull,
+			fmt.Errorf("%w: mailbox of process %d", ErrMailboxFull, msg.To))
+	case err != nil:
+		return p.deadLetter(msg, DeadLetterMailboxFull,
+			fmt.Errorf("%w: mailbox of process %d after %v", ErrMailboxFull, msg.To, timeout))
+	}
+	if ok {
+		p.ClockMgr.leave(evicted)
+		owner, local := p.ClockMgr.Lookup(evicted.From)
+		if !local {
+			owner = target
+		}
+		owner.deadLetter(evicted, DeadLetterEvicted,
+			fmt.Errorf("%w: mailbox of process %d (%s)", ErrMailboxFull, msg.To, OverflowDropOldest))
+	}
+	return nil
+}
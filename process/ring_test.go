@@ -0,0 +1,208 @@
+package process
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOverflowPolicyString(t *testing.T) {
+	for policy, want := range map[OverflowPolicy]string{
+		OverflowBlock:      "block",
+		OverflowDropOldest: "drop-oldest",
+		OverflowDropNewest: "drop-newest",
+		OverflowPolicy(9):  "OverflowPolicy(9)",
+	} {
+		if got := policy.String(); got != want {
+			t.Fatalf("String() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestRingDropOldestKeepsNewest(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	r := NewProcess(0, vcm, WithRingMailbox(2, OverflowDropOldest))
+	a := NewProcess(1, vcm)
+	for _, event := range []string{"first", "second", "third"} {
+		if err := a.Send(0, event); err != nil {
+			t.Fatalf("send %s: %v", event, err)
+		}
+	}
+
+	if n := r.Ring().Dropped(); n != 1 {
+		t.Fatalf("Dropped() = %d, want 1", n)
+	}
+	letters := a.DeadLetters()
+	if len(letters) != 1 || letters[0].Reason != DeadLetterEvicted || letters[0].Message.Event != "first" {
+		t.Fatalf("dead letters = %+v, want the evicted first message", letters)
+	}
+	if got := receiveEvent(t, r); got != "second" {
+		t.Fatalf("received %q, want second", got)
+	}
+	if got := receiveEvent(t, r); got != "third" {
+		t.Fatalf("received %q, want third", got)
+	}
+}
+
+func TestRingBlockTimesOut(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	NewProcess(0, vcm, WithRingMailbox(1, OverflowBlock))
+	a := NewProcess(1, vcm, WithSendTimeout(10*time.Millisecond))
+	if err := a.Send(0, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Send(0, "second"); !errors.Is(err, ErrMailboxFull) {
+		t.Fatalf("send to a full ring = %v, want ErrMailboxFull after the timeout", err)
+	}
+	if letters := a.DeadLetters(); len(letters) != 1 || letters[0].Reason != DeadLetterMailboxFull {
+		t.Fatalf("dead letters = %+v, want one mailbox-full letter", letters)
+	}
+}
+
+func TestRingReceiveMergesAndClosedRingRejects(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	r := NewProcess(0, vcm, WithRingMailbox(2, OverflowBlock))
+	a := NewProcess(1, vcm)
+	if err := a.Send(0, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if n := r.Ring().Len(); n != 1 {
+		t.Fatalf("ring holds %d messages, want 1", n)
+	}
+	if err := r.ReceiveMessages(r.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if got := vcm.GetClock(0); got[1] != 1 {
+		t.Fatalf("clock = %v, want the ring message merged", got)
+	}
+
+	r.Ring().Close()
+	if err := a.Send(0, "late"); !errors.Is(err, ErrChannelClosed) {
+		t.Fatalf("send to a closed ring = %v, want ErrChannelClosed", err)
+	}
+	if _, ok, _ := r.Ring().pop(nil); ok {
+		t.Fatal("popped a message from a closed, empty ring")
+	}
+}
+
+// receiveEvent 프로세스 p 가 다음에 받은 메시지의 Event
+func receiveEvent(t *testing.T, p *Process) string {
+	t.Helper()
+	msg, err := p.ReceiveMatching(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg.Event
+}
+
+func TestRingDropOldestDeadLettersEvictedSender(t *testing.T) {
+	vcm := NewVectorClockManager(3, WithLogger(nil))
+	r := NewProcess(0, vcm, WithRingMailbox(1, OverflowDropOldest))
+	a := NewProcess(1, vcm)
+	b := NewProcess(2, vcm)
+	if err := a.Send(0, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Send(0, "second"); err != nil {
+		t.Fatal(err)
+	}
+
+	letters := a.DeadLetters()
+	if len(letters) != 1 || letters[0].Reason != DeadLetterEvicted || letters[0].Message.Event != "first" {
+		t.Fatalf("sender 1 dead letters = %+v, want its evicted message", letters)
+	}
+	if n := b.DeadLetterCount(); n != 0 {
+		t.Fatalf("sender 2 has %d dead letters for a message that was delivered", n)
+	}
+	if n := r.Ring().Dropped(); n != 1 {
+		t.Fatalf("Dropped() = %d, want 1", n)
+	}
+	if got := receiveEvent(t, r); got != "second" {
+		t.Fatalf("received %q, want second", got)
+	}
+	if n := vcm.InFlight(); n != 0 {
+		t.Fatalf("InFlight() = %d after draining, want 0", n)
+	}
+}
+
+func TestRingDropNewestRejectsSender(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	r := NewProcess(0, vcm, WithRingMailbox(1, OverflowDropNewest))
+	a := NewProcess(1, vcm)
+	if err := a.Send(0, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Send(0, "second"); !errors.Is(err, ErrMailboxFull) {
+		t.Fatalf("second send = %v, want ErrMailboxFull", err)
+	}
+	if letters := a.DeadLetters(); len(letters) != 1 || letters[0].Message.Event != "second" {
+		t.Fatalf("dead letters = %+v, want the rejected second message", letters)
+	}
+	if got := receiveEvent(t, r); got != "first" {
+		t.Fatalf("received %q, want first", got)
+	}
+}
+
+func TestRingBlockWaitsForSpace(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	r := NewProcess(0, vcm, WithRingMailbox(1, OverflowBlock))
+	a := NewProcess(1, vcm)
+	if err := a.Send(0, "first"); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- a.Send(0, "second") }()
+	select {
+	case err := <-done:
+		t.Fatalf("send to a full blocking ring returned %v without waiting", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	if got := receiveEvent(t, r); got != "first" {
+		t.Fatalf("received %q, want first", got)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if got := receiveEvent(t, r); got != "second" {
+		t.Fatalf("received %q, want second", got)
+	}
+}
+
+func TestRingMessageChFailsFast(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	r := NewProcess(0, vcm, WithRingMailbox(2, OverflowBlock))
+	a := NewProcess(1, vcm)
+
+	// 라이브러리 경로로 MessageCh 에 보내면 링 버퍼로 들어감
+	if err := a.SendMessage(0, "via-name", r.MessageCh, false); err != nil {
+		t.Fatal(err)
+	}
+	if n := r.Ring().Len(); n != 1 {
+		t.Fatalf("ring holds %d messages, want 1", n)
+	}
+
+	select {
+	case _, ok := <-r.MessageCh:
+		if ok {
+			t.Fatal("received a message directly from the ring's MessageCh")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("direct receive from the ring's MessageCh blocked")
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("direct send to the ring's MessageCh did not panic")
+			}
+		}()
+		r.MessageCh <- Message{From: 1, To: 0}
+	}()
+
+	if err := r.ReceiveMessages(r.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if got := vcm.GetClock(0); got[1] != 1 {
+		t.Fatalf("clock = %v, want the ring message merged", got)
+	}
+}
@@ -0,0 +1,275 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MessageKind 메시지 종류
+type MessageKind int
+
+const (
+	// KindEvent 일반 애플리케이션 메시지 (기본값)
+	KindEvent MessageKind = iota
+	// KindRequest 응답을 기다리는 요청 메시지 (Call)
+	KindRequest
+	// KindReply 요청에 대한 응답 메시지
+	KindReply
+	// KindLockRequest 분산 상호 배제 진입 요청 (Lock)
+	KindLockRequest
+	// KindLockReply 분산 상호 배제 진입 허락
+	KindLockReply
+)
+
+// String 종류 이름 반환
+func (k MessageKind) String() string {
+	switch k {
+	case KindEvent:
+		return "event"
+	case KindRequest:
+		return "request"
+	case KindReply:
+		return "reply"
+	case KindLockRequest:
+		return "lock-request"
+	case KindLockReply:
+		return "lock-reply"
+	default:
+		return fmt.Sprintf("MessageKind(%d)", int(k))
+	}
+}
+
+var (
+	// ErrCallTimeout Call 이 제한 시간 안에 응답을 받지 못한 경우
+	ErrCallTimeout = errors.New("process: call timed out")
+	// ErrNotRequest 요청 메시지가 아닌 메시지에 응답하려는 경우
+	ErrNotRequest = errors.New("process: message is not a request")
+	// ErrUnclaimedReply 요청자가 더 이상 응답을 기다리지 않는 경우 (제한 시간 초과, 이미 응답함)
+	ErrUnclaimedReply = errors.New("process: reply not awaited")
+)
+
+// DefaultCallTimeout Call 의 기본 응답 대기 시간
+var DefaultCallTimeout = 5 * time.Second
+
+// pendingCall 응답을 기다리는 요청 한 건
+type pendingCall struct {
+	mu   sync.Mutex
+	ch   chan Message // 응답 (버퍼 1)
+	done bool         // 요청자가 대기를 끝냄
+}
+
+// newPendingCall 응답 대기 생성
+func newPendingCall() *pendingCall {
+	return &pendingCall{ch: make(chan Message, 1)}
+}
+
+// deliver 요청자가 기다리는 중이면 응답을 전송 중으로 세고 넘김 (대기가 끝났거나 이미 응답이 있으면 false)
+func (c *pendingCall) deliver(vcm *VectorClockManager, reply Message) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done {
+		return false
+	}
+	vcm.enter(reply)
+	select {
+	case c.ch <- reply:
+		return true
+	default:
+		vcm.leave(reply)
+		return false
+	}
+}
+
+// finish 대기 종료 (그 사이 도착해 꺼내지 않은 응답이 있으면 반환)
+func (c *pendingCall) finish() (Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.done = true
+	select {
+	case reply := <-c.ch:
+		return reply, true
+	default:
+		return Message{}, false
+	}
+}
+
+// callTable 응답을 기다리는 요청 (요청 메시지 ID -> 응답 대기)
+type callTable struct {
+	mu      sync.Mutex
+	pending map[string]*pendingCall
+}
+
+// add 응답 대기 등록
+func (t *callTable) add(id string, c *pendingCall) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pending == nil {
+		t.pending = make(map[string]*pendingCall)
+	}
+	t.pending[id] = c
+}
+
+// remove 응답 대기 해제
+func (t *callTable) remove(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.pending, id)
+}
+
+// lookup 응답 대기 조회
+func (t *callTable) lookup(id string) (*pendingCall, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c, ok := t.pending[id]
+	return c, ok
+}
+
+// Call 요청/응답 교환 (RPC)
+//
+// 요청 메시지를 보낸 뒤 응답을 기다리고, 응답에 실린 상대의 Vector Clock 을 병합한 뒤 반환한다.
+// 상대는 Reply 로 (액터라면 Outgoing.Reply 로) 응답해야 하며, DefaultCallTimeout 안에
+// 응답이 없으면 ErrCallTimeout 을 반환한다.
+func (p *Process) Call(to int, event string) (Message, error) {
+	return p.call(to, event, DefaultCallTimeout)
+}
+
+// call Call 구현
+func (p *Process) call(to int, event string, timeout time.Duration) (Message, error) {
+	mailbox, err := p.ClockMgr.mailbox(to)
+	if err != nil {
+		return Message{}, err
+	}
+
+	currentClock, epoch := p.tick(to)
+	req := p.newMessage(to, event, currentClock, epoch)
+	req.Kind = KindRequest
+
+	pending := newPendingCall()
+	p.calls.add(req.MessageID, pending)
+	defer p.calls.remove(req.MessageID)
+	defer p.finishCall(pending)
+
+	if err := p.push(mailbox, req); err != nil {
+		return Message{}, err
+	}
+	p.logf("Process %d: Sent request to Process %d, Vector: %v\n", p.ID, to, req.Vector)
+
+	return p.awaitReply(req, pending.ch, timeout)
+}
+
+// SendSync 응답 채널을 실은 요청 메시지를 targetCh 로 보내고 응답을 기다림
+//
+// 받는 쪽은 Reply 로 응답하면 되며, 응답은 메시지에 실린 채널로 바로 전달되므로
+// 요청/응답을 짝지을 필요가 없다. timeout 안에 응답이 없으면 ErrCallTimeout 을 반환한다
+// (0 이하면 무한정 기다리며, 가상 시간이면 가상 시각 기준).
+func (p *Process) SendSync(to int, event string, targetCh chan<- Message, timeout time.Duration) (Message, error) {
+	currentClock, epoch := p.tick(to)
+	req := p.newMessage(to, event, currentClock, epoch)
+	req.Kind = KindRequest
+	pending := newPendingCall()
+	req.ReplyCh = pending.ch
+	req.call = pending
+	p.sign(&req)
+	defer p.finishCall(pending)
+
+	p.throttle()
+	if err := p.offer(targetCh, req, max(timeout, 0)); err != nil {
+		return Message{}, err
+	}
+	p.recordSend(req)
+	p.logf("Process %d: Sent sync request to Process %d, Vector: %v\n", p.ID, to, req.Vector)
+
+	return p.awaitReply(req, pending.ch, timeout)
+}
+
+// finishCall 응답 대기를 끝내고, 기다리지 않게 된 뒤 도착한 응답은 확인 응답 후 dead-letter 처리
+func (p *Process) finishCall(pending *pendingCall) {
+	if late, ok := pending.finish(); ok {
+		p.ack(late)
+		p.deadLetter(late, DeadLetterUnclaimed,
+			fmt.Errorf("%w: reply %s to request %s", ErrUnclaimedReply, late.MessageID, late.ReplyTo))
+	}
+}
+
+// awaitReply 요청 req 에 대한 응답을 기다려 병합 (제한 시간이 지나면 ErrCallTimeout, 교착 상태면 *DeadlockError)
+func (p *Process) awaitReply(req Message, replyCh <-chan Message, timeout time.Duration) (Message, error) {
+	w := p.ClockMgr.blockTimeout(p.ID, WaitReply, []int{req.To}, timeout)
+	defer p.ClockMgr.unblock(p.ID)
+	select {
+	case <-w.abort:
+		return Message{}, w.err
+	case reply := <-replyCh:
+		// 병합(확인 응답)으로 전송 중 메시지가 0 이 될 때 아직 대기 중으로 남아 있으면 교착 상태로 잘못 감지됨
+		p.ClockMgr.unblock(p.ID)
+		if err := p.receive(reply); err != nil {
+			return reply, err
+		}
+		return reply, nil
+	case <-w.timeout:
+		return Message{}, fmt.Errorf("%w: request %s to process %d", ErrCallTimeout, req.MessageID, req.To)
+	}
+}
+
+// Reply 요청 메시지에 응답 (현재 Vector Clock 을 실어 보냄)
+//
+// 요청에 응답 채널이 실려 있으면(SendSync) 그 채널로, 아니면(Call) 요청자에게 전달한다.
+func (p *Process) Reply(req Message, event string) error {
+	if req.Kind != KindRequest {
+		return ErrNotRequest
+	}
+
+	currentClock, epoch := p.tick(req.From)
+	reply := p.newMessage(req.From, event, currentClock, epoch)
+	reply.Kind = KindReply
+	reply.ReplyTo = req.MessageID
+	p.sign(&reply)
+
+	p.throttle()
+	if req.call == nil && req.ReplyCh != nil {
+		// SendSync 밖에서 만든 응답 채널: 받는 쪽이 비어 있을 때만 넘김
+		p.ClockMgr.enter(reply)
+		select {
+		case req.ReplyCh <- reply:
+		default:
+			p.ClockMgr.leave(reply)
+			return p.unclaimed(req, reply)
+		}
+		p.recordSend(reply)
+		p.logf("Process %d: Sent reply to Process %d, Vector: %v\n", p.ID, req.From, reply.Vector)
+		return nil
+	}
+
+	// 요청자가 응답을 기다리는 중이면 메일박스를 거치지 않고 바로 전달 (SendSync 는 요청에 실린 대기로)
+	pending := req.call
+	if pending == nil {
+		if target, ok := p.ClockMgr.Lookup(req.From); ok {
+			pending, _ = target.calls.lookup(req.MessageID)
+		}
+	}
+	if pending != nil {
+		if !pending.deliver(p.ClockMgr, reply) {
+			return p.unclaimed(req, reply)
+		}
+	} else {
+		mailbox, err := p.ClockMgr.mailbox(req.From)
+		if err != nil {
+			return err
+		}
+		if err := p.offer(mailbox, reply, p.sendTimeout); err != nil {
+			return err
+		}
+	}
+	p.recordSend(reply)
+	p.logf("Process %d: Sent reply to Process %d, Vector: %v\n", p.ID, req.From, reply.Vector)
+	return nil
+}
+
+// unclaimed 요청자가 기다리지 않는 응답을 dead-letter 처리하고 ErrUnclaimedReply 반환
+func (p *Process) unclaimed(req, reply Message) error {
+	return p.deadLetter(reply, DeadLetterUnclaimed,
+		fmt.Errorf("%w: request %s from process %d", ErrUnclaimedReply, req.MessageID, req.From))
+}
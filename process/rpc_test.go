@@ -0,0 +1,255 @@
+package process
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCallMergesReplyClock(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	client := NewProcess(0, vcm)
+	server := NewProcess(1, vcm)
+	if err := server.Start(func(msg Message) []Outgoing {
+		return []Outgoing{{Reply: true, Event: "pong"}}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	reply, err := client.Call(1, "ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Kind != KindReply || reply.Event != "pong" || reply.ReplyTo == "" {
+		t.Fatalf("reply = %+v", reply)
+	}
+	// 요청 송신 1, 응답 수신 1 / 서버 요청 수신 1 + 응답 송신 1
+	if got := vcm.GetClock(0); got[0] != 2 || got[1] != 2 {
+		t.Fatalf("client clock = %v, want [2 2]", got)
+	}
+}
+
+func TestCallTimesOutWithoutReply(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	client := NewProcess(0, vcm)
+	NewProcess(1, vcm)
+
+	if _, err := client.call(1, "ping", 10*time.Millisecond); !errors.Is(err, ErrCallTimeout) {
+		t.Fatalf("call = %v, want ErrCallTimeout", err)
+	}
+	if _, err := client.Call(7, "ping"); !errors.Is(err, ErrUnknownProcess) {
+		t.Fatalf("Call to unregistered process = %v, want ErrUnknownProcess", err)
+	}
+}
+
+func TestReplyRejectsNonRequest(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	NewProcess(0, vcm)
+	server := NewProcess(1, vcm)
+
+	if err := server.Reply(Message{From: 0, Kind: KindEvent}, "pong"); !errors.Is(err, ErrNotRequest) {
+		t.Fatalf("Reply to plain message = %v, want ErrNotRequest", err)
+	}
+	if KindRequest.String() != "request" || MessageKind(9).String() != "MessageKind(9)" {
+		t.Fatalf("unexpected kind names %q %q", KindRequest, MessageKind(9))
+	}
+}
+
+func TestSendSyncReceivesReplyOnChannel(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	client := NewProcess(0, vcm)
+	server := NewProcess(1, vcm)
+	inbox := make(chan Message, 1)
+
+	go func() {
+		req := <-inbox
+		if err := server.receive(req); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := server.Reply(req, "done"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	reply, err := client.SendSync(1, "work", inbox, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Event != "done" || reply.Kind != KindReply {
+		t.Fatalf("reply = %+v", reply)
+	}
+	if got := vcm.GetClock(0); got[0] != 2 || got[1] != 2 {
+		t.Fatalf("client clock = %v, want [2 2]", got)
+	}
+}
+
+func TestSendSyncDeadLettersOnFullMailbox(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	client := NewProcess(0, vcm)
+	NewProcess(1, vcm)
+	full := make(chan Message)
+
+	if _, err := client.SendSync(1, "work", full, 10*time.Millisecond); !errors.Is(err, ErrMailboxFull) {
+		t.Fatalf("SendSync = %v, want ErrMailboxFull", err)
+	}
+	if n := client.DeadLetterCount(); n != 1 {
+		t.Fatalf("DeadLetterCount() = %d, want 1", n)
+	}
+}
+
+func TestCallDoesNotReportDeadlock(t *testing.T) {
+	log := &captureLogger{}
+	vcm := NewVectorClockManager(2, WithLogger(log))
+	caller := NewProcess(0, vcm, WithMailboxSize(4))
+	callee := NewProcess(1, vcm, WithMailboxSize(4))
+	if err := callee.Start(func(Message) []Outgoing { return []Outgoing{{Reply: true, Event: "pong"}} }); err != nil {
+		t.Fatal(err)
+	}
+	defer callee.Stop()
+
+	const calls = 50
+	for i := 0; i < calls; i++ {
+		reply, err := caller.Call(1, "ping")
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		if reply.Event != "pong" || reply.Kind != KindReply {
+			t.Fatalf("call %d: got %+v", i, reply)
+		}
+	}
+	if n := log.count("deadlock"); n != 0 {
+		t.Fatalf("%d successful calls reported %d deadlocks", calls, n)
+	}
+	// 요청, 응답마다 호출자 시계가 2 씩 증가
+	if got := vcm.GetClockCopy(0)[0]; got != 2*calls {
+		t.Fatalf("caller entry = %d, want %d", got, 2*calls)
+	}
+}
+
+func TestSendSyncDoesNotReportDeadlock(t *testing.T) {
+	log := &captureLogger{}
+	vcm := NewVectorClockManager(2, WithLogger(log))
+	caller := NewProcess(0, vcm)
+	callee := NewProcess(1, vcm, WithMailboxSize(4))
+	if err := callee.Start(func(Message) []Outgoing { return []Outgoing{{Reply: true, Event: "pong"}} }); err != nil {
+		t.Fatal(err)
+	}
+	defer callee.Stop()
+
+	for i := 0; i < 20; i++ {
+		if _, err := caller.SendSync(1, "ping", callee.MessageCh, time.Second); err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+	if n := log.count("deadlock"); n != 0 {
+		t.Fatalf("successful sync sends reported %d deadlocks", n)
+	}
+}
+
+func TestCallTimesOutWithoutReceiver(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	caller := NewProcess(0, vcm, WithMailboxSize(4))
+	NewProcess(1, vcm, WithMailboxSize(4)) // 응답하지 않는 수신자
+
+	// 수신자가 요청을 꺼내지 않으므로 전송 중 메시지가 남아 교착 상태가 아니라 제한 시간으로 끝나야 함
+	_, err := caller.call(1, "ping", 50*time.Millisecond)
+	if !errors.Is(err, ErrCallTimeout) {
+		t.Fatalf("call without a receiver: got %v, want ErrCallTimeout", err)
+	}
+}
+
+// countUnclaimed 프로세스들의 dead-letter 큐에 있는 응답 수
+func countUnclaimed(procs ...*Process) int {
+	n := 0
+	for _, p := range procs {
+		for _, l := range p.DeadLetters() {
+			if l.Reason == DeadLetterUnclaimed {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestLateReplyAfterCallTimeout(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	caller := NewProcess(0, vcm, WithMailboxSize(4))
+	callee := NewProcess(1, vcm, WithMailboxSize(4))
+
+	if _, err := caller.call(1, "ping", 20*time.Millisecond); !errors.Is(err, ErrCallTimeout) {
+		t.Fatalf("got %v, want ErrCallTimeout", err)
+	}
+	req, err := callee.ReceiveFrom(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 요청자가 기다리지 않으므로 응답은 요청자의 메일박스로 가서 일반 메시지로 병합됨
+	if err := callee.Reply(req, "late"); err != nil {
+		t.Fatalf("late reply: %v", err)
+	}
+	if err := caller.ReceiveMessages(caller.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if err := vcm.AwaitTermination(time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLateReplyAfterSendSyncTimeout(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	caller := NewProcess(0, vcm)
+	callee := NewProcess(1, vcm, WithMailboxSize(4))
+
+	if _, err := caller.SendSync(1, "ping", callee.MessageCh, 20*time.Millisecond); !errors.Is(err, ErrCallTimeout) {
+		t.Fatalf("got %v, want ErrCallTimeout", err)
+	}
+	req, err := callee.ReceiveFrom(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- callee.Reply(req, "late") }()
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrUnclaimedReply) {
+			t.Fatalf("late reply: got %v, want ErrUnclaimedReply", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("late reply blocked")
+	}
+	if n := countUnclaimed(callee); n != 1 {
+		t.Fatalf("%d unclaimed replies dead-lettered, want 1", n)
+	}
+	if err := vcm.AwaitTermination(time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSecondReplyIsDeadLettered(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	caller := NewProcess(0, vcm, WithMailboxSize(4))
+	callee := NewProcess(1, vcm, WithMailboxSize(4))
+	if err := callee.Start(func(Message) []Outgoing {
+		return []Outgoing{{Reply: true, Event: "first"}, {Reply: true, Event: "second"}}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer callee.Stop()
+
+	reply, err := caller.Call(1, "ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Event != "first" {
+		t.Fatalf("got reply %q, want first", reply.Event)
+	}
+	if err := vcm.AwaitTermination(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	// 두 번째 응답은 응답자(이미 응답이 있음) 또는 요청자(대기를 끝낸 뒤 도착)가 dead-letter 처리
+	if n := countUnclaimed(caller, callee); n != 1 {
+		t.Fatalf("%d unclaimed replies dead-lettered, want 1", n)
+	}
+}
@@ -0,0 +1,296 @@
+package process
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Scheduler 지정한 시각에 작업을 실행하는 스케줄러
+//
+// 실제 시간 모드에서는 백그라운드 고루틴이 작업 시각이 되면 실행하고,
+// 가상 시간 모드에서는 Advance / Run 을 호출할 때만 시간이 흐르며 작업이 시각 순서대로 실행된다.
+// 시각은 스케줄러 생성 시점부터의 경과 시간으로 표현하며, 같은 시각의 작업은 등록 순서대로 실행된다.
+type Scheduler struct {
+	mu      sync.Mutex
+	idle    *sync.Cond
+	virtual bool
+	start   time.Time
+	now     time.Duration // 가상 시간 모드의 현재 시각
+	tasks   taskQueue
+	seq     uint64
+	running bool          // 실제 시간 모드 실행 고루틴 동작 여부
+	wake    chan struct{} // 실행 고루틴 깨우기
+	stopped bool
+}
+
+// task 예약된 작업
+type task struct {
+	at  time.Duration
+	seq uint64
+	fn  func()
+}
+
+// taskQueue 실행 시각 순서의 작업 힙
+type taskQueue []*task
+
+func (q taskQueue) Len() int { return len(q) }
+func (q taskQueue) Less(i, j int) bool {
+	if q[i].at != q[j].at {
+		return q[i].at < q[j].at
+	}
+	return q[i].seq < q[j].seq
+}
+func (q taskQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *taskQueue) Push(x interface{}) { *q = append(*q, x.(*task)) }
+func (q *taskQueue) Pop() interface{} {
+	old := *q
+	t := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return t
+}
+
+// NewScheduler 실제 시간으로 동작하는 스케줄러 생성
+func NewScheduler() *Scheduler {
+	s := &Scheduler{start: time.Now(), wake: make(chan struct{}, 1)}
+	s.idle = sync.NewCond(&s.mu)
+	return s
+}
+
+// VirtualEpoch 가상 시간 모드에서 시각 0 에 해당하는 벽시계 시각 (Wall)
+var VirtualEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// NewVirtualScheduler 가상 시간으로 동작하는 스케줄러 생성 (시각 0 에서 시작)
+func NewVirtualScheduler() *Scheduler {
+	s := NewScheduler()
+	s.virtual = true
+	s.start = VirtualEpoch
+	return s
+}
+
+// Virtual 가상 시간 모드 여부
+func (s *Scheduler) Virtual() bool {
+	return s.virtual
+}
+
+// Now 스케줄러 기준 현재 시각 (생성 시점부터의 경과 시간)
+func (s *Scheduler) Now() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nowLocked()
+}
+
+// nowLocked 현재 시각 (s.mu 보유 상태에서 호출)
+func (s *Scheduler) nowLocked() time.Duration {
+	if s.virtual {
+		return s.now
+	}
+	return time.Since(s.start)
+}
+
+// At 시각 t 에 fn 실행 예약 (이미 지난 시각이면 가능한 한 빨리 실행)
+func (s *Scheduler) At(t time.Duration, fn func()) {
+	s.schedule(t, fn)
+}
+
+// schedule 시각 t 에 fn 실행 예약 (중지된 스케줄러면 false)
+func (s *Scheduler) schedule(t time.Duration, fn func()) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return false
+	}
+	s.seq++
+	heap.Push(&s.tasks, &task{at: t, seq: s.seq, fn: fn})
+
+	if s.virtual {
+		return true
+	}
+	if !s.running {
+		s.running = true
+		go s.runReal()
+		return true
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// After 현재 시각으로부터 d 뒤에 fn 실행 예약
+func (s *Scheduler) After(d time.Duration, fn func()) {
+	s.At(s.Now()+d, fn)
+}
+
+// Timer d 뒤에 닫히는 채널 (가상 시간 모드에서는 가상 시각이 d 만큼 흐른 뒤)
+//
+// 중지된 가상 시간 스케줄러에서는 시간이 흐르지 않으므로 바로 닫힌 채널을 반환한다.
+func (s *Scheduler) Timer(d time.Duration) <-chan struct{} {
+	ch := make(chan struct{})
+	if !s.virtual {
+		time.AfterFunc(d, func() { close(ch) })
+		return ch
+	}
+	if !s.schedule(s.Now()+d, func() { close(ch) }) {
+		close(ch)
+	}
+	return ch
+}
+
+// Sleep d 동안 대기 (가상 시간 모드에서는 Advance / Run 이 시간을 d 만큼 진행할 때까지)
+func (s *Scheduler) Sleep(d time.Duration) {
+	<-s.Timer(d)
+}
+
+// Wall 스케줄러 시각을 벽시계 시각으로 돌려주는 TimeSource (가상 시간이면 VirtualEpoch 기준)
+func (s *Scheduler) Wall() TimeSource {
+	return TimeFunc(func() time.Time { return s.start.Add(s.Now()) })
+}
+
+// Pending 실행을 기다리는 작업 수
+func (s *Scheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tasks)
+}
+
+// Advance 가상 시간을 d 만큼 진행하며 그 사이에 예약된 작업을 시각 순서대로 실행
+//
+// 실제 시간 모드에서는 d 만큼 기다린다.
+func (s *Scheduler) Advance(d time.Duration) {
+	if !s.virtual {
+		time.Sleep(d)
+		return
+	}
+	s.mu.Lock()
+	target := s.now + d
+	s.mu.Unlock()
+
+	for s.runNextVirtual(target) {
+	}
+
+	s.mu.Lock()
+	if s.now < target {
+		s.now = target
+	}
+	s.mu.Unlock()
+}
+
+// Run 예약된 작업이 모두 실행될 때까지 진행
+//
+// 가상 시간 모드에서는 다음 작업 시각으로 시간을 건너뛰며 즉시 실행하고,
+// 실제 시간 모드에서는 실행 고루틴이 모든 작업을 끝낼 때까지 기다린다.
+func (s *Scheduler) Run() {
+	if s.virtual {
+		for s.runNextVirtual(-1) {
+		}
+		return
+	}
+	s.mu.Lock()
+	for s.running && !s.stopped {
+		s.idle.Wait()
+	}
+	s.mu.Unlock()
+}
+
+// Stop 남은 작업을 버리고 스케줄러 중지
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	s.tasks = nil
+	s.idle.Broadcast()
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// runNextVirtual 시각 until 이전(음수면 제한 없음)의 다음 작업 하나를 실행 (실행했으면 true)
+func (s *Scheduler) runNextVirtual(until time.Duration) bool {
+	s.mu.Lock()
+	if s.stopped || len(s.tasks) == 0 || (until >= 0 && s.tasks[0].at > until) {
+		s.mu.Unlock()
+		return false
+	}
+	t := heap.Pop(&s.tasks).(*task)
+	if t.at > s.now {
+		s.now = t.at
+	}
+	s.mu.Unlock()
+
+	t.fn()
+	return true
+}
+
+// runReal 실제 시간 모드 실행 고루틴 (작업이 없으면 종료)
+func (s *Scheduler) runReal() {
+	for {
+		s.mu.Lock()
+		if s.stopped || len(s.tasks) == 0 {
+			s.running = false
+			s.idle.Broadcast()
+			s.mu.Unlock()
+			return
+		}
+		wait := s.tasks[0].at - s.nowLocked()
+		if wait <= 0 {
+			t := heap.Pop(&s.tasks).(*task)
+			s.mu.Unlock()
+			t.fn()
+			continue
+		}
+		s.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-s.wake:
+			timer.Stop()
+		}
+	}
+}
+
+// WithScheduler 예약 송신(SendAfter / SendAt)에 사용할 스케줄러 지정
+func WithScheduler(s *Scheduler) ManagerOption {
+	return func(vcm *VectorClockManager) {
+		vcm.scheduler = s
+	}
+}
+
+// Scheduler 매니저의 스케줄러 (지정하지 않았으면 실제 시간 스케줄러 생성)
+func (vcm *VectorClockManager) Scheduler() *Scheduler {
+	vcm.rlockManager()
+	s := vcm.scheduler
+	vcm.Mu.RUnlock()
+	if s != nil {
+		return s
+	}
+
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+	if vcm.scheduler == nil {
+		vcm.scheduler = NewScheduler()
+	}
+	return vcm.scheduler
+}
+
+// SendAfter d 뒤에 레지스트리를 통해 메시지 전송 예약
+func (p *Process) SendAfter(d time.Duration, to int, event string) {
+	p.ClockMgr.Scheduler().After(d, func() { p.sendScheduled(to, event) })
+}
+
+// SendAt 스케줄러 기준 시각 t 에 레지스트리를 통해 메시지 전송 예약 (예: 500ms 에 P2 가 송신)
+func (p *Process) SendAt(t time.Duration, to int, event string) {
+	p.ClockMgr.Scheduler().At(t, func() { p.sendScheduled(to, event) })
+}
+
+// sendScheduled 예약된 송신 실행
+func (p *Process) sendScheduled(to int, event string) {
+	if err := p.Send(to, event); err != nil {
+		p.logf("Process %d: scheduled send to %d failed: %v\n", p.ID, to, err)
+	}
+}
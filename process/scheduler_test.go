@@ -0,0 +1,72 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVirtualSchedulerRunsTasksInTimeOrder(t *testing.T) {
+	s := NewVirtualScheduler()
+	var order []string
+	s.At(30*time.Millisecond, func() { order = append(order, "c") })
+	s.At(10*time.Millisecond, func() { order = append(order, "a") })
+	s.At(10*time.Millisecond, func() {
+		order = append(order, "b")
+		// 실행 중에 추가한 작업도 시각 순서대로 실행
+		s.After(5*time.Millisecond, func() { order = append(order, "b+5") })
+	})
+
+	s.Advance(20 * time.Millisecond)
+	if want := []string{"a", "b", "b+5"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("after Advance(20ms) ran %v, want %v", order, want)
+	}
+	if now := s.Now(); now != 20*time.Millisecond {
+		t.Fatalf("Now() = %v, want 20ms", now)
+	}
+	if s.Pending() != 1 {
+		t.Fatalf("Pending() = %d, want 1", s.Pending())
+	}
+	s.Run()
+	if order[len(order)-1] != "c" || s.Now() != 30*time.Millisecond {
+		t.Fatalf("Run ran %v and stopped at %v", order, s.Now())
+	}
+}
+
+func TestRealSchedulerRunsAfterDelay(t *testing.T) {
+	s := NewScheduler()
+	done := make(chan time.Duration, 1)
+	s.After(10*time.Millisecond, func() { done <- s.Now() })
+	s.Run()
+	select {
+	case at := <-done:
+		if at < 10*time.Millisecond {
+			t.Fatalf("task ran at %v, want after 10ms", at)
+		}
+	default:
+		t.Fatal("Run returned before the task ran")
+	}
+
+	s.Stop()
+	s.After(0, func() { t.Error("task ran after Stop") })
+	if s.Pending() != 0 {
+		t.Fatalf("Pending() after Stop = %d, want 0", s.Pending())
+	}
+}
+
+func TestSendAfterUsesManagerScheduler(t *testing.T) {
+	s := NewVirtualScheduler()
+	vcm := NewVectorClockManager(2, WithScheduler(s))
+	sender := NewProcess(0, vcm)
+	receiver := NewProcess(1, vcm)
+
+	sender.SendAfter(time.Second, 1, "late")
+	s.Advance(999 * time.Millisecond)
+	if len(receiver.MessageCh) != 0 {
+		t.Fatal("message sent before its time")
+	}
+	s.Advance(time.Millisecond)
+	if msg := <-receiver.MessageCh; msg.Event != "late" {
+		t.Fatalf("received %+v, want late", msg)
+	}
+}
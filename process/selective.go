@@ -0,0 +1,144 @@
+package process
+
+import (
+	"fmt"
+	"sync"
+)
+
+// stashState 메일박스에서 꺼냈지만 아직 전달하지 않은 메시지 (선택 수신이 건너뛴 메시지)
+//
+// 보관된 메시지는 전달될 때까지 전송 중으로 세므로 메일박스 깊이와 종료 감지에 그대로 포함된다.
+type stashState struct {
+	mu   sync.Mutex
+	msgs []Message // 꺼낸 순서
+}
+
+// put 메시지 보관
+func (s *stashState) put(msg Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.msgs = append(s.msgs, msg)
+}
+
+// take match 를 만족하는 보관 메시지 중 인과 순서상 가장 앞선 메시지를 꺼냄 (match 가 nil 이면 모든 메시지)
+//
+// 벡터 합이 가장 작은 메시지를 고르므로(같으면 먼저 꺼낸 메시지) happens-before 를 거스르지 않는다.
+func (s *stashState) take(match func(Message) bool) (Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	best := s.bestLocked(match)
+	if best < 0 {
+		return Message{}, false
+	}
+	msg := s.msgs[best]
+	s.msgs = append(s.msgs[:best], s.msgs[best+1:]...)
+	return msg, true
+}
+
+// first 인과 순서상 가장 앞선 보관 메시지 (꺼내지 않음)
+func (s *stashState) first() (Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	best := s.bestLocked(nil)
+	if best < 0 {
+		return Message{}, false
+	}
+	return s.msgs[best], true
+}
+
+// bestLocked match 를 만족하는 보관 메시지 중 벡터 합이 가장 작은 메시지의 위치 (없으면 -1, s.mu 보유 상태에서 호출)
+func (s *stashState) bestLocked(match func(Message) bool) int {
+	best := -1
+	for i, m := range s.msgs {
+		if match != nil && !match(m) {
+			continue
+		}
+		if best < 0 || sum(m.Vector) < sum(s.msgs[best].Vector) {
+			best = i
+		}
+	}
+	return best
+}
+
+// Stashed 선택 수신이 건너뛰어 보관 중인 메시지 수
+func (p *Process) Stashed() int {
+	p.stash.mu.Lock()
+	defer p.stash.mu.Unlock()
+	return len(p.stash.msgs)
+}
+
+// ReceiveFrom 송신자 senderID 의 메시지 한 건만 수신하고 반환
+//
+// 그 사이에 도착한 다른 송신자의 메시지는 보관했다가, 이후 ReceiveMessages / ReceiveBatch / DeliverCausal /
+// Start 의 수신 루프가 메일박스보다 먼저 인과 순서대로 전달한다. 스냅샷 알고리즘의 마커 처리처럼 특정 채널의
+// 메시지를 먼저 받아야 하는 경우에 쓴다. 메일박스가 닫히면 ErrChannelClosed 를 반환한다.
+func (p *Process) ReceiveFrom(senderID int) (Message, error) {
+	return p.ReceiveMatching(func(m Message) bool { return m.From == senderID })
+}
+
+// ReceiveMatching match 를 만족하는 메시지 한 건만 수신하고 반환 (예: 특정 MessageID 에 대한 응답, nil 이면 모든 메시지)
+//
+// 보관된 메시지 중 만족하는 메시지가 있으면 인과 순서상 가장 앞선 것을 먼저 전달하고, 없으면 메일박스에서 기다린다.
+// 기다리는 동안 도착한 만족하지 않는 메시지는 보관했다가 이후 수신에서 인과 순서대로 전달한다 (ReceiveFrom 참고).
+// match 는 보관 상태의 잠금을 쥔 채 호출될 수 있으므로 같은 프로세스의 수신 메서드를 부르지 않는다.
+func (p *Process) ReceiveMatching(match func(Message) bool) (Message, error) {
+	if match == nil {
+		match = func(Message) bool { return true }
+	}
+	msg, ok := p.stash.take(match)
+	for !ok {
+		next, open, err := p.awaitMailbox(p.MessageCh)
+		if err != nil {
+			return Message{}, err
+		}
+		if !open {
+			return Message{}, fmt.Errorf("%w: mailbox of process %d", ErrChannelClosed, p.ID)
+		}
+		if match(next) {
+			msg, ok = next, true
+			break
+		}
+		p.stash.put(next)
+	}
+	return msg, p.receive(msg)
+}
+
+// Peek 다음에 전달될 메시지를 전달하지 않고 확인 (메일박스가 비었거나 닫혔으면 false)
+//
+// 시계를 바꾸지 않고 확인 응답도 하지 않으므로, 스케줄러가 전달 가능 여부(예: 인과 전달 조건)를 미리 판단할 때 쓴다.
+// 메일박스 맨 앞의 메시지를 확인하려면 꺼내야 하므로 그 메시지는 보관했다가 다음 수신에서 가장 먼저 전달한다.
+// 반환된 메시지의 Vector 는 읽기 전용이다.
+func (p *Process) Peek() (Message, bool) {
+	if msg, ok := p.stash.first(); ok {
+		return msg, true
+	}
+	msg, ok, _ := p.tryMailbox()
+	if !ok {
+		return Message{}, false
+	}
+	p.stash.put(msg)
+	return msg, true
+}
+
+// tryMailbox 기다리지 않고 메일박스에서 꺼냄 (꺼냈거나 닫혀서 끝났으면 done, 비어 있기만 하면 done 은 false)
+func (p *Process) tryMailbox() (msg Message, ok, done bool) {
+	if p.ring != nil {
+		return p.ring.tryPop()
+	}
+	select {
+	case msg, ok = <-p.MessageCh:
+		return msg, ok, true
+	default:
+		return Message{}, false, false
+	}
+}
+
+// unstash 자신의 메일박스에서 받을 때 보관된 메시지가 있으면 먼저 꺼냄
+func (p *Process) unstash(messageCh <-chan Message) (Message, bool) {
+	if messageCh != p.MessageCh {
+		return Message{}, false
+	}
+	return p.stash.take(nil)
+}
@@ -0,0 +1,217 @@
+package process
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// newSelectiveGroup n 개 프로세스 (마지막 프로세스의 메일박스 옵션은 opts)
+func newSelectiveGroup(n int, opts ...ProcessOption) (*VectorClockManager, []*Process) {
+	vcm := NewVectorClockManager(n, WithLogger(nil))
+	procs := make([]*Process, n)
+	for i := 0; i < n-1; i++ {
+		procs[i] = NewProcess(i, vcm, WithMailboxSize(8))
+	}
+	procs[n-1] = NewProcess(n-1, vcm, append([]ProcessOption{WithMailboxSize(8)}, opts...)...)
+	return vcm, procs
+}
+
+// receivedEvents 프로세스 id 가 받은 메시지 Event (받은 순서)
+func receivedEvents(vcm *VectorClockManager, id int) []string {
+	var names []string
+	for _, e := range vcm.Events() {
+		if e.Kind == EventReceive && e.Process == id {
+			names = append(names, e.Name)
+		}
+	}
+	return names
+}
+
+func TestReceiveFromBuffersOtherSenders(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []ProcessOption
+	}{
+		{"channel", nil},
+		{"ring", []ProcessOption{WithRingMailbox(8, OverflowBlock)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			vcm, procs := newSelectiveGroup(3, tc.opts...)
+			r := procs[2]
+			if err := procs[0].Send(2, "a"); err != nil {
+				t.Fatal(err)
+			}
+			if err := procs[1].Send(2, "b"); err != nil {
+				t.Fatal(err)
+			}
+
+			msg, err := r.ReceiveFrom(1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if msg.Event != "b" || r.Stashed() != 1 {
+				t.Fatalf("received %q with %d stashed, want b with a stashed", msg.Event, r.Stashed())
+			}
+			if got := vcm.GetClock(2); !reflect.DeepEqual(got, []int{0, 1, 1}) {
+				t.Fatalf("clock = %v, want only the message from 1 merged", got)
+			}
+			if n := r.mailboxLen(); n != 1 {
+				t.Fatalf("mailbox depth %d, want the stashed message counted", n)
+			}
+
+			if err := r.ReceiveMessages(r.MessageCh); err != nil {
+				t.Fatal(err)
+			}
+			if got := vcm.GetClock(2); !reflect.DeepEqual(got, []int{1, 1, 2}) || r.Stashed() != 0 {
+				t.Fatalf("clock = %v with %d stashed, want the stashed message delivered", got, r.Stashed())
+			}
+		})
+	}
+}
+
+func TestStashedMessagesDeliverInCausalOrder(t *testing.T) {
+	vcm, procs := newSelectiveGroup(3)
+	r := procs[2]
+	for _, event := range []string{"x1", "x2"} {
+		if err := procs[0].Send(2, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := procs[1].Send(2, "y"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReceiveFrom(1); err != nil {
+		t.Fatal(err)
+	}
+	batch, err := r.ReceiveBatch(r.MessageCh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batch) != 2 || batch[0].Event != "x1" || batch[1].Event != "x2" {
+		t.Fatalf("batch = %v, want the stashed x1, x2 in order", batch)
+	}
+	if got := receivedEvents(vcm, 2); !reflect.DeepEqual(got, []string{"y", "x1", "x2"}) {
+		t.Fatalf("received %v, want [y x1 x2]", got)
+	}
+}
+
+func TestReceiveFromClosedMailbox(t *testing.T) {
+	_, procs := newSelectiveGroup(2)
+	r := procs[1]
+	if err := procs[0].Send(1, "other"); err != nil {
+		t.Fatal(err)
+	}
+	close(r.MessageCh)
+	if _, err := r.ReceiveFrom(5); !errors.Is(err, ErrChannelClosed) {
+		t.Fatalf("ReceiveFrom on a closed mailbox = %v, want ErrChannelClosed", err)
+	}
+	if n := r.Stashed(); n != 1 {
+		t.Fatalf("Stashed() = %d, want the skipped message kept", n)
+	}
+}
+
+func TestReceiveMatchingPicksByPredicate(t *testing.T) {
+	vcm, procs := newSelectiveGroup(2)
+	r := procs[1]
+	for _, event := range []string{"request", "reply", "other"} {
+		if err := procs[0].Send(1, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	msg, err := r.ReceiveMatching(func(m Message) bool { return m.Event == "reply" })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Event != "reply" || r.Stashed() != 1 {
+		t.Fatalf("received %q with %d stashed, want reply with request stashed", msg.Event, r.Stashed())
+	}
+	// nil 은 모든 메시지: 보관된 request 가 메일박스의 other 보다 먼저
+	if msg, err = r.ReceiveMatching(nil); err != nil || msg.Event != "request" {
+		t.Fatalf("ReceiveMatching(nil) = %q, %v, want request", msg.Event, err)
+	}
+	if msg, err = r.ReceiveFrom(0); err != nil || msg.Event != "other" {
+		t.Fatalf("ReceiveFrom(0) = %q, %v, want other", msg.Event, err)
+	}
+	if got := receivedEvents(vcm, 1); !reflect.DeepEqual(got, []string{"reply", "request", "other"}) {
+		t.Fatalf("received %v", got)
+	}
+}
+
+func TestPeekDoesNotDeliver(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []ProcessOption
+	}{
+		{"channel", nil},
+		{"ring", []ProcessOption{WithRingMailbox(8, OverflowBlock)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			vcm, procs := newSelectiveGroup(2, tc.opts...)
+			r := procs[1]
+			if _, ok := r.Peek(); ok {
+				t.Fatal("Peek on an empty mailbox returned a message")
+			}
+			for _, event := range []string{"first", "second"} {
+				if err := procs[0].Send(1, event); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for i := 0; i < 2; i++ {
+				msg, ok := r.Peek()
+				if !ok || msg.Event != "first" {
+					t.Fatalf("Peek() = %q, %v, want first", msg.Event, ok)
+				}
+			}
+			if got := vcm.GetClock(1); !reflect.DeepEqual(got, []int{0, 0}) {
+				t.Fatalf("clock after Peek = %v, want unchanged", got)
+			}
+			if n := r.mailboxLen(); n != 2 {
+				t.Fatalf("mailbox depth after Peek = %d, want 2", n)
+			}
+
+			for _, want := range []string{"first", "second"} {
+				msg, err := r.ReceiveMatching(nil)
+				if err != nil || msg.Event != want {
+					t.Fatalf("received %q, %v, want %s", msg.Event, err, want)
+				}
+			}
+			if _, ok := r.Peek(); ok {
+				t.Fatal("Peek after draining returned a message")
+			}
+		})
+	}
+}
+
+func TestPeekShowsStashedMessageFirst(t *testing.T) {
+	_, procs := newSelectiveGroup(3)
+	r := procs[2]
+	if err := procs[0].Send(2, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := procs[1].Send(2, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := procs[1].Send(2, "c"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReceiveFrom(1); err != nil {
+		t.Fatal(err)
+	}
+	if msg, ok := r.Peek(); !ok || msg.Event != "a" {
+		t.Fatalf("Peek() = %q, %v, want the stashed a", msg.Event, ok)
+	}
+
+	close(r.MessageCh)
+	if _, err := r.ReceiveMatching(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReceiveMatching(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.Peek(); ok {
+		t.Fatal("Peek on a closed, drained mailbox returned a message")
+	}
+}
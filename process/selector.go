@@ -0,0 +1,107 @@
+package process
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Selector 여러 프로세스의 메일박스를 동시에 기다리며 도착한 메시지를 인과 순서대로 전달 (여러 상대를 듣는 조정자 패턴)
+//
+// 한 고루틴이 여러 프로세스의 수신을 맡을 때 쓴다. 각 메일박스 맨 앞의 메시지 중 벡터 합이 가장 작은 메시지
+// (happens-before 의 선형 확장)를 받는 프로세스에게 전달하며, 같은 메일박스 안의 순서는 그대로 지킨다.
+// 아직 전달하지 않은 맨 앞 메시지는 그 프로세스에 보관되므로 (Peek 참고) Selector 를 그만 쓰더라도
+// 이후 그 프로세스의 수신에서 먼저 전달된다. 수신 루프(Start)가 도는 프로세스에는 쓰지 않는다.
+type Selector struct {
+	procs  []*Process
+	closed []bool // 메일박스가 닫혀 비워진 프로세스
+}
+
+// NewSelector procs 의 메일박스를 함께 기다리는 Selector 생성
+func NewSelector(procs ...*Process) *Selector {
+	return &Selector{
+		procs:  append([]*Process(nil), procs...),
+		closed: make([]bool, len(procs)),
+	}
+}
+
+// Receive 메시지 한 건을 받는 프로세스에게 전달하고 반환 (받은 프로세스는 msg.To)
+//
+// 모든 메일박스가 비어 있으면 하나라도 메시지가 올 때까지 기다린다. 모든 메일박스가 닫혀 비워지면 ErrChannelClosed,
+// 기다리는 프로세스를 포함해 모두 멈춰 메시지가 올 수 없으면 *DeadlockError 를 반환한다.
+// 전달한 메시지의 병합 에러(검증 실패, 중복 등)는 메시지와 함께 반환한다.
+func (s *Selector) Receive() (Message, error) {
+	for {
+		if p := s.earliest(); p != nil {
+			msg, _ := p.stash.take(nil)
+			return msg, p.receive(msg)
+		}
+		if err := s.wait(); err != nil {
+			return Message{}, err
+		}
+	}
+}
+
+// earliest 맨 앞 메시지 중 벡터 합이 가장 작은 메시지를 가진 프로세스 (모두 비었으면 nil)
+func (s *Selector) earliest() *Process {
+	var best *Process
+	var bestSum int
+	for i, p := range s.procs {
+		msg, ok := p.stash.first()
+		if !ok && !s.closed[i] {
+			var done bool
+			if msg, ok, done = p.tryMailbox(); ok {
+				p.stash.put(msg)
+			} else if done {
+				s.closed[i] = true
+			}
+		}
+		if ok && (best == nil || sum(msg.Vector) < bestSum) {
+			best, bestSum = p, sum(msg.Vector)
+		}
+	}
+	return best
+}
+
+// wait 열린 메일박스 중 하나에 메시지가 올 때까지 기다림 (받은 메시지는 그 프로세스에 보관)
+func (s *Selector) wait() error {
+	var cases []reflect.SelectCase
+	var owners []int // cases[i] 를 기다리는 프로세스 위치
+	var waiters []*waiter
+	for i, p := range s.procs {
+		if s.closed[i] {
+			continue
+		}
+		w := p.ClockMgr.block(p.ID, WaitReceive, nil)
+		defer p.ClockMgr.unblock(p.ID)
+		waiters = append(waiters, w)
+
+		ch := reflect.ValueOf(p.MessageCh)
+		if p.ring != nil {
+			ch = reflect.ValueOf(p.ring.ready)
+		}
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: ch})
+		owners = append(owners, i)
+	}
+	if len(cases) == 0 {
+		return fmt.Errorf("%w: all %d mailboxes", ErrChannelClosed, len(s.procs))
+	}
+	mailboxes := len(cases)
+	for _, w := range waiters {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(w.abort)})
+	}
+
+	chosen, value, ok := reflect.Select(cases)
+	if chosen >= mailboxes {
+		return waiters[chosen-mailboxes].err
+	}
+	i := owners[chosen]
+	switch p := s.procs[i]; {
+	case p.ring != nil:
+		// 링 메일박스는 알림만 받았으므로 다음 earliest 에서 꺼냄
+	case ok:
+		p.stash.put(value.Interface().(Message))
+	default:
+		s.closed[i] = true
+	}
+	return nil
+}
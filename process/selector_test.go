@@ -0,0 +1,95 @@
+package process
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSelectorDeliversInCausalOrder(t *testing.T) {
+	vcm := NewVectorClockManager(3, WithLogger(nil))
+	src := NewProcess(0, vcm, WithMailboxSize(4))
+	a := NewProcess(1, vcm, WithMailboxSize(4))
+	b := NewProcess(2, vcm, WithRingMailbox(4, OverflowBlock))
+
+	// 메일박스가 달라도 먼저 보낸 메시지부터 (b1, a1, b2), 링 메일박스도 함께 기다림
+	for _, send := range []struct {
+		to    int
+		event string
+	}{{2, "b1"}, {1, "a1"}, {2, "b2"}} {
+		if err := src.Send(send.to, send.event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sel := NewSelector(a, b)
+	for _, want := range []string{"b1", "a1", "b2"} {
+		msg, err := sel.Receive()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.Event != want {
+			t.Fatalf("received %q, want %s", msg.Event, want)
+		}
+	}
+	if got := vcm.GetClock(2); got[0] != 3 || got[2] != 2 {
+		t.Fatalf("clock of 2 = %v, want both messages merged", got)
+	}
+	if a.Stashed() != 0 || b.Stashed() != 0 {
+		t.Fatalf("stashed %d and %d after draining", a.Stashed(), b.Stashed())
+	}
+}
+
+func TestSelectorWaitsForAnyMailbox(t *testing.T) {
+	vcm := NewVectorClockManager(3, WithLogger(nil))
+	src := NewProcess(0, vcm, WithMailboxSize(4))
+	a := NewProcess(1, vcm, WithMailboxSize(4))
+	b := NewProcess(2, vcm, WithMailboxSize(4))
+	sel := NewSelector(a, b)
+
+	done := make(chan Message, 1)
+	go func() {
+		msg, err := sel.Receive()
+		if err != nil {
+			t.Error(err)
+		}
+		done <- msg
+	}()
+	select {
+	case msg := <-done:
+		t.Fatalf("Receive returned %+v before any message", msg)
+	case <-time.After(20 * time.Millisecond):
+	}
+	if err := src.Send(2, "late"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case msg := <-done:
+		if msg.Event != "late" || msg.To != 2 {
+			t.Fatalf("received %+v, want late for 2", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Receive did not wake for a message")
+	}
+}
+
+func TestSelectorReportsClosedAndDeadlock(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	b := NewProcess(1, vcm, WithMailboxSize(4))
+	if _, err := NewSelector(a, b).Receive(); !errors.Is(err, ErrDeadlock) {
+		t.Fatalf("Receive with every process waiting = %v, want ErrDeadlock", err)
+	}
+
+	if err := a.Send(1, "last"); err != nil {
+		t.Fatal(err)
+	}
+	close(a.MessageCh)
+	close(b.MessageCh)
+	sel := NewSelector(a, b)
+	if msg, err := sel.Receive(); err != nil || msg.Event != "last" {
+		t.Fatalf("Receive = %q, %v, want the message left in a closed mailbox", msg.Event, err)
+	}
+	if _, err := sel.Receive(); !errors.Is(err, ErrChannelClosed) {
+		t.Fatalf("Receive on closed mailboxes = %v, want ErrChannelClosed", err)
+	}
+}
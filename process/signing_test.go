@@ -0,0 +1,151 @@
+package process
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// signedPair 서명을 쓰는 두 프로세스와, 보낸 메시지를 붙잡아 두는 채널
+func signedPair(t *testing.T, opt ManagerOption) (*Process, *Process, Message) {
+	t.Helper()
+	vcm := NewVectorClockManager(3, WithLogger(nil), opt)
+	sender := NewProcess(0, vcm)
+	receiver := NewProcess(1, vcm)
+	NewProcess(2, vcm)
+	hold := make(chan Message, 1)
+	if err := sender.SendMessage(1, "m", hold, false); err != nil {
+		t.Fatal(err)
+	}
+	return sender, receiver, <-hold
+}
+
+func TestSignedMessageIsAccepted(t *testing.T) {
+	_, receiver, msg := signedPair(t, WithSigningKey([]byte("secret")))
+	if len(msg.Signature) == 0 {
+		t.Fatal("message was not signed")
+	}
+	if err := receiver.receive(msg); err != nil {
+		t.Fatalf("receive = %v", err)
+	}
+	if got := receiver.ClockMgr.GetClock(1); got[0] != 1 {
+		t.Fatalf("receiver clock = %v, want the sender's entry merged", got)
+	}
+}
+
+func TestTamperedMessageIsDeadLettered(t *testing.T) {
+	tamper := map[string]func(*Message){
+		"Event":     func(m *Message) { m.Event = "forged" },
+		"Vector":    func(m *Message) { m.Vector = []int{9, 0, 0} },
+		"MessageID": func(m *Message) { m.MessageID = "0-forged" },
+		"Signature": func(m *Message) { m.Signature = nil },
+	}
+	for field, change := range tamper {
+		t.Run(field, func(t *testing.T) {
+			_, receiver, msg := signedPair(t, WithSigningKey([]byte("secret")))
+			change(&msg)
+			if err := receiver.receive(msg); !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("receive = %v, want ErrInvalidSignature", err)
+			}
+			letters := receiver.DeadLetters()
+			if len(letters) != 1 || letters[0].Reason != DeadLetterInvalid {
+				t.Fatalf("dead letters = %+v, want one invalid", letters)
+			}
+			if got := receiver.ClockMgr.GetClock(1); got[0] != 0 {
+				t.Fatalf("receiver merged a forged clock: %v", got)
+			}
+		})
+	}
+}
+
+func TestPerSenderKeysRejectImpersonation(t *testing.T) {
+	keys := map[int][]byte{0: []byte("k0"), 1: []byte("k1"), 2: []byte("k2")}
+	_, receiver, msg := signedPair(t, WithSigningKeys(func(from int) ([]byte, bool) {
+		k, ok := keys[from]
+		return k, ok
+	}))
+	if err := receiver.receive(msg); err != nil {
+		t.Fatalf("receive genuine = %v", err)
+	}
+
+	// 2 가 자신의 키로 서명해 0 을 사칭
+	forged := msg
+	forged.MessageID = "0-forged"
+	forged.Signature = signature(keys[2], forged)
+	if err := receiver.receive(forged); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("receive forged = %v, want ErrInvalidSignature", err)
+	}
+
+	delete(keys, 0)
+	unknown := msg
+	unknown.MessageID = "0-unknown"
+	if err := receiver.receive(unknown); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("receive without a sender key = %v, want ErrInvalidSignature", err)
+	}
+}
+
+func TestSignatureCoversAllDeliveryFields(t *testing.T) {
+	key := []byte("secret")
+	tamper := map[string]func(*Message){
+		"Epoch":      func(m *Message) { m.Epoch++ },
+		"Kind":       func(m *Message) { m.Kind = KindReply },
+		"ReplyTo":    func(m *Message) { m.ReplyTo = "0-9" },
+		"Causal":     func(m *Message) { m.Causal = []int{0, 5} },
+		"DestClocks": func(m *Message) { m.DestClocks = map[int][]int{1: {9, 9}} },
+		"Topic":      func(m *Message) { m.Topic = "other" },
+		"Vector":     func(m *Message) { m.Vector = []int{7, 0} },
+	}
+	for field, change := range tamper {
+		t.Run(field, func(t *testing.T) {
+			vcm := NewVectorClockManager(2, WithLogger(nil), WithSigningKey(key))
+			sender := NewProcess(0, vcm)
+			receiver := NewProcess(1, vcm, WithMailboxSize(4))
+			hold := make(chan Message, 1)
+			if err := sender.SendMessage(1, "m", hold, false); err != nil {
+				t.Fatal(err)
+			}
+			msg := <-hold
+			change(&msg)
+			receiver.MessageCh <- msg
+			if err := receiver.ReceiveMessages(receiver.MessageCh); !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("tampered %s: got %v, want ErrInvalidSignature", field, err)
+			}
+		})
+	}
+}
+
+func TestSignedMessagesVerify(t *testing.T) {
+	for _, delivery := range []DeliveryAlgorithm{DeliveryBSS, DeliverySES} {
+		t.Run(delivery.String(), func(t *testing.T) {
+			vcm := NewVectorClockManager(2, WithLogger(nil), WithSigningKey([]byte("secret")), WithDelivery(delivery))
+			a := NewProcess(0, vcm, WithMailboxSize(4))
+			b := NewProcess(1, vcm, WithMailboxSize(4))
+
+			// 인과 전달 필드를 채운 뒤 서명해야 받는 쪽이 통과시킴
+			a.Broadcast("cast", map[int]chan<- Message{1: b.MessageCh})
+			if got := b.DeliverCausal(b.MessageCh); len(got) != 1 {
+				t.Fatalf("delivered %v, dead letters %+v", got, b.DeadLetters())
+			}
+
+			// 요청과 응답 (Kind, ReplyTo 를 채운 뒤 서명)
+			if err := b.Start(func(msg Message) []Outgoing {
+				if err := b.Reply(msg, "pong"); err != nil {
+					t.Error(err)
+				}
+				return nil
+			}); err != nil {
+				t.Fatal(err)
+			}
+			defer b.Stop()
+			if _, err := a.SendSync(1, "ping", b.MessageCh, 5*time.Second); err != nil {
+				t.Fatalf("SendSync: %v", err)
+			}
+			if _, err := a.Call(1, "ping"); err != nil {
+				t.Fatalf("Call: %v", err)
+			}
+			if n := b.DeadLetterCount() + a.DeadLetterCount(); n != 0 {
+				t.Fatalf("%d signed messages were rejected", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package process
+
+import (
+	"sync"
+	"time"
+)
+
+// clockSkew 프로세스 벽시계의 어긋남 (물리 시각이 인과 순서를 어기는 상황 재현용)
+type clockSkew struct {
+	mu     sync.Mutex
+	offset time.Duration // 프로세스 시계와의 차이
+	drift  float64       // 경과 시간 1초마다 더해지는 어긋남 비율 (예: 0.01 이면 100초에 1초 빨라짐)
+	since  time.Time     // drift 기준 시각 (어긋남을 지정한 시점)
+}
+
+// WithClockSkew 프로세스 벽시계를 offset 만큼 어긋나게 하고 drift 비율로 계속 벌어지게 함
+//
+// 이 프로세스가 보내는 메시지의 Timestamp 는 어긋남을 적용한 Now 를 쓴다.
+// Vector Clock 은 영향을 받지 않으므로 Timestamp 순서와 인과 순서가 어긋나는 것을 관찰할 수 있다.
+func WithClockSkew(offset time.Duration, drift float64) ProcessOption {
+	return func(p *Process) {
+		p.SetClockSkew(offset, drift)
+	}
+}
+
+// SetClockSkew 실행 중에 벽시계 어긋남 변경 (0, 0 이면 프로세스 시계로 되돌림)
+func (p *Process) SetClockSkew(offset time.Duration, drift float64) {
+	p.skew.mu.Lock()
+	defer p.skew.mu.Unlock()
+
+	p.skew.offset = offset
+	p.skew.drift = drift
+	p.skew.since = p.clockNow()
+}
+
+// ClockSkew 현재 벽시계 어긋남 (프로세스 시계와의 차이)
+func (p *Process) ClockSkew() time.Duration {
+	return p.skewAt(p.clockNow())
+}
+
+// Now 프로세스 벽시계 (프로세스 시계 + offset + drift × 경과 시간)
+//
+// 프로세스 시계는 WithProcessTimeSource 로 지정한 시계, 없으면 매니저 시각이다.
+func (p *Process) Now() time.Time {
+	now := p.clockNow()
+	return now.Add(p.skewAt(now))
+}
+
+// clockNow 어긋남을 적용하기 전의 프로세스 시각
+func (p *Process) clockNow() time.Time {
+	if p.timeSource != nil {
+		return p.timeSource.Now()
+	}
+	return p.ClockMgr.now()
+}
+
+// skewAt 프로세스 시각 now 에서의 어긋남
+func (p *Process) skewAt(now time.Time) time.Duration {
+	p.skew.mu.Lock()
+	defer p.skew.mu.Unlock()
+
+	if p.skew.drift == 0 {
+		return p.skew.offset
+	}
+	return p.skew.offset + time.Duration(p.skew.drift*float64(now.Sub(p.skew.since)))
+}
@@ -0,0 +1,58 @@
+package process
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// manualTime 테스트에서 직접 움직이는 매니저 시각
+type manualTime struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (m *manualTime) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+func (m *manualTime) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.now = m.now.Add(d)
+}
+
+func TestClockSkewOffsetsAndDrifts(t *testing.T) {
+	clock := &manualTime{now: time.Unix(1000, 0)}
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithTimeSource(clock))
+	a := NewProcess(0, vcm, WithClockSkew(time.Minute, 0.5))
+	b := NewProcess(1, vcm)
+
+	if got := a.ClockSkew(); got != time.Minute {
+		t.Fatalf("ClockSkew() = %v, want 1m", got)
+	}
+	clock.Advance(10 * time.Second)
+	if got := a.ClockSkew(); got != time.Minute+5*time.Second {
+		t.Fatalf("ClockSkew() after 10s = %v, want 1m5s", got)
+	}
+	if got, want := a.Now(), time.Unix(1075, 0); !got.Equal(want) {
+		t.Fatalf("Now() = %v, want %v", got, want)
+	}
+	if got := b.Now(); !got.Equal(time.Unix(1010, 0)) {
+		t.Fatalf("unskewed Now() = %v, want manager time", got)
+	}
+
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if msg := <-b.MessageCh; msg.Timestamp != 1075 {
+		t.Fatalf("Timestamp = %d, want skewed 1075", msg.Timestamp)
+	}
+
+	a.SetClockSkew(0, 0)
+	if got := a.Now(); !got.Equal(clock.Now()) {
+		t.Fatalf("Now() after reset = %v, want manager time", got)
+	}
+}
@@ -0,0 +1,153 @@
+package process
+
+import (
+	"sort"
+	"time"
+)
+
+// ManagerSnapshot 한 시점의 모든 프로세스 Vector Clock (Snapshot 으로 생성, DiffSnapshots 로 비교)
+type ManagerSnapshot struct {
+	Time   time.Time     `json:"time"`
+	Epoch  int           `json:"epoch"`
+	Origin int           `json:"origin"` // 마지막 ResetEpoch 로 시작된 에포크 (같아야 서로 비교 가능)
+	Base   []int         `json:"base"`   // 에포크 누적 기준 시계 (시계 + Base = 절대 시계)
+	Clocks map[int][]int `json:"clocks"` // 프로세스 ID -> 현재 에포크 기준 시계
+}
+
+// Snapshot 모든 프로세스 Vector Clock 을 한 번의 잠금으로 복사한 스냅샷
+func (vcm *VectorClockManager) Snapshot() ManagerSnapshot {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	s := ManagerSnapshot{
+		Time:   time.Now(),
+		Epoch:  vcm.epoch,
+		Origin: vcm.originLocked(vcm.epoch),
+		Base:   append([]int(nil), vcm.epochBaseLocked(vcm.epoch)...),
+		Clocks: make(map[int][]int, len(vcm.Clock)),
+	}
+	for id, clock := range vcm.Clock {
+		s.Clocks[id] = append([]int(nil), clock...)
+	}
+	return s
+}
+
+// originLocked 에포크 epoch 이 속한, 마지막 재설정으로 시작된 에포크 (vcm.Mu 읽기 잠금 이상 보유 상태에서 호출)
+func (vcm *VectorClockManager) originLocked(epoch int) int {
+	for e := epoch; e > 0; e-- {
+		if e < len(vcm.epochs) && vcm.epochs[e].reset {
+			return e
+		}
+	}
+	return 0
+}
+
+// absolute 프로세스 id 의 절대 시계 (Checkpoint 로 뺀 기준 시계를 더함, 없으면 nil)
+func (s ManagerSnapshot) absolute(id int) []int {
+	clock, ok := s.Clocks[id]
+	if !ok {
+		return nil
+	}
+	abs := make([]int, len(clock))
+	for i, v := range clock {
+		abs[i] = v
+		if i < len(s.Base) {
+			abs[i] += s.Base[i]
+		}
+	}
+	return abs
+}
+
+// ProcessDiff 두 스냅샷 사이 한 프로세스의 시계 변화
+type ProcessDiff struct {
+	Process  int   `json:"process"`
+	Before   []int `json:"before"`   // 앞 스냅샷의 절대 시계 (없던 프로세스면 nil)
+	After    []int `json:"after"`    // 뒤 스냅샷의 절대 시계 (사라진 프로세스면 nil)
+	Advanced []int `json:"advanced"` // 항목별 증가량 (After - Before)
+	Own      int   `json:"own"`      // 자기 항목 증가량 (이 프로세스에서 일어난 이벤트 수)
+	Learned  int   `json:"learned"`  // 다른 항목 증가량 합계 (수신으로 새로 알게 된 다른 프로세스의 이벤트 수)
+	Stalled  bool  `json:"stalled"`  // 두 스냅샷 모두에 있지만 자기 항목이 그대로인 경우
+}
+
+// SnapshotDiff 두 스냅샷 사이의 프로세스별 시계 변화
+type SnapshotDiff struct {
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Elapsed   time.Duration `json:"elapsed"`
+	Processes []ProcessDiff `json:"processes"` // 프로세스 ID 순서
+}
+
+// Stalled 자기 항목이 증가하지 않은(멈춘 것으로 보이는) 프로세스 ID 목록
+func (d SnapshotDiff) Stalled() []int {
+	var ids []int
+	for _, p := range d.Processes {
+		if p.Stalled {
+			ids = append(ids, p.Process)
+		}
+	}
+	return ids
+}
+
+// Rate 프로세스 id 의 초당 이벤트 수 (자기 항목 증가량 / 경과 시간, 없으면 0)
+func (d SnapshotDiff) Rate(id int) float64 {
+	if d.Elapsed <= 0 {
+		return 0
+	}
+	for _, p := range d.Processes {
+		if p.Process == id {
+			return float64(p.Own) / d.Elapsed.Seconds()
+		}
+	}
+	return 0
+}
+
+// DiffSnapshots 스냅샷 a 에서 b 까지 프로세스별로 시계가 얼마나 진행했는지 계산
+//
+// 시계는 에포크 기준 시계를 더한 절대 시계로 비교하므로 사이에 Checkpoint 가 있어도 된다.
+// 사이에 ResetEpoch 가 있었다면 시계를 비교할 수 없으므로 EpochError 를 반환한다.
+func DiffSnapshots(a, b ManagerSnapshot) (SnapshotDiff, error) {
+	if a.Origin != b.Origin {
+		return SnapshotDiff{}, &EpochError{From: a.Epoch, To: b.Epoch}
+	}
+
+	ids := make(map[int]bool, len(a.Clocks)+len(b.Clocks))
+	for id := range a.Clocks {
+		ids[id] = true
+	}
+	for id := range b.Clocks {
+		ids[id] = true
+	}
+	order := make([]int, 0, len(ids))
+	for id := range ids {
+		order = append(order, id)
+	}
+	sort.Ints(order)
+
+	d := SnapshotDiff{From: a.Time, To: b.Time, Elapsed: b.Time.Sub(a.Time)}
+	for _, id := range order {
+		pd := ProcessDiff{Process: id, Before: a.absolute(id), After: b.absolute(id)}
+		n := len(pd.Before)
+		if len(pd.After) > n {
+			n = len(pd.After)
+		}
+		pd.Advanced = make([]int, n)
+		for i := range pd.Advanced {
+			var before, after int
+			if i < len(pd.Before) {
+				before = pd.Before[i]
+			}
+			if i < len(pd.After) {
+				after = pd.After[i]
+			}
+			pd.Advanced[i] = after - before
+			if i == id {
+				pd.Own = pd.Advanced[i]
+			} else {
+				pd.Learned += pd.Advanced[i]
+			}
+		}
+		pd.Stalled = pd.Before != nil && pd.After != nil && pd.Own == 0
+		d.Processes = append(d.Processes, pd)
+	}
+	return d, nil
+}
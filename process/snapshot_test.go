@@ -0,0 +1,86 @@
+package process
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshotCopiesClocks(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	vcm.UpdateClock(0, nil)
+	s := vcm.Snapshot()
+	vcm.UpdateClock(0, nil)
+
+	if got := s.Clocks[0]; !reflect.DeepEqual(got, []int{1, 0}) {
+		t.Fatalf("snapshot clock = %v, want [1 0]", got)
+	}
+	s.Clocks[1][0] = 9
+	if got := vcm.GetClock(1); !reflect.DeepEqual(got, []int{0, 0}) {
+		t.Fatalf("manager clock = %v after editing the snapshot", got)
+	}
+}
+
+func TestDiffSnapshotsReportsProgress(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	a := vcm.Snapshot()
+	vcm.UpdateClock(0, nil)
+	vcm.UpdateClock(0, nil)
+	vcm.UpdateClock(1, vcm.GetClock(0))
+	b := vcm.Snapshot()
+
+	d, err := DiffSnapshots(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Processes) != 3 {
+		t.Fatalf("diff covers %d processes, want 3", len(d.Processes))
+	}
+	p0, p1 := d.Processes[0], d.Processes[1]
+	if p0.Own != 2 || p0.Learned != 0 {
+		t.Fatalf("process 0 = %+v, want own 2", p0)
+	}
+	if p1.Own != 1 || p1.Learned != 2 {
+		t.Fatalf("process 1 = %+v, want own 1 learned 2", p1)
+	}
+	if got := d.Stalled(); !reflect.DeepEqual(got, []int{2}) {
+		t.Fatalf("Stalled() = %v, want [2]", got)
+	}
+	if d.Elapsed > 0 && d.Rate(0) <= 0 {
+		t.Fatalf("Rate(0) = %v, want positive", d.Rate(0))
+	}
+}
+
+func TestDiffSnapshotsAcrossCheckpoint(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	vcm.UpdateClock(0, nil)
+	vcm.UpdateClock(1, vcm.GetClock(0))
+	vcm.UpdateClock(0, vcm.GetClock(1))
+	a := vcm.Snapshot()
+	vcm.Checkpoint()
+	vcm.UpdateClock(1, nil)
+	b := vcm.Snapshot()
+
+	d, err := DiffSnapshots(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := d.Processes[0]; p.Own != 0 || !p.Stalled {
+		t.Fatalf("process 0 = %+v, want stalled", p)
+	}
+	if p := d.Processes[1]; p.Own != 1 || p.Stalled {
+		t.Fatalf("process 1 = %+v, want one event", p)
+	}
+}
+
+func TestDiffSnapshotsRejectsReset(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	a := vcm.Snapshot()
+	vcm.ResetEpoch()
+	b := vcm.Snapshot()
+
+	var epochErr *EpochError
+	if _, err := DiffSnapshots(a, b); !errors.As(err, &epochErr) {
+		t.Fatalf("DiffSnapshots across a reset = %v, want EpochError", err)
+	}
+}
@@ -0,0 +1,35 @@
+package process
+
+// StableClock 모든 프로세스의 Vector Clock 에 대한 원소별 최소값 반환
+//
+// 결과의 j 번째 값이 k 라면, 프로세스 j 의 k 번째 이벤트까지는
+// 모든 프로세스가 이미 알고 있는(인과적으로 안정된) 상태이다.
+func (vcm *VectorClockManager) StableClock() []int {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+	return globalWatermark(vcm.Clock)
+}
+
+// IsStableEvent 프로세스 processID 의 seq 번째 이벤트가 인과적으로 안정되었는지 여부
+func (vcm *VectorClockManager) IsStableEvent(processID, seq int) bool {
+	stable := vcm.StableClock()
+	if processID < 0 || processID >= len(stable) {
+		return false
+	}
+	return stable[processID] >= seq
+}
+
+// IsStable 메시지가 인과적으로 안정되었는지 여부
+//
+// 모든 프로세스가 송신 이벤트(msg.Vector[msg.From])를 알고 있으면 안정된 것으로 본다.
+// 안정된 메시지는 더 이상 재전송/버퍼링할 필요가 없으므로 안전하게 정리할 수 있다.
+func (vcm *VectorClockManager) IsStable(msg Message) bool {
+	if msg.From < 0 || msg.From >= len(msg.Vector) {
+		return false
+	}
+	vector, err := vcm.Translate(msg.Vector, msg.Epoch, vcm.CurrentEpoch())
+	if err != nil {
+		return false
+	}
+	return vcm.IsStableEvent(msg.From, vector[msg.From])
+}
@@ -0,0 +1,43 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStableClockIsElementwiseMinimum(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	vcm.UpdateClock(0, nil)            // [1 0 0]
+	vcm.UpdateClock(0, nil)            // [2 0 0]
+	vcm.UpdateClock(1, []int{1, 0, 0}) // [1 1 0]
+	vcm.UpdateClock(2, []int{2, 1, 0}) // [2 1 1]
+
+	if got, want := vcm.StableClock(), []int{1, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("StableClock() = %v, want %v", got, want)
+	}
+	if !vcm.IsStableEvent(0, 1) {
+		t.Fatal("first event of process 0 is known everywhere but not stable")
+	}
+	if vcm.IsStableEvent(0, 2) {
+		t.Fatal("second event of process 0 is stable before process 1 saw it")
+	}
+	if vcm.IsStableEvent(3, 0) || vcm.IsStableEvent(-1, 0) {
+		t.Fatal("unknown process reported stable")
+	}
+}
+
+func TestIsStableMessage(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	vcm.UpdateClock(0, nil)
+	msg := Message{From: 0, To: 1, Vector: vcm.GetClock(0)}
+	if vcm.IsStable(msg) {
+		t.Fatal("message is stable before the receiver merged it")
+	}
+	vcm.UpdateClock(1, msg.Vector)
+	if !vcm.IsStable(msg) {
+		t.Fatal("message is not stable after every process knows the send")
+	}
+	if vcm.IsStable(Message{From: 5, Vector: []int{1, 1}}) {
+		t.Fatal("message from an unknown sender reported stable")
+	}
+}
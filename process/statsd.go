@@ -0,0 +1,185 @@
+package process
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultStatsDInterval StatsD 로 카운터를 보내는 기본 간격
+const DefaultStatsDInterval = 10 * time.Second
+
+// statsdPacketSize UDP 패킷 하나에 담는 최대 바이트 수 (단편화되지 않는 크기)
+const statsdPacketSize = 1432
+
+// StatsDOption StatsD 내보내기 설정 옵션
+type StatsDOption func(*statsdExporter)
+
+// WithStatsDPrefix 지표 이름 앞에 붙일 접두사 (기본값 "vectorclock", "" 이면 접두사 없음)
+func WithStatsDPrefix(prefix string) StatsDOption {
+	return func(e *statsdExporter) {
+		e.prefix = prefix
+	}
+}
+
+// WithStatsDInterval 카운터를 보내는 간격 (기본값 DefaultStatsDInterval)
+func WithStatsDInterval(d time.Duration) StatsDOption {
+	return func(e *statsdExporter) {
+		if d > 0 {
+			e.interval = d
+		}
+	}
+}
+
+// WithStatsDTags 프로세스별로 붙일 태그 (process 태그 외에 추가, 예: 역할, 노드 이름)
+func WithStatsDTags(tags func(processID int) map[string]string) StatsDOption {
+	return func(e *statsdExporter) {
+		e.tags = tags
+	}
+}
+
+// statsdExporter 주기적으로 카운터 증가분을 StatsD 로 보냄
+type statsdExporter struct {
+	vcm      *VectorClockManager
+	conn     net.Conn
+	prefix   string
+	interval time.Duration
+	tags     func(processID int) map[string]string
+
+	last map[int]ProcessMetrics // 마지막으로 보낸 카운터
+}
+
+// ExportStatsD Metrics 와 같은 카운터(events, sent, received, merges, dropped, rejected, duplicates, mailbox_full_ms)를 addr 의 StatsD 로 내보냄
+//
+// 간격마다 프로세스별 증가분을 "<prefix>.<지표>:<값>|c|#process:<ID>,<태그>" 형식(DogStatsD 태그)의
+// 카운터로, 메일박스 깊이와 최대 깊이(mailbox_depth, mailbox_high_water)는 게이지(|g)로 UDP 전송한다.
+// Datadog 에이전트, Telegraf 등 태그를 지원하는 수집기에서 바로 쓸 수 있다.
+// 반환된 함수로 중지하며, 중지할 때 남은 증가분을 한 번 더 보낸다.
+func (vcm *VectorClockManager) ExportStatsD(addr string, opts ...StatsDOption) (stop func(), err error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	e := &statsdExporter{
+		vcm:      vcm,
+		conn:     conn,
+		prefix:   "vectorclock",
+		interval: DefaultStatsDInterval,
+		last:     make(map[int]ProcessMetrics),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				e.flush()
+			case <-done:
+				e.flush()
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-finished
+			conn.Close()
+		})
+	}, nil
+}
+
+// flush 마지막 전송 이후의 증가분 전송
+func (e *statsdExporter) flush() {
+	metrics := e.vcm.Metrics()
+	ids := make([]int, 0, len(metrics))
+	for id := range metrics {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var packet bytes.Buffer
+	send := func() {
+		if packet.Len() == 0 {
+			return
+		}
+		if _, err := e.conn.Write(packet.Bytes()); err != nil {
+			e.vcm.logf("StatsD: send failed: %v\n", err)
+		}
+		packet.Reset()
+	}
+	write := func(line string) {
+		if packet.Len() > 0 && packet.Len()+1+len(line) > statsdPacketSize {
+			send()
+		}
+		if packet.Len() > 0 {
+			packet.WriteByte('\n')
+		}
+		packet.WriteString(line)
+	}
+	for _, id := range ids {
+		cur, prev := metrics[id], e.last[id]
+		e.last[id] = cur
+		tags := e.tagsOf(id)
+		for _, c := range []struct {
+			name  string
+			delta int64
+		}{
+			{"events", cur.Events - prev.Events},
+			{"sent", cur.Sent - prev.Sent},
+			{"received", cur.Received - prev.Received},
+			{"merges", cur.Merges - prev.Merges},
+			{"dropped", cur.Dropped - prev.Dropped},
+			{"rejected", cur.Rejected - prev.Rejected},
+			{"duplicates", cur.Duplicates - prev.Duplicates},
+			{"mailbox_full_ms", cur.MailboxFull.Milliseconds() - prev.MailboxFull.Milliseconds()},
+		} {
+			if c.delta == 0 {
+				continue
+			}
+			write(e.name(c.name) + ":" + strconv.FormatInt(c.delta, 10) + "|c|#" + tags)
+		}
+		write(e.name("mailbox_depth") + ":" + strconv.FormatInt(cur.MailboxDepth, 10) + "|g|#" + tags)
+		write(e.name("mailbox_high_water") + ":" + strconv.FormatInt(cur.MailboxHighWater, 10) + "|g|#" + tags)
+	}
+	send()
+}
+
+// name 접두사를 붙인 지표 이름
+func (e *statsdExporter) name(metric string) string {
+	if e.prefix == "" {
+		return metric
+	}
+	return e.prefix + "." + metric
+}
+
+// tagsOf 프로세스 id 의 태그 목록 ("process:<ID>" 다음에 추가 태그를 이름 순서로)
+func (e *statsdExporter) tagsOf(id int) string {
+	tags := []string{"process:" + strconv.Itoa(id)}
+	if e.tags != nil {
+		extra := e.tags(id)
+		keys := make([]string, 0, len(extra))
+		for k := range extra {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			tags = append(tags, fmt.Sprintf("%s:%s", k, extra[k]))
+		}
+	}
+	return strings.Join(tags, ",")
+}
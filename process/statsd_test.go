@@ -0,0 +1,87 @@
+package process
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// statsdServer 받은 StatsD 패킷을 한 줄씩 돌려주는 UDP 수신기
+func statsdServer(t *testing.T) (addr string, read func() []string) {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn.LocalAddr().String(), func() []string {
+		buf := make([]byte, 64*1024)
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.Split(string(buf[:n]), "\n")
+	}
+}
+
+func TestExportStatsDSendsTaggedDeltas(t *testing.T) {
+	addr, read := statsdServer(t)
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm, WithMailboxSize(4))
+
+	stop, err := vcm.ExportStatsD(addr, WithStatsDInterval(time.Hour),
+		WithStatsDTags(func(id int) map[string]string { return map[string]string{"role": "node", "az": "a"} }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	stop() // 중지할 때 남은 증가분을 보냄
+	stop()
+
+	want := []string{
+		"vectorclock.events:1|c|#process:0,az:a,role:node",
+		"vectorclock.sent:1|c|#process:0,az:a,role:node",
+		"vectorclock.mailbox_depth:0|g|#process:0,az:a,role:node",
+		"vectorclock.mailbox_high_water:0|g|#process:0,az:a,role:node",
+		"vectorclock.events:1|c|#process:1,az:a,role:node",
+		"vectorclock.received:1|c|#process:1,az:a,role:node",
+		"vectorclock.merges:1|c|#process:1,az:a,role:node",
+		"vectorclock.mailbox_depth:0|g|#process:1,az:a,role:node",
+		"vectorclock.mailbox_high_water:1|g|#process:1,az:a,role:node",
+	}
+	if got := read(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("packet =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestExportStatsDSendsOnlyIncrements(t *testing.T) {
+	addr, read := statsdServer(t)
+	vcm := NewVectorClockManager(1, WithLogger(nil))
+	p := NewProcess(0, vcm)
+	p.LocalEvent("a")
+	p.LocalEvent("b")
+
+	stop, err := vcm.ExportStatsD(addr, WithStatsDPrefix(""), WithStatsDInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+	gauges := []string{"mailbox_depth:0|g|#process:0", "mailbox_high_water:0|g|#process:0"}
+	want := append([]string{"events:2|c|#process:0"}, gauges...)
+	if got := read(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("first packet = %q, want %q", got, want)
+	}
+	p.LocalEvent("c")
+	want = append([]string{"events:1|c|#process:0"}, gauges...)
+	if got := read(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("second packet = %q, want only the new event and the gauges", got)
+	}
+}
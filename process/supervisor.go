@@ -0,0 +1,149 @@
+package process
+
+import (
+	"sync"
+	"time"
+)
+
+// RestartPolicy 수신 루프 재시작 정책
+type RestartPolicy struct {
+	MaxRetries int           // 최대 재시작 횟수 (0 이면 무제한)
+	Backoff    time.Duration // 첫 재시작 전 대기 시간 (재시작마다 두 배)
+	MaxBackoff time.Duration // 대기 시간 상한 (0 이면 상한 없음)
+}
+
+// RestartAlways panic 이 발생할 때마다 즉시 재시작
+func RestartAlways() RestartPolicy {
+	return RestartPolicy{}
+}
+
+// RestartMaxRetries 최대 n 번까지 즉시 재시작
+func RestartMaxRetries(n int) RestartPolicy {
+	return RestartPolicy{MaxRetries: n}
+}
+
+// RestartBackoff initial 부터 두 배씩 늘어나는 대기 후 재시작 (max 는 대기 상한, retries 는 최대 횟수)
+func RestartBackoff(initial, max time.Duration, retries int) RestartPolicy {
+	return RestartPolicy{MaxRetries: retries, Backoff: initial, MaxBackoff: max}
+}
+
+// delay n 번째(1 부터) 재시작 전 대기 시간
+func (rp RestartPolicy) delay(n int) time.Duration {
+	d := rp.Backoff
+	for i := 1; i < n && d > 0; i++ {
+		d *= 2
+		if rp.MaxBackoff > 0 && d >= rp.MaxBackoff {
+			return rp.MaxBackoff
+		}
+	}
+	if rp.MaxBackoff > 0 && d > rp.MaxBackoff {
+		return rp.MaxBackoff
+	}
+	return d
+}
+
+// Supervisor 프로세스 수신 루프 감독
+//
+// behavior 에서 panic 이 발생하면 정책에 따라 수신 루프를 다시 시작한다. 프로세스의 시계는 그대로 둔다.
+// 되돌리면 panic 전에 보낸 메시지나 로컬 이벤트의 카운터 값이 다시 쓰여 서로 다른 이벤트가 같은 시계를 갖게 된다.
+// 재시작 지연은 프로세스의 Sleep 으로 기다리므로 가상 시간(WithVirtualTime)에서는 가상 시각 기준이다.
+type Supervisor struct {
+	Policy RestartPolicy // 재시작 정책
+
+	mu       sync.Mutex
+	children map[int]*supervised
+	stopped  bool
+}
+
+// supervised 감독 대상 프로세스
+type supervised struct {
+	proc     *Process
+	behavior Behavior
+	restarts int
+	failed   bool
+}
+
+// NewSupervisor Supervisor 초기화
+func NewSupervisor(policy RestartPolicy) *Supervisor {
+	return &Supervisor{Policy: policy, children: make(map[int]*supervised)}
+}
+
+// Supervise 프로세스의 수신 루프를 감독 하에 시작
+func (s *Supervisor) Supervise(p *Process, behavior Behavior) error {
+	s.mu.Lock()
+	child := &supervised{proc: p, behavior: behavior}
+	s.children[p.ID] = child
+	s.mu.Unlock()
+
+	return p.start(behavior, func(r interface{}) { s.crashed(child, r) })
+}
+
+// Restarts 프로세스가 재시작된 횟수
+func (s *Supervisor) Restarts(id int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if child, ok := s.children[id]; ok {
+		return child.restarts
+	}
+	return 0
+}
+
+// Failed 재시작 횟수를 모두 소진하여 더 이상 재시작되지 않는 프로세스인지 여부
+func (s *Supervisor) Failed(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if child, ok := s.children[id]; ok {
+		return child.failed
+	}
+	return false
+}
+
+// Stop 모든 감독 대상 프로세스를 멈추고 더 이상 재시작하지 않음
+func (s *Supervisor) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	children := make([]*supervised, 0, len(s.children))
+	for _, child := range s.children {
+		children = append(children, child)
+	}
+	s.mu.Unlock()
+
+	for _, child := range children {
+		child.proc.Stop()
+	}
+}
+
+// crashed 수신 루프 panic 처리
+func (s *Supervisor) crashed(child *supervised, r interface{}) {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	if s.Policy.MaxRetries > 0 && child.restarts >= s.Policy.MaxRetries {
+		child.failed = true
+		s.mu.Unlock()
+		child.proc.logf("Supervisor: Process %d panicked (%v), giving up after %d restarts\n",
+			child.proc.ID, r, child.restarts)
+		return
+	}
+	child.restarts++
+	attempt := child.restarts
+	s.mu.Unlock()
+
+	child.proc.logf("Supervisor: restarting Process %d (attempt %d) after panic: %v, Vector: %v\n",
+		child.proc.ID, attempt, r, child.proc.ClockMgr.GetClock(child.proc.ID))
+
+	go func() {
+		child.proc.Sleep(s.Policy.delay(attempt))
+		s.mu.Lock()
+		stopped := s.stopped
+		s.mu.Unlock()
+		if stopped {
+			return
+		}
+		_ = child.proc.start(child.behavior, func(r interface{}) { s.crashed(child, r) })
+	}()
+}
@@ -0,0 +1,153 @@
+package process
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSupervisorRestartsAfterPanic(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	sender := NewProcess(0, vcm)
+	worker := NewProcess(1, vcm)
+	got := make(chan Message, 1)
+
+	s := NewSupervisor(RestartAlways())
+	defer s.Stop()
+	if err := s.Supervise(worker, func(msg Message) []Outgoing {
+		if msg.Event == "boom" {
+			panic("boom")
+		}
+		got <- msg
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sender.Send(1, "boom"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, 5*time.Second, "restart", func() bool { return s.Restarts(1) == 1 && worker.Running() })
+	if err := sender.Send(1, "after"); err != nil {
+		t.Fatal(err)
+	}
+	if msg := waitMessage(t, got); msg.Event != "after" {
+		t.Fatalf("got %q after restart, want after", msg.Event)
+	}
+	if s.Failed(1) {
+		t.Fatal("RestartAlways reported the worker failed")
+	}
+}
+
+func TestSupervisorGivesUpAfterMaxRetries(t *testing.T) {
+	vcm := NewVectorClockManager(2)
+	sender := NewProcess(0, vcm)
+	worker := NewProcess(1, vcm)
+
+	s := NewSupervisor(RestartMaxRetries(1))
+	defer s.Stop()
+	if err := s.Supervise(worker, func(Message) []Outgoing { panic("always") }); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := sender.Send(1, "boom"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitFor(t, 5*time.Second, "give up", func() bool { return s.Failed(1) })
+	if n := s.Restarts(1); n != 1 {
+		t.Fatalf("Restarts(1) = %d, want 1", n)
+	}
+	if worker.Running() {
+		t.Fatal("worker running after the supervisor gave up")
+	}
+}
+
+func TestRestartBackoffDoublesUpToMax(t *testing.T) {
+	rp := RestartBackoff(10*time.Millisecond, 35*time.Millisecond, 0)
+	for n, want := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 3: 35 * time.Millisecond, 8: 35 * time.Millisecond} {
+		if got := rp.delay(n); got != want {
+			t.Errorf("delay(%d) = %v, want %v", n, got, want)
+		}
+	}
+	if d := RestartAlways().delay(3); d != 0 {
+		t.Fatalf("RestartAlways delay = %v, want 0", d)
+	}
+}
+
+// waitFor cond 가 참이 될 때까지 최대 d 동안 기다림
+func waitFor(t *testing.T, d time.Duration, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(d)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRestartDoesNotReuseClockValues(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	worker := NewProcess(0, vcm, WithMailboxSize(8))
+	sink := NewProcess(1, vcm, WithMailboxSize(8))
+
+	sup := NewSupervisor(RestartAlways())
+	defer sup.Stop()
+	if err := sup.Supervise(worker, func(msg Message) []Outgoing {
+		if err := worker.Send(1, "before-"+msg.Event); err != nil {
+			t.Error(err)
+		}
+		worker.LocalEvent("work")
+		if msg.Event == "boom" {
+			panic("boom")
+		}
+		return []Outgoing{{To: 1, Event: "after-" + msg.Event}}
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, event := range []string{"boom", "ok", "boom", "ok"} {
+		if err := sink.Send(0, event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	last := 0
+	for i := 0; i < 6; i++ {
+		msg, err := sink.ReceiveFrom(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := msg.Vector[0]; got <= last {
+			t.Fatalf("message %q carries worker entry %d after %d: clock value reused", msg.Event, got, last)
+		}
+		last = msg.Vector[0]
+	}
+	if got := sup.Restarts(0); got != 2 {
+		t.Fatalf("Restarts = %d, want 2", got)
+	}
+}
+
+func TestRestartBackoffUsesVirtualTime(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithVirtualTime())
+	worker := NewProcess(0, vcm, WithMailboxSize(8))
+	client := NewProcess(1, vcm, WithMailboxSize(8))
+	if err := client.Start(func(Message) []Outgoing { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	defer client.Stop()
+
+	sup := NewSupervisor(RestartBackoff(time.Hour, 0, 1))
+	defer sup.Stop()
+	if err := sup.Supervise(worker, func(Message) []Outgoing { panic("boom") }); err != nil {
+		t.Fatal(err)
+	}
+	start := vcm.Scheduler().Now()
+	if err := client.Send(0, "crash"); err != nil {
+		t.Fatal(err)
+	}
+	// 한 시간의 재시작 지연은 가상 시각으로 흐르므로 실제로는 곧바로 재시작되어야 함
+	waitFor(t, 5*time.Second, "restart", func() bool { return sup.Restarts(0) == 1 && worker.Running() })
+	if elapsed := vcm.Scheduler().Now() - start; elapsed < time.Hour {
+		t.Fatalf("restarted after %v of virtual time, want at least 1h", elapsed)
+	}
+}
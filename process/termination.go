@@ -0,0 +1,162 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrNotTerminated AwaitTermination 이 제한 시간 안에 종료를 감지하지 못한 경우
+var ErrNotTerminated = errors.New("process: computation has not terminated")
+
+// terminationState 종료 감지를 위한 전송 중 메시지 수와 활성 프로세스
+//
+// 메일박스에 넣은 메시지는 꺼낼 때까지 전송 중으로 세고, 액터는 메시지를 꺼낸 순간부터
+// Behavior 가 돌려준 메시지를 모두 보낼 때까지 활성으로 센다. 메시지를 꺼내기 전에 활성으로
+// 표시하므로, 전송 중 메시지가 0 이고 활성 프로세스가 없으면 더 이상 메시지가 생길 수 없다.
+type terminationState struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	inFlight  int
+	transit   transitLog // 전송 중 메시지 (CaptureState)
+	active    map[int]bool
+	waiting   map[int]*waiter // 대기 중인 프로세스 (교착 상태 감지)
+	advancing bool            // 가상 시간 자동 진행 중 여부
+}
+
+// condLocked 상태 변화 알림용 조건 변수 (t.mu 보유 상태에서 호출)
+func (t *terminationState) condLocked() *sync.Cond {
+	if t.cond == nil {
+		t.cond = sync.NewCond(&t.mu)
+	}
+	return t.cond
+}
+
+// enter 메시지가 메일박스(또는 응답 채널)에 들어감 (전송 중으로 셈)
+func (vcm *VectorClockManager) enter(msg Message) {
+	vcm.transitChanged(msg, 1)
+}
+
+// leave 메시지가 메일박스에서 나감 (꺼냄, 전달 실패, 다른 노드로 넘김)
+func (vcm *VectorClockManager) leave(msg Message) {
+	vcm.transitChanged(msg, -1)
+}
+
+// transitChanged 전송 중 메시지와 메일박스 깊이 갱신 (포화 상태가 바뀌면 잠금을 푼 뒤 알림)
+func (vcm *VectorClockManager) transitChanged(msg Message, delta int) {
+	target, now := vcm.mailboxTarget(msg.To)
+	t := &vcm.term
+	t.mu.Lock()
+	if delta > 0 {
+		t.transit.add(msg)
+	} else {
+		t.transit.remove(msg)
+	}
+	t.inFlight += delta
+	var event BackpressureEvent
+	changed := false
+	if target != nil {
+		depth := vcm.counters(target.ID).mailbox(int64(delta), target.mailboxCap(), now)
+		event, changed = vcm.saturationLocked(target, msg.From, int(depth), now)
+	}
+	t.condLocked().Broadcast()
+	vcm.checkDeadlockLocked()
+	t.mu.Unlock()
+
+	if changed {
+		vcm.notifyBackpressure(event)
+	}
+}
+
+// mailboxTarget 메일박스 지표를 갱신할 로컬 프로세스와 현재 시각 (다른 노드의 프로세스면 nil)
+func (vcm *VectorClockManager) mailboxTarget(to int) (*Process, time.Time) {
+	target, ok := vcm.Lookup(to)
+	if !ok {
+		return nil, time.Time{}
+	}
+	return target, vcm.now()
+}
+
+// setActive 프로세스 활성 상태 변경
+func (vcm *VectorClockManager) setActive(processID int, active bool) {
+	t := &vcm.term
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.active == nil {
+		t.active = make(map[int]bool)
+	}
+	if active {
+		t.active[processID] = true
+	} else {
+		delete(t.active, processID)
+	}
+	t.condLocked().Broadcast()
+	vcm.checkDeadlockLocked()
+}
+
+// InFlight 메일박스에 들어가 아직 꺼내지지 않은 메시지 수
+func (vcm *VectorClockManager) InFlight() int {
+	vcm.term.mu.Lock()
+	defer vcm.term.mu.Unlock()
+	return vcm.term.inFlight
+}
+
+// Active 메시지를 처리 중인 프로세스 ID (정렬)
+func (vcm *VectorClockManager) Active() []int {
+	vcm.term.mu.Lock()
+	defer vcm.term.mu.Unlock()
+
+	ids := make([]int, 0, len(vcm.term.active))
+	for id := range vcm.term.active {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// Terminated 모든 프로세스가 쉬고 있고 전송 중인 메시지가 없는지 여부
+//
+// 스케줄러에 예약된 송신(SendAfter 등)이나 하트비트는 고려하지 않는다.
+func (vcm *VectorClockManager) Terminated() bool {
+	vcm.term.mu.Lock()
+	defer vcm.term.mu.Unlock()
+	return vcm.terminatedLocked()
+}
+
+// terminatedLocked 종료 여부 (vcm.term.mu 보유 상태에서 호출)
+func (vcm *VectorClockManager) terminatedLocked() bool {
+	return vcm.term.inFlight == 0 && len(vcm.term.active) == 0
+}
+
+// AwaitTermination 계산이 끝날 때까지 대기 (timeout 이 0 이하면 무한정)
+//
+// 제한 시간 안에 끝나지 않으면 ErrNotTerminated 를 반환한다.
+func (vcm *VectorClockManager) AwaitTermination(timeout time.Duration) error {
+	t := &vcm.term
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cond := t.condLocked()
+	expired := false
+	if timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			t.mu.Lock()
+			expired = true
+			t.mu.Unlock()
+			cond.Broadcast()
+		})
+		defer timer.Stop()
+	}
+
+	for !vcm.terminatedLocked() {
+		if expired {
+			return fmt.Errorf("%w: %d messages in flight, %d processes active after %v",
+				ErrNotTerminated, t.inFlight, len(t.active), timeout)
+		}
+		cond.Wait()
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package process
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAwaitTerminationAfterPingPong(t *testing.T) {
+	const hops = 40
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	var handled atomic.Int32
+	// 받은 남은 횟수가 0 보다 크면 하나 줄여 상대에게 돌려보냄
+	bounce := func(p *Process) Behavior {
+		return func(msg Message) []Outgoing {
+			handled.Add(1)
+			n, err := strconv.Atoi(msg.Event)
+			if err != nil || n == 0 {
+				return nil
+			}
+			return []Outgoing{{To: 1 - p.ID, Event: strconv.Itoa(n - 1)}}
+		}
+	}
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	b := NewProcess(1, vcm, WithMailboxSize(4))
+	for _, p := range []*Process{a, b} {
+		if err := p.Start(bounce(p)); err != nil {
+			t.Fatal(err)
+		}
+		defer p.Stop()
+	}
+
+	if err := a.Send(1, strconv.Itoa(hops)); err != nil {
+		t.Fatal(err)
+	}
+	if err := vcm.AwaitTermination(5 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if got := handled.Load(); got != hops+1 {
+		t.Fatalf("handled %d messages at termination, want %d", got, hops+1)
+	}
+	if !vcm.Terminated() || vcm.InFlight() != 0 || len(vcm.Active()) != 0 {
+		t.Fatalf("terminated = %v, in flight = %d, active = %v", vcm.Terminated(), vcm.InFlight(), vcm.Active())
+	}
+}
+
+func TestAwaitTerminationTimesOutWithMessageInFlight(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm, WithMailboxSize(4))
+
+	if err := a.Send(1, "ping"); err != nil {
+		t.Fatal(err)
+	}
+	err := vcm.AwaitTermination(20 * time.Millisecond)
+	if !errors.Is(err, ErrNotTerminated) {
+		t.Fatalf("AwaitTermination = %v, want ErrNotTerminated", err)
+	}
+	if vcm.InFlight() != 1 {
+		t.Fatalf("in flight = %d, want 1", vcm.InFlight())
+	}
+
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if err := vcm.AwaitTermination(time.Second); err != nil {
+		t.Fatalf("after receive: %v", err)
+	}
+}
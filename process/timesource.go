@@ -0,0 +1,131 @@
+package process
+
+import (
+	"sync"
+	"time"
+)
+
+// FrozenTime 직접 움직이기 전까지 멈춰 있는 시계 (테스트용)
+type FrozenTime struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+// NewFrozenTime 시각 t 에 멈춘 시계 생성
+func NewFrozenTime(t time.Time) *FrozenTime {
+	return &FrozenTime{t: t}
+}
+
+// Now 멈춰 있는 시각
+func (f *FrozenTime) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.t
+}
+
+// Set 시각을 t 로 변경
+func (f *FrozenTime) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.t = t
+}
+
+// Advance 시각을 d 만큼 진행
+func (f *FrozenTime) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.t = f.t.Add(d)
+}
+
+// MonotonicTime 생성 시점의 벽시계에서 단조 시계로 흐르는 시계
+//
+// 시스템 시계가 조정되어도(NTP step, 수동 변경) 되돌아가거나 건너뛰지 않는다.
+func MonotonicTime() TimeSource {
+	start := time.Now()
+	return TimeFunc(func() time.Time { return start.Add(time.Since(start)) })
+}
+
+// DisciplinedTime 외부 기준(NTP 등)으로 측정한 오차를 천천히 보정하는 시계
+//
+// Adjust 로 알려준 오차를 한꺼번에 반영하지 않고 로컬 시계가 흐르는 속도의 maxSlew 비율만큼씩
+// 반영하며(ntpd 의 slew), 보정 중에도 시각이 되돌아가지 않는다.
+type DisciplinedTime struct {
+	mu      sync.Mutex
+	base    TimeSource    // 로컬 시계
+	maxSlew float64       // 로컬 시계 1초당 최대 보정 비율 (0 이하면 즉시 반영)
+	applied time.Duration // 지금까지 반영한 보정
+	pending time.Duration // 남은 보정
+	last    time.Time     // 마지막으로 보정을 진행한 로컬 시각
+	out     time.Time     // 마지막으로 돌려준 시각 (단조 증가 보장)
+}
+
+// DefaultMaxSlew ntpd 의 최대 slew 비율 (500ppm)
+const DefaultMaxSlew = 0.0005
+
+// NewDisciplinedTime base(nil 이면 MonotonicTime) 를 maxSlew 비율로 보정하는 시계 생성
+func NewDisciplinedTime(base TimeSource, maxSlew float64) *DisciplinedTime {
+	if base == nil {
+		base = MonotonicTime()
+	}
+	return &DisciplinedTime{base: base, maxSlew: maxSlew, last: base.Now()}
+}
+
+// Adjust 측정한 오차 반영 예약 (offset = 기준 시각 - 이 시계의 시각, 양수면 이 시계가 느림)
+//
+// 아직 반영하지 못한 이전 보정은 새 측정으로 대체된다.
+func (d *DisciplinedTime) Adjust(offset time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.slewLocked(d.base.Now())
+	d.pending = offset
+	if d.maxSlew <= 0 {
+		d.applied += d.pending
+		d.pending = 0
+	}
+}
+
+// Offset 지금까지 반영한 보정과 남은 보정
+func (d *DisciplinedTime) Offset() (applied, pending time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.slewLocked(d.base.Now())
+	return d.applied, d.pending
+}
+
+// Now 보정을 반영한 현재 시각
+func (d *DisciplinedTime) Now() time.Time {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	local := d.base.Now()
+	d.slewLocked(local)
+	t := local.Add(d.applied)
+	if t.Before(d.out) {
+		t = d.out
+	}
+	d.out = t
+	return t
+}
+
+// slewLocked 로컬 시각 local 까지 흐른 시간만큼 남은 보정 반영 (d.mu 보유 상태에서 호출)
+func (d *DisciplinedTime) slewLocked(local time.Time) {
+	elapsed := local.Sub(d.last)
+	d.last = local
+	if d.pending == 0 || elapsed <= 0 || d.maxSlew <= 0 {
+		return
+	}
+	step := time.Duration(d.maxSlew * float64(elapsed))
+	switch {
+	case d.pending > 0 && d.pending <= step, d.pending < 0 && -d.pending <= step:
+		d.applied += d.pending
+		d.pending = 0
+	case d.pending > 0:
+		d.applied += step
+		d.pending -= step
+	default:
+		d.applied -= step
+		d.pending += step
+	}
+}
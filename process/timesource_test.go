@@ -0,0 +1,68 @@
+package process
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProcessTimeSourceStampsMessages(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	frozen := NewFrozenTime(time.Unix(500, 0))
+	a := NewProcess(0, vcm, WithProcessTimeSource(frozen), WithClockSkew(time.Second, 0))
+	b := NewProcess(1, vcm)
+
+	frozen.Advance(10 * time.Second)
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if msg := <-b.MessageCh; msg.Timestamp != 511 {
+		t.Fatalf("Timestamp = %d, want 511 from the process clock plus skew", msg.Timestamp)
+	}
+	frozen.Set(time.Unix(100, 0))
+	if got := a.Now(); !got.Equal(time.Unix(101, 0)) {
+		t.Fatalf("Now() = %v, want 101s", got)
+	}
+}
+
+func TestMonotonicTimeNeverGoesBack(t *testing.T) {
+	ts := MonotonicTime()
+	prev := ts.Now()
+	for i := 0; i < 1000; i++ {
+		now := ts.Now()
+		if now.Before(prev) {
+			t.Fatalf("time went back from %v to %v", prev, now)
+		}
+		prev = now
+	}
+}
+
+func TestDisciplinedTimeSlewsOffset(t *testing.T) {
+	base := NewFrozenTime(time.Unix(0, 0))
+	d := NewDisciplinedTime(base, 0.5)
+
+	d.Adjust(time.Second)
+	base.Advance(time.Second)
+	if applied, pending := d.Offset(); applied != 500*time.Millisecond || pending != 500*time.Millisecond {
+		t.Fatalf("Offset() = %v, %v, want 500ms, 500ms", applied, pending)
+	}
+	base.Advance(10 * time.Second)
+	if got := d.Now(); !got.Equal(time.Unix(12, 0)) {
+		t.Fatalf("Now() = %v, want 12s after the full correction", got)
+	}
+
+	// 뒤로 보정해도 이미 돌려준 시각보다 앞서지 않음
+	d.Adjust(-time.Hour)
+	base.Advance(time.Second)
+	if got := d.Now(); got.Before(time.Unix(12, 0)) {
+		t.Fatalf("Now() = %v went back while slewing", got)
+	}
+}
+
+func TestDisciplinedTimeStepsWithoutSlew(t *testing.T) {
+	base := NewFrozenTime(time.Unix(0, 0))
+	d := NewDisciplinedTime(base, 0)
+	d.Adjust(3 * time.Second)
+	if got := d.Now(); !got.Equal(time.Unix(3, 0)) {
+		t.Fatalf("Now() = %v, want the offset applied at once", got)
+	}
+}
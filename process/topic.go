@@ -0,0 +1,139 @@
+package process
+
+import (
+	"fmt"
+	"sort"
+)
+
+// topicState 토픽 구독 상태
+type topicState struct {
+	subscribers []int         // 구독자 ID
+	published   []int         // 발행자별 발행 수
+	bases       map[int][]int // 구독자별 구독 시점의 발행 수
+}
+
+// Subscribe 프로세스가 토픽을 구독 (토픽이 없으면 생성)
+//
+// 구독 이후 발행된 메시지만 받으며, 같은 토픽의 발행은 모든 구독자에게 인과 순서대로 전달된다.
+func (vcm *VectorClockManager) Subscribe(topic string, id int) {
+	n := vcm.Size()
+	vcm.procMu.Lock()
+	defer vcm.procMu.Unlock()
+
+	if vcm.topics == nil {
+		vcm.topics = make(map[string]*topicState)
+	}
+	t, ok := vcm.topics[topic]
+	if !ok {
+		t = &topicState{published: make([]int, n), bases: make(map[int][]int)}
+		vcm.topics[topic] = t
+	}
+	if indexOf(t.subscribers, id) < 0 {
+		t.subscribers = append(t.subscribers, id)
+		t.bases[id] = append([]int(nil), t.published...)
+	}
+}
+
+// Unsubscribe 토픽 구독 해제
+func (vcm *VectorClockManager) Unsubscribe(topic string, id int) {
+	vcm.procMu.Lock()
+	defer vcm.procMu.Unlock()
+
+	t, ok := vcm.topics[topic]
+	if !ok {
+		return
+	}
+	if i := indexOf(t.subscribers, id); i >= 0 {
+		t.subscribers = append(t.subscribers[:i:i], t.subscribers[i+1:]...)
+	}
+}
+
+// Subscribers 토픽 구독자 ID (정렬)
+func (vcm *VectorClockManager) Subscribers(topic string) []int {
+	vcm.procMu.RLock()
+	defer vcm.procMu.RUnlock()
+
+	t, ok := vcm.topics[topic]
+	if !ok {
+		return nil
+	}
+	subs := append([]int(nil), t.subscribers...)
+	sort.Ints(subs)
+	return subs
+}
+
+// Topics 모든 토픽 이름 (정렬)
+func (vcm *VectorClockManager) Topics() []string {
+	vcm.procMu.RLock()
+	defer vcm.procMu.RUnlock()
+
+	names := make([]string, 0, len(vcm.topics))
+	for name := range vcm.topics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// publish 발행 수를 증가시키고 현재 구독자 목록과 발행 번호 반환
+func (vcm *VectorClockManager) publish(topic string, from int) ([]int, int, bool) {
+	n := vcm.Size()
+	vcm.procMu.Lock()
+	defer vcm.procMu.Unlock()
+
+	t, ok := vcm.topics[topic]
+	if !ok || from < 0 || from >= n {
+		return nil, 0, false
+	}
+	// 토픽을 만든 뒤 Grow 로 들어온 발행자
+	t.published = padClock(t.published, n)
+	t.published[from]++
+	subs := append([]int(nil), t.subscribers...)
+	sort.Ints(subs)
+	return subs, t.published[from], true
+}
+
+// subscriptionBase 구독 시점의 발행 수 (구독하지 않았거나 토픽이 없으면 0 벡터)
+func (vcm *VectorClockManager) subscriptionBase(topic string, id int) []int {
+	n := vcm.Size()
+	vcm.procMu.RLock()
+	defer vcm.procMu.RUnlock()
+
+	base := make([]int, n)
+	if t, ok := vcm.topics[topic]; ok {
+		copy(base, t.bases[id])
+	}
+	return base
+}
+
+// Publish 토픽의 모든 구독자(자신 제외)에게 인과 순서를 보장하여 발행
+//
+// 관리자의 Delivery 설정에 따라 토픽별 BSS 벡터 또는 SES 벡터 집합이 메시지에 실린다.
+// 받는 쪽은 DeliverCausal 로 수신해야 인과 순서대로 전달된다.
+func (p *Process) Publish(topic, event string) error {
+	subs, seq, ok := p.ClockMgr.publish(topic, p.ID)
+	if !ok {
+		return fmt.Errorf("process: unknown topic %q", topic)
+	}
+
+	ids := make([]int, 0, len(subs))
+	targets := make(map[int]chan<- Message, len(subs))
+	for _, id := range subs {
+		if id == p.ID {
+			continue
+		}
+		mailbox, err := p.ClockMgr.mailbox(id)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		targets[id] = mailbox
+	}
+
+	if p.ClockMgr.Delivery == DeliverySES {
+		p.sendSES(topic, event, ids, targets)
+		return nil
+	}
+	p.broadcastBSS(topic, event, ids, targets, seq)
+	return nil
+}
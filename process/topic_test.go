@@ -0,0 +1,81 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testTopicCausalOrder(t *testing.T, delivery DeliveryAlgorithm) {
+	vcm, procs, _ := newCausalGroup(3, delivery)
+	p0, p1, p2 := procs[0], procs[1], procs[2]
+	vcm.Subscribe("news", 1)
+	vcm.Subscribe("news", 2)
+
+	if err := p0.Publish("news", "a"); err != nil {
+		t.Fatal(err)
+	}
+	held := <-p2.MessageCh // p2 로 가는 a 를 붙잡아 둠
+	if got := p1.DeliverCausal(p1.MessageCh); len(got) != 1 || got[0].Event != "a" || got[0].Topic != "news" {
+		t.Fatalf("p1 delivered %v, want a", got)
+	}
+	if err := p1.Publish("news", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if got := p2.DeliverCausal(p2.MessageCh); len(got) != 0 {
+		t.Fatalf("p2 delivered %v before its causal predecessor", got)
+	}
+	p2.MessageCh <- held
+	got := p2.DeliverCausal(p2.MessageCh)
+	if len(got) != 2 || got[0].Event != "a" || got[1].Event != "b" {
+		t.Fatalf("p2 delivered %v, want [a b]", got)
+	}
+}
+
+func TestBSSTopicDeliversInCausalOrder(t *testing.T) {
+	testTopicCausalOrder(t, DeliveryBSS)
+}
+
+func TestSESTopicDeliversInCausalOrder(t *testing.T) {
+	testTopicCausalOrder(t, DeliverySES)
+}
+
+func TestLateSubscriberDoesNotWaitForEarlierPublications(t *testing.T) {
+	vcm, procs, _ := newCausalGroup(3, DeliveryBSS)
+	p0, p1, p2 := procs[0], procs[1], procs[2]
+	vcm.Subscribe("news", 1)
+
+	if err := p0.Publish("news", "before"); err != nil {
+		t.Fatal(err)
+	}
+	p1.DeliverCausal(p1.MessageCh)
+	vcm.Subscribe("news", 2)
+	if err := p0.Publish("news", "after"); err != nil {
+		t.Fatal(err)
+	}
+	p1.DeliverCausal(p1.MessageCh)
+	if got := p2.DeliverCausal(p2.MessageCh); len(got) != 1 || got[0].Event != "after" {
+		t.Fatalf("late subscriber delivered %v, want [after]", got)
+	}
+}
+
+func TestTopicMembership(t *testing.T) {
+	vcm, procs, _ := newCausalGroup(3, DeliveryBSS)
+	vcm.Subscribe("b", 2)
+	vcm.Subscribe("a", 2)
+	vcm.Subscribe("a", 0)
+	vcm.Subscribe("a", 2)
+
+	if got := vcm.Topics(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("Topics() = %v", got)
+	}
+	if got := vcm.Subscribers("a"); !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Fatalf("Subscribers(a) = %v", got)
+	}
+	vcm.Unsubscribe("a", 0)
+	if got := vcm.Subscribers("a"); !reflect.DeepEqual(got, []int{2}) {
+		t.Fatalf("Subscribers(a) after Unsubscribe = %v", got)
+	}
+	if err := procs[0].Publish("missing", "x"); err == nil {
+		t.Fatal("Publish to unknown topic succeeded")
+	}
+}
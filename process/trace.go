@@ -0,0 +1,76 @@
+package process
+
+import "sync"
+
+// traceState 메시지 인과 체인 추적 상태
+type traceState struct {
+	mu      sync.Mutex
+	parent  string // 마지막으로 수신한 메시지 ID (다음 송신 메시지의 CausalParent)
+	traceID string // 현재 애플리케이션 trace ID
+}
+
+// SetTraceID 이후 보내는 메시지에 실을 애플리케이션 trace ID 지정
+func (p *Process) SetTraceID(traceID string) {
+	p.trace.mu.Lock()
+	defer p.trace.mu.Unlock()
+	p.trace.traceID = traceID
+}
+
+// TraceID 현재 애플리케이션 trace ID
+func (p *Process) TraceID() string {
+	p.trace.mu.Lock()
+	defer p.trace.mu.Unlock()
+	return p.trace.traceID
+}
+
+// LastReceived 마지막으로 수신한 메시지 ID (다음 송신 메시지의 CausalParent)
+func (p *Process) LastReceived() string {
+	p.trace.mu.Lock()
+	defer p.trace.mu.Unlock()
+	return p.trace.parent
+}
+
+// observe 수신한 메시지를 다음 송신의 인과 부모로 기록하고 수신 이벤트를 남김 (병합 이후 호출)
+func (p *Process) observe(msg Message) {
+	p.trace.mu.Lock()
+	p.trace.parent = msg.MessageID
+	if msg.TraceID != "" {
+		p.trace.traceID = msg.TraceID
+	}
+	p.trace.mu.Unlock()
+
+	p.recordReceive(msg)
+}
+
+// stampTrace 송신 메시지에 인과 부모와 trace ID 기록
+func (p *Process) stampTrace(msg *Message) {
+	p.trace.mu.Lock()
+	defer p.trace.mu.Unlock()
+
+	msg.CausalParent = p.trace.parent
+	msg.TraceID = p.trace.traceID
+}
+
+// CausalChain 메시지 목록에서 id 메시지부터 CausalParent 를 따라 거슬러 올라간 체인 (가장 오래된 것이 먼저)
+func CausalChain(msgs []Message, id string) []Message {
+	byID := make(map[string]Message, len(msgs))
+	for _, m := range msgs {
+		byID[m.MessageID] = m
+	}
+
+	var chain []Message
+	seen := make(map[string]bool)
+	for id != "" && !seen[id] {
+		m, ok := byID[id]
+		if !ok {
+			break
+		}
+		seen[id] = true
+		chain = append(chain, m)
+		id = m.CausalParent
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
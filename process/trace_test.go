@@ -0,0 +1,60 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTraceIDFollowsCausalParent(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	a := NewProcess(0, vcm)
+	b := NewProcess(1, vcm)
+	c := NewProcess(2, vcm)
+
+	a.SetTraceID("req-1")
+	if err := a.Send(1, "first"); err != nil {
+		t.Fatal(err)
+	}
+	first := <-b.MessageCh
+	if first.CausalParent != "" || first.TraceID != "req-1" {
+		t.Fatalf("first = %+v, want no parent and trace req-1", first)
+	}
+	if err := b.receive(first); err != nil {
+		t.Fatal(err)
+	}
+	if b.LastReceived() != first.MessageID || b.TraceID() != "req-1" {
+		t.Fatalf("b last received %q trace %q", b.LastReceived(), b.TraceID())
+	}
+
+	if err := b.Send(2, "second"); err != nil {
+		t.Fatal(err)
+	}
+	second := <-c.MessageCh
+	if second.CausalParent != first.MessageID || second.TraceID != "req-1" {
+		t.Fatalf("second = %+v, want parent %s and trace req-1", second, first.MessageID)
+	}
+}
+
+func TestCausalChainWalksParents(t *testing.T) {
+	msgs := []Message{
+		{MessageID: "c", CausalParent: "b"},
+		{MessageID: "a"},
+		{MessageID: "x", CausalParent: "a"},
+		{MessageID: "b", CausalParent: "a"},
+	}
+	var ids []string
+	for _, m := range CausalChain(msgs, "c") {
+		ids = append(ids, m.MessageID)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("chain = %v, want %v", ids, want)
+	}
+	if got := CausalChain(msgs, "missing"); len(got) != 0 {
+		t.Fatalf("chain of unknown id = %v, want empty", got)
+	}
+	// 순환하는 부모에서도 멈춰야 함
+	loop := []Message{{MessageID: "p", CausalParent: "q"}, {MessageID: "q", CausalParent: "p"}}
+	if got := CausalChain(loop, "p"); len(got) != 2 {
+		t.Fatalf("chain over a cycle = %v, want 2 messages", got)
+	}
+}
@@ -0,0 +1,69 @@
+package process
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// UUIDv7IDs UUIDv7(RFC 9562) 메시지 ID ("0190a1b2-c3d4-7e5f-8a6b-7c8d9e0f1a2b" 형식)
+//
+// 앞 48비트가 밀리초 단위 유닉스 시각이고 나머지는 무작위이므로, 따로 다시 시작한 여러 노드의 프로세스가
+// 조정 없이 만들어도 겹치지 않으며 문자열 순서가 대략 생성 시각 순서이다. 한 OS 프로세스 안에서는 같은
+// 밀리초에 만든 ID 도 증가한다. 시각은 가상 시간과 무관하게 벽시계를 쓴다.
+func UUIDv7IDs() IDGenerator {
+	return func(int) string {
+		return newUUIDv7()
+	}
+}
+
+// uuidClock 같은 밀리초에 만든 UUIDv7 의 순번 (RFC 9562 6.2 방법 1, rand_a 12비트를 카운터로 사용)
+var uuidClock struct {
+	mu   sync.Mutex
+	last int64  // 마지막으로 쓴 밀리초
+	seq  uint16 // 그 밀리초 안의 순번
+}
+
+// newUUIDv7 UUIDv7 문자열 생성
+func newUUIDv7() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic("process: uuidv7: " + err.Error()) // 난수 생성기 실패 (crypto/rand)
+	}
+
+	ms := time.Now().UnixMilli()
+	uuidClock.mu.Lock()
+	if ms <= uuidClock.last {
+		// 같은 밀리초(또는 벽시계가 뒤로 감): 순번을 올리고, 넘치면 다음 밀리초로
+		uuidClock.seq++
+		if uuidClock.seq > 0xFFF {
+			uuidClock.last++
+			uuidClock.seq = 0
+		}
+		ms = uuidClock.last
+	} else {
+		uuidClock.last, uuidClock.seq = ms, 0
+	}
+	seq := uuidClock.seq
+	uuidClock.mu.Unlock()
+
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> (40 - 8*i))
+	}
+	b[6] = 0x70 | byte(seq>>8) // 버전 7
+	b[7] = byte(seq)
+	b[8] = b[8]&0x3F | 0x80 // RFC 9562 변형
+
+	var s [36]byte
+	hex.Encode(s[0:8], b[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], b[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], b[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], b[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], b[10:])
+	return string(s[:])
+}
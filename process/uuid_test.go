@@ -0,0 +1,23 @@
+package process
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestUUIDv7IDs(t *testing.T) {
+	format := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	next := UUIDv7IDs()
+	prev := ""
+	for i := 0; i < 10000; i++ {
+		id := next(0)
+		if !format.MatchString(id) {
+			t.Fatalf("%q is not a UUIDv7", id)
+		}
+		// 같은 밀리초에 만든 ID 도 증가해야 함
+		if id <= prev {
+			t.Fatalf("%q after %q, want increasing IDs", id, prev)
+		}
+		prev = id
+	}
+}
@@ -0,0 +1,101 @@
+package process
+
+import "time"
+
+// WithVirtualTime 가상 시간으로 실행 (가상 시간 스케줄러를 쓰고, 할 일이 없으면 시간을 자동으로 진행)
+//
+// 예약 송신(SendAfter / SendAt), Sleep, Call / SendSync 의 제한 시간, 하트비트와 같은 스케줄러 작업이
+// 모두 가상 시각을 기준으로 동작하며, 메시지 Timestamp 도 VirtualEpoch 부터 흐르는 가상 시각을 쓴다.
+// 전송 중 메시지가 없고 모든 프로세스가 기다리는 중(수신, 응답, Sleep 등)이거나 쉬고 있는 액터이면
+// 실제로 기다리지 않고 다음 작업 시각으로 건너뛰므로, 긴 시나리오도 짧은 시간에 같은 순서로 끝난다.
+// 일반 프로세스(액터가 아닌)는 기다리는 동안에만 쉬는 것으로 보므로, 할 일을 마친 일반 프로세스가
+// 있으면 시간이 더 흐르지 않는다 (Advance / Run 으로 직접 진행할 수 있다).
+// 재시작 지연(Supervisor), 송신 속도 제한(WithRateLimit), 메일박스 제한 시간(WithSendTimeout)도 가상 시각으로 기다리며,
+// 이벤트 기록과 dead-letter 의 시각도 가상 시각이다.
+func WithVirtualTime() ManagerOption {
+	return func(vcm *VectorClockManager) {
+		vcm.scheduler = NewVirtualScheduler()
+		vcm.autoAdvance = true
+	}
+}
+
+// virtualScheduler 가상 시간 스케줄러 (지정하지 않았거나 실제 시간이면 nil)
+func (vcm *VectorClockManager) virtualScheduler() *Scheduler {
+	vcm.rlockManager()
+	defer vcm.Mu.RUnlock()
+
+	if vcm.scheduler == nil || !vcm.scheduler.Virtual() {
+		return nil
+	}
+	return vcm.scheduler
+}
+
+// timer d 뒤에 닫히는 채널 (가상 시간이면 가상 시각 기준)
+func (vcm *VectorClockManager) timer(d time.Duration) <-chan struct{} {
+	if s := vcm.virtualScheduler(); s != nil {
+		return s.Timer(d)
+	}
+	ch := make(chan struct{})
+	time.AfterFunc(d, func() { close(ch) })
+	return ch
+}
+
+// blockTimeout block 과 같지만 d 가 지나면 대기를 해제하고 waiter 의 timeout 을 닫음 (d 가 0 이하면 제한 없음)
+//
+// 시간 초과 시 대기 해제를 timeout 을 닫기 전에 하므로, 가상 시간을 진행한 직후의 정지 검사가
+// 깨어날 프로세스를 여전히 기다리는 중으로 보지 않는다.
+func (vcm *VectorClockManager) blockTimeout(processID int, kind WaitKind, on []int, d time.Duration) *waiter {
+	w := &waiter{kind: kind, on: on, abort: make(chan struct{})}
+	if d > 0 {
+		timeout := make(chan struct{})
+		w.timeout = timeout
+		fire := func() {
+			vcm.term.mu.Lock()
+			if vcm.term.waiting[processID] == w {
+				delete(vcm.term.waiting, processID)
+			}
+			vcm.term.mu.Unlock()
+			close(timeout)
+		}
+		if s := vcm.virtualScheduler(); s != nil {
+			if !s.schedule(s.Now()+d, fire) {
+				fire()
+			}
+		} else {
+			time.AfterFunc(d, fire)
+		}
+	}
+
+	t := &vcm.term
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.waiting == nil {
+		t.waiting = make(map[int]*waiter)
+	}
+	t.waiting[processID] = w
+	vcm.checkDeadlockLocked()
+	return w
+}
+
+// advanceVirtual 가상 시간을 다음 작업 시각으로 진행해 작업 하나를 실행하고 다시 정지 검사
+func (vcm *VectorClockManager) advanceVirtual() {
+	vcm.scheduler.runNextVirtual(-1)
+
+	vcm.term.mu.Lock()
+	defer vcm.term.mu.Unlock()
+	vcm.term.advancing = false
+	vcm.checkDeadlockLocked()
+}
+
+// Sleep d 동안 대기 (가상 시간이면 가상 시각 기준)
+//
+// 자는 동안은 대기 중으로 등록되어 가상 시간이 진행될 수 있으며, 교착 상태로 보지 않는다.
+func (p *Process) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	w := p.ClockMgr.blockTimeout(p.ID, WaitSleep, nil, d)
+	defer p.ClockMgr.unblock(p.ID)
+	<-w.timeout
+}
@@ -0,0 +1,154 @@
+package process
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSleepAdvancesVirtualTime(t *testing.T) {
+	vcm := NewVectorClockManager(1, WithLogger(nil), WithVirtualTime())
+	p := NewProcess(0, vcm)
+
+	done := make(chan struct{})
+	go func() {
+		p.Sleep(time.Hour)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sleep waited on real time")
+	}
+	if now := vcm.Scheduler().Now(); now < time.Hour {
+		t.Fatalf("virtual clock at %v after Sleep(1h)", now)
+	}
+}
+
+func TestCallTimesOutOnVirtualTime(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithVirtualTime())
+	client := NewProcess(0, vcm)
+	server := NewProcess(1, vcm, WithMailboxSize(4))
+	// 응답하지 않는 액터: 쉬는 동안 가상 시간이 제한 시간까지 흐름
+	if err := server.Start(func(Message) []Outgoing { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.call(1, "req", time.Minute)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrCallTimeout) {
+			t.Fatalf("call = %v, want ErrCallTimeout", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("call timeout waited on real time")
+	}
+	if now := vcm.Scheduler().Now(); now < time.Minute {
+		t.Fatalf("virtual clock at %v after a 1m timeout", now)
+	}
+}
+
+func TestSendAfterDeliversOnVirtualTime(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithVirtualTime())
+	sender := NewProcess(0, vcm)
+	receiver := NewProcess(1, vcm)
+	sender.SendAfter(time.Hour, 1, "later")
+	// 일반 프로세스는 기다리는 동안에만 쉬는 것으로 보므로 송신자도 잠들어 있어야 시간이 흐름
+	go sender.Sleep(2 * time.Hour)
+
+	done := make(chan error, 1)
+	go func() { done <- receiver.ReceiveMessages(receiver.MessageCh) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduled send waited on real time")
+	}
+	if now := vcm.Scheduler().Now(); now != time.Hour {
+		t.Fatalf("virtual clock at %v, want 1h", now)
+	}
+}
+
+func TestRateLimitUsesVirtualTime(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithVirtualTime())
+	sender := NewProcess(0, vcm, WithRateLimit(1, 1))
+	receiver := NewProcess(1, vcm, WithMailboxSize(8))
+	if err := receiver.Start(func(Message) []Outgoing { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	defer receiver.Stop()
+
+	done := make(chan error, 1)
+	go func() {
+		for i := 0; i < 3; i++ {
+			if err := sender.Send(1, "m"); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("rate-limited sends waited on real time")
+	}
+	// 토큰 하나로 시작해 초당 하나씩 채워지므로 세 번째 송신까지 가상 시각 2 초가 흘러야 함
+	if now := vcm.Scheduler().Now(); now < 2*time.Second {
+		t.Fatalf("virtual clock at %v after three sends, want at least 2s", now)
+	}
+	if got := sender.Throttled(); got < 2*time.Second-time.Millisecond {
+		t.Fatalf("Throttled() = %v, want about 2s", got)
+	}
+}
+
+func TestSendTimeoutUsesVirtualTime(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithVirtualTime())
+	sender := NewProcess(0, vcm, WithSendTimeout(time.Hour))
+	receiver := NewProcess(1, vcm, WithMailboxSize(1))
+	if err := sender.SendMessage(1, "fill", receiver.MessageCh, false); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- sender.SendMessage(1, "overflow", receiver.MessageCh, false) }()
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case err := <-done:
+			if !errors.Is(err, ErrMailboxFull) {
+				t.Fatalf("got %v, want ErrMailboxFull", err)
+			}
+			letters := sender.DeadLetters()
+			if len(letters) != 1 || letters[0].Time.Before(VirtualEpoch.Add(time.Hour)) {
+				t.Fatalf("dead letters = %+v, want one at or after %v", letters, VirtualEpoch.Add(time.Hour))
+			}
+			return
+		case <-deadline:
+			t.Fatal("send timeout waited on real time")
+		default:
+			// 메시지가 메일박스에 남아 있어 자동으로 진행되지 않으므로 직접 진행
+			vcm.Scheduler().Advance(time.Minute)
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+func TestEventTimeUsesVirtualTime(t *testing.T) {
+	vcm := NewVectorClockManager(1, WithLogger(nil), WithVirtualTime())
+	p := NewProcess(0, vcm)
+	vcm.Scheduler().Advance(3 * time.Second)
+	if e := p.LocalEvent("tick"); !e.Time.Equal(VirtualEpoch.Add(3 * time.Second)) {
+		t.Fatalf("event time = %v, want %v", e.Time, VirtualEpoch.Add(3*time.Second))
+	}
+}
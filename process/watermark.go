@@ -0,0 +1,154 @@
+package process
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Watermarks 프로세스별 전달 워터마크와 전역 최소 워터마크 (현재 에포크 기준)
+//
+// 프로세스 p 의 워터마크는 p 의 기본 시계이다. j 번째 값이 k 이면 p 는 프로세스 j 의 k 번째 이벤트까지
+// 전달받아 알고 있다. Global 의 j 번째 값이 k 이면 모든 프로세스가 그 이벤트까지 알고 있으므로,
+// 그보다 앞선 이벤트의 기록은 더 이상 인과 판단에 필요하지 않다.
+type Watermarks struct {
+	Epoch     int           // 워터마크가 속한 에포크
+	Processes map[int][]int // 프로세스 ID -> 전달 워터마크
+	Global    []int         // 원소별 최소값 (StableClock)
+}
+
+// Watermarks 프로세스별 전달 워터마크와 전역 최소 워터마크
+func (vcm *VectorClockManager) Watermarks() Watermarks {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+
+	w := Watermarks{Epoch: vcm.epoch, Processes: make(map[int][]int, len(vcm.Clock))}
+	for id, clock := range vcm.Clock {
+		w.Processes[id] = append([]int(nil), clock...)
+	}
+	w.Global = globalWatermark(w.Processes)
+	return w
+}
+
+// globalWatermark 워터마크의 원소별 최소값 (없으면 nil)
+func globalWatermark(clocks map[int][]int) []int {
+	var global []int
+	for _, clock := range clocks {
+		if global == nil {
+			global = append([]int(nil), clock...)
+			continue
+		}
+		for i := 0; i < len(global) && i < len(clock); i++ {
+			if clock[i] < global[i] {
+				global[i] = clock[i]
+			}
+		}
+	}
+	return global
+}
+
+// markEpochLocked 이벤트 기록에 에포크 경계 표시 (vcm.Mu 쓰기 잠금 보유 상태에서 에포크를 올린 직후 호출)
+//
+// 경계 이후에 기록되는 이벤트의 시계는 모두 새 에포크의 시계이다 (시계를 읽는 경로는 vcm.Mu 를 기다림).
+func (vcm *VectorClockManager) markEpochLocked() {
+	vcm.log.mu.Lock()
+	defer vcm.log.mu.Unlock()
+	vcm.log.boundaries = append(vcm.log.boundaries, vcm.log.seq)
+}
+
+// epochOfLocked 기록 순서 seq 인 이벤트의 에포크 (vcm.log.mu 보유 상태에서 호출)
+func (vcm *VectorClockManager) epochOfLocked(seq int64) int {
+	b := vcm.log.boundaries
+	return sort.Search(len(b), func(i int) bool { return b[i] >= seq })
+}
+
+// Compact 전역 워터마크 아래의 이벤트를 이벤트 기록에서 지우고 지운 이벤트 수 반환
+//
+// 이벤트가 일어난 프로세스의 시계 항목이 전역 워터마크 이하이면 모든 프로세스가 이미 알고 있으므로 지운다.
+// 체크포인트 이전 에포크의 이벤트는 현재 에포크로 변환해 판단하고, 재설정(ResetEpoch) 이전 에포크의 이벤트는
+// 더 이상 병합될 수 없으므로 모두 지운다. 도메인 시계 이벤트와 ClockPerChannel 모드의 이벤트는 남긴다.
+// 중복 제거 창(WithDedupe)에서 Stable 을 켠 프로세스는 전역 워터마크 아래의 메시지 ID 도 잊는다.
+// 오래 실행되는 시스템에서 기록의 메모리를 묶어 두려면 주기적으로 호출한다 (CompactEvery).
+// 지운 이벤트는 Events 에 나오지 않으므로, 전체 이력이 필요하면 먼저 내보낸다.
+func (vcm *VectorClockManager) Compact() int {
+	vcm.lockManager()
+	defer vcm.Mu.Unlock()
+	if vcm.Mode != ClockPerProcess {
+		return 0
+	}
+	global := globalWatermark(vcm.Clock)
+
+	vcm.log.mu.Lock()
+	defer vcm.log.mu.Unlock()
+	kept := vcm.log.events[:0]
+	for _, e := range vcm.log.events {
+		if !vcm.belowWatermarkLocked(e, global) {
+			kept = append(kept, e)
+		}
+	}
+	removed := len(vcm.log.events) - len(kept)
+	clear(vcm.log.events[len(kept):]) // 지운 이벤트의 시계를 붙잡지 않음
+	vcm.log.events = kept
+
+	ids := 0
+	for _, p := range vcm.Processes() {
+		ids += vcm.compactDedupeLocked(p, global)
+	}
+	if removed > 0 || ids > 0 {
+		vcm.logf("Compacted %d events and %d dedupe IDs below watermark %v\n", removed, ids, global)
+	}
+	return removed
+}
+
+// belowWatermarkLocked 이벤트가 전역 워터마크 아래인지 여부 (vcm.Mu 와 vcm.log.mu 보유 상태에서 호출)
+func (vcm *VectorClockManager) belowWatermarkLocked(e Event, global []int) bool {
+	if e.Domain != "" || e.Process < 0 || e.Process >= len(global) || e.Process >= len(e.Clock) {
+		return false
+	}
+	return vcm.stableEntryLocked(global, e.Process, e.Clock[e.Process], vcm.epochOfLocked(e.Seq))
+}
+
+// stableEntryLocked 에포크 epoch 에서 프로세스 process 의 value 번째 이벤트가 전역 워터마크 이하인지 여부 (vcm.Mu 보유 상태에서 호출)
+//
+// 체크포인트로 나뉜 에포크는 현재 에포크로 변환해 비교하고, 재설정 이전 에포크의 이벤트는 안정된 것으로 본다.
+func (vcm *VectorClockManager) stableEntryLocked(global []int, process, value, epoch int) bool {
+	if process < 0 || process >= len(global) || epoch > vcm.epoch {
+		return false
+	}
+	if epoch != vcm.epoch {
+		if !vcm.comparableLocked(epoch, vcm.epoch) {
+			return true
+		}
+		from, to := vcm.epochBaseLocked(epoch), vcm.epochBaseLocked(vcm.epoch)
+		if process < len(from) && process < len(to) {
+			value += from[process] - to[process]
+		}
+	}
+	return value <= global[process]
+}
+
+// CompactEvery d 간격으로 Compact 실행 (반환된 함수로 중지)
+func (vcm *VectorClockManager) CompactEvery(d time.Duration) (stop func()) {
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				vcm.Compact()
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-finished
+		})
+	}
+}
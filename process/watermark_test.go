@@ -0,0 +1,98 @@
+package process
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// pingPong p0 -> p1, p1 -> p0 를 주고받은 두 프로세스 (p0 [2 2], p1 [1 2])
+func pingPong(t *testing.T, opts ...ManagerOption) (*VectorClockManager, *Process, *Process) {
+	t.Helper()
+	vcm := NewVectorClockManager(2, append([]ManagerOption{WithLogger(nil)}, opts...)...)
+	a := NewProcess(0, vcm, WithMailboxSize(4))
+	b := NewProcess(1, vcm, WithMailboxSize(4))
+	if err := a.Send(1, "ping"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Send(0, "pong"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ReceiveMessages(a.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	return vcm, a, b
+}
+
+func TestWatermarksReportGlobalMinimum(t *testing.T) {
+	vcm, _, _ := pingPong(t)
+	w := vcm.Watermarks()
+	if w.Epoch != 0 || !reflect.DeepEqual(w.Processes[0], []int{2, 2}) || !reflect.DeepEqual(w.Processes[1], []int{1, 2}) {
+		t.Fatalf("Watermarks() = %+v", w)
+	}
+	if !reflect.DeepEqual(w.Global, []int{1, 2}) || !reflect.DeepEqual(vcm.StableClock(), w.Global) {
+		t.Fatalf("global watermark = %v, stable clock %v, want [1 2]", w.Global, vcm.StableClock())
+	}
+}
+
+func TestCompactDropsEventsKnownEverywhere(t *testing.T) {
+	vcm, _, _ := pingPong(t)
+	if n := vcm.Compact(); n != 3 {
+		t.Fatalf("Compact() = %d, want 3", n)
+	}
+	events := vcm.Events()
+	if len(events) != 1 || events[0].Process != 0 || events[0].Kind != EventReceive {
+		t.Fatalf("kept %+v, want only the receive p1 does not know about", events)
+	}
+	if n := vcm.Compact(); n != 0 {
+		t.Fatalf("second Compact() = %d, want 0", n)
+	}
+}
+
+func TestCompactTranslatesCheckpointedEpochs(t *testing.T) {
+	vcm, a, _ := pingPong(t)
+	vcm.Checkpoint()
+	a.LocalEvent("after")
+	if n := vcm.Compact(); n != 3 {
+		t.Fatalf("Compact() after checkpoint = %d, want 3", n)
+	}
+	if events := vcm.Events(); len(events) != 2 || events[1].Name != "after" {
+		t.Fatalf("kept %+v, want the unknown receive and the new local event", events)
+	}
+}
+
+func TestCompactDropsEventsBeforeReset(t *testing.T) {
+	vcm, a, _ := pingPong(t)
+	vcm.ResetEpoch()
+	a.LocalEvent("after")
+	if n := vcm.Compact(); n != 4 {
+		t.Fatalf("Compact() after reset = %d, want all 4 earlier events", n)
+	}
+	if events := vcm.Events(); len(events) != 1 || events[0].Name != "after" {
+		t.Fatalf("kept %+v, want only the event after the reset", events)
+	}
+}
+
+func TestCompactKeepsChannelModeEvents(t *testing.T) {
+	vcm, _, _ := pingPong(t, WithClockMode(ClockPerChannel))
+	if n := vcm.Compact(); n != 0 {
+		t.Fatalf("Compact() in per-channel mode = %d, want 0", n)
+	}
+}
+
+func TestCompactEvery(t *testing.T) {
+	vcm, _, _ := pingPong(t)
+	stop := vcm.CompactEvery(time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for len(vcm.Events()) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("events were not compacted: %d left", len(vcm.Events()))
+		}
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+	stop() // 두 번 불러도 됨
+}
@@ -0,0 +1,142 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrWindowFull 흐름 제어 윈도우가 제한 시간 안에 비지 않은 경우 (WithSendTimeout)
+	ErrWindowFull = errors.New("process: flow control window full")
+	// ErrRemoteWindow 확인 응답을 받을 수 없는 다른 노드의 프로세스로 가는 링크에 윈도우를 지정한 경우
+	ErrRemoteWindow = errors.New("process: flow control window requires a local receiver")
+)
+
+// flowControl 링크별 슬라이딩 윈도우 흐름 제어 (송신자 측)
+type flowControl struct {
+	mu       sync.Mutex
+	changed  chan struct{} // 확인 응답이 오면 닫고 새로 만듦
+	window   int           // 기본 윈도우 크기 (0 이면 제한 없음)
+	links    map[int]int   // 링크별 윈도우 크기 (받는 프로세스 ID -> 크기)
+	inflight map[int]int   // 링크별 확인 응답을 받지 못한 메시지 수
+}
+
+// WithWindow 모든 링크에 대해 확인 응답 없이 보낼 수 있는 최대 메시지 수 지정
+//
+// 받는 쪽이 메일박스에서 메시지를 꺼내 처리하면 확인 응답으로 간주한다.
+// 윈도우가 가득 차면 송신은 확인 응답이 올 때까지 대기한다 (WithSendTimeout 이 있으면 그 시간까지, 넘으면 ErrWindowFull).
+// 확인 응답은 같은 매니저에 등록된 받는 쪽만 보낼 수 있으므로, Transport 로 보내는 다른 노드의 프로세스에는
+// 윈도우를 적용하지 않는다 (WithLinkWindow 로 지정하면 송신이 ErrRemoteWindow 로 실패).
+func WithWindow(w int) ProcessOption {
+	return func(p *Process) {
+		p.flowControl().window = w
+	}
+}
+
+// WithLinkWindow 특정 받는 프로세스로의 링크에 대한 윈도우 크기 지정 (WithWindow 보다 우선)
+func WithLinkWindow(to, w int) ProcessOption {
+	return func(p *Process) {
+		p.flowControl().links[to] = w
+	}
+}
+
+// flowControl 흐름 제어 상태 반환 (없으면 생성)
+func (p *Process) flowControl() *flowControl {
+	if p.flow == nil {
+		p.flow = &flowControl{links: make(map[int]int), inflight: make(map[int]int), changed: make(chan struct{})}
+	}
+	return p.flow
+}
+
+// limit 링크의 윈도우 크기 (fc.mu 보유 상태에서 호출)
+func (fc *flowControl) limit(to int) int {
+	if w, ok := fc.links[to]; ok {
+		return w
+	}
+	return fc.window
+}
+
+// tryAcquire 윈도우에 자리가 있으면 확보 (윈도우가 없으면 limited 가 false, 가득 찼으면 다음 확인 응답 알림 채널)
+func (fc *flowControl) tryAcquire(to int) (acquired, limited bool, changed <-chan struct{}) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	w := fc.limit(to)
+	if w <= 0 {
+		return false, false, nil
+	}
+	if fc.inflight[to] < w {
+		fc.inflight[to]++
+		return true, true, nil
+	}
+	return false, true, fc.changed
+}
+
+// acquireWindow 받는 프로세스 to 로의 윈도우에 자리가 날 때까지 대기 후 자리 확보 (윈도우가 없으면 false)
+//
+// 기다리는 동안은 WaitWindow 대기 상태로 등록되어 교착 상태 감지에 포함되며, WithSendTimeout 이 지나면 ErrWindowFull 을 반환한다.
+func (p *Process) acquireWindow(to int) (bool, error) {
+	fc := p.flow
+	if _, local := p.ClockMgr.Lookup(to); !local {
+		fc.mu.Lock()
+		_, explicit := fc.links[to]
+		fc.mu.Unlock()
+		if explicit {
+			return false, fmt.Errorf("%w: process %d", ErrRemoteWindow, to)
+		}
+		return false, nil
+	}
+
+	var w *waiter
+	for {
+		acquired, limited, changed := fc.tryAcquire(to)
+		if acquired || !limited {
+			return acquired, nil
+		}
+		if w == nil {
+			w = p.ClockMgr.blockTimeout(p.ID, WaitWindow, []int{to}, p.sendTimeout)
+			defer p.ClockMgr.unblock(p.ID)
+		}
+		select {
+		case <-changed:
+		case <-w.abort:
+			return false, w.err
+		case <-w.timeout:
+			return false, fmt.Errorf("%w: link to process %d after %v", ErrWindowFull, to, p.sendTimeout)
+		}
+	}
+}
+
+// release 확인 응답 처리
+func (fc *flowControl) release(to int) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	if fc.inflight[to] > 0 {
+		fc.inflight[to]--
+	}
+	close(fc.changed)
+	fc.changed = make(chan struct{})
+}
+
+// Unacked 받는 프로세스 to 로 보낸 뒤 아직 확인 응답을 받지 못한 메시지 수
+func (p *Process) Unacked(to int) int {
+	if p.flow == nil {
+		return 0
+	}
+	p.flow.mu.Lock()
+	defer p.flow.mu.Unlock()
+	return p.flow.inflight[to]
+}
+
+// ack 메일박스에서 꺼낸 메시지에 대해 송신자에게 확인 응답 (전송 중 메시지 수 감소)
+func (p *Process) ack(msg Message) {
+	p.ClockMgr.leave(msg)
+	if !msg.windowed {
+		return
+	}
+	if sender, ok := p.ClockMgr.Lookup(msg.From); ok && sender.flow != nil {
+		sender.flow.release(p.ID)
+	}
+}
@@ -0,0 +1,126 @@
+package process
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLinkWindowOverridesDefault(t *testing.T) {
+	vcm := NewVectorClockManager(3)
+	sender := NewProcess(0, vcm, WithWindow(1), WithLinkWindow(2, 0))
+	NewProcess(1, vcm)
+	NewProcess(2, vcm)
+	inbox := make(chan Message, 4)
+
+	// 2 로 가는 링크는 제한이 없으므로 확인 응답 없이 계속 보낼 수 있음
+	for i := 0; i < 3; i++ {
+		sender.SendMessage(2, "m", inbox, false)
+	}
+	if got := sender.Unacked(2); got != 0 {
+		t.Fatalf("Unacked(2) = %d, want 0 on an unlimited link", got)
+	}
+	sender.SendMessage(1, "m", inbox, false)
+	if got := sender.Unacked(1); got != 1 {
+		t.Fatalf("Unacked(1) = %d, want 1", got)
+	}
+}
+
+// chanTransport 다른 노드로 가는 메시지를 채널에 모으는 Transport
+type chanTransport struct {
+	ch chan Message
+}
+
+func (t chanTransport) Mailbox(int) (chan<- Message, error) {
+	return t.ch, nil
+}
+
+func TestWindowBlocksUntilAck(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	sender := NewProcess(0, vcm, WithWindow(2))
+	receiver := NewProcess(1, vcm, WithMailboxSize(8))
+
+	for i := 0; i < 2; i++ {
+		if err := sender.Send(1, "m"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sent := make(chan error, 1)
+	go func() { sent <- sender.Send(1, "third") }()
+	select {
+	case err := <-sent:
+		t.Fatalf("third send did not wait for the window: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if err := receiver.ReceiveMessages(receiver.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-sent:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("ack did not free a window slot")
+	}
+	if got := sender.Unacked(1); got != 2 {
+		t.Fatalf("Unacked = %d, want 2", got)
+	}
+}
+
+func TestWindowTimesOut(t *testing.T) {
+	vcm := NewVectorClockManager(2, WithLogger(nil))
+	sender := NewProcess(0, vcm, WithWindow(1), WithSendTimeout(20*time.Millisecond))
+	NewProcess(1, vcm, WithMailboxSize(8))
+
+	if err := sender.Send(1, "first"); err != nil {
+		t.Fatal(err)
+	}
+	err := sender.Send(1, "second")
+	if !errors.Is(err, ErrWindowFull) {
+		t.Fatalf("got %v, want ErrWindowFull", err)
+	}
+	letters := sender.DeadLetters()
+	if len(letters) != 1 || letters[0].Reason != DeadLetterWindowFull {
+		t.Fatalf("dead letters = %+v, want one window-full", letters)
+	}
+}
+
+func TestWindowSkipsRemotePeers(t *testing.T) {
+	remote := chanTransport{ch: make(chan Message, 8)}
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithTransport(remote))
+	sender := NewProcess(0, vcm, WithWindow(1))
+
+	// 다른 노드의 프로세스 1 은 확인 응답을 보낼 수 없으므로 윈도우를 넘어도 막히지 않아야 함
+	done := make(chan error, 1)
+	go func() {
+		for i := 0; i < 5; i++ {
+			if err := sender.Send(1, "m"); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("send to a remote peer blocked on the window")
+	}
+	if got := sender.Unacked(1); got != 0 {
+		t.Fatalf("Unacked = %d for a remote peer, want 0", got)
+	}
+}
+
+func TestLinkWindowRejectsRemotePeer(t *testing.T) {
+	remote := chanTransport{ch: make(chan Message, 8)}
+	vcm := NewVectorClockManager(2, WithLogger(nil), WithTransport(remote))
+	sender := NewProcess(0, vcm, WithLinkWindow(1, 4))
+
+	if err := sender.Send(1, "m"); !errors.Is(err, ErrRemoteWindow) {
+		t.Fatalf("got %v, want ErrRemoteWindow", err)
+	}
+}
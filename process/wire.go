@@ -0,0 +1,125 @@
+package process
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// WireVersion 현재 메시지 직렬화 형식 버전
+//
+// 형식이 바뀌면 버전을 올리고, 바로 전 버전을 새 형식으로 옮기는 함수를 wireMigrations 에 더한다.
+const WireVersion = 1
+
+// ErrUnsupportedVersion 이 빌드가 모르는 (더 새로운) 형식 버전의 메시지
+var ErrUnsupportedVersion = errors.New("process: unsupported message version")
+
+// WireMessage 노드 사이 전송과 기록용 Message 직렬화 형식 (gob, JSON)
+//
+// Version 이 0 이면 버전 필드가 생기기 전에 Message 를 그대로 직렬화한 기록이다. gob 은 필드 이름으로,
+// JSON 은 대소문자를 가리지 않는 필드 이름으로 값을 맞추므로(JSON 이름은 Go 필드 이름의 camelCase)
+// 그런 기록도 WireMessage 로 읽히며, Decode 가 현재 형식으로 옮긴다.
+// 채널(ReplyCh)처럼 직렬화할 수 없는 필드는 포함하지 않는다.
+type WireMessage struct {
+	Version int `json:"v"`
+
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Vector    []int  `json:"vector"`
+	Event     string `json:"event"`
+	MessageID string `json:"messageId"`
+	Timestamp int64  `json:"timestamp"`
+	Epoch     int    `json:"epoch,omitempty"`
+	Domain    string `json:"domain,omitempty"`
+	Topic     string `json:"topic,omitempty"`
+
+	Kind    MessageKind `json:"kind,omitempty"`
+	ReplyTo string      `json:"replyTo,omitempty"`
+
+	CausalParent string `json:"causalParent,omitempty"`
+	TraceID      string `json:"traceId,omitempty"`
+
+	Signature []byte `json:"signature,omitempty"`
+
+	Causal     []int         `json:"causal,omitempty"`
+	DestClocks map[int][]int `json:"destClocks,omitempty"`
+
+	buf *vectorBuf // Vector 를 빌려 온 풀 버퍼 (AcquireWire, 직렬화하지 않음)
+}
+
+// wireMigrations 버전 v 형식을 v+1 형식으로 옮기는 함수 (인덱스 = v)
+var wireMigrations = []func(*WireMessage) error{
+	0: func(w *WireMessage) error { return nil }, // 필드 구성은 같고 버전만 붙음
+}
+
+// Wire 메시지의 현재 버전 직렬화 형식
+func (msg Message) Wire() WireMessage {
+	return WireMessage{
+		Version:      WireVersion,
+		From:         msg.From,
+		To:           msg.To,
+		Vector:       msg.Vector,
+		Event:        msg.Event,
+		MessageID:    msg.MessageID,
+		Timestamp:    msg.Timestamp,
+		Epoch:        msg.Epoch,
+		Domain:       msg.Domain,
+		Topic:        msg.Topic,
+		Kind:         msg.Kind,
+		ReplyTo:      msg.ReplyTo,
+		CausalParent: msg.CausalParent,
+		TraceID:      msg.TraceID,
+		Signature:    msg.Signature,
+		Causal:       msg.Causal,
+		DestClocks:   msg.DestClocks,
+	}
+}
+
+// Decode 직렬화 형식을 현재 버전으로 옮긴 뒤 Message 로 변환
+//
+// 더 새로운 버전이면 ErrUnsupportedVersion 을 반환한다.
+func (w WireMessage) Decode() (Message, error) {
+	if w.Version < 0 || w.Version > WireVersion {
+		return Message{}, fmt.Errorf("%w: %d (this build reads up to %d), message %s",
+			ErrUnsupportedVersion, w.Version, WireVersion, w.MessageID)
+	}
+	for v := w.Version; v < WireVersion; v++ {
+		if err := wireMigrations[v](&w); err != nil {
+			return Message{}, fmt.Errorf("process: migrate message %s from version %d: %w", w.MessageID, v, err)
+		}
+		w.Version = v + 1
+	}
+	return Message{
+		From:         w.From,
+		To:           w.To,
+		Vector:       w.Vector,
+		Event:        w.Event,
+		MessageID:    w.MessageID,
+		Timestamp:    w.Timestamp,
+		Epoch:        w.Epoch,
+		Domain:       w.Domain,
+		Topic:        w.Topic,
+		Kind:         w.Kind,
+		ReplyTo:      w.ReplyTo,
+		CausalParent: w.CausalParent,
+		TraceID:      w.TraceID,
+		Signature:    w.Signature,
+		Causal:       w.Causal,
+		DestClocks:   w.DestClocks,
+		buf:          w.buf,
+	}, nil
+}
+
+// MarshalMessage 메시지를 현재 버전 JSON 으로 직렬화
+func MarshalMessage(msg Message) ([]byte, error) {
+	return json.Marshal(msg.Wire())
+}
+
+// UnmarshalMessage JSON 메시지를 읽어 현재 버전으로 옮김 (버전 필드가 없는 이전 기록 포함)
+func UnmarshalMessage(data []byte) (Message, error) {
+	var w WireMessage
+	if err := json.Unmarshal(data, &w); err != nil {
+		return Message{}, err
+	}
+	return w.Decode()
+}
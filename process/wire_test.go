@@ -0,0 +1,76 @@
+package process
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalMessageRoundTrip(t *testing.T) {
+	msg := Message{
+		From: 1, To: 2, Vector: []int{0, 3, 1}, Event: "m", MessageID: "1-3", Timestamp: 42,
+		Epoch: 2, Topic: "t", Kind: KindRequest, TraceID: "trace", Signature: []byte{1, 2},
+		DestClocks: map[int][]int{0: {0, 3, 0}},
+	}
+	data, err := MarshalMessage(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"v":1`)) {
+		t.Fatalf("encoded %s without the wire version", data)
+	}
+	got, err := UnmarshalMessage(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, msg) {
+		t.Fatalf("round trip = %+v, want %+v", got, msg)
+	}
+}
+
+func TestUnmarshalMessageMigratesUnversioned(t *testing.T) {
+	// 버전 필드가 생기기 전에 Message 를 그대로 JSON 으로 쓴 기록
+	old := []byte(`{"From":0,"To":1,"Vector":[1,0],"Event":"old","MessageID":"0-1","Timestamp":7}`)
+	got, err := UnmarshalMessage(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Message{From: 0, To: 1, Vector: []int{1, 0}, Event: "old", MessageID: "0-1", Timestamp: 7}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("migrated = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeRejectsNewerVersion(t *testing.T) {
+	w := Message{MessageID: "0-1"}.Wire()
+	w.Version = WireVersion + 1
+	if _, err := w.Decode(); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("Decode of version %d = %v, want ErrUnsupportedVersion", w.Version, err)
+	}
+}
+
+func TestWireMessageReadsUnversionedGob(t *testing.T) {
+	var buf bytes.Buffer
+	type legacy struct { // 버전 필드가 없던 노드가 보내던 형식
+		From, To  int
+		Vector    []int
+		Event     string
+		MessageID string
+	}
+	if err := gob.NewEncoder(&buf).Encode(legacy{From: 3, To: 0, Vector: []int{0, 0, 0, 1}, Event: "g", MessageID: "3-1"}); err != nil {
+		t.Fatal(err)
+	}
+	var w WireMessage
+	if err := gob.NewDecoder(&buf).Decode(&w); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := w.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.From != 3 || msg.Event != "g" || !reflect.DeepEqual(msg.Vector, []int{0, 0, 0, 1}) {
+		t.Fatalf("decoded %+v", msg)
+	}
+}
@@ -0,0 +1,225 @@
+// Package scenarios 분산 시스템에서 자주 보는 통신 패턴을 매개변수로 만드는 표준 시나리오 모음
+//
+//	res, err := scenarios.RequestReply(4, 2).Seed(1).Run()
+//
+// 각 함수는 sim.Scenario 를 반환하므로 실행 전에 단계를 더하거나 네모시스를 붙일 수 있다.
+// CLI 에서는 이름으로 고른다 (vectorclock simulate -scenario broadcast-storm -n 5 -rounds 3).
+package scenarios
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+	"github.com/seoyhaein/vectorclock/sim"
+)
+
+var (
+	// ErrUnknownScenario 등록되지 않은 시나리오 이름
+	ErrUnknownScenario = errors.New("scenarios: unknown scenario")
+	// ErrInvalidParams 시나리오가 받아들이지 않는 프로세스 수 / 반복 횟수
+	ErrInvalidParams = errors.New("scenarios: invalid parameters")
+)
+
+// Entry 이름으로 고를 수 있는 시나리오
+type Entry struct {
+	Name    string // CLI 에서 쓰는 이름 (예: request-reply)
+	Summary string // 한 줄 설명
+	MinN    int    // 최소 프로세스 수
+	N       int    // 기본 프로세스 수
+	Rounds  int    // 기본 반복 횟수
+	Build   func(n, rounds int, opts ...vc.ManagerOption) *sim.Scenario
+}
+
+// registry 등록된 시나리오 (이름 -> 항목)
+var registry = map[string]Entry{}
+
+// register 시나리오 등록
+func register(e Entry) {
+	registry[e.Name] = e
+}
+
+func init() {
+	register(Entry{
+		Name:    "request-reply",
+		Summary: "P0 서버에게 나머지 클라이언트가 요청을 보내고 응답을 받음",
+		MinN:    2,
+		N:       3,
+		Rounds:  1,
+		Build:   RequestReply,
+	})
+	register(Entry{
+		Name:    "broadcast-storm",
+		Summary: "라운드마다 모든 프로세스가 다른 모든 프로세스에게 동시에 브로드캐스트",
+		MinN:    2,
+		N:       4,
+		Rounds:  2,
+		Build:   BroadcastStorm,
+	})
+	register(Entry{
+		Name:    "partition-and-heal",
+		Summary: "네트워크가 둘로 나뉜 동안 양쪽이 따로 진행하고, 복구 후 서로의 이력을 합침",
+		MinN:    2,
+		N:       4,
+		Rounds:  1,
+		Build:   PartitionAndHeal,
+	})
+	register(Entry{
+		Name:    "concurrent-writers",
+		Summary: "모든 프로세스가 서로 모르게 같은 값을 쓴 뒤 쓰기를 복제 (동시 이벤트)",
+		MinN:    2,
+		N:       3,
+		Rounds:  2,
+		Build:   ConcurrentWriters,
+	})
+}
+
+// Names 등록된 시나리오 이름 (오름차순)
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup 이름으로 시나리오 항목 찾기
+func Lookup(name string) (Entry, bool) {
+	e, ok := registry[name]
+	return e, ok
+}
+
+// Build 이름으로 시나리오 생성 (n, rounds 가 0 이면 시나리오의 기본값)
+func Build(name string, n, rounds int, opts ...vc.ManagerOption) (*sim.Scenario, error) {
+	e, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScenario, name)
+	}
+	if n == 0 {
+		n = e.N
+	}
+	if rounds == 0 {
+		rounds = e.Rounds
+	}
+	if n < e.MinN || rounds < 1 {
+		return nil, fmt.Errorf("%w: %s needs at least %d processes and 1 round (got n=%d, rounds=%d)",
+			ErrInvalidParams, name, e.MinN, n, rounds)
+	}
+	return e.Build(n, rounds, opts...), nil
+}
+
+// RequestReply P0 가 서버, P1..P(n-1) 이 클라이언트인 요청-응답 시나리오 (클라이언트마다 rounds 번)
+//
+// 라운드마다 모든 클라이언트가 먼저 요청을 보내고, 서버는 요청을 받는 대로 처리해 보낸 클라이언트에게
+// 응답한다. 응답은 요청 뒤에 일어나므로 클라이언트의 다음 요청은 서버가 그때까지 처리한 모든 요청 뒤에 온다.
+func RequestReply(n, rounds int, opts ...vc.ManagerOption) *sim.Scenario {
+	s := sim.New(n, opts...)
+	for r := 0; r < rounds; r++ {
+		requests := make([]int, n)
+		for c := 1; c < n; c++ {
+			requests[c] = len(s.Steps)
+			s.Send(c, 0, fmt.Sprintf("request %d.%d", c, r))
+		}
+		for c := 1; c < n; c++ {
+			s.RecvOf(0, requests[c])
+			s.Local(0, fmt.Sprintf("handle %d.%d", c, r))
+			s.Send(0, c, fmt.Sprintf("reply %d.%d", c, r))
+			s.Recv(c)
+		}
+	}
+	return s
+}
+
+// BroadcastStorm 라운드마다 n 개 프로세스가 모두 서로에게 보내는 시나리오
+//
+// 한 라운드의 송신은 모두 수신보다 앞서므로 같은 라운드의 송신끼리는 동시(concurrent)이고,
+// 라운드가 끝나면 모든 프로세스의 시계가 다른 모든 프로세스의 그 라운드 송신을 포함한다.
+func BroadcastStorm(n, rounds int, opts ...vc.ManagerOption) *sim.Scenario {
+	s := sim.New(n, opts...)
+	for r := 0; r < rounds; r++ {
+		for from := 0; from < n; from++ {
+			for to := 0; to < n; to++ {
+				if to != from {
+					s.Send(from, to, fmt.Sprintf("broadcast %d.%d", from, r))
+				}
+			}
+		}
+		for id := 0; id < n; id++ {
+			for k := 0; k < n-1; k++ {
+				s.Recv(id)
+			}
+		}
+	}
+	return s
+}
+
+// PartitionAndHeal 네트워크를 앞쪽 절반과 뒤쪽 절반으로 나누었다가 복구하는 시나리오
+//
+// 분할된 동안 rounds 번 각 그룹 안에서 이웃에게 보내고, 다른 그룹으로 보낸 메시지는 유실된다.
+// 복구 후에는 각 그룹의 첫 프로세스가 상대 그룹 전체에게 보내 양쪽 이력이 합쳐진다.
+func PartitionAndHeal(n, rounds int, opts ...vc.ManagerOption) *sim.Scenario {
+	half := (n + 1) / 2
+	var left, right []int
+	for id := 0; id < n; id++ {
+		if id < half {
+			left = append(left, id)
+		} else {
+			right = append(right, id)
+		}
+	}
+
+	s := sim.New(n, opts...)
+	s.Partition(left, right)
+	for r := 0; r < rounds; r++ {
+		for _, group := range [][]int{left, right} {
+			if len(group) < 2 {
+				s.Local(group[0], fmt.Sprintf("isolated %d", r))
+				continue
+			}
+			for i, from := range group {
+				to := group[(i+1)%len(group)]
+				s.Send(from, to, fmt.Sprintf("inside %d.%d", from, r))
+				s.Recv(to)
+			}
+		}
+		// 분할을 넘는 메시지 (유실)
+		s.Send(left[0], right[0], fmt.Sprintf("across %d", r))
+	}
+	s.Heal()
+	for _, pair := range [][2][]int{{left, right}, {right, left}} {
+		from := pair[0][0]
+		for _, to := range pair[1] {
+			s.Send(from, to, fmt.Sprintf("heal %d", from))
+			s.Recv(to)
+		}
+	}
+	return s
+}
+
+// ConcurrentWriters n 개 프로세스가 서로 모르게 rounds 번씩 같은 값을 쓴 뒤 마지막 쓰기를 모두에게 복제하는 시나리오
+//
+// 복제 전의 쓰기는 모두 서로 동시이므로 충돌 감지(Compare 가 Concurrent)의 기본 예가 된다.
+// 복제가 끝나면 모든 프로세스의 시계가 모든 쓰기를 포함한다.
+func ConcurrentWriters(n, rounds int, opts ...vc.ManagerOption) *sim.Scenario {
+	s := sim.New(n, opts...)
+	for r := 0; r < rounds; r++ {
+		for id := 0; id < n; id++ {
+			s.Local(id, fmt.Sprintf("write x=%d.%d", id, r))
+		}
+	}
+	for from := 0; from < n; from++ {
+		for to := 0; to < n; to++ {
+			if to != from {
+				s.Send(from, to, fmt.Sprintf("replicate x=%d.%d", from, rounds-1))
+			}
+		}
+	}
+	for id := 0; id < n; id++ {
+		for k := 0; k < n-1; k++ {
+			s.Recv(id)
+		}
+	}
+	return s
+}
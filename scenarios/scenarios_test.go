@@ -0,0 +1,152 @@
+package scenarios
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+	"github.com/seoyhaein/vectorclock/sim"
+)
+
+// run 시나리오를 조용히 실행
+func run(t *testing.T, name string, n, rounds int) *sim.Result {
+	t.Helper()
+	s, err := Build(name, n, rounds, vc.WithLogger(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Seed(1).Run()
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return res
+}
+
+// event 프로세스 id 에서 일어난 kind 종류의 name 이벤트
+func event(t *testing.T, res *sim.Result, kind vc.EventKind, id int, name string) vc.Event {
+	t.Helper()
+	for _, e := range res.Events {
+		if e.Kind == kind && e.Process == id && e.Name == name {
+			return e
+		}
+	}
+	t.Fatalf("no %s event %q at P%d", kind, name, id)
+	return vc.Event{}
+}
+
+// dominates 최종 시계가 이벤트 시계를 포함하는지
+func dominates(clock, event []int) bool {
+	o := vc.Compare(event, clock)
+	return o == vc.Before || o == vc.Equal
+}
+
+func TestNamesAreSortedAndBuildable(t *testing.T) {
+	names := Names()
+	if len(names) != 4 || !sort.StringsAreSorted(names) {
+		t.Fatalf("Names() = %v", names)
+	}
+	for _, name := range names {
+		e, ok := Lookup(name)
+		if !ok || e.Name != name {
+			t.Fatalf("Lookup(%q) = %+v, %v", name, e, ok)
+		}
+		if res := run(t, name, 0, 0); len(res.Clocks) != e.N {
+			t.Fatalf("%s ran %d processes, want the default %d", name, len(res.Clocks), e.N)
+		}
+	}
+}
+
+func TestBuildRejectsUnknownAndInvalid(t *testing.T) {
+	if _, err := Build("gossip", 0, 0); !errors.Is(err, ErrUnknownScenario) {
+		t.Fatalf("Build(gossip) = %v, want ErrUnknownScenario", err)
+	}
+	if _, err := Build("request-reply", 1, 0); !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("Build(request-reply, n=1) = %v, want ErrInvalidParams", err)
+	}
+	if _, err := Build("broadcast-storm", 3, -1); !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("Build(broadcast-storm, rounds=-1) = %v, want ErrInvalidParams", err)
+	}
+}
+
+func TestRequestReplyOrdersReplyAfterHandling(t *testing.T) {
+	res := run(t, "request-reply", 3, 2)
+	handle := event(t, res, vc.EventLocal, 0, "handle 2.0")
+	reply := event(t, res, vc.EventReceive, 2, "reply 2.0")
+	if !vc.HappenedBefore(handle.Clock, reply.Clock) {
+		t.Fatalf("handle %v does not happen before reply %v", handle.Clock, reply.Clock)
+	}
+	// 서버의 마지막 응답은 두 클라이언트의 마지막 요청을 모두 포함
+	last := event(t, res, vc.EventSend, 0, "reply 2.1")
+	for c := 1; c < 3; c++ {
+		req := event(t, res, vc.EventSend, c, fmt.Sprintf("request %d.1", c))
+		if !vc.HappenedBefore(req.Clock, last.Clock) {
+			t.Fatalf("request of P%d %v does not happen before %v", c, req.Clock, last.Clock)
+		}
+	}
+}
+
+func TestBroadcastStormSendsAreConcurrentWithinRound(t *testing.T) {
+	res := run(t, "broadcast-storm", 3, 2)
+	a := event(t, res, vc.EventSend, 0, "broadcast 0.0")
+	b := event(t, res, vc.EventSend, 1, "broadcast 1.0")
+	if o := vc.Compare(a.Clock, b.Clock); o != vc.Concurrent {
+		t.Fatalf("sends of one round are %s, want concurrent", o)
+	}
+	next := event(t, res, vc.EventSend, 0, "broadcast 0.1")
+	if !vc.HappenedBefore(b.Clock, next.Clock) {
+		t.Fatalf("round 1 send %v does not follow round 0 send %v", next.Clock, b.Clock)
+	}
+	for id, clock := range res.Clocks {
+		for _, e := range res.Events {
+			if e.Kind == vc.EventSend && e.To == id && !dominates(clock, e.Clock) {
+				t.Fatalf("P%d %v misses send %q %v addressed to it", id, clock, e.Name, e.Clock)
+			}
+		}
+	}
+}
+
+func TestPartitionAndHealMergesBothSides(t *testing.T) {
+	res := run(t, "partition-and-heal", 4, 2)
+	if len(res.Dropped) != 2 {
+		t.Fatalf("dropped %v, want one message across the partition per round", res.Dropped)
+	}
+	left := event(t, res, vc.EventSend, 1, "inside 1.1")
+	right := event(t, res, vc.EventSend, 3, "inside 3.1")
+	if o := vc.Compare(left.Clock, right.Clock); o != vc.Concurrent {
+		t.Fatalf("partitioned sides are %s, want concurrent", o)
+	}
+	for id, clock := range res.Clocks {
+		if !dominates(clock, left.Clock) || !dominates(clock, right.Clock) {
+			t.Fatalf("P%d %v has not merged both sides after healing", id, clock)
+		}
+	}
+}
+
+func TestConcurrentWritersConflictThenConverge(t *testing.T) {
+	res := run(t, "concurrent-writers", 3, 2)
+	var writes []vc.Event
+	for _, e := range res.Events {
+		if e.Kind == vc.EventLocal {
+			writes = append(writes, e)
+		}
+	}
+	if len(writes) != 6 {
+		t.Fatalf("recorded %d writes, want 6", len(writes))
+	}
+	for _, a := range writes {
+		for _, b := range writes {
+			if a.Process != b.Process && vc.Compare(a.Clock, b.Clock) != vc.Concurrent {
+				t.Fatalf("writes %q and %q are not concurrent", a.Name, b.Name)
+			}
+		}
+	}
+	for id, clock := range res.Clocks {
+		for _, w := range writes {
+			if !dominates(clock, w.Clock) {
+				t.Fatalf("P%d %v misses write %q", id, clock, w.Name)
+			}
+		}
+	}
+}
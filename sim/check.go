@@ -0,0 +1,195 @@
+package sim
+
+import (
+	"fmt"
+	"strings"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// Violation 인과 일관성 위반 한 건
+type Violation struct {
+	Index   int    // 위반한 이벤트의 위치
+	Process int    // 위반한 프로세스
+	Rule    string // 위반한 규칙 (monotone, increment, merge, unmatched, causal-delivery)
+	Detail  string // 설명
+}
+
+// String 위반 요약
+func (v Violation) String() string {
+	return fmt.Sprintf("event %d, process %d: %s: %s", v.Index, v.Process, v.Rule, v.Detail)
+}
+
+// CausalReport 인과 일관성 검사 결과
+type CausalReport struct {
+	Events     int         // 검사한 이벤트 수
+	Violations []Violation // 위반 목록 (이벤트 순서)
+}
+
+// OK 위반이 없는지 여부
+func (r *CausalReport) OK() bool {
+	return len(r.Violations) == 0
+}
+
+// String 사람이 읽을 수 있는 보고서
+func (r *CausalReport) String() string {
+	if r.OK() {
+		return fmt.Sprintf("causal consistency: OK (%d events)", r.Events)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "causal consistency: %d violations in %d events", len(r.Violations), r.Events)
+	for _, v := range r.Violations {
+		b.WriteString("\n  ")
+		b.WriteString(v.String())
+	}
+	return b.String()
+}
+
+// CheckCausal 기록된 이벤트의 인과 일관성 검사
+//
+// 프로세스별 시계(ClockPerProcess)의 기본 시계 이벤트를 대상으로 다음을 확인한다.
+//   - monotone: 프로세스의 시계는 줄어들지 않는다.
+//   - increment: 로컬/송신 이벤트는 자신의 항목을 정확히 1 증가시킨다.
+//   - merge: 수신 이벤트의 시계는 직전 시계와 송신 시계의 원소별 최대값(새 정보가 있으면 자신의 항목 +1)이다.
+//   - unmatched: 모든 수신은 기록된 송신과 짝이 맞고, 받는 프로세스가 일치한다.
+//   - causal-delivery: 한 프로세스에서 인과적으로 앞선 송신의 메시지를 나중 메시지보다 먼저 받는다.
+//
+// 도메인 시계 이벤트는 건너뛰며, ResetEpoch 로 시계가 재설정된 구간은 검사할 수 없다.
+func CheckCausal(events []vc.Event) *CausalReport {
+	r := &CausalReport{Events: len(events)}
+	report := func(i int, e vc.Event, rule, format string, args ...interface{}) {
+		r.Violations = append(r.Violations, Violation{Index: i, Process: e.Process, Rule: rule, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	sends := make(map[string]vc.Event)
+	for _, e := range events {
+		if (e.Kind == vc.EventSend || e.Kind == vc.EventDrop) && e.Domain == "" {
+			sends[e.MessageID] = e
+		}
+	}
+
+	last := make(map[int][]int)        // 프로세스별 직전 시계
+	delivered := make(map[int][][]int) // 프로세스별로 받은 메시지의 송신 시계 (받은 순서)
+	for i, e := range events {
+		if e.Domain != "" {
+			continue
+		}
+		prev, ok := last[e.Process]
+		if !ok {
+			prev = make([]int, len(e.Clock))
+		}
+		if !geq(e.Clock, prev) {
+			report(i, e, "monotone", "clock %v went back from %v", e.Clock, prev)
+		}
+		own := e.Process >= 0 && e.Process < len(e.Clock) && e.Process < len(prev)
+
+		switch e.Kind {
+		case vc.EventLocal, vc.EventSend, vc.EventDrop:
+			if own && e.Clock[e.Process] != prev[e.Process]+1 {
+				report(i, e, "increment", "%s event moved own entry %d -> %d", e.Kind, prev[e.Process], e.Clock[e.Process])
+			}
+		case vc.EventReceive:
+			send, ok := sends[e.MessageID]
+			if !ok {
+				report(i, e, "unmatched", "received message %s from %d was never sent", e.MessageID, e.From)
+				break
+			}
+			if send.To != e.Process {
+				report(i, e, "unmatched", "message %s addressed to %d was received by %d", e.MessageID, send.To, e.Process)
+			}
+			if want, alt := expectedMerge(prev, send.Clock, e.Process); !equal(e.Clock, want) && (alt == nil || !equal(e.Clock, alt)) {
+				report(i, e, "merge", "merging %v into %v gave %v, want %v", send.Clock, prev, e.Clock, want)
+			}
+			for _, earlier := range delivered[e.Process] {
+				if vc.HappenedBefore(send.Clock, earlier) {
+					report(i, e, "causal-delivery", "message sent at %v delivered after causally later message sent at %v",
+						send.Clock, earlier)
+					break
+				}
+			}
+			delivered[e.Process] = append(delivered[e.Process], send.Clock)
+		}
+		last[e.Process] = e.Clock
+	}
+	return r
+}
+
+// expectedMerge 수신 후 예상 시계 (새 정보가 없을 때 병합하지 않는 경우와 항상 증가하는 경우를 모두 허용)
+func expectedMerge(prev, sent []int, self int) (want, alt []int) {
+	merged := make([]int, len(prev))
+	copy(merged, prev)
+	fresh := false
+	for i := 0; i < len(sent) && i < len(merged); i++ {
+		if sent[i] > merged[i] {
+			merged[i] = sent[i]
+			fresh = true
+		}
+	}
+	ticked := append([]int(nil), merged...)
+	if self >= 0 && self < len(ticked) {
+		ticked[self]++
+	}
+	if fresh {
+		return ticked, nil
+	}
+	// ReceiveMessages 는 새 정보가 없으면 병합하지 않고, 인과 전달(MergeClock)은 항상 증가
+	return merged, ticked
+}
+
+// Inversion 인과적으로 앞선 송신의 Timestamp 가 뒤따른 송신보다 늦은 경우
+type Inversion struct {
+	Before vc.Event // 인과적으로 앞선 송신
+	After  vc.Event // Before 뒤에 일어난 송신 (Before.Timestamp > After.Timestamp)
+}
+
+// String 역전 요약
+func (v Inversion) String() string {
+	return fmt.Sprintf("%s %v (ts %d) -> %s %v (ts %d)",
+		v.Before.MessageID, v.Before.Clock, v.Before.Timestamp, v.After.MessageID, v.After.Clock, v.After.Timestamp)
+}
+
+// TimestampInversions Timestamp 순서가 인과 순서와 어긋나는 송신 쌍 (기록 순서)
+//
+// 벽시계가 어긋난 프로세스(WithClockSkew, Scenario.Skew)가 있으면 Timestamp 로만 정렬한 순서는
+// After 를 Before 보다 앞에 두지만, Vector Clock 은 Before 가 After 보다 먼저 일어났음을 보여 준다.
+func TimestampInversions(events []vc.Event) []Inversion {
+	var sends []vc.Event
+	for _, e := range events {
+		if (e.Kind == vc.EventSend || e.Kind == vc.EventDrop) && e.Domain == "" {
+			sends = append(sends, e)
+		}
+	}
+
+	var out []Inversion
+	for j, after := range sends {
+		for _, before := range sends[:j] {
+			if before.Timestamp > after.Timestamp && geq(after.Clock, before.Clock) && !equal(after.Clock, before.Clock) {
+				out = append(out, Inversion{Before: before, After: after})
+			}
+		}
+	}
+	return out
+}
+
+// geq a 의 모든 원소가 b 이상인지 여부
+func geq(a, b []int) bool {
+	for i := 0; i < len(b); i++ {
+		if i >= len(a) || a[i] < b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equal 두 시계가 같은지 여부
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
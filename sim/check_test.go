@@ -0,0 +1,50 @@
+package sim
+
+import (
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestRecordedRunIsCausallyConsistent(t *testing.T) {
+	res, err := New(3, vc.WithLogger(nil)).
+		Local(0, "start").
+		Send(0, 1, "a").
+		Recv(1).
+		Send(1, 2, "b").
+		Send(0, 2, "c").
+		Recv(2).
+		Recv(2).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := CheckCausal(res.Events); !r.OK() || r.Events != len(res.Events) {
+		t.Fatalf("%v", r)
+	}
+}
+
+func TestCheckCausalReportsViolations(t *testing.T) {
+	events := []vc.Event{
+		{Kind: vc.EventSend, Process: 0, MessageID: "m1", From: 0, To: 2, Clock: []int{1, 0, 0}},
+		{Kind: vc.EventSend, Process: 0, MessageID: "m2", From: 0, To: 2, Clock: []int{2, 0, 0}},
+		// m1 보다 나중에 보낸 m2 를 먼저 받음
+		{Kind: vc.EventReceive, Process: 2, MessageID: "m2", From: 0, To: 2, Clock: []int{2, 0, 1}},
+		{Kind: vc.EventReceive, Process: 2, MessageID: "m1", From: 0, To: 2, Clock: []int{2, 0, 2}},
+		{Kind: vc.EventReceive, Process: 1, MessageID: "ghost", From: 0, To: 1, Clock: []int{0, 1, 0}},
+		{Kind: vc.EventLocal, Process: 1, Clock: []int{0, 3, 0}},
+	}
+	r := CheckCausal(events)
+	rules := make(map[string]int)
+	for _, v := range r.Violations {
+		rules[v.Rule]++
+	}
+	for _, rule := range []string{"causal-delivery", "unmatched", "increment"} {
+		if rules[rule] != 1 {
+			t.Errorf("%s violations = %d, want 1 (%v)", rule, rules[rule], r)
+		}
+	}
+	if r.OK() {
+		t.Fatal("report with violations is OK")
+	}
+}
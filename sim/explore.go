@@ -0,0 +1,221 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// ErrExplorationLimit 전수 탐색이 실행 횟수 상한에 도달한 경우
+var ErrExplorationLimit = errors.New("sim: exploration limit reached")
+
+// Invariant 실행 결과가 지켜야 하는 성질 (위반이면 에러)
+type Invariant func(*Result) error
+
+// ExploreError 탐색 중 불변식을 위반한(또는 실행이 실패한) 실행
+type ExploreError struct {
+	Seed    int64 // 실패한 실행의 루트 시드 (RunRandom(Seed) 또는 Seed(Seed).RunChoices(Choices) 로 재현)
+	Choices []int // 실패한 실행의 선택 순서 (전수 탐색)
+	Run     int   // 몇 번째 실행인지 (0 부터)
+	Order   []int // 실패한 실행의 단계 실행 순서
+	Err     error // 위반한 불변식 또는 실행 에러
+}
+
+func (e *ExploreError) Error() string {
+	if e.Choices != nil {
+		return fmt.Sprintf("sim: run %d (seed %d, choices %v) failed: %v (order %v)", e.Run, e.Seed, e.Choices, e.Err, e.Order)
+	}
+	return fmt.Sprintf("sim: run %d (seed %d) failed: %v (order %v)", e.Run, e.Seed, e.Err, e.Order)
+}
+
+func (e *ExploreError) Unwrap() error {
+	return e.Err
+}
+
+// RunRandom seed 로 정한 무작위 순서로 실행
+//
+// 각 프로세스의 단계 순서는 지키면서 프로세스 사이의 실행 순서를 무작위로 섞고,
+// 수신 단계는 도착해 있는 메시지 중 하나를 무작위로 받는다 (받을 메시지가 있는 수신만 실행 가능).
+// 장애 단계(Crash, Restart, Partition, Heal, Skew)는 앞뒤 단계가 섞이지 않는 경계로 취급한다.
+// seed 는 실행의 루트 시드이므로 (Scenario.Seed 와 무관하게) 같은 시드는 항상 같은 순서와 장애를 만든다.
+func (s *Scenario) RunRandom(seed int64) (*Result, error) {
+	rng := rand.New(rand.NewSource(derive(seed, streamSchedule)))
+	return s.execute(seed, rng.Intn)
+}
+
+// Explore seed, seed+1, ... 로 runs 번 무작위 실행하며 매번 불변식 검사
+//
+// 처음 실패한 실행을 *ExploreError 로 반환하며, 모두 통과하면 nil 을 반환한다.
+func (s *Scenario) Explore(seed int64, runs int, invariants ...Invariant) error {
+	for run := 0; run < runs; run++ {
+		res, err := s.RunRandom(seed + int64(run))
+		if err == nil {
+			err = check(res, invariants)
+		}
+		if err != nil {
+			return &ExploreError{Seed: seed + int64(run), Run: run, Order: res.Order, Err: err}
+		}
+	}
+	return nil
+}
+
+// RunChoices 선택 순서를 그대로 따라 실행 (ExploreAll 이 보고한 실행 재현)
+//
+// 선택 i 는 i 번째 선택 지점(실행할 프로세스 또는 받을 메시지)에서 고를 선택지 번호이며,
+// 선택 순서가 끝난 뒤에는 항상 첫 번째 선택지를 고른다. 네모시스 등 나머지 무작위 선택은
+// 루트 시드(Scenario.Seed)를 따른다.
+func (s *Scenario) RunChoices(choices []int) (*Result, error) {
+	pos := 0
+	return s.execute(s.rootSeed(), func(n int) int {
+		k := 0
+		if pos < len(choices) && choices[pos] < n {
+			k = choices[pos]
+		}
+		pos++
+		return k
+	})
+}
+
+// ExploreAll 가능한 모든 실행 순서와 메시지 전달 순서를 차례로 실행하며 불변식 검사
+//
+// 선택 지점마다 모든 선택지를 깊이 우선으로 시도하므로 프로세스와 메시지 수가 작은 시나리오에서
+// 성질을 증명하는 데 쓴다. 실행 횟수와 처음 실패한 실행(*ExploreError)을 반환하며,
+// limit(0 이면 무제한) 번 실행해도 끝나지 않으면 ErrExplorationLimit 을 반환한다.
+// 모든 실행은 같은 루트 시드(Scenario.Seed, 없으면 탐색 시작 시각)를 쓴다.
+func (s *Scenario) ExploreAll(limit int, invariants ...Invariant) (runs int, err error) {
+	type choice struct{ n, k int }
+	var path []choice
+	seed := s.rootSeed()
+
+	for {
+		pos := 0
+		res, runErr := s.execute(seed, func(n int) int {
+			if pos < len(path) {
+				pos++
+				return path[pos-1].k
+			}
+			path = append(path, choice{n: n})
+			pos++
+			return 0
+		})
+		runs++
+		if runErr == nil {
+			runErr = check(res, invariants)
+		}
+		if runErr != nil {
+			choices := make([]int, pos)
+			for i := range choices {
+				choices[i] = path[i].k
+			}
+			return runs, &ExploreError{Seed: seed, Choices: choices, Run: runs - 1, Order: res.Order, Err: runErr}
+		}
+
+		// 다음 실행: 아직 시도하지 않은 선택지가 남은 가장 깊은 지점에서 다음 선택지로
+		path = path[:pos]
+		for len(path) > 0 && path[len(path)-1].k+1 >= path[len(path)-1].n {
+			path = path[:len(path)-1]
+		}
+		if len(path) == 0 {
+			return runs, nil
+		}
+		path[len(path)-1].k++
+		if limit > 0 && runs >= limit {
+			return runs, ErrExplorationLimit
+		}
+	}
+}
+
+// check 불변식을 순서대로 검사
+func check(res *Result, invariants []Invariant) error {
+	for _, inv := range invariants {
+		if err := inv(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// global 프로세스 사이의 순서를 섞지 않는 경계 단계 여부
+func global(step Step) bool {
+	switch step.Op {
+	case OpCrash, OpRestart, OpPartition, OpHeal, OpSkew:
+		return true
+	}
+	return false
+}
+
+// owner 단계를 실행하는 프로세스
+func owner(step Step) int {
+	if step.Op == OpRecv {
+		return step.To
+	}
+	return step.From
+}
+
+// interleave 경계 사이의 단계를 프로세스별 순서만 지키며 st.choose 로 섞어 실행
+func (st *runState) interleave(res *Result, steps []Step) error {
+	for start := 0; start < len(steps); {
+		end := start
+		for end < len(steps) && !global(steps[end]) {
+			end++
+		}
+
+		queues := make(map[int][]int)
+		for i := start; i < end; i++ {
+			queues[owner(steps[i])] = append(queues[owner(steps[i])], i)
+		}
+		ids := make([]int, 0, len(queues))
+		for id := range queues {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+
+		for remaining := end - start; remaining > 0; remaining-- {
+			var enabled []int
+			for _, id := range ids {
+				if q := queues[id]; len(q) > 0 && st.enabled(steps[q[0]]) {
+					enabled = append(enabled, id)
+				}
+			}
+			var id int
+			if len(enabled) == 0 {
+				// 실행할 수 있는 단계가 없으면 가장 앞선 단계를 실행해 실패를 보고
+				id = -1
+				for _, cand := range ids {
+					if q := queues[cand]; len(q) > 0 && (id < 0 || q[0] < queues[id][0]) {
+						id = cand
+					}
+				}
+			} else {
+				id = enabled[st.choose(len(enabled))]
+			}
+			i := queues[id][0]
+			queues[id] = queues[id][1:]
+			if err := st.apply(res, i, steps[i]); err != nil {
+				return err
+			}
+		}
+
+		if end < len(steps) {
+			if err := st.apply(res, end, steps[end]); err != nil {
+				return err
+			}
+		}
+		start = end + 1
+	}
+	return nil
+}
+
+// enabled 지금 실행할 수 있는 단계인지 여부 (수신은 받을 메시지가 있어야 함)
+func (st *runState) enabled(step Step) bool {
+	if step.Op != OpRecv || step.To < 0 || step.To >= len(st.procs) || st.crashed[step.To] {
+		return true
+	}
+	p := st.procs[step.To]
+	if step.Of != nil {
+		_, ok := st.sent[*step.Of]
+		return ok
+	}
+	return len(st.inbox[p.ID]) > 0 || len(p.MessageCh) > 0
+}
@@ -0,0 +1,115 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// race 0 과 1 이 각각 2 에게 보내는 시나리오 (2 가 받는 순서는 실행마다 다를 수 있음)
+func race() *Scenario {
+	return New(3, vc.WithLogger(nil)).
+		Send(0, 2, "a").
+		Send(1, 2, "b").
+		Recv(2).
+		Recv(2)
+}
+
+// received 프로세스 id 가 받은 메시지 내용 (받은 순서)
+func received(res *Result, id int) []string {
+	var names []string
+	for _, e := range res.Events {
+		if e.Kind == vc.EventReceive && e.Process == id {
+			names = append(names, e.Name)
+		}
+	}
+	return names
+}
+
+// aFirst 2 가 a 를 b 보다 먼저 받았는지 검사하는 (틀린) 불변식
+func aFirst(res *Result) error {
+	if got := received(res, 2); len(got) > 0 && got[0] != "a" {
+		return fmt.Errorf("received %v", got)
+	}
+	return nil
+}
+
+func TestRunRandomIsDeterministicPerSeed(t *testing.T) {
+	first, err := race().RunRandom(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := race().RunRandom(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(first.Order, second.Order) || !reflect.DeepEqual(received(first, 2), received(second, 2)) {
+		t.Fatalf("seed 42 ran %v/%v then %v/%v", first.Order, received(first, 2), second.Order, received(second, 2))
+	}
+	if len(first.Order) != 4 {
+		t.Fatalf("Order = %v, want all 4 steps", first.Order)
+	}
+}
+
+func TestExploreFindsReproducibleViolation(t *testing.T) {
+	err := race().Explore(1, 50, aFirst)
+	var ee *ExploreError
+	if !errors.As(err, &ee) {
+		t.Fatalf("Explore = %v, want *ExploreError", err)
+	}
+	res, runErr := race().RunRandom(ee.Seed)
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+	if aFirst(res) == nil || !reflect.DeepEqual(res.Order, ee.Order) {
+		t.Fatalf("seed %d did not reproduce the failing order %v (got %v)", ee.Seed, ee.Order, res.Order)
+	}
+
+	// 인과 관계가 없는 두 수신도 결국 모두 일어남
+	both := func(res *Result) error {
+		if got := received(res, 2); len(got) != 2 {
+			return fmt.Errorf("received %v", got)
+		}
+		return nil
+	}
+	if err := race().Explore(1, 50, both); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExploreAllVisitsEveryOrder(t *testing.T) {
+	seen := make(map[string]bool)
+	record := func(res *Result) error {
+		seen[fmt.Sprint(received(res, 2))] = true
+		return nil
+	}
+	runs, err := race().ExploreAll(0, record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs < 2 || !seen["[a b]"] || !seen["[b a]"] {
+		t.Fatalf("%d runs saw %v, want both delivery orders", runs, seen)
+	}
+
+	if _, err := race().ExploreAll(1); !errors.Is(err, ErrExplorationLimit) {
+		t.Fatalf("ExploreAll(1) = %v, want ErrExplorationLimit", err)
+	}
+}
+
+func TestExploreAllReportsReplayableChoices(t *testing.T) {
+	_, err := race().ExploreAll(0, aFirst)
+	var ee *ExploreError
+	if !errors.As(err, &ee) || ee.Choices == nil {
+		t.Fatalf("ExploreAll = %v, want *ExploreError with choices", err)
+	}
+	res, runErr := race().RunChoices(ee.Choices)
+	if runErr != nil {
+		t.Fatal(runErr)
+	}
+	if aFirst(res) == nil {
+		t.Fatalf("choices %v did not reproduce the violation", ee.Choices)
+	}
+}
@@ -0,0 +1,252 @@
+package sim
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// File 시나리오 파일 형식 (JSON, simyaml 을 가져오면 YAML 도 가능, 아래는 YAML 예)
+//
+//	processes: 3
+//	seed: 42                 # 루트 시드 (생략하면 실행마다 현재 시각)
+//	delivery: BSS            # BSS | SES
+//	clock_mode: per-process  # per-process | per-channel
+//	topology: [[0, 1], [1, 2]]
+//	schedule:
+//	  - {op: send, from: 0, to: 1, event: a}
+//	  - {op: recv, process: 1}               # 먼저 도착한 메시지
+//	  - {op: recv, process: 1, message: 0}   # schedule[0] 에서 보낸 메시지
+//	  - {op: local, process: 1, event: checkpoint}
+//	faults:
+//	  - {at: 1, type: crash, process: 2}           # schedule[1] 직전에 실행
+//	  - {at: 2, type: partition, groups: [[0], [1, 2]]}
+//	  - {at: 3, type: heal}
+//	  - {at: 0, type: drop}                        # schedule[0] 의 송신이 유실됨
+//	  - {at: 2, type: skew, process: 1, skew: 2s}  # 이후 1 이 보내는 메시지 시각이 2초 앞섬
+//	  - {at: 2, type: skew, process: 2, drift: 0.5} # 2 의 시계가 1초마다 0.5초씩 빨라짐
+type File struct {
+	Processes int         `json:"processes" yaml:"processes"`
+	Seed      *int64      `json:"seed,omitempty" yaml:"seed,omitempty"`
+	Delivery  string      `json:"delivery,omitempty" yaml:"delivery,omitempty"`
+	ClockMode string      `json:"clock_mode,omitempty" yaml:"clock_mode,omitempty"`
+	Topology  [][2]int    `json:"topology,omitempty" yaml:"topology,omitempty"`
+	Schedule  []FileStep  `json:"schedule" yaml:"schedule"`
+	Faults    []FileFault `json:"faults,omitempty" yaml:"faults,omitempty"`
+}
+
+// FileStep 메시지 일정의 한 단계
+type FileStep struct {
+	Op      string `json:"op" yaml:"op"`                               // send | recv | local
+	From    int    `json:"from,omitempty" yaml:"from,omitempty"`       // send
+	To      int    `json:"to,omitempty" yaml:"to,omitempty"`           // send
+	Process int    `json:"process,omitempty" yaml:"process,omitempty"` // recv, local
+	Event   string `json:"event,omitempty" yaml:"event,omitempty"`     // send, local
+	Message *int   `json:"message,omitempty" yaml:"message,omitempty"` // recv: 받을 메시지를 보낸 일정 단계 번호
+}
+
+// FileFault 장애 주입
+type FileFault struct {
+	At      int     `json:"at" yaml:"at"`                               // 이 장애를 실행할 일정 단계 번호 (그 단계 직전)
+	Type    string  `json:"type" yaml:"type"`                           // crash | restart | partition | heal | drop | skew
+	Process int     `json:"process,omitempty" yaml:"process,omitempty"` // crash, restart, skew
+	Groups  [][]int `json:"groups,omitempty" yaml:"groups,omitempty"`   // partition
+	Skew    string  `json:"skew,omitempty" yaml:"skew,omitempty"`       // skew: 벽시계 어긋남 (예: 2s, -500ms)
+	Drift   float64 `json:"drift,omitempty" yaml:"drift,omitempty"`     // skew: 1초마다 더해지는 어긋남 비율
+}
+
+// Format 시나리오 파일 형식의 인코더와 디코더 (RegisterFormat 참고)
+type Format struct {
+	Decode func(r io.Reader, v any) error // 모르는 필드가 있으면 에러
+	Encode func(w io.Writer, v any) error
+}
+
+// formats 등록된 시나리오 파일 형식 (이름 -> 형식, JSON 은 기본 등록)
+var formats = struct {
+	sync.RWMutex
+	m map[string]Format
+}{m: map[string]Format{"json": {Decode: decodeJSON, Encode: encodeJSON}}}
+
+// RegisterFormat 시나리오 파일 형식 name(소문자, 파일 확장자와 같음) 등록
+//
+// 외부 라이브러리가 필요한 형식은 따로 둔 모듈이 가져올 때 등록한다 (YAML 은 github.com/seoyhaein/vectorclock/sim/simyaml).
+func RegisterFormat(name string, f Format) {
+	formats.Lock()
+	defer formats.Unlock()
+	formats.m[strings.ToLower(name)] = f
+}
+
+// lookupFormat 이름이 name 인 형식
+func lookupFormat(name string) (Format, error) {
+	formats.RLock()
+	defer formats.RUnlock()
+	f, ok := formats.m[strings.ToLower(name)]
+	if !ok {
+		return Format{}, fmt.Errorf("sim: unknown scenario format %q (YAML needs github.com/seoyhaein/vectorclock/sim/simyaml)", name)
+	}
+	return f, nil
+}
+
+// formatOf 파일 확장자로 정한 형식 이름 (확장자가 없으면 json)
+func formatOf(path string) string {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if ext == "" {
+		return "json"
+	}
+	return strings.ToLower(ext)
+}
+
+// decodeJSON 모르는 필드를 거부하는 JSON 디코더
+func decodeJSON(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// encodeJSON 들여쓴 JSON 인코더
+func encodeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// LoadFile 시나리오 파일을 읽어 Scenario 생성 (형식은 확장자로 정함, RegisterFormat 참고)
+func LoadFile(path string, opts ...vc.ManagerOption) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Load(bytes.NewReader(data), formatOf(path), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Load r 에서 format(등록된 형식 이름, 예: "json") 형식의 시나리오를 읽어 Scenario 생성
+//
+// opts 는 파일에 지정된 설정 뒤에 적용되므로 파일 설정을 덮어쓸 수 있다.
+func Load(r io.Reader, format string, opts ...vc.ManagerOption) (*Scenario, error) {
+	dec, err := lookupFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	var f File
+	if err := dec.Decode(r, &f); err != nil {
+		return nil, fmt.Errorf("sim: decode scenario: %w", err)
+	}
+	return f.Scenario(opts...)
+}
+
+// Scenario 파일 내용으로 Scenario 생성 (장애는 지정한 일정 단계 직전에 끼워 넣음)
+func (f *File) Scenario(opts ...vc.ManagerOption) (*Scenario, error) {
+	if f.Processes <= 0 {
+		return nil, fmt.Errorf("sim: scenario needs at least one process")
+	}
+
+	var fileOpts []vc.ManagerOption
+	switch strings.ToUpper(f.Delivery) {
+	case "", "BSS":
+	case "SES":
+		fileOpts = append(fileOpts, vc.WithDelivery(vc.DeliverySES))
+	default:
+		return nil, fmt.Errorf("sim: unknown delivery %q", f.Delivery)
+	}
+	switch f.ClockMode {
+	case "", vc.ClockPerProcess.String():
+	case vc.ClockPerChannel.String():
+		fileOpts = append(fileOpts, vc.WithClockMode(vc.ClockPerChannel))
+	default:
+		return nil, fmt.Errorf("sim: unknown clock mode %q", f.ClockMode)
+	}
+
+	// 일정 단계 번호 -> 시나리오 단계 번호 (장애 단계가 끼어들어 밀림)
+	stepOf := make([]int, len(f.Schedule))
+	before := make(map[int][]FileFault)
+	drops := make(map[int]bool)
+	for i, fault := range f.Faults {
+		if fault.At < 0 || fault.At > len(f.Schedule) {
+			return nil, fmt.Errorf("sim: fault %d: step %d out of range", i, fault.At)
+		}
+		if fault.Type == "drop" {
+			if fault.At == len(f.Schedule) || f.Schedule[fault.At].Op != "send" {
+				return nil, fmt.Errorf("sim: fault %d: step %d is not a send", i, fault.At)
+			}
+			drops[fault.At] = true
+			continue
+		}
+		before[fault.At] = append(before[fault.At], fault)
+	}
+
+	s := New(f.Processes, append(fileOpts, opts...)...)
+	if strings.EqualFold(f.Delivery, "SES") {
+		s.delivery = vc.DeliverySES
+	}
+	if f.ClockMode == vc.ClockPerChannel.String() {
+		s.mode = vc.ClockPerChannel
+	}
+	s.Links = append(s.Links, f.Topology...)
+	s.seed = f.Seed
+	for i := 0; i <= len(f.Schedule); i++ {
+		// 같은 단계의 장애는 파일에 적힌 순서대로
+		for _, fault := range before[i] {
+			switch fault.Type {
+			case "crash":
+				s.Crash(fault.Process)
+			case "restart":
+				s.Restart(fault.Process)
+			case "partition":
+				s.Partition(fault.Groups...)
+			case "heal":
+				s.Heal()
+			case "skew":
+				var d time.Duration
+				if fault.Skew != "" {
+					var err error
+					if d, err = time.ParseDuration(fault.Skew); err != nil {
+						return nil, fmt.Errorf("sim: skew fault at step %d: %w", fault.At, err)
+					}
+				}
+				s.Drift(fault.Process, d, fault.Drift)
+			default:
+				return nil, fmt.Errorf("sim: unknown fault type %q", fault.Type)
+			}
+		}
+		if i == len(f.Schedule) {
+			break
+		}
+
+		step := f.Schedule[i]
+		stepOf[i] = len(s.Steps)
+		switch step.Op {
+		case "send":
+			if drops[i] {
+				s.Drop(step.From, step.To, step.Event)
+			} else {
+				s.Send(step.From, step.To, step.Event)
+			}
+		case "recv":
+			if step.Message == nil {
+				s.Recv(step.Process)
+				break
+			}
+			if m := *step.Message; m < 0 || m >= i || f.Schedule[m].Op != "send" {
+				return nil, fmt.Errorf("sim: schedule step %d: message %d is not an earlier send", i, m)
+			}
+			s.RecvOf(step.Process, stepOf[*step.Message])
+		case "local":
+			s.Local(step.Process, step.Event)
+		default:
+			return nil, fmt.Errorf("sim: schedule step %d: unknown op %q", i, step.Op)
+		}
+	}
+	return s, nil
+}
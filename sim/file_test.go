@@ -0,0 +1,69 @@
+package sim
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+const partitionJSON = `{
+  "processes": 3,
+  "delivery": "BSS",
+  "schedule": [
+    {"op": "send", "from": 0, "to": 1, "event": "a"},
+    {"op": "send", "from": 0, "to": 2, "event": "b"},
+    {"op": "recv", "process": 1},
+    {"op": "local", "process": 2, "event": "alone"}
+  ],
+  "faults": [
+    {"at": 1, "type": "partition", "groups": [[0, 1], [2]]},
+    {"at": 3, "type": "crash", "process": 2}
+  ]
+}`
+
+func TestLoadFileRunsScheduleWithFaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partition.json")
+	if err := os.WriteFile(path, []byte(partitionJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := LoadFile(path, vc.WithLogger(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 분할로 0 -> 2 는 유실, 2 가 멈춘 뒤의 로컬 이벤트는 건너뜀
+	if !reflect.DeepEqual(res.Dropped, []int{2}) {
+		t.Fatalf("Dropped = %v, want [2]", res.Dropped)
+	}
+	if len(res.Skipped) != 1 {
+		t.Fatalf("Skipped = %v, want one step", res.Skipped)
+	}
+	if got := res.Clock(1); !reflect.DeepEqual(got, []int{1, 1, 0}) {
+		t.Fatalf("Clock(1) = %v, want [1 1 0]", got)
+	}
+	if got := res.Clock(2); !reflect.DeepEqual(got, []int{0, 0, 0}) {
+		t.Fatalf("Clock(2) = %v, want [0 0 0]", got)
+	}
+}
+
+func TestLoadRejectsBadScenarios(t *testing.T) {
+	for name, tc := range map[string]struct{ format, body string }{
+		"unknown field":  {"json", `{"processes": 1, "schedule": [], "bogus": 1}`},
+		"no processes":   {"json", `{"schedule": []}`},
+		"unknown op":     {"json", `{"processes": 1, "schedule": [{"op": "jump"}]}`},
+		"fault range":    {"json", `{"processes": 1, "schedule": [], "faults": [{"at": 5, "type": "heal"}]}`},
+		"drop non-send":  {"json", `{"processes": 1, "schedule": [{"op": "recv"}], "faults": [{"at": 0, "type": "drop"}]}`},
+		"unknown format": {"toml", `processes = 1`},
+	} {
+		if _, err := Load(strings.NewReader(tc.body), tc.format); err == nil {
+			t.Errorf("%s: Load succeeded", name)
+		}
+	}
+}
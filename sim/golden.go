@@ -0,0 +1,143 @@
+package sim
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// TraceEvent 골든 트레이스의 이벤트 (실행마다 달라지는 메시지 ID 와 시각은 제외)
+type TraceEvent struct {
+	Kind    string `json:"kind"`
+	Process int    `json:"process"`
+	Name    string `json:"name,omitempty"`
+	From    int    `json:"from"`
+	To      int    `json:"to"`
+	Clock   []int  `json:"clock"`
+}
+
+// String 이벤트 요약
+func (e TraceEvent) String() string {
+	return fmt.Sprintf("%s at process %d (%d->%d %q), Vector: %v", e.Kind, e.Process, e.From, e.To, e.Name, e.Clock)
+}
+
+// Trace 기록 순서의 이벤트 목록
+type Trace []TraceEvent
+
+// TraceOf 이벤트 기록을 트레이스로 변환
+func TraceOf(events []vc.Event) Trace {
+	t := make(Trace, 0, len(events))
+	for _, e := range events {
+		t = append(t, TraceEvent{
+			Kind:    e.Kind.String(),
+			Process: e.Process,
+			Name:    e.Name,
+			From:    e.From,
+			To:      e.To,
+			Clock:   append([]int(nil), e.Clock...),
+		})
+	}
+	return t
+}
+
+// Trace 실행 결과의 이벤트 트레이스
+func (r *Result) Trace() Trace {
+	return TraceOf(r.Events)
+}
+
+// LoadTrace JSON 골든 트레이스 파일 읽기
+func LoadTrace(path string) (Trace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var t Trace
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("sim: decode trace %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// Save JSON 골든 트레이스 파일로 저장
+func (t Trace) Save(path string) error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Divergence 골든 트레이스와 처음 달라진 지점
+type Divergence struct {
+	Index   int         // 골든 트레이스에서의 위치 (골든 트레이스가 먼저 끝났으면 그 길이)
+	Process int         // 달라진 프로세스
+	Want    *TraceEvent // 골든 트레이스의 이벤트 (nil 이면 실행에 남는 이벤트가 더 있음)
+	Got     *TraceEvent // 실행의 이벤트 (nil 이면 실행에 이벤트가 빠짐)
+}
+
+func (d *Divergence) Error() string {
+	switch {
+	case d.Want == nil:
+		return fmt.Sprintf("sim: trace diverges at event %d on process %d: unexpected %v", d.Index, d.Process, *d.Got)
+	case d.Got == nil:
+		return fmt.Sprintf("sim: trace diverges at event %d on process %d: missing %v", d.Index, d.Process, *d.Want)
+	default:
+		return fmt.Sprintf("sim: trace diverges at event %d on process %d:\n  want %v\n  got  %v",
+			d.Index, d.Process, *d.Want, *d.Got)
+	}
+}
+
+// Compare 실행 트레이스를 골든 트레이스와 비교해 처음 달라진 지점 반환 (같으면 nil)
+//
+// 동시에 일어난 이벤트의 기록 순서는 실행마다 다를 수 있으므로 프로세스별 이벤트 순서를 비교하고,
+// 달라진 이벤트 중 골든 트레이스에서 가장 앞선 것을 보고한다.
+func Compare(golden, got Trace) *Divergence {
+	type indexed struct {
+		index int
+		event TraceEvent
+	}
+	split := func(t Trace) map[int][]indexed {
+		m := make(map[int][]indexed)
+		for i, e := range t {
+			m[e.Process] = append(m[e.Process], indexed{i, e})
+		}
+		return m
+	}
+	want, have := split(golden), split(got)
+
+	var first *Divergence
+	consider := func(d *Divergence) {
+		if first == nil || d.Index < first.Index || (d.Index == first.Index && d.Process < first.Process) {
+			first = d
+		}
+	}
+	for id, ws := range want {
+		hs := have[id]
+		for k, w := range ws {
+			if k >= len(hs) {
+				w := w.event
+				consider(&Divergence{Index: ws[k].index, Process: id, Want: &w})
+				break
+			}
+			if h := hs[k].event; !reflect.DeepEqual(w.event, h) {
+				w := w.event
+				consider(&Divergence{Index: ws[k].index, Process: id, Want: &w, Got: &h})
+				break
+			}
+		}
+		if len(hs) > len(ws) {
+			h := hs[len(ws)].event
+			consider(&Divergence{Index: len(golden), Process: id, Got: &h})
+		}
+	}
+	for id, hs := range have {
+		if _, ok := want[id]; !ok {
+			h := hs[0].event
+			consider(&Divergence{Index: len(golden), Process: id, Got: &h})
+		}
+	}
+	return first
+}
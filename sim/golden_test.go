@@ -0,0 +1,84 @@
+package sim
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// pingPong 0 과 1 이 메시지를 주고받는 시나리오
+func pingPong() *Scenario {
+	return New(3, vc.WithLogger(nil)).
+		Send(0, 1, "ping").
+		Recv(1).
+		Send(1, 0, "pong").
+		Recv(0)
+}
+
+func TestGoldenTraceMatchesAfterSaveAndLoad(t *testing.T) {
+	res, err := pingPong().Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "ping.golden.json")
+	if err := res.Trace().Save(path); err != nil {
+		t.Fatal(err)
+	}
+	golden, err := LoadTrace(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 다른 프로세스의 이벤트가 끼어드는 순서만 달라지면 같은 트레이스
+	again, err := New(3, vc.WithLogger(nil)).
+		Send(0, 1, "ping").
+		Local(2, "idle").
+		Recv(1).
+		Send(1, 0, "pong").
+		Recv(0).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := Compare(golden, again.Trace()[:1]); d == nil || d.Want == nil || d.Got != nil {
+		t.Fatalf("truncated run: divergence = %v, want a missing event", d)
+	}
+	withoutIdle := append(again.Trace()[:1:1], again.Trace()[2:]...)
+	if d := Compare(golden, withoutIdle); d != nil {
+		t.Fatalf("same run diverged: %v", d)
+	}
+	if d := Compare(golden, again.Trace()); d == nil || d.Process != 2 || d.Want != nil || d.Index != len(golden) {
+		t.Fatalf("extra event: divergence = %v", d)
+	}
+}
+
+func TestCompareReportsFirstChangedEvent(t *testing.T) {
+	golden, err := pingPong().Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, err := New(3, vc.WithLogger(nil)).
+		Send(0, 1, "ping").
+		Recv(1).
+		Send(1, 0, "PONG").
+		Recv(0).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := Compare(golden.Trace(), changed.Trace())
+	if d == nil || d.Index != 2 || d.Process != 1 || d.Want.Name != "pong" || d.Got.Name != "PONG" {
+		t.Fatalf("divergence = %+v", d)
+	}
+	if !strings.Contains(d.Error(), "diverges at event 2 on process 1") {
+		t.Fatalf("Error() = %q", d.Error())
+	}
+}
+
+func TestLoadTraceRejectsBadFile(t *testing.T) {
+	if _, err := LoadTrace(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("LoadTrace of missing file succeeded")
+	}
+}
@@ -0,0 +1,187 @@
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Point 네모시스가 호출되는 지점
+type Point struct {
+	Position int  // 지금까지 실행한 단계 수 (Result.Order 의 길이)
+	Step     int  // 곧 실행할 단계 번호
+	Next     Step // 곧 실행할 단계
+}
+
+// Nemesis 시뮬레이션 실행 중 단계 사이에 장애를 주입하는 주체 (Jepsen 의 nemesis)
+//
+// 실행기는 Scenario.Nemesis 로 지정한 위치마다 Invoke 를 호출하며,
+// Invoke 는 Chaos 를 통해 프로세스를 멈추거나 네트워크를 분할하거나 벽시계를 어긋나게 한다.
+// 주입한 장애는 곧 실행할 단계부터 적용되고 Result.Faults 에 기록된다.
+type Nemesis interface {
+	Invoke(pt Point, c *Chaos)
+}
+
+// NemesisFunc 함수를 Nemesis 로 사용
+type NemesisFunc func(pt Point, c *Chaos)
+
+// Invoke 장애 주입
+func (f NemesisFunc) Invoke(pt Point, c *Chaos) {
+	f(pt, c)
+}
+
+// Fault 네모시스가 주입한 장애
+type Fault struct {
+	At   int  // 주입한 위치 (그때까지 실행한 단계 수)
+	Step Step // 주입한 장애 (OpCrash, OpRestart, OpPartition, OpHeal, OpSkew)
+}
+
+// String 장애 요약
+func (f Fault) String() string {
+	return fmt.Sprintf("@%d %v", f.At, f.Step)
+}
+
+// Chaos 네모시스가 장애를 주입하는 통로 (Invoke 안에서만 유효)
+type Chaos struct {
+	st  *runState
+	res *Result
+	at  int
+	err error
+}
+
+// N 프로세스 수
+func (c *Chaos) N() int {
+	return len(c.st.procs)
+}
+
+// Crashed 프로세스 id 가 멈춰 있는지 여부
+func (c *Chaos) Crashed(id int) bool {
+	return c.st.crashed[id]
+}
+
+// Partitioned 네트워크가 분할되어 있는지 여부
+func (c *Chaos) Partitioned() bool {
+	return c.st.partition != nil
+}
+
+// Rand 실행의 루트 시드에서 유도한 난수 (무작위 네모시스는 이것만 써야 재현됨)
+func (c *Chaos) Rand() *rand.Rand {
+	return c.st.rng
+}
+
+// Crash 프로세스 id 를 멈춤
+func (c *Chaos) Crash(id int) error {
+	return c.inject(Step{Op: OpCrash, From: id})
+}
+
+// Restart 멈춘 프로세스 id 를 다시 실행
+func (c *Chaos) Restart(id int) error {
+	return c.inject(Step{Op: OpRestart, From: id})
+}
+
+// Partition 네트워크를 groups 로 분할 (어느 그룹에도 없는 프로세스는 각자 고립)
+func (c *Chaos) Partition(groups ...[]int) error {
+	return c.inject(Step{Op: OpPartition, Groups: groups})
+}
+
+// Heal 네트워크 분할 해제
+func (c *Chaos) Heal() error {
+	return c.inject(Step{Op: OpHeal})
+}
+
+// Skew 프로세스 id 의 벽시계를 d 만큼 어긋나게 함 (0 이면 되돌림)
+func (c *Chaos) Skew(id int, d time.Duration) error {
+	return c.inject(Step{Op: OpSkew, From: id, Skew: d})
+}
+
+// Drift 프로세스 id 의 벽시계를 offset 만큼 어긋나게 하고 1초마다 rate 초씩 더 벌어지게 함
+func (c *Chaos) Drift(id int, offset time.Duration, rate float64) error {
+	return c.inject(Step{Op: OpSkew, From: id, Skew: offset, Drift: rate})
+}
+
+// inject 장애 단계를 실행하고 기록 (실패하면 실행 전체가 그 에러로 끝남)
+func (c *Chaos) inject(step Step) error {
+	if _, err := c.st.run(-1, step); err != nil {
+		err = fmt.Errorf("sim: nemesis at %d (%v): %w", c.at, step, err)
+		if c.err == nil {
+			c.err = err
+		}
+		return err
+	}
+	c.res.Faults = append(c.res.Faults, Fault{At: c.at, Step: step})
+	return nil
+}
+
+// Nemesis 실행 중 n 을 호출할 위치 지정 (at 은 그때까지 실행한 단계 수, 비어 있으면 모든 단계 직전)
+func (s *Scenario) Nemesis(n Nemesis, at ...int) *Scenario {
+	s.nemesis = n
+	s.points = nil
+	if len(at) > 0 {
+		s.points = make(map[int]bool, len(at))
+		for _, pos := range at {
+			s.points[pos] = true
+		}
+	}
+	return s
+}
+
+// provoke 지정한 위치면 단계 i 직전에 네모시스 호출
+func (st *runState) provoke(res *Result, i int, step Step) error {
+	pos := len(res.Order)
+	if st.nemesis == nil || (st.points != nil && !st.points[pos]) {
+		return nil
+	}
+	c := &Chaos{st: st, res: res, at: pos}
+	st.nemesis.Invoke(Point{Position: pos, Step: i, Next: step}, c)
+	return c.err
+}
+
+// RandomNemesis 호출될 때마다 rate 확률로 무작위 장애를 하나씩 주입
+//
+// 멈춤/재시작, 무작위 두 그룹 분할/해제, ±maxSkew 안의 벽시계 어긋남 중 하나를 고르며,
+// 항상 한 프로세스는 멈추지 않은 채로 둔다. 난수는 Chaos.Rand 를 쓰므로 같은 루트 시드는 같은 장애를 만든다.
+func RandomNemesis(rate float64, maxSkew time.Duration) Nemesis {
+	return NemesisFunc(func(pt Point, c *Chaos) {
+		rng := c.Rand()
+		if c.N() == 0 || rng.Float64() >= rate {
+			return
+		}
+		id := rng.Intn(c.N())
+		switch rng.Intn(3) {
+		case 0:
+			if c.Crashed(id) {
+				c.Restart(id)
+				return
+			}
+			alive := 0
+			for i := 0; i < c.N(); i++ {
+				if !c.Crashed(i) {
+					alive++
+				}
+			}
+			if alive > 1 {
+				c.Crash(id)
+			}
+		case 1:
+			if c.Partitioned() || c.N() < 2 {
+				c.Heal()
+				return
+			}
+			var a, b []int
+			for _, i := range rng.Perm(c.N()) {
+				if len(a) == 0 || (len(b) > 0 && rng.Intn(2) == 0) {
+					a = append(a, i)
+				} else {
+					b = append(b, i)
+				}
+			}
+			c.Partition(a, b)
+		case 2:
+			var d time.Duration
+			if maxSkew > 0 {
+				d = time.Duration(rng.Int63n(int64(2*maxSkew)+1)) - maxSkew
+			}
+			c.Skew(id, d)
+		}
+	})
+}
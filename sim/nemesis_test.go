@@ -0,0 +1,66 @@
+package sim
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestNemesisInjectsFaultsAtPositions(t *testing.T) {
+	crash := NemesisFunc(func(pt Point, c *Chaos) {
+		if err := c.Crash(1); err != nil {
+			t.Error(err)
+		}
+	})
+	res, err := New(2, vc.WithLogger(nil)).
+		Send(0, 1, "a").
+		Recv(1).
+		Nemesis(crash, 1).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Faults) != 1 || res.Faults[0].At != 1 || res.Faults[0].Step.Op != OpCrash {
+		t.Fatalf("Faults = %v", res.Faults)
+	}
+	if !reflect.DeepEqual(res.Skipped, []int{1}) {
+		t.Fatalf("Skipped = %v, want the receive of the crashed process", res.Skipped)
+	}
+}
+
+func TestSkewShiftsMessageTimestamps(t *testing.T) {
+	res, err := New(2, vc.WithLogger(nil)).
+		Skew(0, time.Hour).
+		Send(0, 1, "late").
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := <-res.Processes[1].MessageCh
+	if ahead := time.Unix(msg.Timestamp, 0).Sub(time.Now()); ahead < 59*time.Minute {
+		t.Fatalf("message timestamp is %v ahead, want about 1h", ahead)
+	}
+}
+
+func TestRandomNemesisIsDeterministic(t *testing.T) {
+	run := func() []Fault {
+		s := New(3, vc.WithLogger(nil))
+		for i := 0; i < 20; i++ {
+			s.Local(i%3, "tick")
+		}
+		res, err := s.Seed(7).Nemesis(RandomNemesis(0.5, time.Second)).Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.Faults
+	}
+	first := run()
+	if len(first) == 0 {
+		t.Fatal("RandomNemesis injected nothing at rate 0.5")
+	}
+	if second := run(); !reflect.DeepEqual(first, second) {
+		t.Fatalf("same seed injected %v then %v", first, second)
+	}
+}
@@ -0,0 +1,174 @@
+package sim
+
+import (
+	"io"
+	"os"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// Record 매니저의 이벤트 기록으로 실행을 그대로 재현하는 시나리오 생성
+//
+// 기록된 전역 순서(스케줄러와 고루틴 실행 순서가 정한 순서)대로 로컬 이벤트, 송신, 수신을 단계로 옮기며,
+// 수신 단계는 받은 메시지를 보낸 단계를 가리키므로 메일박스 도착 순서와 무관하게 같은 메시지를 받는다.
+// 전달하지 못한 송신(dead-letter)은 유실 단계로 기록된다.
+// Send / ReceiveMessages / LocalEvent 로 이루어진 실행은 같은 시계로 재현되며,
+// 브로드캐스트, 도메인 시계, 하트비트처럼 한 번에 여러 시계를 바꾸는 기능은 일반 송수신으로 근사된다.
+func Record(mgr *vc.VectorClockManager, opts ...vc.ManagerOption) *Scenario {
+	state := mgr.State()
+	var fileOpts []vc.ManagerOption
+	if mgr.Delivery != vc.DeliveryBSS {
+		fileOpts = append(fileOpts, vc.WithDelivery(mgr.Delivery))
+	}
+	if mgr.Mode != vc.ClockPerProcess {
+		fileOpts = append(fileOpts, vc.WithClockMode(mgr.Mode))
+	}
+	s := New(len(state.Clocks), append(fileOpts, opts...)...)
+	s.delivery, s.mode = mgr.Delivery, mgr.Mode
+
+	sentAt := make(map[string]int)
+	for _, e := range linearize(mgr.Events()) {
+		switch e.Kind {
+		case vc.EventLocal:
+			s.Local(e.Process, e.Name)
+		case vc.EventSend:
+			sentAt[e.MessageID] = len(s.Steps)
+			s.Send(e.From, e.To, e.Name)
+		case vc.EventDrop:
+			s.Drop(e.From, e.To, e.Name)
+		case vc.EventReceive:
+			if at, ok := sentAt[e.MessageID]; ok {
+				s.RecvOf(e.Process, at)
+			}
+		}
+	}
+	return s
+}
+
+// linearize 프로세스별 순서와 송신 -> 수신 순서를 지키도록 이벤트 재배열
+//
+// 송신 이벤트는 메일박스에 넣은 뒤에 기록되므로 받는 쪽의 수신 기록이 먼저 남을 수 있다.
+// 각 프로세스의 이벤트 순서는 유지하면서, 보낸 기록이 아직 없는 수신은 송신이 나올 때까지 미룬다.
+func linearize(events []vc.Event) []vc.Event {
+	queues := make(map[int][]vc.Event)
+	var ids []int
+	sent := make(map[string]bool)
+	for _, e := range events {
+		if _, ok := queues[e.Process]; !ok {
+			ids = append(ids, e.Process)
+		}
+		queues[e.Process] = append(queues[e.Process], e)
+		if e.Kind == vc.EventSend {
+			sent[e.MessageID] = false
+		}
+	}
+
+	out := make([]vc.Event, 0, len(events))
+	for len(out) < len(events) {
+		best := -1
+		for _, id := range ids {
+			q := queues[id]
+			if len(q) == 0 {
+				continue
+			}
+			// 기록된 송신이 아직 내보내지지 않은 수신은 대기
+			if e := q[0]; e.Kind == vc.EventReceive {
+				if done, ok := sent[e.MessageID]; ok && !done {
+					continue
+				}
+			}
+			if best < 0 || q[0].Seq < queues[best][0].Seq {
+				best = id
+			}
+		}
+		if best < 0 {
+			// 순환 대기는 생길 수 없지만, 남은 이벤트는 기록 순서대로 붙임
+			for _, id := range ids {
+				out = append(out, queues[id]...)
+				queues[id] = nil
+			}
+			break
+		}
+		e := queues[best][0]
+		queues[best] = queues[best][1:]
+		if e.Kind == vc.EventSend {
+			sent[e.MessageID] = true
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// File 시나리오를 파일 형식으로 변환 (장애 단계는 faults 로 옮김)
+func (s *Scenario) File() *File {
+	f := &File{Processes: s.N, Seed: s.seed, Topology: append([][2]int(nil), s.Links...)}
+	if s.delivery != vc.DeliveryBSS {
+		f.Delivery = s.delivery.String()
+	}
+	if s.mode != vc.ClockPerProcess {
+		f.ClockMode = s.mode.String()
+	}
+
+	// 시나리오 단계 번호 -> 일정 단계 번호
+	scheduleOf := make(map[int]int)
+	for i, step := range s.Steps {
+		at := len(f.Schedule)
+		switch step.Op {
+		case OpSend, OpDrop:
+			scheduleOf[i] = at
+			f.Schedule = append(f.Schedule, FileStep{Op: "send", From: step.From, To: step.To, Event: step.Event})
+			if step.Op == OpDrop {
+				f.Faults = append(f.Faults, FileFault{At: at, Type: "drop"})
+			}
+		case OpRecv:
+			fs := FileStep{Op: "recv", Process: step.To}
+			if step.Of != nil {
+				if m, ok := scheduleOf[*step.Of]; ok {
+					fs.Message = &m
+				}
+			}
+			f.Schedule = append(f.Schedule, fs)
+		case OpLocal:
+			f.Schedule = append(f.Schedule, FileStep{Op: "local", Process: step.From, Event: step.Event})
+		case OpCrash, OpRestart:
+			f.Faults = append(f.Faults, FileFault{At: at, Type: step.Op.String(), Process: step.From})
+		case OpPartition:
+			f.Faults = append(f.Faults, FileFault{At: at, Type: "partition", Groups: step.Groups})
+		case OpHeal:
+			f.Faults = append(f.Faults, FileFault{At: at, Type: "heal"})
+		case OpSkew:
+			fault := FileFault{At: at, Type: "skew", Process: step.From, Drift: step.Drift}
+			if step.Skew != 0 || step.Drift == 0 {
+				fault.Skew = step.Skew.String()
+			}
+			f.Faults = append(f.Faults, fault)
+		}
+	}
+	return f
+}
+
+// Write format(등록된 형식 이름, 예: "json") 형식으로 출력
+func (f *File) Write(w io.Writer, format string) error {
+	enc, err := lookupFormat(format)
+	if err != nil {
+		return err
+	}
+	return enc.Encode(w, f)
+}
+
+// Save 시나리오 파일로 저장 (형식은 확장자로 정함, RegisterFormat 참고)
+func (f *File) Save(path string) error {
+	enc, err := lookupFormat(formatOf(path))
+	if err != nil {
+		return err
+	}
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := enc.Encode(out, f); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
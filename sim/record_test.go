@@ -0,0 +1,80 @@
+package sim
+
+import (
+	"bytes"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// liveRun 실제 프로세스로 실행한 매니저 (1 이 두 메시지를 보낸 순서와 반대로 받음)
+func liveRun(t *testing.T) *vc.VectorClockManager {
+	t.Helper()
+	mgr := vc.NewVectorClockManager(3, vc.WithLogger(nil))
+	a := vc.NewProcess(0, mgr, vc.WithMailboxSize(4))
+	b := vc.NewProcess(1, mgr, vc.WithMailboxSize(4))
+	vc.NewProcess(2, mgr, vc.WithMailboxSize(4))
+
+	a.LocalEvent("start")
+	if err := a.Send(1, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Send(2, "aside"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	return mgr
+}
+
+func TestRecordReplaysLiveRun(t *testing.T) {
+	mgr := liveRun(t)
+	res, err := Record(mgr, vc.WithLogger(nil)).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for id := 0; id < 3; id++ {
+		if got, want := res.Clock(id), mgr.GetClock(id); !reflect.DeepEqual(got, want) {
+			t.Fatalf("replayed Clock(%d) = %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestRecordedFileRoundTrips(t *testing.T) {
+	mgr := liveRun(t)
+	f := Record(mgr).File()
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf, "json"); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Load(&buf, "json", vc.WithLogger(nil))
+	if err != nil {
+		t.Fatalf("recorded file does not load: %v", err)
+	}
+	if got := s.File(); !reflect.DeepEqual(got, f) {
+		t.Fatalf("reloaded file = %+v, want %+v", got, f)
+	}
+
+	path := filepath.Join(t.TempDir(), "run.json")
+	if err := f.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	s, err = LoadFile(path, vc.WithLogger(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := s.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.Clock(1), mgr.GetClock(1); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Clock(1) from saved file = %v, want %v", got, want)
+	}
+	if err := f.Write(&buf, "toml"); err == nil {
+		t.Fatal("Write accepted an unknown format")
+	}
+}
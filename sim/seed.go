@@ -0,0 +1,54 @@
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// 루트 시드에서 갈라져 나오는 난수 흐름
+const (
+	streamSchedule = iota + 1 // 실행 순서와 수신 메시지 선택 (RunRandom)
+	streamNemesis             // 네모시스의 장애 선택 (Chaos.Rand)
+	streamIDs                 // 메시지 ID
+)
+
+// Seed 실행의 루트 시드 지정
+//
+// 실행 순서, 네모시스, 메시지 ID 등 실행 중 모든 무작위 선택은 루트 시드 하나에서 갈라져 나오므로
+// 같은 시드로 다시 실행하면 같은 결과를 얻는다. 지정하지 않으면 실행마다 현재 시각으로 정하며,
+// 어느 쪽이든 Result.Seed 와 매니저 로그("Simulation: Seed ...")로 보고된다.
+func (s *Scenario) Seed(seed int64) *Scenario {
+	s.seed = &seed
+	return s
+}
+
+// rootSeed 지정한 루트 시드 (없으면 현재 시각)
+func (s *Scenario) rootSeed() int64 {
+	if s.seed != nil {
+		return *s.seed
+	}
+	return time.Now().UnixNano()
+}
+
+// derive 루트 시드에서 흐름 stream 의 시드 유도 (splitmix64)
+func derive(seed int64, stream int) int64 {
+	z := uint64(seed) + uint64(stream)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
+
+// seededIDs 시드에서 만든 메시지 ID ("송신자-16진수")
+func seededIDs(seed int64) vc.IDGenerator {
+	var mu sync.Mutex
+	rng := rand.New(rand.NewSource(derive(seed, streamIDs)))
+	return func(from int) string {
+		mu.Lock()
+		defer mu.Unlock()
+		return fmt.Sprintf("%d-%016x", from, rng.Uint64())
+	}
+}
@@ -0,0 +1,73 @@
+package sim
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// messageIDs 실행 중 보낸 메시지 ID (기록 순서)
+func messageIDs(res *Result) []string {
+	var ids []string
+	for _, e := range res.Events {
+		if e.Kind == vc.EventSend {
+			ids = append(ids, e.MessageID)
+		}
+	}
+	return ids
+}
+
+func TestRootSeedReproducesMessageIDs(t *testing.T) {
+	run := func(seed int64) *Result {
+		res, err := race().Seed(seed).Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	first, second := run(9), run(9)
+	if first.Seed != 9 || !reflect.DeepEqual(messageIDs(first), messageIDs(second)) {
+		t.Fatalf("seed %d gave IDs %v then %v", first.Seed, messageIDs(first), messageIDs(second))
+	}
+	if ids := messageIDs(first); len(ids) != 2 || !strings.HasPrefix(ids[0], "0-") || ids[0] == ids[1] {
+		t.Fatalf("message IDs = %v", ids)
+	}
+	if reflect.DeepEqual(messageIDs(first), messageIDs(run(10))) {
+		t.Fatal("different seeds gave the same message IDs")
+	}
+}
+
+func TestSeedIsReportedInLog(t *testing.T) {
+	logs := &lines{}
+	res, err := New(1, vc.WithLogger(logs)).Local(0, "x").Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Seed == 0 || !logs.contains("Simulation: Seed") {
+		t.Fatalf("seed %d, logs %v", res.Seed, logs.all)
+	}
+	again, err := race().RunRandom(res.Seed)
+	if err != nil || again.Seed != res.Seed {
+		t.Fatalf("RunRandom(%d) reported seed %d (%v)", res.Seed, again.Seed, err)
+	}
+}
+
+// lines 로그를 모으는 Logger
+type lines struct {
+	all []string
+}
+
+func (l *lines) Printf(format string, v ...interface{}) {
+	l.all = append(l.all, format)
+}
+
+func (l *lines) contains(substr string) bool {
+	for _, line := range l.all {
+		if strings.Contains(line, substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,466 @@
+// Package sim 프로세스 간 메시지 송수신 시나리오를 선언적으로 기술하고 실행
+//
+//	res, err := sim.New(3).Send(0, 1, "a").Recv(1).Send(1, 0, "b").Recv(0).Run()
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+var (
+	// ErrEmptyMailbox Recv 단계에서 받을 메시지가 없는 경우
+	ErrEmptyMailbox = errors.New("sim: mailbox is empty")
+	// ErrNoLink 토폴로지에 없는 링크로 보내려는 경우
+	ErrNoLink = errors.New("sim: no link between processes")
+)
+
+// Op 시나리오 단계 종류
+type Op int
+
+const (
+	// OpSend From 이 To 에게 Event 전송
+	OpSend Op = iota
+	// OpRecv To 가 메일박스에서 메시지 한 건 수신
+	OpRecv
+	// OpLocal From 의 내부 이벤트 Event
+	OpLocal
+	// OpDrop From 이 To 에게 Event 를 보내지만 메시지가 유실됨 (송신 이벤트만 발생)
+	OpDrop
+	// OpCrash From 이 멈춤 (이후 From 의 송신/수신/로컬 단계는 건너뜀)
+	OpCrash
+	// OpRestart 멈춘 From 을 다시 실행 (시계는 멈춘 시점 그대로)
+	OpRestart
+	// OpPartition 네트워크를 Groups 로 분할 (그룹 사이의 메시지는 유실)
+	OpPartition
+	// OpHeal 네트워크 분할 해제
+	OpHeal
+	// OpSkew From 의 벽시계를 Skew 만큼 어긋나게 하고 Drift 비율로 벌어지게 함 (이후 From 이 보내는 메시지의 Timestamp)
+	OpSkew
+)
+
+// String 종류 이름 반환
+func (o Op) String() string {
+	switch o {
+	case OpSend:
+		return "send"
+	case OpRecv:
+		return "recv"
+	case OpLocal:
+		return "local"
+	case OpDrop:
+		return "drop"
+	case OpCrash:
+		return "crash"
+	case OpRestart:
+		return "restart"
+	case OpPartition:
+		return "partition"
+	case OpHeal:
+		return "heal"
+	case OpSkew:
+		return "skew"
+	default:
+		return fmt.Sprintf("Op(%d)", int(o))
+	}
+}
+
+// Step 시나리오의 한 단계
+type Step struct {
+	Op     Op            // 단계 종류
+	From   int           // 보내는 프로세스 (OpSend, OpDrop) / 대상 프로세스 (OpLocal, OpCrash, OpRestart, OpSkew)
+	To     int           // 받는 프로세스 (OpSend, OpDrop, OpRecv)
+	Event  string        // 메시지 내용 / 로컬 이벤트 이름
+	Groups [][]int       // 분할된 프로세스 그룹 (OpPartition)
+	Of     *int          // 받을 메시지를 보낸 단계 번호 (OpRecv, nil 이면 먼저 도착한 메시지)
+	Skew   time.Duration // 벽시계 어긋남 (OpSkew, Skew 와 Drift 가 0 이면 되돌림)
+	Drift  float64       // 경과 시간 1초마다 더해지는 어긋남 비율 (OpSkew)
+}
+
+// String 단계 요약
+func (s Step) String() string {
+	switch s.Op {
+	case OpSend:
+		return fmt.Sprintf("send %d->%d %q", s.From, s.To, s.Event)
+	case OpRecv:
+		if s.Of != nil {
+			return fmt.Sprintf("recv %d of step %d", s.To, *s.Of)
+		}
+		return fmt.Sprintf("recv %d", s.To)
+	case OpLocal:
+		return fmt.Sprintf("local %d %q", s.From, s.Event)
+	case OpDrop:
+		return fmt.Sprintf("drop %d->%d %q", s.From, s.To, s.Event)
+	case OpCrash, OpRestart:
+		return fmt.Sprintf("%v %d", s.Op, s.From)
+	case OpPartition:
+		return fmt.Sprintf("partition %v", s.Groups)
+	case OpSkew:
+		if s.Drift != 0 {
+			return fmt.Sprintf("skew %d %v drift %g", s.From, s.Skew, s.Drift)
+		}
+		return fmt.Sprintf("skew %d %v", s.From, s.Skew)
+	default:
+		return s.Op.String()
+	}
+}
+
+// Scenario n 개 프로세스에서 순서대로 실행할 단계 목록
+type Scenario struct {
+	N     int      // 프로세스 수
+	Steps []Step   // 실행할 단계 (순서대로)
+	Links [][2]int // 메시지를 보낼 수 있는 링크 (양방향, 비어 있으면 완전 연결)
+
+	opts     []vc.ManagerOption
+	seed     *int64               // 루트 시드 (nil 이면 실행마다 현재 시각)
+	nemesis  Nemesis              // 단계 사이에 장애를 주입하는 주체
+	points   map[int]bool         // 네모시스를 호출할 위치 (nil 이면 모든 단계 직전)
+	delivery vc.DeliveryAlgorithm // 파일로 옮길 인과 전달 알고리즘
+	mode     vc.ClockMode         // 파일로 옮길 시계 유지 단위
+}
+
+// Result 시나리오 실행 결과
+type Result struct {
+	Manager   *vc.VectorClockManager // 실행에 사용한 매니저
+	Processes []*vc.Process          // 프로세스 (인덱스 = ID)
+	Clocks    [][]int                // 실행 후 프로세스별 Vector Clock
+	Events    []vc.Event             // 실행 중 기록된 이벤트
+	Dropped   []int                  // 메시지가 유실된 단계 (OpDrop, 분할/멈춤으로 유실된 송신)
+	Skipped   []int                  // 멈춘 프로세스라서 건너뛴 단계
+	Order     []int                  // 실제로 실행한 단계 순서
+	Faults    []Fault                // 네모시스가 주입한 장애
+	Seed      int64                  // 실행의 루트 시드 (Scenario.Seed 로 재현)
+}
+
+// New n 개 프로세스로 구성된 빈 시나리오 생성 (opts 는 매니저 옵션)
+func New(n int, opts ...vc.ManagerOption) *Scenario {
+	return &Scenario{N: n, opts: opts}
+}
+
+// Send from 이 to 에게 event 전송하는 단계 추가
+func (s *Scenario) Send(from, to int, event string) *Scenario {
+	s.Steps = append(s.Steps, Step{Op: OpSend, From: from, To: to, Event: event})
+	return s
+}
+
+// Recv id 가 메일박스에서 메시지 한 건을 받는 단계 추가
+func (s *Scenario) Recv(id int) *Scenario {
+	s.Steps = append(s.Steps, Step{Op: OpRecv, To: id})
+	return s
+}
+
+// RecvOf id 가 step 번째 단계에서 보낸 메시지를 받는 단계 추가 (먼저 도착한 메시지는 보관해 둠)
+func (s *Scenario) RecvOf(id, step int) *Scenario {
+	s.Steps = append(s.Steps, Step{Op: OpRecv, To: id, Of: &step})
+	return s
+}
+
+// Local id 의 내부 이벤트 단계 추가
+func (s *Scenario) Local(id int, name string) *Scenario {
+	s.Steps = append(s.Steps, Step{Op: OpLocal, From: id, Event: name})
+	return s
+}
+
+// Link a 와 b 사이의 양방향 링크 추가 (링크를 하나라도 추가하면 링크가 없는 쌍으로는 보낼 수 없음)
+func (s *Scenario) Link(a, b int) *Scenario {
+	s.Links = append(s.Links, [2]int{a, b})
+	return s
+}
+
+// Drop from 이 to 에게 보낸 event 가 유실되는 단계 추가
+func (s *Scenario) Drop(from, to int, event string) *Scenario {
+	s.Steps = append(s.Steps, Step{Op: OpDrop, From: from, To: to, Event: event})
+	return s
+}
+
+// Crash id 가 멈추는 단계 추가
+func (s *Scenario) Crash(id int) *Scenario {
+	s.Steps = append(s.Steps, Step{Op: OpCrash, From: id})
+	return s
+}
+
+// Restart 멈춘 id 를 다시 실행하는 단계 추가
+func (s *Scenario) Restart(id int) *Scenario {
+	s.Steps = append(s.Steps, Step{Op: OpRestart, From: id})
+	return s
+}
+
+// Partition 네트워크를 groups 로 분할하는 단계 추가 (어느 그룹에도 없는 프로세스는 각자 고립)
+func (s *Scenario) Partition(groups ...[]int) *Scenario {
+	s.Steps = append(s.Steps, Step{Op: OpPartition, Groups: groups})
+	return s
+}
+
+// Heal 네트워크 분할을 해제하는 단계 추가
+func (s *Scenario) Heal() *Scenario {
+	s.Steps = append(s.Steps, Step{Op: OpHeal})
+	return s
+}
+
+// Skew id 의 벽시계를 d 만큼 어긋나게 하는 단계 추가 (0 이면 되돌림)
+func (s *Scenario) Skew(id int, d time.Duration) *Scenario {
+	s.Steps = append(s.Steps, Step{Op: OpSkew, From: id, Skew: d})
+	return s
+}
+
+// Drift id 의 벽시계를 offset 만큼 어긋나게 하고 경과 시간 1초마다 rate 초씩 더 벌어지게 하는 단계 추가
+func (s *Scenario) Drift(id int, offset time.Duration, rate float64) *Scenario {
+	s.Steps = append(s.Steps, Step{Op: OpSkew, From: id, Skew: offset, Drift: rate})
+	return s
+}
+
+// runState 실행 중 장애 상태
+type runState struct {
+	procs     []*vc.Process
+	links     map[[2]int]bool
+	crashed   map[int]bool
+	partition map[int]int // 프로세스 -> 그룹 번호 (nil 이면 분할 없음)
+	lost      chan vc.Message
+	sent      map[int]string       // 단계 번호 -> 보낸 메시지 ID
+	inbox     map[int][]vc.Message // 다른 메시지를 기다리느라 메일박스에서 꺼내 둔 메시지
+	choose    chooser              // 실행 순서 선택 (nil 이면 단계 순서 그대로)
+	nemesis   Nemesis
+	points    map[int]bool
+	rng       *rand.Rand // 네모시스의 난수 (루트 시드에서 유도)
+}
+
+// chooser 선택지 n 개 중 하나의 번호를 고름
+type chooser func(n int) int
+
+// Run 새 매니저와 프로세스를 만들어 단계를 순서대로 실행
+//
+// 메일박스는 모든 송신을 담을 수 있는 크기로 만들어 송신이 막히지 않으며,
+// 받을 메시지가 없는 Recv 단계는 기다리지 않고 ErrEmptyMailbox 로 실패한다.
+// 유실된 메시지는 송신 이벤트로 시계만 증가시키고 어디에도 전달되지 않는다.
+// 실패해도 그때까지의 결과를 함께 반환한다.
+func (s *Scenario) Run() (*Result, error) {
+	return s.execute(s.rootSeed(), nil)
+}
+
+// execute 루트 시드 seed 로 시나리오 실행 (choose 가 nil 이 아니면 실행 순서와 수신 메시지를 choose 로 고름)
+func (s *Scenario) execute(seed int64, choose chooser) (*Result, error) {
+	st := &runState{
+		rng:     rand.New(rand.NewSource(derive(seed, streamNemesis))),
+		crashed: make(map[int]bool),
+		lost:    make(chan vc.Message, len(s.Steps)),
+		sent:    make(map[int]string),
+		inbox:   make(map[int][]vc.Message),
+		choose:  choose,
+		nemesis: s.nemesis,
+		points:  s.points,
+	}
+	base := []vc.ManagerOption{vc.WithIDGenerator(seededIDs(seed))}
+	mgr := vc.NewVectorClockManager(s.N, append(base, s.opts...)...)
+	mgr.Logger().Printf("Simulation: Seed %d\n", seed)
+	res := &Result{Manager: mgr, Seed: seed}
+	for i := 0; i < s.N; i++ {
+		res.Processes = append(res.Processes, vc.NewProcess(i, mgr, vc.WithMailboxSize(len(s.Steps))))
+	}
+	st.procs = res.Processes
+	if len(s.Links) > 0 {
+		st.links = make(map[[2]int]bool, 2*len(s.Links))
+		for _, l := range s.Links {
+			st.links[l] = true
+			st.links[[2]int{l[1], l[0]}] = true
+		}
+	}
+
+	var err error
+	if choose == nil {
+		for i, step := range s.Steps {
+			if err = st.apply(res, i, step); err != nil {
+				break
+			}
+		}
+	} else {
+		err = st.interleave(res, s.Steps)
+	}
+
+	for _, p := range res.Processes {
+		res.Clocks = append(res.Clocks, mgr.GetClockCopy(p.ID))
+	}
+	res.Events = mgr.Events()
+	return res, err
+}
+
+// apply 단계 하나를 실행하고 결과 기록
+func (st *runState) apply(res *Result, i int, step Step) error {
+	if err := st.provoke(res, i, step); err != nil {
+		return err
+	}
+	out, err := st.run(i, step)
+	res.Order = append(res.Order, i)
+	if err != nil {
+		return fmt.Errorf("sim: step %d (%v): %w", i, step, err)
+	}
+	switch out {
+	case outcomeDropped:
+		res.Dropped = append(res.Dropped, i)
+	case outcomeSkipped:
+		res.Skipped = append(res.Skipped, i)
+	}
+	return nil
+}
+
+// outcome 단계 실행 결과
+type outcome int
+
+const (
+	outcomeDone outcome = iota
+	outcomeDropped
+	outcomeSkipped
+)
+
+// run 단계 하나 실행
+func (st *runState) run(i int, step Step) (outcome, error) {
+	check := func(ids ...int) error {
+		for _, id := range ids {
+			if id < 0 || id >= len(st.procs) {
+				return fmt.Errorf("%w: %d", vc.ErrUnknownProcess, id)
+			}
+		}
+		return nil
+	}
+
+	switch step.Op {
+	case OpSend, OpDrop:
+		if err := check(step.From, step.To); err != nil {
+			return outcomeDone, err
+		}
+		if st.links != nil && !st.links[[2]int{step.From, step.To}] {
+			return outcomeDone, fmt.Errorf("%w: %d -> %d", ErrNoLink, step.From, step.To)
+		}
+		if st.crashed[step.From] {
+			return outcomeSkipped, nil
+		}
+		p := st.procs[step.From]
+		out, err := outcomeDone, error(nil)
+		if step.Op == OpDrop || st.crashed[step.To] || !st.connected(step.From, step.To) {
+			out, err = outcomeDropped, p.SendMessage(step.To, step.Event, st.lost, false)
+		} else {
+			err = p.Send(step.To, step.Event)
+		}
+		if err == nil {
+			st.sent[i] = p.LastMessageID()
+		}
+		return out, err
+	case OpRecv:
+		if err := check(step.To); err != nil {
+			return outcomeDone, err
+		}
+		if st.crashed[step.To] {
+			return outcomeSkipped, nil
+		}
+		msg, err := st.take(step)
+		if err != nil {
+			return outcomeDone, err
+		}
+		ch := make(chan vc.Message, 1)
+		ch <- msg
+		return outcomeDone, st.procs[step.To].ReceiveMessages(ch)
+	case OpLocal:
+		if err := check(step.From); err != nil {
+			return outcomeDone, err
+		}
+		if st.crashed[step.From] {
+			return outcomeSkipped, nil
+		}
+		st.procs[step.From].LocalEvent(step.Event)
+		return outcomeDone, nil
+	case OpCrash, OpRestart:
+		if err := check(step.From); err != nil {
+			return outcomeDone, err
+		}
+		st.crashed[step.From] = step.Op == OpCrash
+		return outcomeDone, nil
+	case OpPartition:
+		st.partition = make(map[int]int)
+		for g, members := range step.Groups {
+			if err := check(members...); err != nil {
+				return outcomeDone, err
+			}
+			for _, id := range members {
+				st.partition[id] = g
+			}
+		}
+		return outcomeDone, nil
+	case OpHeal:
+		st.partition = nil
+		return outcomeDone, nil
+	case OpSkew:
+		if err := check(step.From); err != nil {
+			return outcomeDone, err
+		}
+		st.procs[step.From].SetClockSkew(step.Skew, step.Drift)
+		return outcomeDone, nil
+	default:
+		return outcomeDone, fmt.Errorf("sim: unknown op %v", step.Op)
+	}
+}
+
+// take 수신 단계에서 받을 메시지를 메일박스(또는 꺼내 둔 메시지)에서 꺼냄
+func (st *runState) take(step Step) (vc.Message, error) {
+	p := st.procs[step.To]
+	if step.Of == nil && st.choose != nil {
+		// 도착한 메시지 중 하나를 골라 받음 (전달 순서 탐색)
+		for len(p.MessageCh) > 0 {
+			st.inbox[p.ID] = append(st.inbox[p.ID], <-p.MessageCh)
+		}
+		inbox := st.inbox[p.ID]
+		if len(inbox) == 0 {
+			return vc.Message{}, ErrEmptyMailbox
+		}
+		k := st.choose(len(inbox))
+		msg := inbox[k]
+		st.inbox[p.ID] = append(inbox[:k:k], inbox[k+1:]...)
+		return msg, nil
+	}
+	if step.Of == nil {
+		if inbox := st.inbox[p.ID]; len(inbox) > 0 {
+			st.inbox[p.ID] = inbox[1:]
+			return inbox[0], nil
+		}
+		if len(p.MessageCh) == 0 {
+			return vc.Message{}, ErrEmptyMailbox
+		}
+		return <-p.MessageCh, nil
+	}
+
+	id, ok := st.sent[*step.Of]
+	if !ok {
+		return vc.Message{}, fmt.Errorf("sim: step %d sent no message", *step.Of)
+	}
+	for len(p.MessageCh) > 0 {
+		st.inbox[p.ID] = append(st.inbox[p.ID], <-p.MessageCh)
+	}
+	inbox := st.inbox[p.ID]
+	for k, msg := range inbox {
+		if msg.MessageID == id {
+			st.inbox[p.ID] = append(inbox[:k:k], inbox[k+1:]...)
+			return msg, nil
+		}
+	}
+	return vc.Message{}, fmt.Errorf("%w: message of step %d", ErrEmptyMailbox, *step.Of)
+}
+
+// connected 현재 분할 상태에서 a 와 b 가 통신할 수 있는지 여부
+func (st *runState) connected(a, b int) bool {
+	if st.partition == nil {
+		return true
+	}
+	ga, okA := st.partition[a]
+	gb, okB := st.partition[b]
+	return okA && okB && ga == gb
+}
+
+// Clock 실행 후 프로세스 id 의 Vector Clock
+func (r *Result) Clock(id int) []int {
+	if id < 0 || id >= len(r.Clocks) {
+		return nil
+	}
+	return r.Clocks[id]
+}
@@ -0,0 +1,82 @@
+package sim
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestScenarioRunsStepsInOrder(t *testing.T) {
+	res, err := New(2, vc.WithLogger(nil)).
+		Local(0, "start").
+		Send(0, 1, "a").
+		Recv(1).
+		Send(1, 0, "b").
+		Recv(0).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := res.Clock(0); !reflect.DeepEqual(got, []int{3, 2}) {
+		t.Fatalf("Clock(0) = %v, want [3 2]", got)
+	}
+	if got := res.Clock(1); !reflect.DeepEqual(got, []int{2, 2}) {
+		t.Fatalf("Clock(1) = %v, want [2 2]", got)
+	}
+	if res.Clock(5) != nil {
+		t.Fatal("Clock of an unknown process is not nil")
+	}
+	if len(res.Events) != 5 {
+		t.Fatalf("recorded %d events, want 5", len(res.Events))
+	}
+}
+
+func TestRecvOnEmptyMailboxFails(t *testing.T) {
+	res, err := New(2, vc.WithLogger(nil)).
+		Send(0, 1, "a").
+		Recv(0).
+		Run()
+	if !errors.Is(err, ErrEmptyMailbox) {
+		t.Fatalf("Run = %v, want ErrEmptyMailbox", err)
+	}
+	// 실패 전까지의 결과는 남아 있음
+	if got := res.Clock(0); !reflect.DeepEqual(got, []int{1, 0}) {
+		t.Fatalf("Clock(0) = %v, want [1 0]", got)
+	}
+	if _, err := New(1, vc.WithLogger(nil)).Send(3, 0, "x").Run(); !errors.Is(err, vc.ErrUnknownProcess) {
+		t.Fatalf("Run with unknown sender = %v, want ErrUnknownProcess", err)
+	}
+}
+
+func TestRecvOfTakesMessageOfStep(t *testing.T) {
+	res, err := New(2, vc.WithLogger(nil)).
+		Send(0, 1, "a").
+		Send(0, 1, "b").
+		RecvOf(1, 1).
+		Recv(1).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range res.Events {
+		if e.Kind == vc.EventReceive && e.Process == 1 {
+			got = append(got, e.Name)
+		}
+	}
+	if want := []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("process 1 received %v, want %v", got, want)
+	}
+}
+
+func TestRecvOfDroppedMessage(t *testing.T) {
+	_, err := New(2, vc.WithLogger(nil)).
+		Drop(0, 1, "lost").
+		RecvOf(1, 0).
+		Run()
+	if err == nil {
+		t.Fatal("received a dropped message")
+	}
+}
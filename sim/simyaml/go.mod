@@ -0,0 +1,10 @@
+module github.com/seoyhaein/vectorclock/sim/simyaml
+
+go 1.22
+
+require (
+	github.com/seoyhaein/vectorclock v0.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+replace github.com/seoyhaein/vectorclock => ../..
@@ -0,0 +1,38 @@
+// Package simyaml 시뮬레이션 시나리오 파일의 YAML 형식 ("yaml", "yml")
+//
+//	import _ "github.com/seoyhaein/vectorclock/sim/simyaml"
+//
+//	s, err := sim.LoadFile("partition.yaml")
+//
+// 가져오기만 하면 sim.RegisterFormat 으로 등록된다. YAML 라이브러리를 루트 모듈에 두지 않도록 따로 둔 모듈이다.
+package simyaml
+
+import (
+	"io"
+
+	"github.com/seoyhaein/vectorclock/sim"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	f := sim.Format{Decode: decode, Encode: encode}
+	sim.RegisterFormat("yaml", f)
+	sim.RegisterFormat("yml", f)
+}
+
+// decode 모르는 필드를 거부하는 YAML 디코더
+func decode(r io.Reader, v any) error {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	return dec.Decode(v)
+}
+
+// encode 2칸 들여쓴 YAML 인코더
+func encode(w io.Writer, v any) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
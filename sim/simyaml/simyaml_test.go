@@ -0,0 +1,60 @@
+package simyaml
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+	"github.com/seoyhaein/vectorclock/sim"
+)
+
+const pingYAML = `
+processes: 2
+delivery: SES
+schedule:
+  - {op: send, from: 0, to: 1, event: ping}
+  - {op: recv, process: 1}
+`
+
+func TestLoadRegistersYAML(t *testing.T) {
+	for _, format := range []string{"yaml", "yml"} {
+		s, err := sim.Load(strings.NewReader(pingYAML), format, vc.WithLogger(nil))
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		res, err := s.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res.Clock(1); !reflect.DeepEqual(got, []int{1, 1}) {
+			t.Fatalf("%s: Clock(1) = %v, want [1 1]", format, got)
+		}
+	}
+}
+
+func TestDecodeRejectsUnknownFields(t *testing.T) {
+	if _, err := sim.Load(strings.NewReader("processes: 1\nschedule: []\nbogus: 1\n"), "yaml"); err == nil {
+		t.Fatal("Load accepted an unknown field")
+	}
+}
+
+func TestWriteRoundTripsYAML(t *testing.T) {
+	s, err := sim.Load(strings.NewReader(pingYAML), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := s.File()
+	var buf bytes.Buffer
+	if err := f.Write(&buf, "yml"); err != nil {
+		t.Fatal(err)
+	}
+	again, err := sim.Load(&buf, "yaml")
+	if err != nil {
+		t.Fatalf("written YAML does not load: %v\n%s", err, buf.String())
+	}
+	if got := again.File(); !reflect.DeepEqual(got, f) {
+		t.Fatalf("round trip = %+v, want %+v", got, f)
+	}
+}
@@ -0,0 +1,42 @@
+package sim
+
+import (
+	"testing"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestSkewedSenderInvertsTimestamps(t *testing.T) {
+	res, err := New(2, vc.WithLogger(nil)).
+		Skew(0, time.Hour).
+		Send(0, 1, "a").
+		Recv(1).
+		Send(1, 0, "b").
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	inv := TimestampInversions(res.Events)
+	if len(inv) != 1 {
+		t.Fatalf("inversions = %v, want one", inv)
+	}
+	if inv[0].Before.Name != "a" || inv[0].After.Name != "b" {
+		t.Fatalf("inversion = %v, want a before b", inv[0])
+	}
+}
+
+func TestDriftWithoutOffsetKeepsOrder(t *testing.T) {
+	res, err := New(2, vc.WithLogger(nil)).
+		Drift(0, 0, 0.001).
+		Send(0, 1, "a").
+		Recv(1).
+		Send(1, 0, "b").
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv := TimestampInversions(res.Events); len(inv) != 0 {
+		t.Fatalf("inversions = %v, want none", inv)
+	}
+}
@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// AncestorsOf 저장소 ID id 인 이벤트보다 인과적으로 앞선 이벤트 (a -> 이벤트, ID 순서)
+//
+// 시계의 모든 항목이 이벤트의 시계 이하인 기록만 시계 인덱스로 골라 온 뒤 시계를 비교하므로,
+// 저장소 전체를 읽지 않는다. 같은 시계 도메인의 이벤트끼리만 비교한다.
+func AncestorsOf(ctx context.Context, s EventStore, id int64) ([]Record, error) {
+	return causal(ctx, s, id, vc.Before, func(target Record, f *Filter) {
+		f.ClockMax = clockBound(target.Clock)
+	})
+}
+
+// DescendantsOf 저장소 ID id 인 이벤트보다 인과적으로 뒤인 이벤트 (이벤트 -> b, ID 순서)
+//
+// 시계의 모든 항목이 이벤트의 시계 이상인 기록만 시계 인덱스로 골라 와 비교한다.
+func DescendantsOf(ctx context.Context, s EventStore, id int64) ([]Record, error) {
+	return causal(ctx, s, id, vc.After, func(target Record, f *Filter) {
+		f.ClockMin = clockBound(target.Clock)
+	})
+}
+
+// ConcurrentWith 저장소 ID id 인 이벤트와 인과 관계가 없는 이벤트 (ID 순서)
+//
+// 이벤트에서 이어지지 않은 기록은 이벤트가 일어난 프로세스의 항목이 이벤트의 값 이하이므로,
+// 그 항목으로만 골라 온 뒤 시계를 비교한다.
+func ConcurrentWith(ctx context.Context, s EventStore, id int64) ([]Record, error) {
+	return causal(ctx, s, id, vc.Concurrent, func(target Record, f *Filter) {
+		if target.Process >= 0 && target.Process < len(target.Clock) {
+			f.ClockMax = map[int]int{target.Process: target.Clock[target.Process]}
+		}
+	})
+}
+
+// Relation 두 기록 a, b 의 인과 관계 (a 가 b 보다 앞서면 Before)
+//
+// 시계가 같은 두 기록은 같은 노드, 같은 프로세스이면(병합할 것이 없던 수신) 기록 순서로 정하고,
+// 아니면 동시로 본다. 도메인이 다른 기록은 비교할 수 없으므로 동시로 본다.
+func Relation(a, b Record) vc.Ordering {
+	if a.Domain != b.Domain {
+		return vc.Concurrent
+	}
+	o := vc.Compare(a.Clock, b.Clock)
+	if o != vc.Equal {
+		return o
+	}
+	switch {
+	case a.ID == b.ID:
+		return vc.Equal
+	case a.Node == b.Node && a.Process == b.Process && a.Seq < b.Seq:
+		return vc.Before
+	case a.Node == b.Node && a.Process == b.Process && a.Seq > b.Seq:
+		return vc.After
+	default:
+		return vc.Concurrent
+	}
+}
+
+// causal 이벤트 id 와 관계가 rel 인 기록 (narrow 로 시계 인덱스 조건을 붙여 후보를 줄임)
+func causal(ctx context.Context, s EventStore, id int64, rel vc.Ordering, narrow func(Record, *Filter)) ([]Record, error) {
+	target, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	domain := target.Domain
+	f := Filter{Domain: &domain}
+	narrow(target, &f)
+
+	var records []Record
+	err = s.Scan(ctx, f, func(r Record) error {
+		if Relation(r, target) == rel {
+			records = append(records, r)
+		}
+		return nil
+	})
+	return records, err
+}
+
+// clockBound 시계의 모든 항목 (항목 -> 값)
+func clockBound(clock []int) map[int]int {
+	bound := make(map[int]int, len(clock))
+	for i, v := range clock {
+		bound[i] = v
+	}
+	return bound
+}
@@ -0,0 +1,23 @@
+package store
+
+import (
+	"database/sql"
+	"strconv"
+)
+
+// postgresDialect Postgres SQL
+var postgresDialect = dialect{
+	name:        "postgres",
+	placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+	idColumn:    "BIGSERIAL PRIMARY KEY",
+	schemaLock:  "SELECT pg_advisory_xact_lock(7263857000125)", // 스키마 생성 전용 임의 키
+}
+
+// NewPostgres 이미 연 Postgres 데이터베이스 db 로 이벤트 저장소 생성 (테이블이 없으면 생성, pgx stdlib 등 어떤 드라이버든)
+//
+// 여러 노드가 각자 이름(Append 의 node)으로 같은 데이터베이스에 이벤트를 쓰면, 한곳에 모인 기록으로
+// 노드 사이의 인과 관계를 분석할 수 있다. 같은 노드의 같은 Seq 는 한 번만 저장된다.
+// 드라이버를 포함해 연결 문자열로 바로 여는 것은 store/postgres 모듈의 Open 이다.
+func NewPostgres(db *sql.DB) (*SQLStore, error) {
+	return newSQLStore(db, postgresDialect)
+}
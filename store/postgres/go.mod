@@ -0,0 +1,10 @@
+module github.com/seoyhaein/vectorclock/store/postgres
+
+go 1.22
+
+require (
+	github.com/lib/pq v1.10.9
+	github.com/seoyhaein/vectorclock v0.0.0
+)
+
+replace github.com/seoyhaein/vectorclock => ../..
@@ -0,0 +1,28 @@
+// Package postgres lib/pq 드라이버로 여는 store.SQLStore
+//
+//	s, err := postgres.Open("postgres://user:pass@db:5432/traces?sslmode=disable")
+//	defer s.Close()
+//
+// 드라이버를 루트 모듈에 두지 않도록 따로 둔 모듈이다 (다른 드라이버로 연 *sql.DB 는 store.NewPostgres).
+package postgres
+
+import (
+	"database/sql"
+
+	_ "github.com/lib/pq" // database/sql 드라이버 "postgres"
+	"github.com/seoyhaein/vectorclock/store"
+)
+
+// Open 연결 문자열 dsn 의 Postgres 이벤트 저장소 열기 (테이블이 없으면 생성)
+func Open(dsn string) (*store.SQLStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	s, err := store.NewPostgres(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+	"github.com/seoyhaein/vectorclock/store"
+)
+
+// open VECTORCLOCK_POSTGRES_DSN 의 데이터베이스에 연결 (없으면 건너뜀)
+func open(t *testing.T) *store.SQLStore {
+	t.Helper()
+	dsn := os.Getenv("VECTORCLOCK_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("VECTORCLOCK_POSTGRES_DSN not set")
+	}
+	s, err := Open(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestAppendAndQuery(t *testing.T) {
+	ctx := context.Background()
+	s := open(t)
+	vcm := vc.NewVectorClockManager(2, vc.WithLogger(nil))
+	a := vc.NewProcess(0, vcm)
+	b := vc.NewProcess(1, vcm)
+	if err := a.Send(1, "m"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ReceiveMessages(b.MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	events := vcm.Events()
+
+	// 같은 데이터베이스를 여러 번 실행해도 겹치지 않도록 노드 이름을 새로 만듦
+	node := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	for i := 0; i < 2; i++ {
+		if err := s.Append(ctx, node, events); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := store.Query(ctx, s, store.Filter{Nodes: []string{node}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(events) {
+		t.Fatalf("stored %d records, want %d", len(got), len(events))
+	}
+	for i, r := range got {
+		if r.Seq != events[i].Seq || !reflect.DeepEqual(r.Clock, events[i].Clock) {
+			t.Fatalf("record %d = %+v, want %+v", i, r, events[i])
+		}
+	}
+	recv, err := store.Query(ctx, s, store.Filter{Nodes: []string{node}, ClockMin: map[int]int{1: 1}})
+	if err != nil || len(recv) != 1 || recv[0].Kind != vc.EventReceive {
+		t.Fatalf("clock query = %+v, %v", recv, err)
+	}
+}
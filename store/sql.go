@@ -0,0 +1,323 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// dialect 데이터베이스별 SQL 차이
+type dialect struct {
+	name        string
+	placeholder func(n int) string // n 번째(1 부터) 인자 자리
+	idColumn    string             // 자동 증가 기본 키 열 정의
+	schemaLock  string             // 스키마를 만드는 동안 다른 노드를 막는 문 ("" 이면 없음)
+}
+
+// SQLStore database/sql 기반 EventStore (NewSQLite, NewPostgres, 드라이버를 포함한 store/sqlite, store/postgres 모듈)
+//
+// 이벤트는 events 테이블에, 시계 항목은 (event_id, entry, value) 행으로 event_clocks 테이블에 저장하며
+// process, message_id, (entry, value) 에 인덱스가 있어 프로세스, 메시지, 시계 항목 값으로 빠르게 찾는다.
+type SQLStore struct {
+	db *sql.DB
+	d  dialect
+}
+
+// newSQLStore 스키마를 만들고 저장소 생성
+func newSQLStore(db *sql.DB, d dialect) (*SQLStore, error) {
+	s := &SQLStore{db: db, d: d}
+	if err := s.migrate(context.Background()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// migrate 테이블과 인덱스 생성 (이미 있으면 그대로)
+//
+// 여러 노드가 동시에 열어도 한 트랜잭션 안에서 schemaLock 을 잡고 만들므로 서로 부딪히지 않는다.
+func (s *SQLStore) migrate(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS events (
+	id ` + s.d.idColumn + `,
+	node TEXT NOT NULL,
+	seq BIGINT NOT NULL,
+	kind TEXT NOT NULL,
+	process INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	message_id TEXT NOT NULL,
+	from_process INTEGER NOT NULL,
+	to_process INTEGER NOT NULL,
+	domain TEXT NOT NULL,
+	clock TEXT NOT NULL,
+	own BIGINT NOT NULL,
+	msg_timestamp BIGINT NOT NULL,
+	recorded_at BIGINT NOT NULL,
+	UNIQUE (node, seq)
+)`,
+		`CREATE TABLE IF NOT EXISTS event_clocks (
+	event_id BIGINT NOT NULL REFERENCES events (id),
+	entry INTEGER NOT NULL,
+	value BIGINT NOT NULL,
+	PRIMARY KEY (event_id, entry)
+)`,
+		`CREATE INDEX IF NOT EXISTS events_process ON events (process, id)`,
+		`CREATE INDEX IF NOT EXISTS events_message_id ON events (message_id)`,
+		`CREATE INDEX IF NOT EXISTS event_clocks_entry_value ON event_clocks (entry, value)`,
+	}
+	if s.d.schemaLock != "" {
+		stmts = append([]string{s.d.schemaLock}, stmts...)
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("store: %s schema: %w", s.d.name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// Append 노드 node 의 이벤트를 트랜잭션 하나로 저장 (이미 저장된 Seq 는 건너뜀)
+func (s *SQLStore) Append(ctx context.Context, node string, events []vc.Event) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	p := s.d.placeholder
+	insertEvent, err := tx.PrepareContext(ctx, `INSERT INTO events
+	(node, seq, kind, process, name, message_id, from_process, to_process, domain, clock, own, msg_timestamp, recorded_at)
+	VALUES (`+placeholders(p, 1, 13)+`)
+	ON CONFLICT (node, seq) DO NOTHING
+	RETURNING id`)
+	if err != nil {
+		return err
+	}
+	defer insertEvent.Close()
+
+	for _, e := range events {
+		clock, err := json.Marshal(e.Clock)
+		if err != nil {
+			return err
+		}
+		own := 0
+		if e.Process >= 0 && e.Process < len(e.Clock) {
+			own = e.Clock[e.Process]
+		}
+		var id int64
+		err = insertEvent.QueryRowContext(ctx,
+			node, e.Seq, e.Kind.String(), e.Process, e.Name, e.MessageID, e.From, e.To, e.Domain,
+			string(clock), own, e.Timestamp, e.Time.UnixNano(),
+		).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue // 이미 저장됨
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.insertClock(ctx, tx, id, e.Clock); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// insertClock 이벤트 id 의 시계 항목을 문장 하나로 저장 (네트워크 왕복을 줄이기 위해 여러 행을 한 번에)
+func (s *SQLStore) insertClock(ctx context.Context, tx *sql.Tx, id int64, clock []int) error {
+	if len(clock) == 0 {
+		return nil
+	}
+	rows := make([]string, len(clock))
+	args := make([]interface{}, 0, 3*len(clock))
+	for entry, value := range clock {
+		rows[entry] = `(` + placeholders(s.d.placeholder, len(args)+1, 3) + `)`
+		args = append(args, id, entry, value)
+	}
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO event_clocks (event_id, entry, value) VALUES `+strings.Join(rows, `, `), args...)
+	return err
+}
+
+// selectColumns 기록 조회 열 (scanRecord 와 순서가 같아야 함)
+const selectColumns = `e.id, e.node, e.seq, e.kind, e.process, e.name, e.message_id,
+	e.from_process, e.to_process, e.domain, e.clock, e.msg_timestamp, e.recorded_at`
+
+// Get 저장소 ID 로 기록 조회
+func (s *SQLStore) Get(ctx context.Context, id int64) (Record, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT `+selectColumns+` FROM events e WHERE e.id = `+s.d.placeholder(1), id)
+	r, err := scanRecord(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Record{}, fmt.Errorf("%w: event %d", ErrNotFound, id)
+	}
+	return r, err
+}
+
+// Scan 조건에 맞는 기록을 ID 순서로 fn 에 넘김
+func (s *SQLStore) Scan(ctx context.Context, f Filter, fn func(Record) error) error {
+	q := &query{p: s.d.placeholder}
+	q.filter(f)
+	return s.scan(ctx, q, f.Limit, fn)
+}
+
+// scan 만든 조건으로 조회해 fn 에 넘김
+func (s *SQLStore) scan(ctx context.Context, q *query, limit int, fn func(Record) error) error {
+	stmt := `SELECT ` + selectColumns + ` FROM events e`
+	if len(q.where) > 0 {
+		stmt += ` WHERE ` + strings.Join(q.where, ` AND `)
+	}
+	stmt += ` ORDER BY e.id`
+	if limit > 0 {
+		stmt += ` LIMIT ` + strconv.Itoa(limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, stmt, q.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		r, err := scanRecord(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Close 데이터베이스 닫기
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
+
+// DB 내부 데이터베이스 (직접 SQL 로 분석할 때)
+func (s *SQLStore) DB() *sql.DB {
+	return s.db
+}
+
+// query 조회 조건과 인자
+type query struct {
+	p     func(n int) string
+	where []string
+	args  []interface{}
+}
+
+// arg 인자를 더하고 그 자리 반환
+func (q *query) arg(v interface{}) string {
+	q.args = append(q.args, v)
+	return q.p(len(q.args))
+}
+
+// in 열 col 이 values 중 하나인 조건
+func (q *query) in(col string, values []interface{}) {
+	if len(values) == 0 {
+		return
+	}
+	marks := make([]string, len(values))
+	for i, v := range values {
+		marks[i] = q.arg(v)
+	}
+	q.where = append(q.where, col+` IN (`+strings.Join(marks, `, `)+`)`)
+}
+
+// clock 시계 항목 entry 의 값이 op value 인 조건 (event_clocks 인덱스 사용)
+func (q *query) clock(entry int, op string, value int) {
+	q.where = append(q.where, `EXISTS (SELECT 1 FROM event_clocks c WHERE c.event_id = e.id AND c.entry = `+
+		q.arg(entry)+` AND c.value `+op+` `+q.arg(value)+`)`)
+}
+
+// filter Filter 를 조건으로 변환
+func (q *query) filter(f Filter) {
+	nodes := make([]interface{}, len(f.Nodes))
+	for i, n := range f.Nodes {
+		nodes[i] = n
+	}
+	q.in(`e.node`, nodes)
+	procs := make([]interface{}, len(f.Processes))
+	for i, id := range f.Processes {
+		procs[i] = id
+	}
+	q.in(`e.process`, procs)
+	kinds := make([]interface{}, len(f.Kinds))
+	for i, k := range f.Kinds {
+		kinds[i] = k.String()
+	}
+	q.in(`e.kind`, kinds)
+	if f.MessageID != "" {
+		q.where = append(q.where, `e.message_id = `+q.arg(f.MessageID))
+	}
+	if f.Domain != nil {
+		q.where = append(q.where, `e.domain = `+q.arg(*f.Domain))
+	}
+	for _, entry := range sortedEntries(f.ClockMin) {
+		q.clock(entry, `>=`, f.ClockMin[entry])
+	}
+	for _, entry := range sortedEntries(f.ClockMax) {
+		q.clock(entry, `<=`, f.ClockMax[entry])
+	}
+	if f.AfterID > 0 {
+		q.where = append(q.where, `e.id > `+q.arg(f.AfterID))
+	}
+}
+
+// sortedEntries 맵의 항목 번호 (인자 순서를 고정)
+func sortedEntries(m map[int]int) []int {
+	entries := make([]int, 0, len(m))
+	for entry := range m {
+		entries = append(entries, entry)
+	}
+	sort.Ints(entries)
+	return entries
+}
+
+// placeholders from 번째부터 n 개의 인자 자리
+func placeholders(p func(int) string, from, n int) string {
+	marks := make([]string, n)
+	for i := range marks {
+		marks[i] = p(from + i)
+	}
+	return strings.Join(marks, `, `)
+}
+
+// rowScanner *sql.Row 와 *sql.Rows 공통
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRecord 조회한 행을 Record 로
+func scanRecord(row rowScanner) (Record, error) {
+	var r Record
+	var kind, clock string
+	var nanos int64
+	err := row.Scan(&r.ID, &r.Node, &r.Seq, &kind, &r.Process, &r.Name, &r.MessageID,
+		&r.From, &r.To, &r.Domain, &clock, &r.Timestamp, &nanos)
+	if err != nil {
+		return Record{}, err
+	}
+	k, ok := vc.ParseEventKind(kind)
+	if !ok {
+		return Record{}, fmt.Errorf("store: unknown event kind %q in event %d", kind, r.ID)
+	}
+	r.Kind = k
+	if err := json.Unmarshal([]byte(clock), &r.Clock); err != nil {
+		return Record{}, fmt.Errorf("store: clock of event %d: %w", r.ID, err)
+	}
+	r.Time = time.Unix(0, nanos)
+	return r, nil
+}
@@ -0,0 +1,42 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterUsesDialectPlaceholders(t *testing.T) {
+	f := Filter{Processes: []int{1, 2}, ClockMin: map[int]int{0: 3}, AfterID: 5}
+	for _, tt := range []struct {
+		d     dialect
+		where []string
+	}{
+		{sqliteDialect, []string{
+			`e.process IN (?1, ?2)`,
+			`EXISTS (SELECT 1 FROM event_clocks c WHERE c.event_id = e.id AND c.entry = ?3 AND c.value >= ?4)`,
+			`e.id > ?5`,
+		}},
+		{postgresDialect, []string{
+			`e.process IN ($1, $2)`,
+			`EXISTS (SELECT 1 FROM event_clocks c WHERE c.event_id = e.id AND c.entry = $3 AND c.value >= $4)`,
+			`e.id > $5`,
+		}},
+	} {
+		t.Run(tt.d.name, func(t *testing.T) {
+			q := &query{p: tt.d.placeholder}
+			q.filter(f)
+			if !reflect.DeepEqual(q.where, tt.where) {
+				t.Fatalf("where = %q, want %q", q.where, tt.where)
+			}
+			if want := []interface{}{1, 2, 0, 3, int64(5)}; !reflect.DeepEqual(q.args, want) {
+				t.Fatalf("args = %v, want %v", q.args, want)
+			}
+		})
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	if got := placeholders(postgresDialect.placeholder, 4, 3); got != "$4, $5, $6" {
+		t.Fatalf("placeholders = %q", got)
+	}
+}
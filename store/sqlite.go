@@ -0,0 +1,20 @@
+package store
+
+import (
+	"database/sql"
+	"strconv"
+)
+
+// sqliteDialect SQLite SQL
+var sqliteDialect = dialect{
+	name:        "sqlite",
+	placeholder: func(n int) string { return "?" + strconv.Itoa(n) },
+	idColumn:    "INTEGER PRIMARY KEY AUTOINCREMENT",
+}
+
+// NewSQLite 이미 연 SQLite 데이터베이스 db 로 이벤트 저장소 생성 (테이블이 없으면 생성)
+//
+// 드라이버를 포함해 파일로 바로 여는 것은 store/sqlite 모듈의 Open 이다.
+func NewSQLite(db *sql.DB) (*SQLStore, error) {
+	return newSQLStore(db, sqliteDialect)
+}
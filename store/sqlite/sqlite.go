@@ -0,0 +1,33 @@
+// Package sqlite 순수 Go SQLite 드라이버(modernc.org/sqlite)로 여는 store.SQLStore
+//
+//	s, err := sqlite.Open("events.db")
+//	defer s.Close()
+//
+// 드라이버를 루트 모듈에 두지 않도록 따로 둔 모듈이다 (이미 연 *sql.DB 는 store.NewSQLite).
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/seoyhaein/vectorclock/store"
+	_ "modernc.org/sqlite" // database/sql 드라이버 "sqlite" (cgo 불필요)
+)
+
+// Open 파일 path 의 SQLite 이벤트 저장소 열기 (없으면 생성, ":memory:" 면 메모리)
+//
+// WAL 저널을 써서 저장하는 동안에도 다른 연결에서 조회할 수 있다.
+func Open(path string) (*store.SQLStore, error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)")
+	if err != nil {
+		return nil, err
+	}
+	if path == ":memory:" {
+		db.SetMaxOpenConns(1) // 연결마다 다른 메모리 데이터베이스가 되지 않도록
+	}
+	s, err := store.NewSQLite(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,195 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+	"github.com/seoyhaein/vectorclock/store"
+)
+
+// history 0 이 1 에게 보내고, 1 이 받은 뒤 2 에게 보내며, 2 는 그와 별개로 로컬 이벤트 하나
+func history(t *testing.T) []vc.Event {
+	t.Helper()
+	vcm := vc.NewVectorClockManager(3, vc.WithLogger(nil))
+	ps := make([]*vc.Process, 3)
+	for i := range ps {
+		ps[i] = vc.NewProcess(i, vcm, vc.WithMailboxSize(4))
+	}
+	ps[2].LocalEvent("solo")
+	if err := ps[0].Send(1, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ps[1].ReceiveMessages(ps[1].MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	if err := ps[1].Send(2, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ps[2].ReceiveMessages(ps[2].MessageCh); err != nil {
+		t.Fatal(err)
+	}
+	events := vcm.Events()
+	if len(events) != 5 {
+		t.Fatalf("recorded %d events, want 5", len(events))
+	}
+	return events
+}
+
+func open(t *testing.T) *store.SQLStore {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "events.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestAppendIsIdempotentPerNode(t *testing.T) {
+	ctx := context.Background()
+	s := open(t)
+	events := history(t)
+	for i := 0; i < 2; i++ {
+		if err := s.Append(ctx, "node-a", events); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Append(ctx, "node-b", events[:2]); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := store.Query(ctx, s, store.Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != len(events)+2 {
+		t.Fatalf("stored %d records, want %d", len(all), len(events)+2)
+	}
+	for i, r := range all[:len(events)] {
+		want := events[i]
+		if r.Node != "node-a" || r.Seq != want.Seq || r.Kind != want.Kind || r.Process != want.Process ||
+			r.MessageID != want.MessageID || !reflect.DeepEqual(r.Clock, want.Clock) {
+			t.Fatalf("record %d = %+v, want %+v", i, r, want)
+		}
+	}
+
+	got, err := s.Get(ctx, all[0].ID)
+	if err != nil || !reflect.DeepEqual(got, all[0]) {
+		t.Fatalf("Get = %+v, %v, want %+v", got, err, all[0])
+	}
+	if _, err := s.Get(ctx, all[len(all)-1].ID+1); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("Get of missing id: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestScanFilters(t *testing.T) {
+	ctx := context.Background()
+	s := open(t)
+	events := history(t)
+	if err := s.Append(ctx, "node-a", events); err != nil {
+		t.Fatal(err)
+	}
+	var send vc.Event
+	for _, e := range events {
+		if e.Kind == vc.EventSend && e.Process == 0 {
+			send = e
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter store.Filter
+		want   int
+	}{
+		{"process", store.Filter{Processes: []int{2}}, 2},
+		{"kind", store.Filter{Kinds: []vc.EventKind{vc.EventSend}}, 2},
+		{"message", store.Filter{MessageID: send.MessageID}, 2},
+		{"clock min", store.Filter{ClockMin: map[int]int{0: 1}}, 4},
+		{"clock range", store.Filter{ClockMin: map[int]int{0: 1}, ClockMax: map[int]int{1: 0}}, 1},
+		{"node", store.Filter{Nodes: []string{"other"}}, 0},
+		{"limit", store.Filter{Limit: 3}, 3},
+	}
+	for _, tt := range tests {
+		got, err := store.Query(ctx, s, tt.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if len(got) != tt.want {
+			t.Fatalf("%s: %d records, want %d: %+v", tt.name, len(got), tt.want, got)
+		}
+	}
+
+	// 페이지를 이어 읽으면 전체와 같음
+	var paged []store.Record
+	for after := int64(0); ; {
+		page, err := store.Query(ctx, s, store.Filter{AfterID: after, Limit: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		paged = append(paged, page...)
+		after = page[len(page)-1].ID
+	}
+	all, err := store.Query(ctx, s, store.Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(paged, all) {
+		t.Fatalf("paged records differ from a full scan")
+	}
+}
+
+func TestCausalQueries(t *testing.T) {
+	ctx := context.Background()
+	s := open(t)
+	if err := s.Append(ctx, "node-a", history(t)); err != nil {
+		t.Fatal(err)
+	}
+	all, err := store.Query(ctx, s, store.Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	find := func(process int, kind vc.EventKind) store.Record {
+		for _, r := range all {
+			if r.Process == process && r.Kind == kind {
+				return r
+			}
+		}
+		t.Fatalf("no %s event on process %d", kind, process)
+		return store.Record{}
+	}
+	first := find(0, vc.EventSend)
+	solo := find(2, vc.EventLocal)
+	last := find(2, vc.EventReceive)
+
+	descendants, err := store.DescendantsOf(ctx, s, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(descendants) != 3 || descendants[len(descendants)-1].ID != last.ID {
+		t.Fatalf("descendants of the first send = %+v", descendants)
+	}
+	ancestors, err := store.AncestorsOf(ctx, s, last.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ancestors) != 4 {
+		t.Fatalf("ancestors of the last receive = %d, want 4", len(ancestors))
+	}
+	concurrent, err := store.ConcurrentWith(ctx, s, solo.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(concurrent) != 3 {
+		t.Fatalf("events concurrent with the local event = %d, want 3", len(concurrent))
+	}
+	if got := store.Relation(first, last); got != vc.Before {
+		t.Fatalf("Relation(first send, last receive) = %v, want Before", got)
+	}
+}
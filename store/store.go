@@ -0,0 +1,63 @@
+// Package store 매니저 이벤트 기록(process.Event)을 데이터베이스에 저장하고 조회하는 이벤트 저장소
+//
+//	s, err := sqlite.Open("events.db") // store/sqlite 모듈 (이미 연 *sql.DB 는 store.NewSQLite)
+//	defer s.Close()
+//	s.Append(ctx, "node-a", mgr.Events())
+//	s.Scan(ctx, store.Filter{Processes: []int{2}}, func(r store.Record) error { ... })
+//
+// 조회는 결과를 한 건씩 넘기므로(Scan) 수백만 건의 기록도 메모리에 모두 올리지 않고 다룰 수 있다.
+package store
+
+import (
+	"context"
+	"errors"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// ErrNotFound 찾는 기록이 없는 경우
+var ErrNotFound = errors.New("store: not found")
+
+// Record 저장된 이벤트 한 건
+type Record struct {
+	ID   int64  // 저장소가 붙인 ID (저장 순서)
+	Node string // 이벤트를 저장한 노드 이름 (한 노드 안에서 Seq 가 유일)
+	vc.Event
+}
+
+// Filter 조회 조건 (비어 있는 조건은 모두 통과, 여러 조건은 모두 만족해야 함)
+type Filter struct {
+	Nodes     []string       // 노드 이름 중 하나
+	Processes []int          // 이벤트가 일어난 프로세스 ID 중 하나
+	Kinds     []vc.EventKind // 이벤트 종류 중 하나
+	MessageID string         // 메시지 ID
+	Domain    *string        // 시계 도메인 (nil 이면 모두, "" 이면 기본 시계)
+	ClockMin  map[int]int    // 시계 항목 -> 최소값 (항목 값이 이 값 이상)
+	ClockMax  map[int]int    // 시계 항목 -> 최대값 (항목 값이 이 값 이하)
+	AfterID   int64          // 이 ID 다음 기록부터 (페이지 나누기)
+	Limit     int            // 최대 건수 (0 이하면 제한 없음)
+}
+
+// EventStore 이벤트 저장소
+//
+// 같은 노드의 같은 Seq 는 한 번만 저장되므로, 매니저의 기록 전체를 여러 번 Append 해도 새 이벤트만 더해진다.
+type EventStore interface {
+	// Append 노드 node 의 이벤트 저장 (이미 저장된 Seq 는 건너뜀)
+	Append(ctx context.Context, node string, events []vc.Event) error
+	// Get 저장소 ID 로 기록 조회 (없으면 ErrNotFound)
+	Get(ctx context.Context, id int64) (Record, error)
+	// Scan 조건에 맞는 기록을 ID 순서로 fn 에 넘김 (fn 이 에러를 반환하면 멈추고 그 에러 반환)
+	Scan(ctx context.Context, f Filter, fn func(Record) error) error
+	// Close 저장소 닫기
+	Close() error
+}
+
+// Query 조건에 맞는 기록 목록 (ID 순서, 많을 때는 Scan 이나 Limit 사용)
+func Query(ctx context.Context, s EventStore, f Filter) ([]Record, error) {
+	var records []Record
+	err := s.Scan(ctx, f, func(r Record) error {
+		records = append(records, r)
+		return nil
+	})
+	return records, err
+}
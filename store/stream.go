@@ -0,0 +1,78 @@
+//go:build go1.23
+
+package store
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize Events, Stream 이 한 번에 읽는 기록 수
+const DefaultPageSize = 500
+
+// Cursor 스트림 위치 (마지막으로 받은 기록의 저장소 ID, 0 이면 처음부터)
+type Cursor int64
+
+// Cursor 이 기록 다음부터 이어 읽는 위치
+func (r Record) Cursor() Cursor {
+	return Cursor(r.ID)
+}
+
+// Events from 다음 기록부터 저장소 끝까지 ID 순서로 넘기는 스트림 (Go 1.23 이상, 아니면 EventStore.Scan)
+//
+//	var pos store.Cursor
+//	for r, err := range store.Events(ctx, s, pos) {
+//		if err != nil { ... }
+//		pos = r.Cursor()
+//	}
+//
+// 끝에 닿으면 멈추므로, 새 기록을 따라가려면 마지막 위치로 다시 부른다.
+func Events(ctx context.Context, s EventStore, from Cursor) iter.Seq2[Record, error] {
+	return Stream(ctx, s, Filter{AfterID: int64(from)})
+}
+
+// Stream 조건 f 에 맞는 기록을 페이지 단위로 읽어 넘기는 스트림 (f.AfterID 부터, f.Limit 은 전체 건수)
+//
+// 한 페이지(DefaultPageSize 건)를 다 읽은 뒤에 넘기므로, 받는 쪽이 반복 중에 저장소를 다시
+// 조회해도 연결을 붙잡고 있지 않는다. 에러가 나면 그 에러를 한 번 넘기고 멈춘다.
+func Stream(ctx context.Context, s EventStore, f Filter) iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		remaining := f.Limit
+		page := make([]Record, 0, DefaultPageSize)
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(Record{}, err)
+				return
+			}
+			pf := f
+			pf.Limit = DefaultPageSize
+			if remaining > 0 && remaining < pf.Limit {
+				pf.Limit = remaining
+			}
+			page = page[:0]
+			err := s.Scan(ctx, pf, func(r Record) error {
+				page = append(page, r)
+				return nil
+			})
+			if err != nil {
+				yield(Record{}, err)
+				return
+			}
+			for _, r := range page {
+				if !yield(r, nil) {
+					return
+				}
+			}
+			if len(page) < pf.Limit {
+				return // 끝
+			}
+			if remaining > 0 {
+				remaining -= len(page)
+				if remaining == 0 {
+					return
+				}
+			}
+			f.AfterID = page[len(page)-1].ID
+		}
+	}
+}
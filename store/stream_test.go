@@ -0,0 +1,134 @@
+//go:build go1.23
+
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// memStore AfterID 와 Limit 만 지원하는 메모리 EventStore (Scan 호출 수를 셈)
+type memStore struct {
+	records []Record
+	scans   int
+	err     error
+}
+
+func newMemStore(n int) *memStore {
+	s := &memStore{}
+	for i := 1; i <= n; i++ {
+		s.records = append(s.records, Record{ID: int64(i), Node: "n", Event: vc.Event{Seq: int64(i)}})
+	}
+	return s
+}
+
+func (s *memStore) Append(context.Context, string, []vc.Event) error { return nil }
+
+func (s *memStore) Get(_ context.Context, id int64) (Record, error) {
+	if id < 1 || int(id) > len(s.records) {
+		return Record{}, ErrNotFound
+	}
+	return s.records[id-1], nil
+}
+
+func (s *memStore) Scan(_ context.Context, f Filter, fn func(Record) error) error {
+	s.scans++
+	if s.err != nil {
+		return s.err
+	}
+	n := 0
+	for _, r := range s.records {
+		if r.ID <= f.AfterID {
+			continue
+		}
+		if f.Limit > 0 && n == f.Limit {
+			break
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+		n++
+	}
+	return nil
+}
+
+func (s *memStore) Close() error { return nil }
+
+func TestEventsStreamsAllPages(t *testing.T) {
+	s := newMemStore(2*DefaultPageSize + 7)
+	var pos Cursor
+	n := 0
+	for r, err := range Events(context.Background(), s, 0) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.ID != int64(pos)+1 {
+			t.Fatalf("record %d after cursor %d", r.ID, pos)
+		}
+		pos = r.Cursor()
+		n++
+	}
+	if n != len(s.records) || s.scans != 3 {
+		t.Fatalf("streamed %d records in %d scans, want %d in 3", n, s.scans, len(s.records))
+	}
+
+	// 마지막 위치에서 이어 읽으면 새 기록만
+	s.records = append(s.records, Record{ID: int64(len(s.records) + 1)})
+	var more []Record
+	for r, err := range Events(context.Background(), s, pos) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		more = append(more, r)
+	}
+	if len(more) != 1 || more[0].ID != int64(len(s.records)) {
+		t.Fatalf("resumed stream = %+v, want the new record", more)
+	}
+}
+
+func TestStreamHonoursLimitAndStop(t *testing.T) {
+	s := newMemStore(DefaultPageSize + 10)
+	n := 0
+	for _, err := range Stream(context.Background(), s, Filter{AfterID: 5, Limit: DefaultPageSize + 2}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+	}
+	if n != DefaultPageSize+2 {
+		t.Fatalf("streamed %d records, want %d", n, DefaultPageSize+2)
+	}
+
+	s.scans = 0
+	for r := range Stream(context.Background(), s, Filter{}) {
+		if r.ID == 3 {
+			break
+		}
+	}
+	if s.scans != 1 {
+		t.Fatalf("early break scanned %d pages, want 1", s.scans)
+	}
+}
+
+func TestStreamYieldsErrors(t *testing.T) {
+	s := newMemStore(3)
+	s.err = errors.New("boom")
+	var errs []error
+	for _, err := range Stream(context.Background(), s, Filter{}) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || errs[0] != s.err {
+		t.Fatalf("errors = %v, want the scan error once", errs)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range Events(ctx, newMemStore(1), 0) {
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("stream of a cancelled context yielded %v", err)
+		}
+	}
+}
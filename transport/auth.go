@@ -0,0 +1,191 @@
+package transport
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// ErrUnauthenticated 연결한 프로세스를 인증하지 못한 경우
+var ErrUnauthenticated = errors.New("transport: unauthenticated")
+
+// DefaultHandshakeTimeout 연결 시 인증을 마쳐야 하는 제한 시간
+var DefaultHandshakeTimeout = 5 * time.Second
+
+// nonceSize 받는 쪽이 보내는 일회용 값 크기
+const nonceSize = 32
+
+// Authenticator 연결 시 송신 프로세스 증명과 확인 (TokenAuth, KeyAuth 참고)
+//
+// 받는 쪽이 연결마다 새 nonce 를 보내고 보내는 쪽이 그 nonce 에 대한 증명을 돌려주므로,
+// 엿들은 증명을 다른 연결에서 다시 쓸 수 없다.
+type Authenticator interface {
+	// Prove 로컬 프로세스 id 로서 nonce 에 대한 증명 (id 의 자격 증명이 없으면 에러)
+	Prove(id int, nonce []byte) ([]byte, error)
+	// Verify 프로세스 id 라고 주장하는 상대의 증명 확인
+	Verify(id int, nonce, proof []byte) error
+}
+
+// WithAuth 연결할 때 송신 프로세스를 인증 (양쪽 노드가 모두 같은 방식을 써야 함)
+//
+// 보내는 쪽은 송신 프로세스마다 따로 연결해 그 프로세스로 인증하고, 받는 쪽은 인증된 프로세스와
+// From 이 다른 메시지를 버린다. 다른 노드의 프로세스 ID 를 사칭해 시계를 조작할 수 없다.
+func WithAuth(a Authenticator) Option {
+	return func(t *TCP) {
+		t.auth = a
+	}
+}
+
+// hello 연결 시 보내는 쪽의 인증 요청
+type hello struct {
+	ID    int    // 주장하는 송신 프로세스 ID
+	Proof []byte // nonce 에 대한 증명
+}
+
+// welcome 받는 쪽의 인증 결과
+type welcome struct {
+	Err string // 거부 사유 ("" 이면 수락)
+}
+
+// authenticate 연결 conn 에서 로컬 프로세스 id 로 인증 (보내는 쪽, r 은 conn 을 읽는 버퍼)
+func (t *TCP) authenticate(conn net.Conn, r *bufio.Reader, id int) error {
+	conn.SetDeadline(time.Now().Add(DefaultHandshakeTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(r, nonce); err != nil {
+		return err
+	}
+	proof, err := t.auth.Prove(id, nonce)
+	if err != nil {
+		return err
+	}
+	if err := gob.NewEncoder(conn).Encode(hello{ID: id, Proof: proof}); err != nil {
+		return err
+	}
+	var w welcome
+	if err := gob.NewDecoder(r).Decode(&w); err != nil {
+		return err
+	}
+	if w.Err != "" {
+		reason := strings.TrimPrefix(w.Err, ErrUnauthenticated.Error()+": ")
+		return fmt.Errorf("%w: rejected as process %d (%s)", ErrUnauthenticated, id, reason)
+	}
+	return nil
+}
+
+// acceptAuth 연결 conn 의 송신 프로세스 인증 (받는 쪽, r 은 conn 을 읽는 버퍼로 이후 메시지도 r 에서 읽음)
+//
+// 인증 요청과 메시지는 서로 다른 gob 스트림이므로 디코더를 따로 만든다.
+func (t *TCP) acceptAuth(conn net.Conn, r *bufio.Reader) (int, error) {
+	conn.SetDeadline(time.Now().Add(DefaultHandshakeTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return -1, err
+	}
+	if _, err := conn.Write(nonce); err != nil {
+		return -1, err
+	}
+	var h hello
+	if err := gob.NewDecoder(r).Decode(&h); err != nil {
+		return -1, err
+	}
+	verr := t.auth.Verify(h.ID, nonce, h.Proof)
+	var w welcome
+	if verr != nil {
+		w.Err = verr.Error()
+	}
+	if err := gob.NewEncoder(conn).Encode(w); err != nil {
+		return -1, err
+	}
+	if verr != nil {
+		return -1, verr
+	}
+	return h.ID, nil
+}
+
+// tokenAuth 프로세스별 공유 토큰 인증
+type tokenAuth struct {
+	tokens map[int][]byte
+}
+
+// TokenAuth 프로세스별 공유 토큰으로 인증 (모든 노드가 같은 tokens 를 가짐)
+//
+// 토큰 자체는 보내지 않고 nonce 에 대한 HMAC-SHA256 만 보낸다. 토큰을 아는 노드는 그 프로세스로
+// 인증할 수 있으므로, 노드마다 다른 비밀이 필요하면 KeyAuth 를 쓴다.
+func TokenAuth(tokens map[int]string) Authenticator {
+	a := &tokenAuth{tokens: make(map[int][]byte, len(tokens))}
+	for id, tok := range tokens {
+		a.tokens[id] = []byte(tok)
+	}
+	return a
+}
+
+// Prove 토큰으로 nonce 의 HMAC 계산
+func (a *tokenAuth) Prove(id int, nonce []byte) ([]byte, error) {
+	tok, ok := a.tokens[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: no token for process %d", ErrUnauthenticated, id)
+	}
+	mac := hmac.New(sha256.New, tok)
+	mac.Write(nonce)
+	return mac.Sum(nil), nil
+}
+
+// Verify 같은 토큰으로 계산한 HMAC 과 비교
+func (a *tokenAuth) Verify(id int, nonce, proof []byte) error {
+	want, err := a.Prove(id, nonce)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(proof, want) {
+		return fmt.Errorf("%w: bad token for process %d", ErrUnauthenticated, id)
+	}
+	return nil
+}
+
+// keyAuth 프로세스별 Ed25519 키 쌍 인증
+type keyAuth struct {
+	private map[int]ed25519.PrivateKey
+	public  map[int]ed25519.PublicKey
+}
+
+// KeyAuth 프로세스별 Ed25519 키 쌍으로 인증
+//
+// private 는 이 노드에 있는 프로세스의 개인 키, public 은 연결해 올 수 있는 프로세스의 공개 키이다.
+// 개인 키는 노드 밖으로 나가지 않으므로 한 노드가 탈취되어도 다른 노드의 프로세스로 인증할 수 없다.
+func KeyAuth(private map[int]ed25519.PrivateKey, public map[int]ed25519.PublicKey) Authenticator {
+	return &keyAuth{private: private, public: public}
+}
+
+// Prove 개인 키로 nonce 서명
+func (a *keyAuth) Prove(id int, nonce []byte) ([]byte, error) {
+	key, ok := a.private[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: no private key for process %d", ErrUnauthenticated, id)
+	}
+	return ed25519.Sign(key, nonce), nil
+}
+
+// Verify 공개 키로 서명 확인
+func (a *keyAuth) Verify(id int, nonce, proof []byte) error {
+	key, ok := a.public[id]
+	if !ok {
+		return fmt.Errorf("%w: unknown process %d", ErrUnauthenticated, id)
+	}
+	if !ed25519.Verify(key, nonce, proof) {
+		return fmt.Errorf("%w: bad signature for process %d", ErrUnauthenticated, id)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package transport
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenAuthDeliversAuthenticatedSender(t *testing.T) {
+	tokens := map[int]string{0: "zero", 1: "one"}
+	b := startNode(t, 2, []int{1}, nil, WithAuth(TokenAuth(tokens)))
+	a := startNode(t, 2, []int{0}, map[int]string{1: b.addr()}, WithAuth(TokenAuth(tokens)))
+
+	if err := a.procs[0].Send(1, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if msg, ok := b.receive(1, 5*time.Second); !ok || msg.From != 0 {
+		t.Fatalf("received %+v, %v", msg, ok)
+	}
+}
+
+func TestTokenAuthRejectsWrongToken(t *testing.T) {
+	b := startNode(t, 2, []int{1}, nil, WithAuth(TokenAuth(map[int]string{0: "zero"})))
+	a := startNode(t, 2, []int{0}, map[int]string{1: b.addr()}, WithAuth(TokenAuth(map[int]string{0: "guess"})))
+
+	if err := a.procs[0].Send(1, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	a.log.waitFor(t, "rejected as process 0 (bad token for process 0)")
+	if msg, ok := b.receive(1, 100*time.Millisecond); ok {
+		t.Fatalf("received %+v from an unauthenticated sender", msg)
+	}
+}
+
+func TestKeyAuthAuthenticatesEachSendingProcess(t *testing.T) {
+	pub0, priv0, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public := map[int]ed25519.PublicKey{0: pub0}
+	b := startNode(t, 4, []int{2, 3}, nil, WithAuth(KeyAuth(nil, public)))
+	// a 는 0 과 1 을 실행하지만 개인 키는 0 의 것만 있음
+	a := startNode(t, 4, []int{0, 1}, map[int]string{2: b.addr(), 3: b.addr()},
+		WithAuth(KeyAuth(map[int]ed25519.PrivateKey{0: priv0}, public)))
+
+	if err := a.procs[1].Send(2, "unproven"); err != nil {
+		t.Fatal(err)
+	}
+	a.log.waitFor(t, "no private key for process 1")
+	// 대상마다 송신 대기열이 따로 있으므로 1 이 인증을 기다리는 동안에도 0 은 보낼 수 있음
+	if err := a.procs[0].Send(3, "proven"); err != nil {
+		t.Fatal(err)
+	}
+	msg, ok := b.receive(3, 5*time.Second)
+	if !ok || msg.From != 0 || msg.Event != "proven" {
+		t.Fatalf("received %+v, %v, want the message of process 0", msg, ok)
+	}
+	if msg, ok := b.receive(2, 100*time.Millisecond); ok {
+		t.Fatalf("received %+v from an unauthenticated sender", msg)
+	}
+}
+
+func TestAuthProofIsBoundToNonce(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, a := range map[string]Authenticator{
+		"token": TokenAuth(map[int]string{3: "three"}),
+		"key":   KeyAuth(map[int]ed25519.PrivateKey{3: priv}, map[int]ed25519.PublicKey{3: pub}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			nonce := make([]byte, nonceSize)
+			proof, err := a.Prove(3, nonce)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := a.Verify(3, nonce, proof); err != nil {
+				t.Fatalf("Verify = %v", err)
+			}
+			other := make([]byte, nonceSize)
+			other[0] = 1
+			if err := a.Verify(3, other, proof); !errors.Is(err, ErrUnauthenticated) {
+				t.Fatalf("Verify replayed proof = %v, want ErrUnauthenticated", err)
+			}
+			if err := a.Verify(4, nonce, proof); !errors.Is(err, ErrUnauthenticated) {
+				t.Fatalf("Verify as another process = %v, want ErrUnauthenticated", err)
+			}
+		})
+	}
+}
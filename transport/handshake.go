@@ -0,0 +1,169 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+// ProtocolVersion 연결 시 협상하는 전송 프로토콜 버전
+const ProtocolVersion = 1
+
+// ClockDense 모든 항목을 싣는 시계 표현 (process.Message.Vector)
+const ClockDense = "dense"
+
+// ErrIncompatiblePeer 연결 시 협상에서 상대 노드와 프로토콜, 시계 표현, 프로세스 구성이 맞지 않는 경우
+var ErrIncompatiblePeer = errors.New("transport: incompatible peer")
+
+// handshakeMagic 협상으로 시작하는 연결의 첫 바이트 (없으면 협상 이전 노드의 연결)
+var handshakeMagic = []byte("VCTP")
+
+// greeting 연결 시 주고받는 협상 정보
+type greeting struct {
+	Protocol int    // 전송 프로토콜 버전 (ProtocolVersion)
+	Wire     int    // 읽을 수 있는 최대 메시지 형식 버전 (process.WireVersion)
+	Clock    string // 시계 표현
+	Size     int    // 시계 항목 수 (0 이면 알 수 없음, 다르면 양쪽 모두 큰 쪽으로 늘림)
+	Process  int    // 보내는 쪽: 송신 프로세스 ID (받는 쪽은 -1)
+	Target   int    // 보내는 쪽: 처음 보낼 대상 프로세스 ID (받는 쪽은 -1)
+	Hosts    []int  // 받는 쪽: 이 노드의 로컬 프로세스 ID
+	Err      string // 받는 쪽: 거부 사유 ("" 이면 수락)
+}
+
+// local 이 노드의 협상 정보
+func (t *TCP) local() greeting {
+	t.mu.Lock()
+	mgr := t.mgr
+	t.mu.Unlock()
+
+	g := greeting{Protocol: ProtocolVersion, Wire: vc.WireVersion, Clock: ClockDense, Process: -1, Target: -1}
+	if mgr != nil {
+		g.Size = mgr.Size()
+		for _, p := range mgr.Processes() {
+			g.Hosts = append(g.Hosts, p.ID)
+		}
+	}
+	return g
+}
+
+// compatible 상대 협상 정보 o 를 이 노드가 받아들일 수 있는지 확인
+func (g greeting) compatible(o greeting) error {
+	switch {
+	case o.Protocol != g.Protocol:
+		return fmt.Errorf("%w: peer speaks protocol %d, this node speaks %d", ErrIncompatiblePeer, o.Protocol, g.Protocol)
+	case o.Clock != g.Clock:
+		return fmt.Errorf("%w: peer uses %s clocks, this node uses %s", ErrIncompatiblePeer, o.Clock, g.Clock)
+	}
+	return nil
+}
+
+// grow 상대가 알려 준 시계 크기 n 이 이 노드보다 크면 매니저를 n 으로 늘림 (process.VectorClockManager.Grow)
+//
+// 멤버십이 실행 중에 바뀌거나 노드마다 아는 프로세스 수가 달라도, 연결한 두 노드의 시계는 큰 쪽 크기로 맞춰진다.
+func (t *TCP) grow(n int) {
+	t.mu.Lock()
+	mgr := t.mgr
+	t.mu.Unlock()
+	if mgr != nil && n > mgr.Size() {
+		mgr.Grow(n)
+	}
+}
+
+// negotiate 연결 conn 에서 송신 프로세스 from 으로 대상 to 에게 보내기 위한 협상 (보내는 쪽, r 은 conn 을 읽는 버퍼)
+//
+// 상대가 프로토콜, 시계 표현이 다르거나 to 를 갖고 있지 않으면 ErrIncompatiblePeer 를 반환한다.
+// 시계 크기가 다르면 작은 쪽이 큰 쪽으로 늘린다.
+func (t *TCP) negotiate(conn net.Conn, r *bufio.Reader, from, to int) error {
+	conn.SetDeadline(time.Now().Add(DefaultHandshakeTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	hello := t.local()
+	hello.Process, hello.Target, hello.Hosts = from, to, nil
+	var buf bytes.Buffer
+	buf.Write(handshakeMagic)
+	if err := gob.NewEncoder(&buf).Encode(hello); err != nil {
+		return err
+	}
+	if _, err := conn.Write(buf.Bytes()); err != nil {
+		return err
+	}
+
+	var reply greeting
+	if err := gob.NewDecoder(r).Decode(&reply); err != nil {
+		return fmt.Errorf("%w: no handshake reply from %v (%v)", ErrIncompatiblePeer, conn.RemoteAddr(), err)
+	}
+	if reply.Err != "" {
+		reason := strings.TrimPrefix(reply.Err, ErrIncompatiblePeer.Error()+": ")
+		return fmt.Errorf("%w: rejected by %v (%s)", ErrIncompatiblePeer, conn.RemoteAddr(), reason)
+	}
+	if err := hello.compatible(reply); err != nil {
+		return err
+	}
+	if reply.Wire < hello.Wire {
+		return fmt.Errorf("%w: peer reads message version %d, this node writes %d", ErrIncompatiblePeer, reply.Wire, hello.Wire)
+	}
+	t.grow(reply.Size)
+	return nil
+}
+
+// acceptNegotiation 연결 conn 의 협상 (받는 쪽, peer 는 TLS 인증서로 확인한 송신자 또는 -1)
+//
+// 협상 없이 바로 메시지를 보내는 이전 노드의 연결이면 legacy 가 true 이다.
+func (t *TCP) acceptNegotiation(conn net.Conn, r *bufio.Reader, peer int) (legacy bool, err error) {
+	conn.SetDeadline(time.Now().Add(DefaultHandshakeTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	magic, err := r.Peek(len(handshakeMagic))
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Equal(magic, handshakeMagic) {
+		return true, nil
+	}
+	if _, err := r.Discard(len(handshakeMagic)); err != nil {
+		return false, err
+	}
+	var hello greeting
+	if err := gob.NewDecoder(r).Decode(&hello); err != nil {
+		return false, err
+	}
+
+	reply := t.local()
+	rerr := reply.compatible(hello)
+	switch {
+	case rerr != nil:
+	case hello.Wire > reply.Wire:
+		rerr = fmt.Errorf("%w: peer writes message version %d, this node reads up to %d", ErrIncompatiblePeer, hello.Wire, reply.Wire)
+	case peer >= 0 && hello.Process != peer:
+		rerr = fmt.Errorf("%w: peer claims process %d but its certificate belongs to process %d", ErrIncompatiblePeer, hello.Process, peer)
+	case hello.Target >= 0 && !hosts(reply.Hosts, hello.Target):
+		rerr = fmt.Errorf("%w: process %d is not hosted at %v (hosts %v)", ErrIncompatiblePeer, hello.Target, conn.LocalAddr(), reply.Hosts)
+	}
+	if rerr != nil {
+		reply.Err = rerr.Error()
+	} else if hello.Size > reply.Size {
+		t.grow(hello.Size)
+		reply.Size = hello.Size
+	}
+	if err := gob.NewEncoder(conn).Encode(reply); err != nil {
+		return false, err
+	}
+	return false, rerr
+}
+
+// hosts ids 에 id 가 있는지 여부
+func hosts(ids []int, id int) bool {
+	for _, h := range ids {
+		if h == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package transport
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestHandshakeGrowsSmallerNode(t *testing.T) {
+	// 노드마다 아는 프로세스 수가 다름 (vcnode 의 -n 0)
+	b := startNode(t, 3, []int{1}, nil)
+	a := startNode(t, 2, []int{0}, map[int]string{1: b.addr()})
+
+	if err := a.procs[0].Send(1, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := b.receive(1, 5*time.Second); !ok {
+		t.Fatal("message did not arrive")
+	}
+	if n := a.mgr.Size(); n != 3 {
+		t.Fatalf("sender Size() = %d after handshake, want 3", n)
+	}
+
+	// 연결한 뒤 보내는 쪽이 늘린 시계
+	a.mgr.Grow(5)
+	if err := a.procs[0].Send(1, "wider"); err != nil {
+		t.Fatal(err)
+	}
+	msg, ok := b.receive(1, 5*time.Second)
+	if !ok {
+		t.Fatal("message after Grow did not arrive")
+	}
+	if len(msg.Vector) != 5 || b.mgr.Size() != 5 {
+		t.Fatalf("received %d entries, receiver Size() = %d, want 5", len(msg.Vector), b.mgr.Size())
+	}
+}
+
+func TestHandshakeRejectsIncompatiblePeer(t *testing.T) {
+	b := startNode(t, 2, []int{1}, nil)
+	local := b.tcp.local()
+
+	for _, tc := range []struct {
+		name   string
+		mutate func(*greeting)
+	}{
+		{"protocol", func(g *greeting) { g.Protocol++ }},
+		{"clock", func(g *greeting) { g.Clock = "sparse" }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			peer := local
+			tc.mutate(&peer)
+			if err := local.compatible(peer); !errors.Is(err, ErrIncompatiblePeer) {
+				t.Fatalf("compatible = %v, want ErrIncompatiblePeer", err)
+			}
+		})
+	}
+	peer := local
+	peer.Size = local.Size + 3
+	if err := local.compatible(peer); err != nil {
+		t.Fatalf("different sizes rejected: %v", err)
+	}
+}
+
+func TestHandshakeChecksTarget(t *testing.T) {
+	b := startNode(t, 3, []int{1}, nil)
+	a := startNode(t, 3, []int{0}, nil)
+	conn, err := net.Dial("tcp", b.addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	err = a.tcp.negotiate(conn, bufio.NewReader(conn), 0, 2)
+	if !errors.Is(err, ErrIncompatiblePeer) {
+		t.Fatalf("negotiate for unhosted target = %v, want ErrIncompatiblePeer", err)
+	}
+}
+
+func TestLegacyPeerWithoutHandshake(t *testing.T) {
+	b := startNode(t, 2, []int{1}, nil)
+	conn, err := net.Dial("tcp", b.addr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// 협상 이전 노드는 바로 메시지를 보냄
+	msg := vc.Message{From: 0, To: 1, Event: "old", Vector: []int{1, 0}, MessageID: "legacy"}
+	if err := gob.NewEncoder(conn).Encode(msg); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := b.receive(1, 5*time.Second)
+	if !ok || got.MessageID != "legacy" {
+		t.Fatalf("received %+v, %v", got, ok)
+	}
+}
@@ -0,0 +1,459 @@
+// Package transport 다른 노드(OS 프로세스)의 프로세스와 메시지를 주고받는 네트워크 전송 계층
+//
+//	tcp := transport.NewTCP(map[int]string{1: "10.0.0.2:7000"})
+//	mgr := process.NewVectorClockManager(2, process.WithTransport(tcp))
+//	p0 := process.NewProcess(0, mgr)
+//	go tcp.Serve(mgr, ":7000")
+//	p0.Send(1, "hello") // 1 은 다른 노드에 있음
+package transport
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+var (
+	// ErrUnknownPeer 주소를 모르는 프로세스로 보내려는 경우
+	ErrUnknownPeer = errors.New("transport: unknown peer")
+	// ErrClosed 닫힌 전송 계층을 사용하려는 경우
+	ErrClosed = errors.New("transport: closed")
+)
+
+// DefaultQueueSize 대상 프로세스별 송신 대기열 크기
+const DefaultQueueSize = 64
+
+// DefaultRedialInterval 연결이 끊긴 뒤 다시 연결을 시도하는 간격
+var DefaultRedialInterval = 500 * time.Millisecond
+
+// TCP TCP(선택적으로 TLS) 연결로 다른 노드의 프로세스에 메시지를 전달하는 process.Transport
+//
+// 메시지는 버전이 붙은 process.WireMessage 로 gob 인코딩되며 ReplyCh 처럼 노드 밖으로 나갈 수 없는 필드는
+// 전달되지 않는다. 버전이 없던 이전 노드의 메시지도 읽으며, 더 새로운 버전의 메시지는 기록을 남기고 버린다.
+// 송신 프로세스와 대상 프로세스 쌍마다 연결 하나를 만들어 재사용하고, 연결이 끊기면 다시 연결될 때까지
+// 기다렸다가 보낸다. 연결할 때 프로토콜 버전, 시계 표현, 송신/대상 프로세스를 협상하며,
+// 맞지 않으면(ErrIncompatiblePeer) 다시 시도하지 않고 메시지를 버린다. 시계 크기가 다르면 작은 쪽 노드의
+// 매니저를 큰 쪽으로 늘리고(Grow), 연결한 뒤 상대가 더 늘린 시계를 보내도 그 크기로 늘려 받는다.
+type TCP struct {
+	mu     sync.Mutex
+	peers  map[int]string // 프로세스 ID -> 주소
+	links  map[int]chan vc.Message
+	conns  map[connKey]*peerConn
+	mgr    *vc.VectorClockManager
+	ln     net.Listener
+	closed bool
+	done   chan struct{}
+
+	tls      *tls.Config   // nil 이면 평문
+	identity IdentityFunc  // 인증서 -> 프로세스 ID (TLS)
+	auth     Authenticator // 연결 시 송신 프로세스 인증 (nil 이면 인증하지 않음)
+	queue    int
+	dialer   net.Dialer
+}
+
+// Option TCP 설정 옵션
+type Option func(*TCP)
+
+// WithQueueSize 대상 프로세스별 송신 대기열 크기 지정
+func WithQueueSize(n int) Option {
+	return func(t *TCP) {
+		if n < 0 {
+			n = 0
+		}
+		t.queue = n
+	}
+}
+
+// NewTCP 프로세스 ID -> 주소 목록으로 TCP 전송 계층 생성
+//
+// 옵션으로 TLS(WithTLS), 인증서 신원(WithIdentity), 프로세스 인증(WithAuth),
+// 송신 대기열 크기(WithQueueSize) 를 지정할 수 있다.
+func NewTCP(peers map[int]string, opts ...Option) *TCP {
+	t := &TCP{
+		peers: make(map[int]string, len(peers)),
+		links: make(map[int]chan vc.Message),
+		conns: make(map[connKey]*peerConn),
+		done:  make(chan struct{}),
+		queue: DefaultQueueSize,
+	}
+	for id, addr := range peers {
+		t.peers[id] = addr
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// AddPeer 프로세스 id 의 주소 추가 (이미 있으면 변경, 기존 연결은 그대로)
+func (t *TCP) AddPeer(id int, addr string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.peers[id] = addr
+}
+
+// Mailbox 프로세스 to 에게 보내는 송신 대기열 (process.Transport)
+func (t *TCP) Mailbox(to int) (chan<- vc.Message, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil, ErrClosed
+	}
+	if ch, ok := t.links[to]; ok {
+		return ch, nil
+	}
+	addr, ok := t.peers[to]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownPeer, to)
+	}
+	ch := make(chan vc.Message, t.queue)
+	t.links[to] = ch
+	go t.forward(to, addr, ch)
+	return ch, nil
+}
+
+// Serve addr 에서 연결을 받아 도착한 메시지를 mgr 의 로컬 프로세스에 전달 (Close 할 때까지 반환하지 않음)
+//
+// mgr 은 이 전송 계층으로 보낸 메시지를 정리(Forwarded)할 매니저이기도 하다.
+func (t *TCP) Serve(mgr *vc.VectorClockManager, addr string) error {
+	ln, err := t.listen(mgr, addr)
+	if err != nil {
+		return err
+	}
+	return t.accept(ln)
+}
+
+// listen addr 에서 연결을 받을 준비 (연결 수락은 accept)
+func (t *TCP) listen(mgr *vc.VectorClockManager, addr string) (net.Listener, error) {
+	var ln net.Listener
+	var err error
+	if t.tls != nil {
+		ln, err = tls.Listen("tcp", addr, t.tls)
+	} else {
+		ln, err = net.Listen("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		ln.Close()
+		return nil, ErrClosed
+	}
+	t.mgr = mgr
+	t.ln = ln
+	t.mu.Unlock()
+	return ln, nil
+}
+
+// Bind 송신만 하는 노드에서 이 전송 계층을 쓰는 매니저 지정 (Serve / Start 는 자동으로 지정)
+//
+// 다른 노드로 넘긴 메시지를 매니저의 전송 중 계산에서 빼려면(Forwarded) 매니저를 알아야 한다.
+func (t *TCP) Bind(mgr *vc.VectorClockManager) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mgr = mgr
+}
+
+// Start addr 에서 연결을 받을 준비를 마치고 백그라운드에서 연결을 받음 (주소는 Addr 로 확인)
+func (t *TCP) Start(mgr *vc.VectorClockManager, addr string) error {
+	ln, err := t.listen(mgr, addr)
+	if err != nil {
+		return err
+	}
+	go t.accept(ln)
+	return nil
+}
+
+// Addr 연결을 받는 주소 (Serve / Start 전이면 nil)
+func (t *TCP) Addr() net.Addr {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ln == nil {
+		return nil
+	}
+	return t.ln.Addr()
+}
+
+// Close 연결을 받지 않고 모든 연결을 닫음 (대기열에 남은 메시지는 버려짐)
+func (t *TCP) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	close(t.done)
+	var err error
+	if t.ln != nil {
+		err = t.ln.Close()
+	}
+	for _, c := range t.conns {
+		c.close()
+	}
+	return err
+}
+
+// logf 매니저 로그 출력 (매니저가 없으면 버림)
+func (t *TCP) logf(format string, v ...interface{}) {
+	t.mu.Lock()
+	mgr := t.mgr
+	t.mu.Unlock()
+	if mgr != nil {
+		mgr.Logger().Printf(format, v...)
+	}
+}
+
+// accept 연결 수락 루프
+func (t *TCP) accept(ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			select {
+			case <-t.done:
+				return nil
+			default:
+				return err
+			}
+		}
+		go t.receive(conn)
+	}
+}
+
+// receive 연결 하나에서 메시지를 읽어 로컬 프로세스에 전달
+func (t *TCP) receive(conn net.Conn) {
+	defer conn.Close()
+
+	peer := -1 // 인증된 송신자 (TLS 클라이언트 인증서가 없으면 -1)
+	if tc, ok := conn.(*tls.Conn); ok {
+		if err := tc.Handshake(); err != nil {
+			t.logf("Transport: TLS handshake with %v failed: %v\n", conn.RemoteAddr(), err)
+			return
+		}
+		id, err := t.peerIdentity(tc)
+		if err != nil {
+			t.logf("Transport: rejected connection from %v: %v\n", conn.RemoteAddr(), err)
+			return
+		}
+		peer = id
+	}
+
+	r := bufio.NewReader(conn)
+	legacy, err := t.acceptNegotiation(conn, r, peer)
+	if err != nil {
+		t.logf("Transport: rejected connection from %v: %v\n", conn.RemoteAddr(), err)
+		return
+	}
+	if legacy {
+		t.logf("Transport: connection from %v has no handshake (older node), reading messages as version 0\n", conn.RemoteAddr())
+	}
+	sender := -1 // 인증된 송신 프로세스 (WithAuth 를 쓰지 않으면 -1)
+	if t.auth != nil {
+		id, err := t.acceptAuth(conn, r)
+		if err != nil {
+			t.logf("Transport: rejected connection from %v: %v\n", conn.RemoteAddr(), err)
+			return
+		}
+		sender = id
+	}
+	dec := gob.NewDecoder(r)
+
+	for {
+		w := vc.AcquireWire()
+		if err := dec.Decode(w); err != nil {
+			vc.ReleaseWire(w)
+			return
+		}
+		msg, err := w.Decode()
+		vc.ReleaseWire(w)
+		if err != nil {
+			t.logf("Transport: dropped message from %v: %v\n", conn.RemoteAddr(), err)
+			continue
+		}
+		if peer >= 0 && msg.From != peer {
+			t.logf("Transport: dropped message %s claiming sender %d on connection of process %d\n",
+				msg.MessageID, msg.From, peer)
+			continue
+		}
+		if sender >= 0 && msg.From != sender {
+			t.logf("Transport: dropped message %s claiming sender %d on connection authenticated as process %d\n",
+				msg.MessageID, msg.From, sender)
+			continue
+		}
+		if msg.Domain == "" {
+			t.grow(len(msg.Vector))
+		}
+		t.mu.Lock()
+		mgr := t.mgr
+		t.mu.Unlock()
+		if err := mgr.Deliver(msg); err != nil {
+			t.logf("Transport: dropped message %s: %v\n", msg.MessageID, err)
+		}
+	}
+}
+
+// forward 대상 프로세스 to 의 송신 대기열을 주소 addr 의 연결로 보냄
+func (t *TCP) forward(to int, addr string, ch <-chan vc.Message) {
+	for {
+		select {
+		case <-t.done:
+			return
+		case msg := <-ch:
+			t.mu.Lock()
+			mgr := t.mgr
+			t.mu.Unlock()
+			if mgr != nil {
+				mgr.Forwarded(msg)
+			}
+			for {
+				err := t.send(to, addr, msg)
+				if err == nil {
+					break
+				}
+				if errors.Is(err, ErrIncompatiblePeer) {
+					// 다시 연결해도 협상이 바뀌지 않으므로 기다리지 않고 버림
+					t.logf("Transport: dropped message %s to process %d: %v\n", msg.MessageID, to, err)
+					break
+				}
+				select {
+				case <-t.done:
+					return
+				case <-time.After(DefaultRedialInterval):
+				}
+			}
+		}
+	}
+}
+
+// send 메시지 한 건 전송 (연결하지 못했거나 쓰지 못했으면 에러, 연결은 다음 전송에서 다시 만듦)
+func (t *TCP) send(to int, addr string, msg vc.Message) error {
+	key := connKey{from: msg.From, to: to}
+	c, err := t.conn(addr, key)
+	if err != nil {
+		t.logf("Transport: connect to process %d at %s failed: %v\n", to, addr, err)
+		return err
+	}
+	if err := c.write(msg); err != nil {
+		t.logf("Transport: send to process %d at %s failed: %v\n", to, addr, err)
+		t.drop(key, c)
+		return err
+	}
+	return nil
+}
+
+// connKey 연결 구분 (송신 프로세스, 대상 프로세스)
+//
+// 같은 주소의 다른 대상 프로세스와 연결을 나누지 않으므로, 인증서 신원 확인과 협상의 대상 확인이 대상마다 이루어진다.
+type connKey struct {
+	from int
+	to   int
+}
+
+// peerConn 송신 프로세스 하나가 대상 프로세스 하나로 보내는 연결
+type peerConn struct {
+	mu   sync.Mutex
+	conn net.Conn
+	w    *bufio.Writer
+	enc  *gob.Encoder
+}
+
+// write 메시지 한 건 인코딩 후 전송
+func (c *peerConn) write(msg vc.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := msg.Wire()
+	if err := c.enc.Encode(&w); err != nil {
+		return err
+	}
+	return c.w.Flush()
+}
+
+// close 연결 닫기
+func (c *peerConn) close() {
+	c.conn.Close()
+}
+
+// conn key 의 연결 (없으면 주소 addr 로 연결하고 WithAuth 이면 송신 프로세스로 인증)
+func (t *TCP) conn(addr string, key connKey) (*peerConn, error) {
+	t.mu.Lock()
+	if c, ok := t.conns[key]; ok {
+		t.mu.Unlock()
+		return c, nil
+	}
+	t.mu.Unlock()
+
+	conn, err := t.dial(key.to, addr)
+	if err != nil {
+		return nil, err
+	}
+	r := bufio.NewReader(conn)
+	if err := t.negotiate(conn, r, key.from, key.to); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if t.auth != nil {
+		if err := t.authenticate(conn, r, key.from); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	w := bufio.NewWriter(conn)
+	c := &peerConn{conn: conn, w: w, enc: gob.NewEncoder(w)}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		conn.Close()
+		return nil, ErrClosed
+	}
+	if existing, ok := t.conns[key]; ok {
+		conn.Close()
+		return existing, nil
+	}
+	t.conns[key] = c
+	return c, nil
+}
+
+// drop 끊긴 연결 정리
+func (t *TCP) drop(key connKey, c *peerConn) {
+	t.mu.Lock()
+	if t.conns[key] == c {
+		delete(t.conns, key)
+	}
+	t.mu.Unlock()
+	c.close()
+}
+
+// dial 주소 addr 에 연결 (TLS 면 상대 인증서가 프로세스 to 의 것인지 확인)
+func (t *TCP) dial(to int, addr string) (net.Conn, error) {
+	if t.tls == nil {
+		return t.dialer.Dial("tcp", addr)
+	}
+	conn, err := tls.DialWithDialer(&t.dialer, "tcp", addr, t.tls)
+	if err != nil {
+		return nil, err
+	}
+	if t.identity != nil {
+		id, err := t.peerIdentity(conn)
+		if err != nil || id != to {
+			conn.Close()
+			if err == nil {
+				err = fmt.Errorf("%w: certificate belongs to process %d, want %d", ErrIdentityMismatch, id, to)
+			}
+			return nil, err
+		}
+	}
+	return conn, nil
+}
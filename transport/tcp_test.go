@@ -0,0 +1,267 @@
+package transport
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	vc "github.com/seoyhaein/vectorclock/process"
+)
+
+func TestMTLSDeliversBetweenNodes(t *testing.T) {
+	pki := newTestPKI(t)
+	b := startNode(t, 2, []int{1}, nil, WithTLS(pki.config(t, 1)))
+	a := startNode(t, 2, []int{0}, map[int]string{1: b.addr()}, WithTLS(pki.config(t, 0)))
+
+	if err := a.procs[0].Send(1, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	if msg, ok := b.receive(1, 5*time.Second); !ok || msg.Event != "hello" {
+		t.Fatalf("received %+v, %v", msg, ok)
+	}
+}
+
+func TestTCPRejectsServerCertificateOfAnotherProcess(t *testing.T) {
+	pki := newTestPKI(t)
+	// 1 이 있다고 알려진 주소의 노드가 2 의 인증서를 가짐
+	b := startNode(t, 3, []int{1}, nil, WithTLS(pki.config(t, 2)))
+	a := startNode(t, 3, []int{0}, map[int]string{1: b.addr()}, WithTLS(pki.config(t, 0)))
+
+	if err := a.procs[0].Send(1, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	a.log.waitFor(t, "certificate belongs to process 2, want 1")
+	if msg, ok := b.receive(1, 100*time.Millisecond); ok {
+		t.Fatalf("process 1 received %+v from an unverified connection", msg)
+	}
+}
+
+func TestMTLSDropsMessagesFromOtherSenders(t *testing.T) {
+	pki := newTestPKI(t)
+	b := startNode(t, 3, []int{2}, nil, WithTLS(pki.config(t, 2)))
+	// a 는 0 과 1 을 실행하지만 클라이언트 인증서는 0 의 것
+	a := startNode(t, 3, []int{0, 1}, map[int]string{2: b.addr()}, WithTLS(pki.config(t, 0)))
+
+	if err := a.procs[1].Send(2, "spoofed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.procs[0].Send(2, "genuine"); err != nil {
+		t.Fatal(err)
+	}
+	msg, ok := b.receive(2, 5*time.Second)
+	if !ok {
+		t.Fatal("message from the certified sender did not arrive")
+	}
+	if msg.From != 0 || msg.Event != "genuine" {
+		t.Fatalf("received %+v, want only the certified sender's message", msg)
+	}
+	if msg, ok := b.receive(2, 100*time.Millisecond); ok {
+		t.Fatalf("received %+v from a sender without its certificate", msg)
+	}
+}
+
+func TestCommonNameIdentity(t *testing.T) {
+	for cn, want := range map[string]int{"process-3": 3, "7": 7} {
+		id, err := CommonNameIdentity(&x509.Certificate{Subject: pkix.Name{CommonName: cn}})
+		if err != nil || id != want {
+			t.Fatalf("CommonNameIdentity(%q) = %d, %v, want %d", cn, id, err, want)
+		}
+	}
+	if _, err := CommonNameIdentity(&x509.Certificate{Subject: pkix.Name{CommonName: "node-a"}}); !errors.Is(err, ErrIdentityMismatch) {
+		t.Fatalf("CommonNameIdentity(node-a) = %v, want ErrIdentityMismatch", err)
+	}
+}
+
+// logBook 매니저 로그를 모으는 process.Logger
+type logBook struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *logBook) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+// waitFor substr 를 담은 로그가 나올 때까지 대기
+func (l *logBook) waitFor(t *testing.T, substr string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		l.mu.Lock()
+		for _, line := range l.lines {
+			if strings.Contains(line, substr) {
+				l.mu.Unlock()
+				return
+			}
+		}
+		l.mu.Unlock()
+		if time.Now().After(deadline) {
+			t.Fatalf("no log line containing %q", substr)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// node 전송 계층 하나와 그 매니저, 로컬 프로세스
+type node struct {
+	tcp   *TCP
+	mgr   *vc.VectorClockManager
+	procs map[int]*vc.Process
+	log   *logBook
+}
+
+// startNode size 크기의 매니저로 hosts 프로세스를 실행하고 임의의 포트에서 연결을 받는 노드
+func startNode(t *testing.T, size int, hosts []int, peers map[int]string, opts ...Option) *node {
+	t.Helper()
+	n := &node{tcp: NewTCP(peers, opts...), procs: make(map[int]*vc.Process), log: &logBook{}}
+	n.mgr = vc.NewVectorClockManager(size, vc.WithTransport(n.tcp), vc.WithLogger(n.log))
+	for _, id := range hosts {
+		n.procs[id] = vc.NewProcess(id, n.mgr, vc.WithMailboxSize(16))
+	}
+	if err := n.tcp.Start(n.mgr, "127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { n.tcp.Close() })
+	return n
+}
+
+// addr 연결을 받는 주소
+func (n *node) addr() string {
+	return n.tcp.Addr().String()
+}
+
+// receive 프로세스 id 의 메일박스에서 메시지 하나 (timeout 안에 없으면 false)
+func (n *node) receive(id int, timeout time.Duration) (vc.Message, bool) {
+	select {
+	case msg := <-n.procs[id].MessageCh:
+		return msg, true
+	case <-time.After(timeout):
+		return vc.Message{}, false
+	}
+}
+
+// testPKI 테스트용 CA 와 프로세스별 인증서
+type testPKI struct {
+	ca    *x509.Certificate
+	key   *ecdsa.PrivateKey
+	pool  *x509.CertPool
+	nextN int64
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	return &testPKI{ca: ca, key: key, pool: pool, nextN: 2}
+}
+
+// config 프로세스 id 의 인증서로 만든 mTLS 설정
+func (pki *testPKI) config(t *testing.T, id int) *tls.Config {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(pki.nextN),
+		Subject:      pkix.Name{CommonName: fmt.Sprintf("process-%d", id)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	pki.nextN++
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, pki.ca, &key.PublicKey, pki.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewTLSConfig(tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pki.pool, true)
+}
+
+func TestTCPDeliversBetweenNodes(t *testing.T) {
+	b := startNode(t, 2, []int{1}, nil)
+	a := startNode(t, 2, []int{0}, map[int]string{1: b.addr()})
+
+	if err := a.procs[0].Send(1, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	msg, ok := b.receive(1, 5*time.Second)
+	if !ok {
+		t.Fatal("message did not arrive")
+	}
+	if msg.From != 0 || msg.Event != "hello" || msg.Vector[0] != 1 {
+		t.Fatalf("received %+v", msg)
+	}
+}
+
+func TestTCPChecksEachTargetOnSharedAddress(t *testing.T) {
+	b := startNode(t, 3, []int{1}, nil)
+	// 2 도 b 에 있다고 잘못 알고 있음
+	a := startNode(t, 3, []int{0}, map[int]string{1: b.addr(), 2: b.addr()})
+
+	if err := a.procs[0].Send(1, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := b.receive(1, 5*time.Second); !ok {
+		t.Fatal("message to process 1 did not arrive")
+	}
+	// 1 로 가는 연결을 다시 쓰지 않고 2 에 대해 따로 협상해야 함
+	if err := a.procs[0].Send(2, "second"); err != nil {
+		t.Fatal(err)
+	}
+	a.log.waitFor(t, "process 2 is not hosted")
+}
+
+func TestTCPVerifiesCertificateOfEachTarget(t *testing.T) {
+	pki := newTestPKI(t)
+	// b 는 1 과 2 를 실행하지만 인증서는 1 의 것
+	b := startNode(t, 3, []int{1, 2}, nil, WithTLS(pki.config(t, 1)))
+	a := startNode(t, 3, []int{0}, map[int]string{1: b.addr(), 2: b.addr()}, WithTLS(pki.config(t, 0)))
+
+	if err := a.procs[0].Send(1, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := b.receive(1, 5*time.Second); !ok {
+		t.Fatal("message to process 1 did not arrive")
+	}
+	if err := a.procs[0].Send(2, "second"); err != nil {
+		t.Fatal(err)
+	}
+	a.log.waitFor(t, "certificate belongs to process 1, want 2")
+	if msg, ok := b.receive(2, 100*time.Millisecond); ok {
+		t.Fatalf("process 2 received %+v over a connection verified for process 1", msg)
+	}
+}
@@ -0,0 +1,119 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrIdentityMismatch 상대 인증서가 기대한 프로세스의 것이 아니거나 프로세스 ID 를 알 수 없는 경우
+var ErrIdentityMismatch = errors.New("transport: certificate identity mismatch")
+
+// IdentityFunc 상대 인증서에서 프로세스 ID 추출 (알 수 없으면 에러)
+type IdentityFunc func(cert *x509.Certificate) (int, error)
+
+// WithTLS TLS 로 연결 (cfg 는 서버와 클라이언트 양쪽에 쓰임, LoadTLS 참고)
+//
+// cfg.ClientAuth 가 tls.RequireAndVerifyClientCert 이면 mTLS 로 동작해 연결마다 상대 인증서의 신원을
+// 프로세스 ID 로 바꾸고(WithIdentity), 그 연결로 다른 프로세스를 사칭한 메시지(From 불일치)는 버린다.
+func WithTLS(cfg *tls.Config) Option {
+	return func(t *TCP) {
+		t.tls = cfg
+		if t.identity == nil {
+			t.identity = CommonNameIdentity
+		}
+	}
+}
+
+// WithIdentity 인증서 -> 프로세스 ID 변환 지정 (기본값 CommonNameIdentity)
+//
+// 연결하는 쪽은 서버 인증서가 대상 프로세스의 것인지, 받는 쪽은 클라이언트 인증서의 프로세스가
+// 메시지의 From 과 같은지 확인한다. nil 이면 인증서 체인 검증만 하고 신원은 확인하지 않는다.
+func WithIdentity(fn IdentityFunc) Option {
+	return func(t *TCP) {
+		t.identity = fn
+	}
+}
+
+// CommonNameIdentity 인증서 Subject CommonName 의 프로세스 ID ("process-3" 또는 "3")
+func CommonNameIdentity(cert *x509.Certificate) (int, error) {
+	cn := strings.TrimPrefix(cert.Subject.CommonName, "process-")
+	id, err := strconv.Atoi(cn)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("%w: common name %q is not a process ID", ErrIdentityMismatch, cert.Subject.CommonName)
+	}
+	return id, nil
+}
+
+// LoadTLS PEM 파일로 TLS 설정 생성
+//
+// certFile / keyFile 은 이 노드의 인증서와 키, caFile 은 상대 인증서를 검증할 CA 이다.
+// mutual 이면 클라이언트 인증서도 요구하고 검증한다 (mTLS). 한 노드가 서버와 클라이언트를 겸하므로
+// 인증서에 serverAuth / clientAuth 용도가 모두 있어야 한다.
+func LoadTLS(certFile, keyFile, caFile string, mutual bool) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("transport: no certificates in %s", caFile)
+	}
+	return NewTLSConfig(cert, pool, mutual), nil
+}
+
+// NewTLSConfig 인증서와 CA 로 TLS 설정 생성 (mutual 이면 mTLS)
+//
+// 인증서의 호스트 이름 대신 CA 체인과 프로세스 신원(WithIdentity)으로 상대를 확인하므로
+// 주소가 바뀌어도(IP, 컨테이너) 인증서를 다시 발급할 필요가 없다.
+func NewTLSConfig(cert tls.Certificate, ca *x509.CertPool, mutual bool) *tls.Config {
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      ca,
+		ClientCAs:    ca,
+		MinVersion:   tls.VersionTLS12,
+		// 호스트 이름 검증을 끄고 VerifyConnection 에서 체인만 직접 검증한다.
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			return verifyChain(cs, ca)
+		},
+	}
+	if mutual {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg
+}
+
+// verifyChain 상대 인증서가 ca 로 서명되었는지 확인 (클라이언트 인증서가 없는 서버 쪽 연결은 통과)
+func verifyChain(cs tls.ConnectionState, ca *x509.CertPool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return nil
+	}
+	opts := x509.VerifyOptions{
+		Roots:         ca,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	for _, c := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(c)
+	}
+	_, err := cs.PeerCertificates[0].Verify(opts)
+	return err
+}
+
+// peerIdentity TLS 연결 상대의 프로세스 ID (신원 확인을 하지 않거나 인증서가 없으면 -1)
+func (t *TCP) peerIdentity(conn *tls.Conn) (int, error) {
+	certs := conn.ConnectionState().PeerCertificates
+	if t.identity == nil || len(certs) == 0 {
+		return -1, nil
+	}
+	return t.identity(certs[0])
+}